@@ -24,6 +24,7 @@ import (
 	"io"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 // The ABI holds information about a contract's context and available
@@ -189,3 +190,42 @@ func (abi *ABI) EventByID(topic common.Hash) (*Event, error) {
 	}
 	return nil, fmt.Errorf("no event with id: %#x", topic.Hex())
 }
+
+// revertSelector is the function selector of Error(string), the payload
+// Solidity reverts with when given a reason.
+var revertSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+
+// UnpackRevert resolves the reason of an abi-encoded Error(string) revert
+// payload, as returned by a reverted execution.
+func UnpackRevert(data []byte) (string, error) {
+	if len(data) < 4 || !bytes.Equal(data[:4], revertSelector) {
+		return "", errors.New("invalid data for unpacking")
+	}
+	typ, _ := NewType("string", "", nil)
+	unpacked, err := (Arguments{{Type: typ}}).UnpackValues(data[4:])
+	if err != nil {
+		return "", err
+	}
+	return unpacked[0].(string), nil
+}
+
+// RevertError is returned by calls and gas estimations whose execution hit a
+// REVERT, carrying the data the contract reverted with.
+type RevertError struct {
+	Data   []byte // Raw revert data, e.g. an abi-encoded Error(string) or custom error
+	Reason string // Reason decoded from an Error(string) revert, empty otherwise
+}
+
+// NewRevertError creates the error of an execution that reverted with the
+// given data, decoding the reason if it is an Error(string).
+func NewRevertError(data []byte) *RevertError {
+	reason, _ := UnpackRevert(data)
+	return &RevertError{Data: data, Reason: reason}
+}
+
+func (e *RevertError) Error() string {
+	if e.Reason == "" {
+		return "execution reverted"
+	}
+	return "execution reverted: " + e.Reason
+}
@@ -0,0 +1,196 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package backends
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	mockEngine "github.com/ethereum/go-ethereum/consensus/consensustest"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	"github.com/ethereum/go-ethereum/consensus/istanbul/validator"
+	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	blscrypto "github.com/ethereum/go-ethereum/crypto/bls"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ownerABI contains the functions of the core contracts restricted to their owner.
+const ownerABI = `[
+	{
+		"constant": false,
+		"inputs": [{"name": "to", "type": "address"}, {"name": "value", "type": "uint256"}],
+		"name": "mint",
+		"outputs": [{"name": "", "type": "bool"}],
+		"type": "function"
+	},
+	{
+		"constant": false,
+		"inputs": [{"name": "token", "type": "address"}, {"name": "numerator", "type": "uint256"}, {"name": "denominator", "type": "uint256"}],
+		"name": "setMedianRate",
+		"outputs": [],
+		"type": "function"
+	}
+]`
+
+// ownerGas is the gas limit of the transactions made by the owner of the core
+// contracts, enough for any of their functions.
+const ownerGas = 200000
+
+var (
+	errNoCoreContracts     = errors.New("simulatedBackend has no Celo core contracts")
+	errInvalidExchangeRate = errors.New("exchange rate must be positive and fit in 128 bits")
+	errInvalidMintAmount   = errors.New("mint amount must be positive")
+
+	ownerFuncABI, _ = abi.JSON(strings.NewReader(ownerABI))
+	ownerBalance    = new(big.Int).Mul(big.NewInt(1000000), big.NewInt(params.Ether))
+	maxRate         = new(big.Int).Lsh(common.Big1, 128)
+
+	// EpochValidatorReward is the CELO paid to every validator of an epoch at
+	// its last block.
+	EpochValidatorReward = big.NewInt(params.Ether)
+)
+
+// NewCeloSimulatedBackendWithDatabase creates a new binding backend based on the
+// given database, whose genesis preloads the Celo core contracts next to the
+// given accounts: a Registry with a GoldToken, a StableToken usable as fee
+// currency, SortedOracles, a FeeCurrencyWhitelist and a GasPriceMinimum. The
+// given validators make up the validator set until another one is elected.
+//
+// Accounts of the alloc at the addresses of the core contracts are replaced.
+// The system calls of the protocol made on the states of the backend go to its
+// blockchain, until it's closed.
+func NewCeloSimulatedBackendWithDatabase(database ethdb.Database, alloc core.GenesisAlloc, validators []common.Address) *SimulatedBackend {
+	owner, err := crypto.GenerateKey()
+	if err != nil {
+		panic(err)
+	}
+	ownerAddress := crypto.PubkeyToAddress(owner.PublicKey)
+
+	genesisAlloc := make(core.GenesisAlloc)
+	for address, account := range alloc {
+		genesisAlloc[address] = account
+	}
+	genesisAlloc[ownerAddress] = core.GenesisAccount{Balance: ownerBalance}
+	for address, account := range CeloGenesisAlloc(ownerAddress) {
+		genesisAlloc[address] = account
+	}
+	genesis := core.Genesis{Config: params.IstanbulTestChainConfig, Alloc: genesisAlloc}
+
+	validatorSet := make([]istanbul.Validator, len(validators))
+	for i, address := range validators {
+		validatorSet[i] = validator.New(address, blscrypto.SerializedPublicKey{})
+	}
+	engine := mockEngine.NewFakerWithValidators(validatorSet, genesis.Config.Istanbul.Epoch, EpochValidatorReward)
+
+	backend := newSimulatedBackend(database, &genesis, engine)
+	backend.owner = owner
+	contract_comm.BindInternalEVMHandler(database, backend.blockchain)
+	return backend
+}
+
+// NewCeloSimulatedBackend creates a new binding backend using a simulated
+// blockchain preloaded with the Celo core contracts for testing purposes.
+func NewCeloSimulatedBackend(alloc core.GenesisAlloc, validators []common.Address) *SimulatedBackend {
+	return NewCeloSimulatedBackendWithDatabase(rawdb.NewMemoryDatabase(), alloc, validators)
+}
+
+// SetExchangeRate adds a transaction to the pending block reporting that a
+// CELO is worth numerator/denominator of the token, as the median rate of the
+// SortedOracles.
+func (b *SimulatedBackend) SetExchangeRate(token common.Address, numerator, denominator *big.Int) error {
+	if numerator.Sign() <= 0 || denominator.Sign() <= 0 || numerator.Cmp(maxRate) >= 0 || denominator.Cmp(maxRate) >= 0 {
+		return errInvalidExchangeRate
+	}
+	return b.ownerTransact(SortedOraclesAddress, "setMedianRate", token, numerator, denominator)
+}
+
+// MintStableToken adds a transaction to the pending block minting the amount
+// of StableToken to the account.
+func (b *SimulatedBackend) MintStableToken(to common.Address, amount *big.Int) error {
+	if amount.Sign() <= 0 {
+		return errInvalidMintAmount
+	}
+	return b.ownerTransact(StableTokenAddress, "mint", to, amount)
+}
+
+// ownerTransact adds a transaction from the owner of the core contracts
+// calling the method of the contract to the pending block.
+func (b *SimulatedBackend) ownerTransact(contract common.Address, method string, args ...interface{}) error {
+	if b.owner == nil {
+		return errNoCoreContracts
+	}
+	data, err := ownerFuncABI.Pack(method, args...)
+	if err != nil {
+		return err
+	}
+	nonce, err := b.PendingNonceAt(context.Background(), crypto.PubkeyToAddress(b.owner.PublicKey))
+	if err != nil {
+		return err
+	}
+	gasPrice, err := b.SuggestGasPrice(context.Background())
+	if err != nil {
+		return err
+	}
+	tx := types.NewTransaction(nonce, contract, new(big.Int), ownerGas, gasPrice, nil, nil, nil, data)
+	signed, err := types.SignTx(tx, types.NewEIP155Signer(b.config.ChainID), b.owner)
+	if err != nil {
+		return err
+	}
+	return b.SendTransaction(context.Background(), signed)
+}
+
+// ElectValidators sets the validator set elected at the end of the current and
+// following epochs. It makes up the validator set from the next epoch on.
+func (b *SimulatedBackend) ElectValidators(validators []common.Address) error {
+	if b.owner == nil {
+		return errNoCoreContracts
+	}
+	validatorSet := make([]istanbul.Validator, len(validators))
+	for i, address := range validators {
+		validatorSet[i] = validator.New(address, blscrypto.SerializedPublicKey{})
+	}
+	b.engine.Elect(validatorSet)
+	return nil
+}
+
+// AdvanceEpoch imports the pending block, followed by empty blocks up to the
+// last block of the epoch, so that the next imported block starts a new epoch.
+// The last block runs the epoch processing: the validators of the epoch are
+// paid EpochValidatorReward and the elected validator set takes effect.
+func (b *SimulatedBackend) AdvanceEpoch() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for {
+		if _, err := b.blockchain.InsertChain([]*types.Block{b.pendingBlock}); err != nil {
+			panic(err) // This cannot happen unless the simulator is wrong, fail in that case
+		}
+		b.rollback()
+		if istanbul.IsLastBlockOfEpoch(b.blockchain.CurrentBlock().NumberU64(), b.config.Istanbul.Epoch) {
+			return
+		}
+	}
+}
@@ -0,0 +1,888 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package backends
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/asm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// The core contracts preloaded by NewCeloSimulatedBackend are minimal stand-ins
+// of the celo-monorepo ones, written in EVM assembly. They implement the
+// functions the protocol itself calls and the ERC20 functions of the tokens,
+// with the same ABI, plus a few setters restricted to the owner of the
+// simulated backend.
+
+// registrySource maps registry identifiers to addresses, which are stored in
+// the slot of the identifier itself.
+const registrySource = `
+	PUSH 0
+	CALLDATALOAD
+	PUSH 224
+	SHR
+	;; getAddressFor(bytes32)
+	PUSH 0xdd927233
+	EQ
+	JUMPI @getAddressFor
+	PUSH 0
+	DUP1
+	REVERT
+
+getAddressFor:
+	PUSH 4
+	CALLDATALOAD
+	SLOAD
+	PUSH 0
+	MSTORE
+	PUSH 32
+	PUSH 0
+	RETURN
+`
+
+// goldTokenSource exposes the native balances as an ERC20 token, moved with
+// the transfer precompile. The allowances are a nested mapping at slot 3.
+const goldTokenSource = `
+	PUSH 0
+	CALLDATALOAD
+	PUSH 224
+	SHR
+	;; balanceOf(address)
+	DUP1
+	PUSH 0x70a08231
+	EQ
+	JUMPI @balanceOf
+	;; transfer(address,uint256)
+	DUP1
+	PUSH 0xa9059cbb
+	EQ
+	JUMPI @transfer
+	;; transferFrom(address,address,uint256)
+	DUP1
+	PUSH 0x23b872dd
+	EQ
+	JUMPI @transferFrom
+` + allowanceDispatchSource + `
+fail:
+	PUSH 0
+	DUP1
+	REVERT
+
+returnTrue:
+	PUSH 1
+	PUSH 0
+	MSTORE
+	PUSH 32
+	PUSH 0
+	RETURN
+
+balanceOf:
+	PUSH 4
+	CALLDATALOAD
+	BALANCE
+	PUSH 0
+	MSTORE
+	PUSH 32
+	PUSH 0
+	RETURN
+
+transfer:
+	PUSH @transferMoved
+	CALLER
+	PUSH 4
+	CALLDATALOAD
+	PUSH 36
+	CALLDATALOAD
+	JUMP @move
+transferMoved:
+	PUSH 36
+	CALLDATALOAD
+	PUSH 0
+	MSTORE
+	PUSH 4
+	CALLDATALOAD
+	CALLER
+	;; Transfer(address,address,uint256)
+	PUSH 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef
+	PUSH 32
+	PUSH 0
+	LOG3
+	JUMP @returnTrue
+
+transferFrom:
+	PUSH @transferFromSpent
+	PUSH 4
+	CALLDATALOAD
+	PUSH 68
+	CALLDATALOAD
+	JUMP @spendAllowance
+transferFromSpent:
+	PUSH @transferFromMoved
+	PUSH 4
+	CALLDATALOAD
+	PUSH 36
+	CALLDATALOAD
+	PUSH 68
+	CALLDATALOAD
+	JUMP @move
+transferFromMoved:
+	PUSH 68
+	CALLDATALOAD
+	PUSH 0
+	MSTORE
+	PUSH 36
+	CALLDATALOAD
+	PUSH 4
+	CALLDATALOAD
+	;; Transfer(address,address,uint256)
+	PUSH 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef
+	PUSH 32
+	PUSH 0
+	LOG3
+	JUMP @returnTrue
+
+;; move(ret, from, to, amount) transfers the amount of CELO with the transfer
+;; precompile, reverting if it fails.
+move:
+	PUSH 64
+	MSTORE
+	PUSH 32
+	MSTORE
+	PUSH 0
+	MSTORE
+	PUSH 0
+	DUP1
+	PUSH 96
+	PUSH 0
+	DUP1
+	;; transfer precompile
+	PUSH 0xfd
+	GAS
+	CALL
+	ISZERO
+	JUMPI @fail
+	JUMP
+` + allowanceSource
+
+// allowanceDispatchSource dispatches the allowance functions of an ERC20 token
+// to allowanceSource.
+const allowanceDispatchSource = `
+	;; approve(address,uint256)
+	DUP1
+	PUSH 0x095ea7b3
+	EQ
+	JUMPI @approve
+	;; allowance(address,address)
+	DUP1
+	PUSH 0xdd62ed3e
+	EQ
+	JUMPI @allowance
+`
+
+// allowanceSource implements the allowances of an ERC20 token, stored as a
+// nested mapping from owner to spender at slot 3. It relies on the fail and
+// returnTrue labels of the token.
+const allowanceSource = `
+approve:
+	PUSH @approveSlot
+	CALLER
+	PUSH 4
+	CALLDATALOAD
+	JUMP @allowanceSlot
+approveSlot:
+	PUSH 36
+	CALLDATALOAD
+	SWAP1
+	SSTORE
+	PUSH 36
+	CALLDATALOAD
+	PUSH 0
+	MSTORE
+	PUSH 4
+	CALLDATALOAD
+	CALLER
+	;; Approval(address,address,uint256)
+	PUSH 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925
+	PUSH 32
+	PUSH 0
+	LOG3
+	JUMP @returnTrue
+
+allowance:
+	PUSH @allowanceOfSlot
+	PUSH 4
+	CALLDATALOAD
+	PUSH 36
+	CALLDATALOAD
+	JUMP @allowanceSlot
+allowanceOfSlot:
+	SLOAD
+	PUSH 0
+	MSTORE
+	PUSH 32
+	PUSH 0
+	RETURN
+
+;; spendAllowance(ret, owner, amount) subtracts the amount from the allowance
+;; of the caller by the owner, reverting if it is insufficient.
+spendAllowance:
+	SWAP1
+	PUSH @spendAllowanceSlot
+	SWAP1
+	CALLER
+	JUMP @allowanceSlot
+spendAllowanceSlot:
+	DUP1
+	SLOAD
+	DUP3
+	DUP2
+	LT
+	JUMPI @fail
+	DUP3
+	SWAP1
+	SUB
+	SWAP1
+	SSTORE
+	POP
+	JUMP
+
+;; allowanceSlot(ret, owner, spender) returns the storage slot of the allowance
+;; of the spender by the owner.
+allowanceSlot:
+	SWAP1
+	PUSH 0
+	MSTORE
+	PUSH 3
+	PUSH 32
+	MSTORE
+	PUSH 64
+	PUSH 0
+	SHA3
+	PUSH 32
+	MSTORE
+	PUSH 0
+	MSTORE
+	PUSH 64
+	PUSH 0
+	SHA3
+	SWAP1
+	JUMP
+`
+
+// stableTokenSource is an ERC20 token usable as fee currency. Slot 0 holds the
+// owner, slot 1 the total supply, the balances are a mapping at slot 2 and the
+// allowances a nested mapping at slot 3.
+const stableTokenSource = `
+	PUSH 0
+	CALLDATALOAD
+	PUSH 224
+	SHR
+	;; balanceOf(address)
+	DUP1
+	PUSH 0x70a08231
+	EQ
+	JUMPI @balanceOf
+	;; totalSupply()
+	DUP1
+	PUSH 0x18160ddd
+	EQ
+	JUMPI @totalSupply
+	;; transfer(address,uint256)
+	DUP1
+	PUSH 0xa9059cbb
+	EQ
+	JUMPI @transfer
+	;; transferFrom(address,address,uint256)
+	DUP1
+	PUSH 0x23b872dd
+	EQ
+	JUMPI @transferFrom
+` + allowanceDispatchSource + `
+	;; mint(address,uint256)
+	DUP1
+	PUSH 0x40c10f19
+	EQ
+	JUMPI @mint
+	;; debitGasFees(address,uint256)
+	DUP1
+	PUSH 0x58cf9672
+	EQ
+	JUMPI @debitGasFees
+	;; creditGasFees(address,address,address,address,uint256,uint256,uint256,uint256)
+	DUP1
+	PUSH 0x6a30b253
+	EQ
+	JUMPI @creditGasFees
+fail:
+	PUSH 0
+	DUP1
+	REVERT
+
+returnTrue:
+	PUSH 1
+	PUSH 0
+	MSTORE
+	PUSH 32
+	PUSH 0
+	RETURN
+
+balanceOf:
+	PUSH @balanceOfSlot
+	PUSH 4
+	CALLDATALOAD
+	JUMP @balanceSlot
+balanceOfSlot:
+	SLOAD
+	PUSH 0
+	MSTORE
+	PUSH 32
+	PUSH 0
+	RETURN
+
+totalSupply:
+	PUSH 1
+	SLOAD
+	PUSH 0
+	MSTORE
+	PUSH 32
+	PUSH 0
+	RETURN
+
+transfer:
+	PUSH @transferDebited
+	CALLER
+	PUSH 36
+	CALLDATALOAD
+	JUMP @debit
+transferDebited:
+	PUSH @transferCredited
+	PUSH 4
+	CALLDATALOAD
+	PUSH 36
+	CALLDATALOAD
+	JUMP @credit
+transferCredited:
+	PUSH 36
+	CALLDATALOAD
+	PUSH 0
+	MSTORE
+	PUSH 4
+	CALLDATALOAD
+	CALLER
+	;; Transfer(address,address,uint256)
+	PUSH 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef
+	PUSH 32
+	PUSH 0
+	LOG3
+	JUMP @returnTrue
+
+transferFrom:
+	PUSH @transferFromSpent
+	PUSH 4
+	CALLDATALOAD
+	PUSH 68
+	CALLDATALOAD
+	JUMP @spendAllowance
+transferFromSpent:
+	PUSH @transferFromDebited
+	PUSH 4
+	CALLDATALOAD
+	PUSH 68
+	CALLDATALOAD
+	JUMP @debit
+transferFromDebited:
+	PUSH @transferFromCredited
+	PUSH 36
+	CALLDATALOAD
+	PUSH 68
+	CALLDATALOAD
+	JUMP @credit
+transferFromCredited:
+	PUSH 68
+	CALLDATALOAD
+	PUSH 0
+	MSTORE
+	PUSH 36
+	CALLDATALOAD
+	PUSH 4
+	CALLDATALOAD
+	;; Transfer(address,address,uint256)
+	PUSH 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef
+	PUSH 32
+	PUSH 0
+	LOG3
+	JUMP @returnTrue
+
+mint:
+	PUSH 0
+	SLOAD
+	CALLER
+	EQ
+	ISZERO
+	JUMPI @fail
+	PUSH @minted
+	PUSH 4
+	CALLDATALOAD
+	PUSH 36
+	CALLDATALOAD
+	JUMP @credit
+minted:
+	PUSH 36
+	CALLDATALOAD
+	PUSH 1
+	SLOAD
+	ADD
+	PUSH 1
+	SSTORE
+	PUSH 36
+	CALLDATALOAD
+	PUSH 0
+	MSTORE
+	PUSH 4
+	CALLDATALOAD
+	PUSH 0
+	;; Transfer(address,address,uint256)
+	PUSH 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef
+	PUSH 32
+	PUSH 0
+	LOG3
+	JUMP @returnTrue
+
+debitGasFees:
+	;; only the VM may debit and credit gas fees
+	CALLER
+	JUMPI @fail
+	PUSH @gasFeesDebited
+	PUSH 4
+	CALLDATALOAD
+	PUSH 36
+	CALLDATALOAD
+	JUMP @debit
+gasFeesDebited:
+	PUSH 36
+	CALLDATALOAD
+	PUSH 1
+	SLOAD
+	SUB
+	PUSH 1
+	SSTORE
+	STOP
+
+creditGasFees:
+	CALLER
+	JUMPI @fail
+	;; refund to the sender
+	PUSH @refundCredited
+	PUSH 4
+	CALLDATALOAD
+	PUSH 132
+	CALLDATALOAD
+	JUMP @credit
+refundCredited:
+	;; tip to the fee recipient
+	PUSH @tipCredited
+	PUSH 36
+	CALLDATALOAD
+	PUSH 164
+	CALLDATALOAD
+	JUMP @credit
+tipCredited:
+	;; gateway fee to the gateway fee recipient
+	PUSH @gatewayFeeCredited
+	PUSH 68
+	CALLDATALOAD
+	PUSH 196
+	CALLDATALOAD
+	JUMP @credit
+gatewayFeeCredited:
+	;; base fee to the community fund
+	PUSH @baseFeeCredited
+	PUSH 100
+	CALLDATALOAD
+	PUSH 228
+	CALLDATALOAD
+	JUMP @credit
+baseFeeCredited:
+	PUSH 132
+	CALLDATALOAD
+	PUSH 164
+	CALLDATALOAD
+	ADD
+	PUSH 196
+	CALLDATALOAD
+	ADD
+	PUSH 228
+	CALLDATALOAD
+	ADD
+	PUSH 1
+	SLOAD
+	ADD
+	PUSH 1
+	SSTORE
+	STOP
+
+;; credit(ret, account, amount) adds the amount to the balance of the account.
+credit:
+	SWAP1
+	PUSH @creditSlot
+	SWAP1
+	JUMP @balanceSlot
+creditSlot:
+	DUP1
+	SLOAD
+	DUP3
+	ADD
+	SWAP1
+	SSTORE
+	POP
+	JUMP
+
+;; debit(ret, account, amount) subtracts the amount from the balance of the
+;; account, reverting if it is insufficient.
+debit:
+	SWAP1
+	PUSH @debitSlot
+	SWAP1
+	JUMP @balanceSlot
+debitSlot:
+	DUP1
+	SLOAD
+	DUP3
+	DUP2
+	LT
+	JUMPI @fail
+	DUP3
+	SWAP1
+	SUB
+	SWAP1
+	SSTORE
+	POP
+	JUMP
+
+;; balanceSlot(ret, account) returns the storage slot of the balance.
+balanceSlot:
+	PUSH 0
+	MSTORE
+	PUSH 2
+	PUSH 32
+	MSTORE
+	PUSH 64
+	PUSH 0
+	SHA3
+	SWAP1
+	JUMP
+` + allowanceSource
+
+// sortedOraclesSource reports the exchange rate of a token against CELO as
+// set by the owner, instead of the median of the oracle reports. Slot 0 holds
+// the owner, the numerators and denominators are mappings at slots 1 and 2.
+const sortedOraclesSource = `
+	PUSH 0
+	CALLDATALOAD
+	PUSH 224
+	SHR
+	;; medianRate(address)
+	DUP1
+	PUSH 0xef90e1b0
+	EQ
+	JUMPI @medianRate
+	;; setMedianRate(address,uint256,uint256)
+	DUP1
+	PUSH 0xab9a30aa
+	EQ
+	JUMPI @setMedianRate
+fail:
+	PUSH 0
+	DUP1
+	REVERT
+
+medianRate:
+	PUSH 4
+	CALLDATALOAD
+	PUSH 0
+	MSTORE
+	PUSH 1
+	PUSH 32
+	MSTORE
+	PUSH 64
+	PUSH 0
+	SHA3
+	SLOAD
+	PUSH 64
+	MSTORE
+	PUSH 2
+	PUSH 32
+	MSTORE
+	PUSH 64
+	PUSH 0
+	SHA3
+	SLOAD
+	;; tokens without a rate have no median
+	DUP1
+	ISZERO
+	JUMPI @fail
+	PUSH 96
+	MSTORE
+	PUSH 64
+	PUSH 64
+	RETURN
+
+setMedianRate:
+	PUSH 0
+	SLOAD
+	CALLER
+	EQ
+	ISZERO
+	JUMPI @fail
+	PUSH 68
+	CALLDATALOAD
+	ISZERO
+	JUMPI @fail
+	PUSH 4
+	CALLDATALOAD
+	PUSH 0
+	MSTORE
+	PUSH 1
+	PUSH 32
+	MSTORE
+	PUSH 36
+	CALLDATALOAD
+	PUSH 64
+	PUSH 0
+	SHA3
+	SSTORE
+	PUSH 2
+	PUSH 32
+	MSTORE
+	PUSH 68
+	CALLDATALOAD
+	PUSH 64
+	PUSH 0
+	SHA3
+	SSTORE
+	STOP
+`
+
+// feeCurrencyWhitelistSource returns the whitelisted fee currencies, stored as
+// a dynamic array at slot 0.
+const feeCurrencyWhitelistSource = `
+	PUSH 0
+	CALLDATALOAD
+	PUSH 224
+	SHR
+	;; getWhitelist()
+	PUSH 0xd01f63f5
+	EQ
+	JUMPI @getWhitelist
+	PUSH 0
+	DUP1
+	REVERT
+
+getWhitelist:
+	PUSH 32
+	PUSH 0
+	MSTORE
+	PUSH 0
+	SLOAD
+	DUP1
+	PUSH 32
+	MSTORE
+	PUSH 0
+loop:
+	DUP2
+	DUP2
+	LT
+	ISZERO
+	JUMPI @done
+	DUP1
+	;; keccak256(0), the start of the array elements
+	PUSH 0x290decd9548b62a8d60345a988386fc84ba6bc95484008f6362f93160ef3e563
+	ADD
+	SLOAD
+	DUP2
+	PUSH 32
+	MUL
+	PUSH 64
+	ADD
+	MSTORE
+	PUSH 1
+	ADD
+	JUMP @loop
+done:
+	POP
+	PUSH 32
+	MUL
+	PUSH 64
+	ADD
+	PUSH 0
+	RETURN
+`
+
+// gasPriceMinimumSource returns the gas price minimum in slot 0, converted to
+// the given token with the rate of the SortedOracles in slot 2 unless it is
+// the GoldToken in slot 1.
+const gasPriceMinimumSource = `
+	PUSH 0
+	CALLDATALOAD
+	PUSH 224
+	SHR
+	;; getGasPriceMinimum(address)
+	PUSH 0xa54b7fc0
+	EQ
+	JUMPI @getGasPriceMinimum
+fail:
+	PUSH 0
+	DUP1
+	REVERT
+
+getGasPriceMinimum:
+	PUSH 4
+	CALLDATALOAD
+	PUSH 1
+	SLOAD
+	EQ
+	JUMPI @native
+	;; medianRate(address)
+	PUSH 0xef90e1b0
+	PUSH 224
+	SHL
+	PUSH 0
+	MSTORE
+	PUSH 4
+	CALLDATALOAD
+	PUSH 4
+	MSTORE
+	PUSH 64
+	PUSH 0
+	PUSH 36
+	PUSH 0
+	PUSH 2
+	SLOAD
+	GAS
+	STATICCALL
+	ISZERO
+	JUMPI @fail
+	PUSH 32
+	MLOAD
+	PUSH 0
+	MLOAD
+	PUSH 0
+	SLOAD
+	MUL
+	DIV
+	PUSH 0
+	MSTORE
+	PUSH 32
+	PUSH 0
+	RETURN
+
+native:
+	PUSH 0
+	SLOAD
+	PUSH 0
+	MSTORE
+	PUSH 32
+	PUSH 0
+	RETURN
+`
+
+// Addresses of the core contracts preloaded by NewCeloSimulatedBackend. The
+// Registry lives at params.RegistrySmartContractAddress.
+var (
+	GoldTokenAddress            = common.HexToAddress("0x000000000000000000000000000000000000d001")
+	StableTokenAddress          = common.HexToAddress("0x000000000000000000000000000000000000d002")
+	SortedOraclesAddress        = common.HexToAddress("0x000000000000000000000000000000000000d003")
+	FeeCurrencyWhitelistAddress = common.HexToAddress("0x000000000000000000000000000000000000d004")
+	GasPriceMinimumAddress      = common.HexToAddress("0x000000000000000000000000000000000000d005")
+)
+
+var (
+	// defaultGasPriceMinimum is the gas price minimum in CELO the simulated
+	// chain starts with.
+	defaultGasPriceMinimum = big.NewInt(1)
+
+	// defaultExchangeRate is the number of StableTokens a CELO is worth when
+	// the simulated chain starts, as numerator and denominator.
+	defaultExchangeRate = [2]*big.Int{big.NewInt(1), big.NewInt(1)}
+
+	registryCode             = mustAssemble(registrySource)
+	goldTokenCode            = mustAssemble(goldTokenSource)
+	stableTokenCode          = mustAssemble(stableTokenSource)
+	sortedOraclesCode        = mustAssemble(sortedOraclesSource)
+	feeCurrencyWhitelistCode = mustAssemble(feeCurrencyWhitelistSource)
+	gasPriceMinimumCode      = mustAssemble(gasPriceMinimumSource)
+)
+
+// mustAssemble compiles the assembly of a core contract into its runtime code.
+func mustAssemble(source string) []byte {
+	compiler := asm.NewCompiler(false)
+	compiler.Feed(asm.Lex([]byte(source), false))
+	bin, errs := compiler.Compile()
+	if len(errs) != 0 {
+		panic(fmt.Sprintf("invalid core contract assembly: %v", errs))
+	}
+	code, err := hex.DecodeString(bin)
+	if err != nil {
+		panic(err)
+	}
+	return code
+}
+
+// mappingSlot returns the storage slot of the key in the mapping at the slot.
+func mappingSlot(key common.Hash, slot uint64) common.Hash {
+	return crypto.Keccak256Hash(key[:], common.BigToHash(new(big.Int).SetUint64(slot)).Bytes())
+}
+
+// CeloGenesisAlloc returns the genesis accounts of the core contracts preloaded
+// by NewCeloSimulatedBackend, owned by the given account.
+func CeloGenesisAlloc(owner common.Address) core.GenesisAlloc {
+	registry := map[common.Hash]common.Hash{
+		params.GoldTokenRegistryId:            GoldTokenAddress.Hash(),
+		params.StableTokenRegistryId:          StableTokenAddress.Hash(),
+		params.SortedOraclesRegistryId:        SortedOraclesAddress.Hash(),
+		params.FeeCurrencyWhitelistRegistryId: FeeCurrencyWhitelistAddress.Hash(),
+		params.GasPriceMinimumRegistryId:      GasPriceMinimumAddress.Hash(),
+	}
+	sortedOracles := map[common.Hash]common.Hash{
+		common.Hash{}: owner.Hash(),
+		mappingSlot(StableTokenAddress.Hash(), 1): common.BigToHash(defaultExchangeRate[0]),
+		mappingSlot(StableTokenAddress.Hash(), 2): common.BigToHash(defaultExchangeRate[1]),
+	}
+	whitelist := map[common.Hash]common.Hash{
+		common.Hash{}: common.BigToHash(common.Big1),
+		crypto.Keccak256Hash(common.Hash{}.Bytes()): StableTokenAddress.Hash(),
+	}
+	gasPriceMinimum := map[common.Hash]common.Hash{
+		common.BigToHash(common.Big0): common.BigToHash(defaultGasPriceMinimum),
+		common.BigToHash(common.Big1): GoldTokenAddress.Hash(),
+		common.BigToHash(common.Big2): SortedOraclesAddress.Hash(),
+	}
+	return core.GenesisAlloc{
+		params.RegistrySmartContractAddress: {Code: registryCode, Storage: registry, Balance: new(big.Int)},
+		GoldTokenAddress:                    {Code: goldTokenCode, Balance: new(big.Int)},
+		StableTokenAddress:                  {Code: stableTokenCode, Storage: map[common.Hash]common.Hash{{}: owner.Hash()}, Balance: new(big.Int)},
+		SortedOraclesAddress:                {Code: sortedOraclesCode, Storage: sortedOracles, Balance: new(big.Int)},
+		FeeCurrencyWhitelistAddress:         {Code: feeCurrencyWhitelistCode, Storage: whitelist, Balance: new(big.Int)},
+		GasPriceMinimumAddress:              {Code: gasPriceMinimumCode, Storage: gasPriceMinimum, Balance: new(big.Int)},
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package backends
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/contract_comm/errors"
+	gpm "github.com/ethereum/go-ethereum/contract_comm/gasprice_minimum"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func stableBalanceOf(t *testing.T, sim *SimulatedBackend, account common.Address) *big.Int {
+	data := append(common.FromHex("0x70a08231"), account.Hash().Bytes()...)
+	res, err := sim.CallContract(context.Background(), ethereum.CallMsg{To: &StableTokenAddress, Data: data}, nil)
+	if err != nil {
+		t.Fatalf("could not get the balance: %v", err)
+	}
+	return new(big.Int).SetBytes(res)
+}
+
+func callUint(t *testing.T, sim *SimulatedBackend, contract common.Address, data []byte) *big.Int {
+	res, err := sim.CallContract(context.Background(), ethereum.CallMsg{To: &contract, Data: data}, nil)
+	if err != nil {
+		t.Fatalf("could not call the contract: %v", err)
+	}
+	return new(big.Int).SetBytes(res)
+}
+
+// transact calls the contract from the account in a new block, returning
+// whether the call succeeded.
+func transact(t *testing.T, sim *SimulatedBackend, key *ecdsa.PrivateKey, contract common.Address, data []byte) bool {
+	nonce, err := sim.PendingNonceAt(context.Background(), crypto.PubkeyToAddress(key.PublicKey))
+	if err != nil {
+		t.Fatalf("could not get the nonce: %v", err)
+	}
+	tx := types.NewTransaction(nonce, contract, new(big.Int), 100000, big.NewInt(1), nil, nil, nil, data)
+	tx, _ = types.SignTx(tx, types.NewEIP155Signer(sim.config.ChainID), key)
+	if err := sim.SendTransaction(context.Background(), tx); err != nil {
+		t.Fatalf("could not send the transaction: %v", err)
+	}
+	sim.Commit()
+
+	receipt, err := sim.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		t.Fatalf("could not get the receipt: %v", err)
+	}
+	return receipt.Status == types.ReceiptStatusSuccessful
+}
+
+func TestCeloSimulatedBackend_FeeCurrency(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	to := common.HexToAddress("0x1")
+	sim := NewCeloSimulatedBackend(core.GenesisAlloc{from: {Balance: big.NewInt(params.Ether)}}, nil)
+	defer sim.Close()
+
+	minted := big.NewInt(params.Ether)
+	if err := sim.MintStableToken(from, minted); err != nil {
+		t.Fatalf("could not mint: %v", err)
+	}
+	if err := sim.SetExchangeRate(StableTokenAddress, big.NewInt(2), big.NewInt(1)); err != nil {
+		t.Fatalf("could not set the exchange rate: %v", err)
+	}
+	sim.Commit()
+	if balance := stableBalanceOf(t, sim, from); balance.Cmp(minted) != 0 {
+		t.Fatalf("stable balance mismatch: have %v, want %v", balance, minted)
+	}
+
+	// The gas price minimum follows the exchange rate
+	statedb, _ := sim.blockchain.State()
+	minimum, err := gpm.GetGasPriceMinimum(&StableTokenAddress, sim.blockchain.CurrentHeader(), statedb)
+	if err != nil || minimum.Cmp(big.NewInt(2)) != 0 {
+		t.Fatalf("gas price minimum mismatch: have %v (%v), want 2", minimum, err)
+	}
+
+	// Pay for a transfer of CELO in StableToken
+	gasPrice := big.NewInt(3)
+	value := big.NewInt(1000)
+	tx := types.NewTransaction(0, to, value, 100000, gasPrice, &StableTokenAddress, nil, nil, nil)
+	tx, _ = types.SignTx(tx, types.NewEIP155Signer(sim.config.ChainID), key)
+	if err := sim.SendTransaction(context.Background(), tx); err != nil {
+		t.Fatalf("could not send the transaction: %v", err)
+	}
+	sim.Commit()
+
+	receipt, err := sim.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		t.Fatalf("could not get the receipt: %v", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatal("transaction failed")
+	}
+	// Without a Governance contract the base fee is refunded, only the tip is paid
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), new(big.Int).Sub(gasPrice, minimum))
+	if balance, want := stableBalanceOf(t, sim, from), new(big.Int).Sub(minted, fee); balance.Cmp(want) != 0 {
+		t.Errorf("stable balance mismatch: have %v, want %v", balance, want)
+	}
+	if balance := stableBalanceOf(t, sim, common.Address{}); balance.Cmp(fee) != 0 {
+		t.Errorf("fee recipient balance mismatch: have %v, want %v", balance, fee)
+	}
+	if balance, _ := sim.BalanceAt(context.Background(), from, nil); balance.Cmp(new(big.Int).Sub(big.NewInt(params.Ether), value)) != 0 {
+		t.Errorf("gas was paid in CELO: balance %v", balance)
+	}
+}
+
+func TestCeloSimulatedBackend_Precompiles(t *testing.T) {
+	validators := []common.Address{common.HexToAddress("0xa"), common.HexToAddress("0xb")}
+	sim := NewCeloSimulatedBackend(core.GenesisAlloc{}, validators)
+	defer sim.Close()
+	sim.Commit()
+
+	epochSizeAddress := common.BytesToAddress([]byte{0, vm.CeloPrecompiledContractsAddressOffset - 7})
+	res, err := sim.CallContract(context.Background(), ethereum.CallMsg{To: &epochSizeAddress}, nil)
+	if err != nil {
+		t.Fatalf("could not get the epoch size: %v", err)
+	}
+	if size := new(big.Int).SetBytes(res); size.Uint64() != params.IstanbulTestChainConfig.Istanbul.Epoch {
+		t.Errorf("epoch size mismatch: have %v, want %d", size, params.IstanbulTestChainConfig.Istanbul.Epoch)
+	}
+
+	getValidatorAddress := common.BytesToAddress([]byte{0, vm.CeloPrecompiledContractsAddressOffset - 5})
+	for i, want := range validators {
+		input := append(common.BigToHash(big.NewInt(int64(i))).Bytes(), common.BigToHash(common.Big1).Bytes()...)
+		res, err := sim.CallContract(context.Background(), ethereum.CallMsg{To: &getValidatorAddress, Data: input}, nil)
+		if err != nil {
+			t.Fatalf("could not get validator %d: %v", i, err)
+		}
+		if have := common.BytesToAddress(res); have != want {
+			t.Errorf("validator %d mismatch: have %x, want %x", i, have, want)
+		}
+	}
+}
+
+func TestCeloSimulatedBackend_Allowances(t *testing.T) {
+	ownerKey, _ := crypto.GenerateKey()
+	spenderKey, _ := crypto.GenerateKey()
+	owner := crypto.PubkeyToAddress(ownerKey.PublicKey)
+	spender := crypto.PubkeyToAddress(spenderKey.PublicKey)
+	to := common.HexToAddress("0x1")
+
+	sim := NewCeloSimulatedBackend(core.GenesisAlloc{
+		owner:   {Balance: big.NewInt(params.Ether)},
+		spender: {Balance: big.NewInt(params.Ether)},
+	}, nil)
+	defer sim.Close()
+
+	if err := sim.MintStableToken(owner, big.NewInt(1000)); err != nil {
+		t.Fatalf("could not mint: %v", err)
+	}
+	sim.Commit()
+
+	var (
+		approve = func(amount int64) []byte {
+			data := append(common.FromHex("0x095ea7b3"), spender.Hash().Bytes()...)
+			return append(data, common.BigToHash(big.NewInt(amount)).Bytes()...)
+		}
+		allowance    = append(append(common.FromHex("0xdd62ed3e"), owner.Hash().Bytes()...), spender.Hash().Bytes()...)
+		transferFrom = func(amount int64) []byte {
+			data := append(append(common.FromHex("0x23b872dd"), owner.Hash().Bytes()...), to.Hash().Bytes()...)
+			return append(data, common.BigToHash(big.NewInt(amount)).Bytes()...)
+		}
+		balanceOf = func(token common.Address, account common.Address) *big.Int {
+			if token == GoldTokenAddress {
+				balance, _ := sim.BalanceAt(context.Background(), account, nil)
+				return balance
+			}
+			return stableBalanceOf(t, sim, account)
+		}
+	)
+	for _, token := range []common.Address{GoldTokenAddress, StableTokenAddress} {
+		if !transact(t, sim, ownerKey, token, approve(100)) {
+			t.Fatalf("%x: approval failed", token)
+		}
+		if have := callUint(t, sim, token, allowance); have.Cmp(big.NewInt(100)) != 0 {
+			t.Fatalf("%x: allowance mismatch: have %v, want 100", token, have)
+		}
+		ownerBalance, toBalance := balanceOf(token, owner), balanceOf(token, to)
+		if !transact(t, sim, spenderKey, token, transferFrom(60)) {
+			t.Fatalf("%x: transfer failed", token)
+		}
+		if have := callUint(t, sim, token, allowance); have.Cmp(big.NewInt(40)) != 0 {
+			t.Errorf("%x: allowance mismatch: have %v, want 40", token, have)
+		}
+		if have, want := balanceOf(token, owner), new(big.Int).Sub(ownerBalance, big.NewInt(60)); have.Cmp(want) != 0 {
+			t.Errorf("%x: owner balance mismatch: have %v, want %v", token, have, want)
+		}
+		if have, want := balanceOf(token, to), new(big.Int).Add(toBalance, big.NewInt(60)); have.Cmp(want) != 0 {
+			t.Errorf("%x: recipient balance mismatch: have %v, want %v", token, have, want)
+		}
+		// Transfers beyond the allowance fail
+		if transact(t, sim, spenderKey, token, transferFrom(50)) {
+			t.Errorf("%x: transfer beyond the allowance succeeded", token)
+		}
+		if have := callUint(t, sim, token, allowance); have.Cmp(big.NewInt(40)) != 0 {
+			t.Errorf("%x: allowance mismatch after failed transfer: have %v, want 40", token, have)
+		}
+	}
+}
+
+func TestCeloSimulatedBackend_AdvanceEpoch(t *testing.T) {
+	first, second := common.HexToAddress("0xa"), common.HexToAddress("0xb")
+	sim := NewCeloSimulatedBackend(core.GenesisAlloc{}, []common.Address{first})
+	defer sim.Close()
+
+	epoch := params.IstanbulTestChainConfig.Istanbul.Epoch
+	validatorOf := func(number uint64) common.Address {
+		validators := sim.engine.GetValidators(new(big.Int).SetUint64(number), common.Hash{})
+		if len(validators) != 1 {
+			t.Fatalf("block %d: validator set size mismatch: have %d, want 1", number, len(validators))
+		}
+		return validators[0].Address()
+	}
+	checkRewards := func(firstRewards, secondRewards int64) {
+		for validator, rewards := range map[common.Address]int64{first: firstRewards, second: secondRewards} {
+			balance, _ := sim.BalanceAt(context.Background(), validator, nil)
+			if want := new(big.Int).Mul(EpochValidatorReward, big.NewInt(rewards)); balance.Cmp(want) != 0 {
+				t.Errorf("validator %x balance mismatch: have %v, want %v", validator, balance, want)
+			}
+		}
+	}
+
+	sim.AdvanceEpoch()
+	if number := sim.blockchain.CurrentBlock().NumberU64(); number != epoch {
+		t.Fatalf("block number mismatch: have %d, want %d", number, epoch)
+	}
+	checkRewards(1, 0)
+
+	// The election at the end of the second epoch changes the validators of the third
+	if err := sim.ElectValidators([]common.Address{second}); err != nil {
+		t.Fatalf("could not elect the validators: %v", err)
+	}
+	sim.AdvanceEpoch()
+	if number := sim.blockchain.CurrentBlock().NumberU64(); number != 2*epoch {
+		t.Fatalf("block number mismatch: have %d, want %d", number, 2*epoch)
+	}
+	checkRewards(2, 0)
+	if have := validatorOf(2 * epoch); have != first {
+		t.Errorf("validator of the second epoch mismatch: have %x, want %x", have, first)
+	}
+	if have := validatorOf(2*epoch + 1); have != second {
+		t.Errorf("validator of the third epoch mismatch: have %x, want %x", have, second)
+	}
+
+	sim.AdvanceEpoch()
+	checkRewards(2, 1)
+}
+
+func TestCeloSimulatedBackend_MultipleBackends(t *testing.T) {
+	sims := make([]*SimulatedBackend, 3)
+	for i := range sims {
+		sims[i] = NewCeloSimulatedBackend(core.GenesisAlloc{}, nil)
+		defer sims[i].Close()
+
+		if err := sims[i].SetExchangeRate(StableTokenAddress, big.NewInt(int64(i+1)), common.Big1); err != nil {
+			t.Fatalf("backend %d: could not set the exchange rate: %v", i, err)
+		}
+		sims[i].Commit()
+	}
+	// The system calls on the states of every backend are made on its own chain
+	for i, sim := range sims {
+		statedb, err := sim.blockchain.State()
+		if err != nil {
+			t.Fatalf("backend %d: could not get the state: %v", i, err)
+		}
+		minimum, err := gpm.GetGasPriceMinimum(&StableTokenAddress, nil, statedb)
+		if err != nil || minimum.Cmp(big.NewInt(int64(i+1))) != 0 {
+			t.Errorf("backend %d: gas price minimum mismatch: have %v (%v), want %d", i, minimum, err, i+1)
+		}
+	}
+	// Closed backends are no longer used for system calls
+	statedb, err := sims[0].blockchain.State()
+	if err != nil {
+		t.Fatalf("could not get the state: %v", err)
+	}
+	sims[0].Close()
+	if _, err := contract_comm.GetRegisteredAddress(params.GasPriceMinimumRegistryId, nil, statedb); err != errors.ErrNoInternalEvmHandlerSingleton {
+		t.Errorf("system call error mismatch after closing: have %v, want %v", err, errors.ErrNoInternalEvmHandlerSingleton)
+	}
+}
+
+func TestSimulatedBackend_NoCoreContracts(t *testing.T) {
+	sim := NewSimulatedBackend(core.GenesisAlloc{})
+	defer sim.Close()
+
+	if err := sim.MintStableToken(common.HexToAddress("0x1"), common.Big1); err != errNoCoreContracts {
+		t.Errorf("mint error mismatch: have %v, want %v", err, errNoCoreContracts)
+	}
+	if err := sim.ElectValidators(nil); err != errNoCoreContracts {
+		t.Errorf("election error mismatch: have %v, want %v", err, errNoCoreContracts)
+	}
+}
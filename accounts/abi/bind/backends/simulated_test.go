@@ -824,3 +824,67 @@ func TestSimulatedBackend_PendingAndCallContract(t *testing.T) {
 		t.Errorf("response from calling contract was expected to be 'hello world' instead received %v", string(res))
 	}
 }
+
+// revertSource is the assembly of a contract reverting every call with the
+// reason "revert reason", encoded as Error(string).
+const revertSource = `
+	PUSH 0x08c379a000000000000000000000000000000000000000000000000000000000
+	PUSH 0
+	MSTORE
+	PUSH 0x20
+	PUSH 4
+	MSTORE
+	PUSH 13
+	PUSH 36
+	MSTORE
+	PUSH 0x72657665727420726561736f6e00000000000000000000000000000000000000
+	PUSH 68
+	MSTORE
+	PUSH 100
+	PUSH 0
+	REVERT
+`
+
+func TestSimulatedBackend_RevertReason(t *testing.T) {
+	testAddr := crypto.PubkeyToAddress(testKey.PublicKey)
+	revertAddr := common.HexToAddress("0x1000")
+	sim := NewSimulatedBackend(
+		core.GenesisAlloc{
+			testAddr:   {Balance: big.NewInt(10000000000)},
+			revertAddr: {Balance: common.Big0, Code: mustAssemble(revertSource)},
+		},
+	)
+	defer sim.Close()
+	bgCtx := context.Background()
+
+	checkRevert := func(name string, err error) {
+		revertErr, ok := err.(*abi.RevertError)
+		if !ok {
+			t.Fatalf("%s: expected revert error, got %v", name, err)
+		}
+		if revertErr.Reason != "revert reason" {
+			t.Errorf("%s: revert reason mismatch: have %q, want %q", name, revertErr.Reason, "revert reason")
+		}
+		if len(revertErr.Data) != 100 {
+			t.Errorf("%s: revert data length mismatch: have %d, want %d", name, len(revertErr.Data), 100)
+		}
+		if revertErr.Error() != "execution reverted: revert reason" {
+			t.Errorf("%s: error message mismatch: have %q", name, revertErr.Error())
+		}
+	}
+	msg := ethereum.CallMsg{From: testAddr, To: &revertAddr}
+
+	_, err := sim.PendingCallContract(bgCtx, msg)
+	checkRevert("PendingCallContract", err)
+
+	_, err = sim.CallContract(bgCtx, msg, nil)
+	checkRevert("CallContract", err)
+
+	_, err = sim.EstimateGas(bgCtx, msg)
+	checkRevert("EstimateGas", err)
+
+	// Bound contracts must surface the revert error untouched
+	contract := bind.NewBoundContract(revertAddr, abi.ABI{}, sim, sim, sim)
+	err = contract.Call(nil, nil, "")
+	checkRevert("BoundContract.Call", err)
+}
@@ -41,6 +41,7 @@ const (
 	LangGo Lang = iota
 	LangJava
 	LangObjC
+	LangGoSystemCall // Go system call bindings around the core contracts
 )
 
 // Bind generates a Go wrapper around a contract ABI. This wrapper isn't meant
@@ -220,7 +221,7 @@ func Bind(types []string, abis []string, bytecodes []string, fsigs []map[string]
 		return "", err
 	}
 	// For Go bindings pass the code through gofmt to clean it up
-	if lang == LangGo {
+	if lang == LangGo || lang == LangGoSystemCall {
 		code, err := format.Source(buffer.Bytes())
 		if err != nil {
 			return "", fmt.Errorf("%v\n%s", err, buffer)
@@ -234,8 +235,9 @@ func Bind(types []string, abis []string, bytecodes []string, fsigs []map[string]
 // bindType is a set of type binders that convert Solidity types to some supported
 // programming language types.
 var bindType = map[Lang]func(kind abi.Type, structs map[string]*tmplStruct) string{
-	LangGo:   bindTypeGo,
-	LangJava: bindTypeJava,
+	LangGo:           bindTypeGo,
+	LangGoSystemCall: bindTypeGo,
+	LangJava:         bindTypeJava,
 }
 
 // bindBasicTypeGo converts basic solidity types(except array, slice and tuple) to Go one.
@@ -356,8 +358,9 @@ func bindTypeJava(kind abi.Type, structs map[string]*tmplStruct) string {
 // bindTopicType is a set of type binders that convert Solidity types to some
 // supported programming language topic types.
 var bindTopicType = map[Lang]func(kind abi.Type, structs map[string]*tmplStruct) string{
-	LangGo:   bindTopicTypeGo,
-	LangJava: bindTopicTypeJava,
+	LangGo:           bindTopicTypeGo,
+	LangGoSystemCall: bindTopicTypeGo,
+	LangJava:         bindTopicTypeJava,
 }
 
 // bindTopicTypeGo converts a Solidity topic type to a Go one. It is almost the same
@@ -397,8 +400,9 @@ func bindTopicTypeJava(kind abi.Type, structs map[string]*tmplStruct) string {
 // bindStructType is a set of type binders that convert Solidity tuple types to some supported
 // programming language struct definition.
 var bindStructType = map[Lang]func(kind abi.Type, structs map[string]*tmplStruct) string{
-	LangGo:   bindStructTypeGo,
-	LangJava: bindStructTypeJava,
+	LangGo:           bindStructTypeGo,
+	LangGoSystemCall: bindStructTypeGo,
+	LangJava:         bindStructTypeJava,
 }
 
 // bindStructTypeGo converts a Solidity tuple type to a Go one and records the mapping
@@ -480,8 +484,9 @@ func bindStructTypeJava(kind abi.Type, structs map[string]*tmplStruct) string {
 // namedType is a set of functions that transform language specific types to
 // named versions that my be used inside method names.
 var namedType = map[Lang]func(string, abi.Type) string{
-	LangGo:   func(string, abi.Type) string { panic("this shouldn't be needed") },
-	LangJava: namedTypeJava,
+	LangGo:           func(string, abi.Type) string { panic("this shouldn't be needed") },
+	LangGoSystemCall: func(string, abi.Type) string { panic("this shouldn't be needed") },
+	LangJava:         namedTypeJava,
 }
 
 // namedTypeJava converts some primitive data types to named variants that can
@@ -522,8 +527,9 @@ func alias(aliases map[string]string, n string) string {
 // methodNormalizer is a name transformer that modifies Solidity method names to
 // conform to target language naming concentions.
 var methodNormalizer = map[Lang]func(string) string{
-	LangGo:   abi.ToCamelCase,
-	LangJava: decapitalise,
+	LangGo:           abi.ToCamelCase,
+	LangGoSystemCall: abi.ToCamelCase,
+	LangJava:         decapitalise,
 }
 
 // capitalise makes a camel-case string which starts with an upper case character.
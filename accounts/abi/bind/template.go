@@ -72,8 +72,9 @@ type tmplStruct struct {
 // tmplSource is language to template mapping containing all the supported
 // programming languages the package can generate to.
 var tmplSource = map[Lang]string{
-	LangGo:   tmplSourceGo,
-	LangJava: tmplSourceJava,
+	LangGo:           tmplSourceGo,
+	LangJava:         tmplSourceJava,
+	LangGoSystemCall: tmplSourceGoSystemCall,
 }
 
 // tmplSourceGo is the Go source template use to generate the contract binding
@@ -523,6 +524,120 @@ var (
 {{end}}
 `
 
+// tmplSourceGoSystemCall is the Go source template use to generate the system
+// call bindings around the core contracts based on.
+const tmplSourceGoSystemCall = `
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package {{.Package}}
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contract_comm"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = abi.U256
+	_ = common.Big1
+	_ = contract_comm.NewRegisteredContract
+)
+
+{{$structs := .Structs}}
+{{range $structs}}
+	// {{.Name}} is an auto generated low-level Go binding around an user-defined struct.
+	type {{.Name}} struct {
+	{{range $field := .Fields}}
+	{{$field.Name}} {{$field.Type}}{{end}}
+	}
+{{end}}
+
+{{range $contract := .Contracts}}
+	// {{.Type}}ABI is the input ABI used to generate the binding from.
+	const {{.Type}}ABI = "{{.InputABI}}"
+
+	// {{.Type}} is an auto generated Go binding around a core contract, calling
+	// it from the system.
+	type {{.Type}} struct {
+	  contract *contract_comm.SystemContract // Generic contract wrapper for the low level calls
+	}
+
+	// New{{.Type}} creates a new instance of {{.Type}}, bound to the core contract
+	// registered under the identifier.
+	func New{{.Type}}(registryId [32]byte) (*{{.Type}}, error) {
+	  parsed, err := abi.JSON(strings.NewReader({{.Type}}ABI))
+	  if err != nil {
+	    return nil, err
+	  }
+	  return &{{.Type}}{contract: contract_comm.NewRegisteredContract(registryId, parsed)}, nil
+	}
+
+	// New{{.Type}}At creates a new instance of {{.Type}}, bound to a specific deployed contract.
+	func New{{.Type}}At(address common.Address) (*{{.Type}}, error) {
+	  parsed, err := abi.JSON(strings.NewReader({{.Type}}ABI))
+	  if err != nil {
+	    return nil, err
+	  }
+	  return &{{.Type}}{contract: contract_comm.NewSystemContractAt(address, parsed)}, nil
+	}
+
+	// At returns an instance of {{.Type}} bound to a specific deployed contract,
+	// reusing the parsed ABI.
+	func (_{{$contract.Type}} *{{$contract.Type}}) At(address common.Address) *{{$contract.Type}} {
+	  return &{{$contract.Type}}{contract: _{{$contract.Type}}.contract.At(address)}
+	}
+
+	{{range .Calls}}
+		// {{.Normalized.Name}} is a free data retrieval system call binding the contract method 0x{{printf "%x" .Original.ID}}.
+		//
+		// Solidity: {{formatmethod .Original $structs}}
+		func (_{{$contract.Type}} *{{$contract.Type}}) {{.Normalized.Name}}(opts *contract_comm.SystemCallOpts {{range .Normalized.Inputs}}, {{.Name}} {{bindtype .Type $structs}} {{end}}) ({{if .Structured}}struct{ {{range .Normalized.Outputs}}{{.Name}} {{bindtype .Type $structs}};{{end}} },{{else}}{{range .Normalized.Outputs}}{{bindtype .Type $structs}},{{end}}{{end}} uint64, error) {
+			{{if .Structured}}ret := new(struct{
+				{{range .Normalized.Outputs}}{{.Name}} {{bindtype .Type $structs}}
+				{{end}}
+			}){{else if .Normalized.Outputs}}var (
+				{{range $i, $_ := .Normalized.Outputs}}ret{{$i}} = new({{bindtype .Type $structs}})
+				{{end}}
+			){{end}}
+			{{if .Structured}}out := ret{{else}}{{if eq (len .Normalized.Outputs) 0}}var out interface{}{{else if eq (len .Normalized.Outputs) 1}}out := ret0{{else}}out := &[]interface{}{
+				{{range $i, $_ := .Normalized.Outputs}}ret{{$i}},
+				{{end}}
+			}{{end}}{{end}}
+			leftoverGas, err := _{{$contract.Type}}.contract.StaticCall(opts, out, "{{.Original.Name}}" {{range .Normalized.Inputs}}, {{.Name}}{{end}})
+			return {{if .Structured}}*ret,{{else}}{{range $i, $_ := .Normalized.Outputs}}*ret{{$i}},{{end}}{{end}} leftoverGas, err
+		}
+	{{end}}
+
+	{{range .Transacts}}
+		// {{.Normalized.Name}} is a state mutating system call binding the contract method 0x{{printf "%x" .Original.ID}}.
+		//
+		// Solidity: {{formatmethod .Original $structs}}
+		func (_{{$contract.Type}} *{{$contract.Type}}) {{.Normalized.Name}}(opts *contract_comm.SystemCallOpts {{range .Normalized.Inputs}}, {{.Name}} {{bindtype .Type $structs}} {{end}}) ({{if .Structured}}struct{ {{range .Normalized.Outputs}}{{.Name}} {{bindtype .Type $structs}};{{end}} },{{else}}{{range .Normalized.Outputs}}{{bindtype .Type $structs}},{{end}}{{end}} uint64, error) {
+			{{if .Structured}}ret := new(struct{
+				{{range .Normalized.Outputs}}{{.Name}} {{bindtype .Type $structs}}
+				{{end}}
+			}){{else if .Normalized.Outputs}}var (
+				{{range $i, $_ := .Normalized.Outputs}}ret{{$i}} = new({{bindtype .Type $structs}})
+				{{end}}
+			){{end}}
+			{{if .Structured}}out := ret{{else}}{{if eq (len .Normalized.Outputs) 0}}var out interface{}{{else if eq (len .Normalized.Outputs) 1}}out := ret0{{else}}out := &[]interface{}{
+				{{range $i, $_ := .Normalized.Outputs}}ret{{$i}},
+				{{end}}
+			}{{end}}{{end}}
+			leftoverGas, err := _{{$contract.Type}}.contract.Call(opts, out, "{{.Original.Name}}" {{range .Normalized.Inputs}}, {{.Name}}{{end}})
+			return {{if .Structured}}*ret,{{else}}{{range $i, $_ := .Normalized.Outputs}}*ret{{$i}},{{end}}{{end}} leftoverGas, err
+		}
+	{{end}}
+{{end}}
+`
+
 // tmplSourceJava is the Java source template use to generate the contract binding
 // based on.
 const tmplSourceJava = `
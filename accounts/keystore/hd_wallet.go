@@ -0,0 +1,462 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"sync"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	blscrypto "github.com/ethereum/go-ethereum/crypto/bls"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// hdWalletDir is the subdirectory of the keystore holding the HD wallet files.
+	// Being a directory, it is skipped by the account cache.
+	hdWalletDir = "hd"
+
+	// hdWalletVersion is the version of the HD wallet file format.
+	hdWalletVersion = 1
+)
+
+var (
+	// ErrHDWalletExists is returned when importing a mnemonic whose wallet is
+	// already present in the keystore.
+	ErrHDWalletExists = errors.New("hd wallet already exists")
+
+	errInvalidHDKey = errors.New("invalid hd key derived, use the next index")
+)
+
+// hdWalletJSON is the on-disk format of an HD wallet. The BIP-39 seed is
+// encrypted the same way as plain keys are, the derived accounts are kept in
+// the clear so that they can be listed without the passphrase.
+type hdWalletJSON struct {
+	Id       string          `json:"id"`
+	Version  int             `json:"version"`
+	Crypto   CryptoJSON      `json:"crypto"`
+	Accounts []hdAccountJSON `json:"accounts"`
+}
+
+type hdAccountJSON struct {
+	Address common.Address `json:"address"`
+	Path    string         `json:"path"`
+}
+
+// hdWallet implements the accounts.Wallet interface for a hierarchical
+// deterministic wallet backed by an encrypted BIP-39 seed in the keystore.
+type hdWallet struct {
+	url      accounts.URL // Location of the wallet file
+	keystore *KeyStore    // Keystore the wallet belongs to
+
+	id       string                                     // Unique identifier of the wallet file
+	crypto   CryptoJSON                                 // Encrypted BIP-39 seed
+	accounts []accounts.Account                         // Derived accounts pinned to the wallet
+	paths    map[common.Address]accounts.DerivationPath // Derivation paths of the pinned accounts
+	seed     []byte                                     // Decrypted seed while the wallet is open
+
+	mu sync.RWMutex
+}
+
+// loadHDWallet reads the HD wallet stored in the given file.
+func loadHDWallet(ks *KeyStore, file string) (*hdWallet, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var stored hdWalletJSON
+	if err := json.Unmarshal(content, &stored); err != nil {
+		return nil, err
+	}
+	if stored.Version != hdWalletVersion {
+		return nil, fmt.Errorf("hd wallet version not supported: %v", stored.Version)
+	}
+	w := &hdWallet{
+		url:      accounts.URL{Scheme: KeyStoreScheme, Path: file},
+		keystore: ks,
+		id:       stored.Id,
+		crypto:   stored.Crypto,
+		paths:    make(map[common.Address]accounts.DerivationPath),
+	}
+	for _, account := range stored.Accounts {
+		path, err := accounts.ParseDerivationPath(account.Path)
+		if err != nil {
+			return nil, err
+		}
+		w.accounts = append(w.accounts, accounts.Account{Address: account.Address, URL: w.accountURL(path)})
+		w.paths[account.Address] = path
+	}
+	return w, nil
+}
+
+// store writes the wallet into its file. The caller must hold the wallet lock.
+func (w *hdWallet) store() error {
+	stored := hdWalletJSON{
+		Id:      w.id,
+		Version: hdWalletVersion,
+		Crypto:  w.crypto,
+	}
+	for _, account := range w.accounts {
+		stored.Accounts = append(stored.Accounts, hdAccountJSON{Address: account.Address, Path: w.paths[account.Address].String()})
+	}
+	content, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	return writeKeyFile(w.url.Path, content)
+}
+
+// accountURL returns the URL of the account derived at the given path.
+func (w *hdWallet) accountURL(path accounts.DerivationPath) accounts.URL {
+	return accounts.URL{Scheme: w.url.Scheme, Path: fmt.Sprintf("%s/%s", w.url.Path, path)}
+}
+
+// pin adds the account derived at the given path to the wallet and persists
+// it. The caller must hold the wallet lock.
+func (w *hdWallet) pin(account accounts.Account, path accounts.DerivationPath) error {
+	if _, ok := w.paths[account.Address]; ok {
+		return nil
+	}
+	w.accounts = append(w.accounts, account)
+	w.paths[account.Address] = path
+	if err := w.store(); err != nil {
+		w.accounts = w.accounts[:len(w.accounts)-1]
+		delete(w.paths, account.Address)
+		return err
+	}
+	return nil
+}
+
+// derive derives the account at the given path from the seed and optionally
+// pins it to the wallet. The caller must hold the wallet lock.
+func (w *hdWallet) derive(seed []byte, path accounts.DerivationPath, pin bool) (accounts.Account, error) {
+	key, err := deriveKey(seed, path)
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	defer zeroKey(key)
+
+	account := accounts.Account{Address: crypto.PubkeyToAddress(key.PublicKey), URL: w.accountURL(path)}
+	if pin {
+		if err := w.pin(account, path); err != nil {
+			return accounts.Account{}, err
+		}
+	}
+	return account, nil
+}
+
+// decryptKey decrypts the seed with the given passphrase and derives the key
+// of a pinned account from it.
+func (w *hdWallet) decryptKey(a accounts.Account, auth string) (*Key, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	path, ok := w.paths[a.Address]
+	if !ok {
+		return nil, ErrNoMatch
+	}
+	seed, err := DecryptDataV3(w.crypto, auth)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroBytes(seed)
+
+	key, err := deriveKey(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	// Make sure we're really operating on the requested key (no swap attacks)
+	if address := crypto.PubkeyToAddress(key.PublicKey); address != a.Address {
+		zeroKey(key)
+		return nil, fmt.Errorf("key content mismatch: have account %x, want %x", address, a.Address)
+	}
+	return newKeyFromECDSA(key), nil
+}
+
+// find returns the pinned account matching the given one, if any.
+func (w *hdWallet) find(a accounts.Account) (accounts.Account, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	path, ok := w.paths[a.Address]
+	if !ok {
+		return accounts.Account{}, false
+	}
+	account := accounts.Account{Address: a.Address, URL: w.accountURL(path)}
+	if a.URL != (accounts.URL{}) && a.URL != account.URL {
+		return accounts.Account{}, false
+	}
+	return account, true
+}
+
+// URL implements accounts.Wallet, returning the URL of the wallet file.
+func (w *hdWallet) URL() accounts.URL {
+	return w.url
+}
+
+// Status implements accounts.Wallet, returning whether the seed of the wallet
+// is currently decrypted or not.
+func (w *hdWallet) Status() (string, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.seed != nil {
+		return "Unlocked", nil
+	}
+	return "Locked", nil
+}
+
+// Open implements accounts.Wallet, decrypting the seed of the wallet so that
+// new accounts can be derived.
+func (w *hdWallet) Open(passphrase string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.seed != nil {
+		return accounts.ErrWalletAlreadyOpen
+	}
+	seed, err := DecryptDataV3(w.crypto, passphrase)
+	if err != nil {
+		return err
+	}
+	w.seed = seed
+	return nil
+}
+
+// Close implements accounts.Wallet, dropping the decrypted seed from memory.
+// Unlocked accounts stay unlocked.
+func (w *hdWallet) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.seed != nil {
+		zeroBytes(w.seed)
+		w.seed = nil
+	}
+	return nil
+}
+
+// Accounts implements accounts.Wallet, returning the list of accounts pinned to
+// the wallet.
+func (w *hdWallet) Accounts() []accounts.Account {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	cpy := make([]accounts.Account, len(w.accounts))
+	copy(cpy, w.accounts)
+	return cpy
+}
+
+// Contains implements accounts.Wallet, returning whether a particular account is
+// or is not pinned into this wallet instance.
+func (w *hdWallet) Contains(account accounts.Account) bool {
+	_, ok := w.find(account)
+	return ok
+}
+
+// Derive implements accounts.Wallet, deriving a new account at the specific
+// derivation path. If pin is set to true, the account will be added to the list
+// of tracked accounts and persisted in the wallet file. The wallet needs to be
+// open.
+func (w *hdWallet) Derive(path accounts.DerivationPath, pin bool) (accounts.Account, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.seed == nil {
+		return accounts.Account{}, accounts.ErrWalletClosed
+	}
+	return w.derive(w.seed, path, pin)
+}
+
+// ConfirmAddress implements accounts.Wallet, but is a noop for keystore wallets
+// since there is no device to display the address on.
+func (w *hdWallet) ConfirmAddress(path accounts.DerivationPath) (common.Address, error) {
+	return common.Address{}, accounts.ErrNotSupported
+}
+
+// SelfDerive implements accounts.Wallet, but is a noop for keystore HD wallets
+// since accounts are derived explicitly and persisted in the wallet file.
+func (w *hdWallet) SelfDerive(bases []accounts.DerivationPath, chain ethereum.ChainStateReader) {
+}
+
+// Decrypt decrypts an ECIES ciphertext.
+func (w *hdWallet) Decrypt(account accounts.Account, c, s1, s2 []byte) ([]byte, error) {
+	if !w.Contains(account) {
+		log.Debug(accounts.ErrUnknownAccount.Error(), "account", account)
+		return nil, accounts.ErrUnknownAccount
+	}
+	return w.keystore.Decrypt(account, c, s1, s2)
+}
+
+// SignData signs keccak256(data). The mimetype parameter describes the type of data being signed
+func (w *hdWallet) SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
+	return w.SignHash(account, crypto.Keccak256(data))
+}
+
+// SignDataWithPassphrase signs keccak256(data) with the account decrypted with
+// the given passphrase.
+func (w *hdWallet) SignDataWithPassphrase(account accounts.Account, passphrase, mimeType string, data []byte) ([]byte, error) {
+	if !w.Contains(account) {
+		log.Debug(accounts.ErrUnknownAccount.Error(), "account", account)
+		return nil, accounts.ErrUnknownAccount
+	}
+	return w.keystore.SignHashWithPassphrase(account, passphrase, crypto.Keccak256(data))
+}
+
+// SignHash implements accounts.Wallet, attempting to sign the given hash with
+// the given unlocked account.
+func (w *hdWallet) SignHash(account accounts.Account, hash []byte) ([]byte, error) {
+	if !w.Contains(account) {
+		log.Debug(accounts.ErrUnknownAccount.Error(), "account", account)
+		return nil, accounts.ErrUnknownAccount
+	}
+	return w.keystore.SignHash(account, hash)
+}
+
+// SignText implements accounts.Wallet, attempting to sign the hash of the
+// given text with the given unlocked account.
+func (w *hdWallet) SignText(account accounts.Account, text []byte) ([]byte, error) {
+	return w.SignHash(account, accounts.TextHash(text))
+}
+
+// SignTextWithPassphrase implements accounts.Wallet, attempting to sign the
+// hash of the given text with the account decrypted with the given passphrase.
+func (w *hdWallet) SignTextWithPassphrase(account accounts.Account, passphrase string, text []byte) ([]byte, error) {
+	if !w.Contains(account) {
+		log.Debug(accounts.ErrUnknownAccount.Error(), "account", account)
+		return nil, accounts.ErrUnknownAccount
+	}
+	return w.keystore.SignHashWithPassphrase(account, passphrase, accounts.TextHash(text))
+}
+
+func (w *hdWallet) SignBLS(account accounts.Account, msg []byte, extraData []byte, useComposite bool) (blscrypto.SerializedSignature, error) {
+	if !w.Contains(account) {
+		log.Debug(accounts.ErrUnknownAccount.Error(), "account", account)
+		return blscrypto.SerializedSignature{}, accounts.ErrUnknownAccount
+	}
+	return w.keystore.SignBLS(account, msg, extraData, useComposite)
+}
+
+func (w *hdWallet) GenerateProofOfPossession(account accounts.Account, address common.Address) ([]byte, []byte, error) {
+	if !w.Contains(account) {
+		log.Debug(accounts.ErrUnknownAccount.Error(), "account", account)
+		return nil, nil, accounts.ErrUnknownAccount
+	}
+	return w.keystore.GenerateProofOfPossession(account, address)
+}
+
+func (w *hdWallet) GenerateProofOfPossessionBLS(account accounts.Account, address common.Address) ([]byte, []byte, error) {
+	if !w.Contains(account) {
+		log.Debug(accounts.ErrUnknownAccount.Error(), "account", account)
+		return nil, nil, accounts.ErrUnknownAccount
+	}
+	return w.keystore.GenerateProofOfPossessionBLS(account, address)
+}
+
+func (w *hdWallet) GetPublicKey(account accounts.Account) (*ecdsa.PublicKey, error) {
+	if !w.Contains(account) {
+		log.Debug(accounts.ErrUnknownAccount.Error(), "account", account)
+		return nil, accounts.ErrUnknownAccount
+	}
+	return w.keystore.GetPublicKey(account)
+}
+
+// SignTx implements accounts.Wallet, attempting to sign the given transaction
+// with the given unlocked account.
+func (w *hdWallet) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if !w.Contains(account) {
+		log.Debug(accounts.ErrUnknownAccount.Error(), "account", account)
+		return nil, accounts.ErrUnknownAccount
+	}
+	return w.keystore.SignTx(account, tx, chainID)
+}
+
+// SignTxWithPassphrase implements accounts.Wallet, attempting to sign the given
+// transaction with the given account using passphrase as extra authentication.
+func (w *hdWallet) SignTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if !w.Contains(account) {
+		log.Debug(accounts.ErrUnknownAccount.Error(), "account", account)
+		return nil, accounts.ErrUnknownAccount
+	}
+	return w.keystore.SignTxWithPassphrase(account, passphrase, tx, chainID)
+}
+
+// deriveKey derives the private key at the given path from a BIP-39 seed, as
+// specified by BIP-32.
+func deriveKey(seed []byte, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	var (
+		curve = crypto.S256()
+		n     = curve.Params().N
+	)
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key, chainCode := new(big.Int).SetBytes(sum[:32]), sum[32:]
+	if key.Sign() == 0 || key.Cmp(n) >= 0 {
+		return nil, errInvalidHDKey
+	}
+	for _, index := range path {
+		var data []byte
+		if index >= 0x80000000 {
+			// Hardened child: 0x00 || ser256(k) || ser32(i)
+			data = append([]byte{0}, math.PaddedBigBytes(key, 32)...)
+		} else {
+			// Normal child: serP(point(k)) || ser32(i)
+			x, y := curve.ScalarBaseMult(math.PaddedBigBytes(key, 32))
+			data = crypto.CompressPubkey(&ecdsa.PublicKey{Curve: curve, X: x, Y: y})
+		}
+		data = append(data, 0, 0, 0, 0)
+		binary.BigEndian.PutUint32(data[len(data)-4:], index)
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+
+		tweak := new(big.Int).SetBytes(sum[:32])
+		if tweak.Cmp(n) >= 0 {
+			return nil, errInvalidHDKey
+		}
+		key = tweak.Add(tweak, key)
+		key.Mod(key, n)
+		if key.Sign() == 0 {
+			return nil, errInvalidHDKey
+		}
+		chainCode = sum[32:]
+	}
+	return crypto.ToECDSA(math.PaddedBigBytes(key, 32))
+}
+
+// zeroBytes zeroes a byte slice in memory.
+func zeroBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
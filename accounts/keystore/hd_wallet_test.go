@@ -0,0 +1,177 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"encoding/hex"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// Tests key derivation against the test vector 1 of BIP-32.
+func TestDeriveKeyBIP32Vector(t *testing.T) {
+	seed := common.FromHex("000102030405060708090a0b0c0d0e0f")
+	tests := []struct {
+		path string
+		key  string
+	}{
+		{"m/0'", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{"m/0'/1", "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{"m/0'/1/2'", "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+		{"m/0'/1/2'/2", "0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4"},
+		{"m/0'/1/2'/2/1000000000", "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+	}
+	for _, tt := range tests {
+		path, err := accounts.ParseDerivationPath(tt.path)
+		if err != nil {
+			t.Fatalf("%s: invalid path: %v", tt.path, err)
+		}
+		key, err := deriveKey(seed, path)
+		if err != nil {
+			t.Fatalf("%s: derivation failed: %v", tt.path, err)
+		}
+		if have := hex.EncodeToString(crypto.FromECDSA(key)); have != tt.key {
+			t.Errorf("%s: key mismatch: have %s, want %s", tt.path, have, tt.key)
+		}
+	}
+}
+
+func TestImportMnemonic(t *testing.T) {
+	dir, ks := tmpKeyStore(t, true)
+	defer os.RemoveAll(dir)
+
+	pass := "passwd"
+	if _, err := ks.ImportMnemonic("abandon abandon", "", pass); err == nil {
+		t.Fatal("expected invalid mnemonic to be rejected")
+	}
+	acc, err := ks.ImportMnemonic(testMnemonic, "", pass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, _ := deriveKey(testSeed(t, ""), accounts.DefaultBaseDerivationPath)
+	if want := crypto.PubkeyToAddress(key.PublicKey); acc.Address != want {
+		t.Fatalf("account address mismatch: have %x, want %x", acc.Address, want)
+	}
+	if _, err := ks.ImportMnemonic(testMnemonic, "", pass); err != ErrHDWalletExists {
+		t.Fatalf("duplicate import error mismatch: have %v, want %v", err, ErrHDWalletExists)
+	}
+	// A mnemonic passphrase yields a different wallet
+	other, err := ks.ImportMnemonic(testMnemonic, "TREZOR", pass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.Address == acc.Address {
+		t.Fatal("expected mnemonic passphrase to change the derived account")
+	}
+	if wallets := ks.Wallets(); len(wallets) != 2 {
+		t.Fatalf("wallet count mismatch: have %d, want 2", len(wallets))
+	}
+	// Accounts can be used with a passphrase and after unlocking
+	if _, err := ks.SignHashWithPassphrase(acc, pass, testSigData); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.SignHashWithPassphrase(acc, "invalid passwd", testSigData); err != ErrDecrypt {
+		t.Fatalf("signing with invalid passphrase error mismatch: have %v, want %v", err, ErrDecrypt)
+	}
+	if err := ks.Unlock(accounts.Account{Address: acc.Address}, pass); err != nil {
+		t.Fatal(err)
+	}
+	sig, err := ks.SignHash(acc, testSigData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pub, err := crypto.SigToPub(testSigData, sig); err != nil || crypto.PubkeyToAddress(*pub) != acc.Address {
+		t.Fatalf("signature recovered to wrong address: %v", err)
+	}
+	if err := ks.Delete(acc, pass); err != accounts.ErrNotSupported {
+		t.Fatalf("delete error mismatch: have %v, want %v", err, accounts.ErrNotSupported)
+	}
+}
+
+func TestDeriveHDAccount(t *testing.T) {
+	dir, ks := tmpKeyStore(t, true)
+	defer os.RemoveAll(dir)
+
+	pass := "passwd"
+	acc, err := ks.ImportMnemonic(testMnemonic, "", pass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := accounts.DerivationPath{0x80000000 + 44, 0x80000000 + 60, 0x80000000 + 0, 0, 0}
+	derived, err := ks.DeriveHDAccount(acc, path, pass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Known address of the test mnemonic on the Ethereum derivation path
+	if want := common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94"); derived.Address != want {
+		t.Fatalf("derived address mismatch: have %x, want %x", derived.Address, want)
+	}
+	// Deriving through the wallet interface requires opening the wallet
+	wallet := ks.Wallets()[0]
+	if _, err := wallet.Derive(accounts.DerivationPath{0x80000000 + 44, 0x80000000 + 52752, 0x80000000, 0, 1}, true); err != accounts.ErrWalletClosed {
+		t.Fatalf("derive on closed wallet error mismatch: have %v, want %v", err, accounts.ErrWalletClosed)
+	}
+	if err := wallet.Open("invalid passwd"); err != ErrDecrypt {
+		t.Fatalf("open error mismatch: have %v, want %v", err, ErrDecrypt)
+	}
+	if err := wallet.Open(pass); err != nil {
+		t.Fatal(err)
+	}
+	second, err := wallet.Derive(accounts.DerivationPath{0x80000000 + 44, 0x80000000 + 52752, 0x80000000, 0, 1}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wallet.Close()
+
+	// Derived accounts survive a restart and a passphrase change
+	if err := ks.Update(acc, pass, "newpasswd"); err != nil {
+		t.Fatal(err)
+	}
+	ks = NewKeyStore(dir, veryLightScryptN, veryLightScryptP)
+	wallets := ks.Wallets()
+	if len(wallets) != 1 {
+		t.Fatalf("wallet count mismatch: have %d, want 1", len(wallets))
+	}
+	want := []accounts.Account{acc, derived, second}
+	if have := wallets[0].Accounts(); len(have) != len(want) {
+		t.Fatalf("account count mismatch: have %d, want %d", len(have), len(want))
+	} else {
+		for i := range want {
+			if have[i] != want[i] {
+				t.Errorf("account %d mismatch: have %v, want %v", i, have[i], want[i])
+			}
+		}
+	}
+	if _, err := ks.SignHashWithPassphrase(second, "newpasswd", testSigData); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testSeed(t *testing.T, passphrase string) []byte {
+	seed, err := bip39.NewSeedWithErrorChecking(testMnemonic, passphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return seed
+}
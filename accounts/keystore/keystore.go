@@ -26,11 +26,13 @@ import (
 	crand "crypto/rand"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"math/big"
 	"os"
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"sort"
 	"sync"
 	"time"
 
@@ -45,6 +47,8 @@ import (
 	"github.com/ethereum/go-ethereum/crypto/ecies"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/pborman/uuid"
+	"github.com/tyler-smith/go-bip39"
 )
 
 var (
@@ -70,6 +74,7 @@ type KeyStore struct {
 	unlocked map[common.Address]*unlocked // Currently unlocked account (decrypted private keys)
 
 	wallets     []accounts.Wallet       // Wallet wrappers around the individual key files
+	hdWallets   []*hdWallet             // HD wallets stored in the hd subdirectory
 	updateFeed  event.Feed              // Event feed to notify wallet additions/removals
 	updateScope event.SubscriptionScope // Subscription scope tracking current live listeners
 	updating    bool                    // Whether the event notification loop is running
@@ -120,10 +125,36 @@ func (ks *KeyStore) init(keydir string) {
 	for i := 0; i < len(accs); i++ {
 		ks.wallets[i] = &keystoreWallet{account: accs[i], keystore: ks}
 	}
+	ks.loadHDWallets()
 }
 
-// Wallets implements accounts.Backend, returning all single-key wallets from the
-// keystore directory.
+// loadHDWallets reads the HD wallets from the hd subdirectory of the keystore.
+// The caller must hold the keystore lock.
+func (ks *KeyStore) loadHDWallets() {
+	dir := ks.storage.JoinPath(hdWalletDir)
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Warn("Failed to read hd wallet directory", "path", dir, "err", err)
+		}
+		return
+	}
+	for _, fi := range files {
+		if nonKeyFile(fi) {
+			continue
+		}
+		path := filepath.Join(dir, fi.Name())
+		wallet, err := loadHDWallet(ks, path)
+		if err != nil {
+			log.Warn("Failed to load hd wallet", "path", path, "err", err)
+			continue
+		}
+		ks.hdWallets = append(ks.hdWallets, wallet)
+	}
+}
+
+// Wallets implements accounts.Backend, returning all single-key wallets and HD
+// wallets from the keystore directory.
 func (ks *KeyStore) Wallets() []accounts.Wallet {
 	// Make sure the list of wallets is in sync with the account cache
 	ks.refreshWallets()
@@ -131,8 +162,14 @@ func (ks *KeyStore) Wallets() []accounts.Wallet {
 	ks.mu.RLock()
 	defer ks.mu.RUnlock()
 
-	cpy := make([]accounts.Wallet, len(ks.wallets))
+	cpy := make([]accounts.Wallet, len(ks.wallets), len(ks.wallets)+len(ks.hdWallets))
 	copy(cpy, ks.wallets)
+	if len(ks.hdWallets) > 0 {
+		for _, wallet := range ks.hdWallets {
+			cpy = append(cpy, wallet)
+		}
+		sort.Sort(accounts.WalletsByURL(cpy))
+	}
 	return cpy
 }
 
@@ -265,6 +302,9 @@ func (ks *KeyStore) Delete(a accounts.Account, passphrase string) error {
 	if err != nil {
 		return err
 	}
+	if _, wallet := ks.findHD(a); wallet != nil {
+		return accounts.ErrNotSupported
+	}
 	// The order is crucial here. The key is dropped from the
 	// cache after the file is gone so that a reload happening in
 	// between won't insert it into the cache again.
@@ -499,13 +539,33 @@ func (ks *KeyStore) TimedUnlock(a accounts.Account, passphrase string, timeout t
 	return nil
 }
 
-// Find resolves the given account into a unique entry in the keystore.
+// Find resolves the given account into a unique entry in the keystore. Key
+// files take precedence over accounts derived from HD wallets.
 func (ks *KeyStore) Find(a accounts.Account) (accounts.Account, error) {
 	ks.cache.maybeReload()
 	ks.cache.mu.Lock()
-	a, err := ks.cache.find(a)
+	found, err := ks.cache.find(a)
 	ks.cache.mu.Unlock()
-	return a, err
+	if err == ErrNoMatch {
+		if account, wallet := ks.findHD(a); wallet != nil {
+			return account, nil
+		}
+	}
+	return found, err
+}
+
+// findHD resolves the given account into an account pinned to one of the HD
+// wallets, returning the wallet it belongs to or nil if there is none.
+func (ks *KeyStore) findHD(a accounts.Account) (accounts.Account, *hdWallet) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	for _, wallet := range ks.hdWallets {
+		if account, ok := wallet.find(a); ok {
+			return account, wallet
+		}
+	}
+	return accounts.Account{}, nil
 }
 
 func (ks *KeyStore) getDecryptedKey(a accounts.Account, auth string) (accounts.Account, *Key, error) {
@@ -513,6 +573,10 @@ func (ks *KeyStore) getDecryptedKey(a accounts.Account, auth string) (accounts.A
 	if err != nil {
 		return a, nil, err
 	}
+	if _, wallet := ks.findHD(a); wallet != nil {
+		key, err := wallet.decryptKey(a, auth)
+		return a, key, err
+	}
 	key, err := ks.storage.GetKey(a.Address, a.URL.Path, auth)
 	return a, key, err
 }
@@ -557,13 +621,17 @@ func (ks *KeyStore) Export(a accounts.Account, passphrase, newPassphrase string)
 	if err != nil {
 		return nil, err
 	}
-	var N, P int
+	N, P := ks.scryptParams()
+	return EncryptKey(key, newPassphrase, N, P)
+}
+
+// scryptParams returns the scrypt parameters to encrypt exported keys and HD
+// wallets with.
+func (ks *KeyStore) scryptParams() (int, int) {
 	if store, ok := ks.storage.(*keyStorePassphrase); ok {
-		N, P = store.scryptN, store.scryptP
-	} else {
-		N, P = StandardScryptN, StandardScryptP
+		return store.scryptN, store.scryptP
 	}
-	return EncryptKey(key, newPassphrase, N, P)
+	return StandardScryptN, StandardScryptP
 }
 
 // Import stores the given encrypted JSON key into the key directory.
@@ -597,15 +665,115 @@ func (ks *KeyStore) importKey(key *Key, passphrase string) (accounts.Account, er
 	return a, nil
 }
 
-// Update changes the passphrase of an existing account.
+// Update changes the passphrase of an existing account. For accounts derived
+// from an HD wallet the passphrase of the whole wallet is changed.
 func (ks *KeyStore) Update(a accounts.Account, passphrase, newPassphrase string) error {
 	a, key, err := ks.getDecryptedKey(a, passphrase)
+	if key != nil {
+		defer zeroKey(key.PrivateKey)
+	}
 	if err != nil {
 		return err
 	}
+	if _, wallet := ks.findHD(a); wallet != nil {
+		return ks.updateHDWallet(wallet, passphrase, newPassphrase)
+	}
 	return ks.storage.StoreKey(a.URL.Path, key, newPassphrase)
 }
 
+// updateHDWallet re-encrypts the seed of an HD wallet with a new passphrase.
+func (ks *KeyStore) updateHDWallet(wallet *hdWallet, passphrase, newPassphrase string) error {
+	wallet.mu.Lock()
+	defer wallet.mu.Unlock()
+
+	seed, err := DecryptDataV3(wallet.crypto, passphrase)
+	if err != nil {
+		return err
+	}
+	defer zeroBytes(seed)
+
+	N, P := ks.scryptParams()
+	cryptoStruct, err := EncryptDataV3(seed, []byte(newPassphrase), N, P)
+	if err != nil {
+		return err
+	}
+	old := wallet.crypto
+	wallet.crypto = cryptoStruct
+	if err := wallet.store(); err != nil {
+		wallet.crypto = old
+		return err
+	}
+	return nil
+}
+
+// ImportMnemonic creates an HD wallet from the given BIP-39 mnemonic and
+// optional mnemonic passphrase, encrypting its seed with passphrase. The first
+// account on the default Celo derivation path is derived and returned.
+func (ks *KeyStore) ImportMnemonic(mnemonic, mnemonicPassphrase, passphrase string) (accounts.Account, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, mnemonicPassphrase)
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	defer zeroBytes(seed)
+
+	key, err := deriveKey(seed, accounts.DefaultBaseDerivationPath)
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	zeroKey(key)
+
+	for _, wallet := range ks.Wallets() {
+		if wallet, ok := wallet.(*hdWallet); ok && wallet.Contains(accounts.Account{Address: address}) {
+			return accounts.Account{}, ErrHDWalletExists
+		}
+	}
+	N, P := ks.scryptParams()
+	cryptoStruct, err := EncryptDataV3(seed, []byte(passphrase), N, P)
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	wallet := &hdWallet{
+		url:      accounts.URL{Scheme: KeyStoreScheme, Path: ks.storage.JoinPath(filepath.Join(hdWalletDir, keyFileName(address)))},
+		keystore: ks,
+		id:       uuid.NewRandom().String(),
+		crypto:   cryptoStruct,
+		paths:    make(map[common.Address]accounts.DerivationPath),
+	}
+	wallet.mu.Lock()
+	account, err := wallet.derive(seed, accounts.DefaultBaseDerivationPath, true)
+	wallet.mu.Unlock()
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	ks.mu.Lock()
+	ks.hdWallets = append(ks.hdWallets, wallet)
+	ks.mu.Unlock()
+
+	ks.updateFeed.Send(accounts.WalletEvent{Wallet: wallet, Kind: accounts.WalletArrived})
+	return account, nil
+}
+
+// DeriveHDAccount derives the account at the given path from the HD wallet
+// containing account a, and pins it to the wallet. The wallet seed is decrypted
+// with the given passphrase.
+func (ks *KeyStore) DeriveHDAccount(a accounts.Account, path accounts.DerivationPath, passphrase string) (accounts.Account, error) {
+	a, wallet := ks.findHD(a)
+	if wallet == nil {
+		return accounts.Account{}, ErrNoMatch
+	}
+	wallet.mu.Lock()
+	defer wallet.mu.Unlock()
+
+	seed, err := DecryptDataV3(wallet.crypto, passphrase)
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	defer zeroBytes(seed)
+
+	return wallet.derive(seed, path, true)
+}
+
 // ImportPreSaleKey decrypts the given Ethereum presale wallet and stores
 // a key file in the key directory. The key file is encrypted with the same passphrase.
 func (ks *KeyStore) ImportPreSaleKey(keyJSON []byte, passphrase string) (accounts.Account, error) {
@@ -101,6 +101,10 @@ var (
 		Name:  "alias",
 		Usage: "Comma separated aliases for function and event renaming, e.g. foo=bar",
 	}
+	syscallFlag = cli.BoolFlag{
+		Name:  "syscall",
+		Usage: "Generate Go system call bindings around core contracts (go only)",
+	}
 )
 
 func init() {
@@ -120,6 +124,7 @@ func init() {
 		outFlag,
 		langFlag,
 		aliasFlag,
+		syscallFlag,
 	}
 	app.Action = utils.MigrateFlags(abigen)
 	cli.CommandHelpTemplate = utils.OriginCommandHelpTemplate
@@ -142,6 +147,12 @@ func abigen(c *cli.Context) error {
 	default:
 		utils.Fatalf("Unsupported destination language \"%s\" (--lang)", c.GlobalString(langFlag.Name))
 	}
+	if c.GlobalBool(syscallFlag.Name) {
+		if lang != bind.LangGo {
+			utils.Fatalf("System call bindings can only be generated for go (--lang)")
+		}
+		lang = bind.LangGoSystemCall
+	}
 	// If the entire solidity code was specified, build and bind based on that
 	var (
 		abis    []string
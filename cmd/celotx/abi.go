@@ -0,0 +1,203 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+var bigT = reflect.TypeOf(new(big.Int))
+
+// packCall ABI encodes a call to the named method, converting the command line
+// arguments to the types of the method inputs.
+func packCall(contract *abi.ABI, name string, args []string) ([]byte, error) {
+	method, ok := contract.Methods[name]
+	if !ok {
+		return nil, fmt.Errorf("method '%s' not found in ABI", name)
+	}
+	if len(args) != len(method.Inputs) {
+		return nil, fmt.Errorf("method '%s' takes %d arguments, got %d", name, len(method.Inputs), len(args))
+	}
+	values := make([]interface{}, len(args))
+	for i, input := range method.Inputs {
+		value, err := parseArg(input.Type, args[i])
+		if err != nil {
+			return nil, fmt.Errorf("argument %d (%s %s): %v", i, input.Type, input.Name, err)
+		}
+		values[i] = value
+	}
+	return contract.Pack(name, values...)
+}
+
+// parseArg converts a command line argument to the Go type the ABI encoder
+// expects for the given type. Integers may be decimal or hex, byte types hex,
+// and arrays are given as JSON lists of the element values.
+func parseArg(t abi.Type, arg string) (interface{}, error) {
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		n, ok := math.ParseBig256(arg)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer '%s'", arg)
+		}
+		if t.T == abi.UintTy && n.Sign() < 0 {
+			return nil, errors.New("negative value for unsigned integer")
+		}
+		if t.Type == bigT {
+			return n, nil
+		}
+		v := reflect.New(t.Type).Elem()
+		if t.T == abi.UintTy {
+			if !n.IsUint64() || v.OverflowUint(n.Uint64()) {
+				return nil, fmt.Errorf("value %v overflows %s", n, t)
+			}
+			v.SetUint(n.Uint64())
+		} else {
+			if !n.IsInt64() || v.OverflowInt(n.Int64()) {
+				return nil, fmt.Errorf("value %v overflows %s", n, t)
+			}
+			v.SetInt(n.Int64())
+		}
+		return v.Interface(), nil
+
+	case abi.BoolTy:
+		return strconv.ParseBool(arg)
+
+	case abi.StringTy:
+		return arg, nil
+
+	case abi.AddressTy:
+		if !common.IsHexAddress(arg) {
+			return nil, fmt.Errorf("invalid address '%s'", arg)
+		}
+		return common.HexToAddress(arg), nil
+
+	case abi.BytesTy:
+		return hexutil.Decode(arg)
+
+	case abi.FixedBytesTy:
+		b, err := hexutil.Decode(arg)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != t.Size {
+			return nil, fmt.Errorf("want %d bytes, got %d", t.Size, len(b))
+		}
+		v := reflect.New(t.Type).Elem()
+		reflect.Copy(v, reflect.ValueOf(b))
+		return v.Interface(), nil
+
+	case abi.SliceTy, abi.ArrayTy:
+		var elems []json.RawMessage
+		if err := json.Unmarshal([]byte(arg), &elems); err != nil {
+			return nil, fmt.Errorf("invalid list '%s': %v", arg, err)
+		}
+		var v reflect.Value
+		if t.T == abi.SliceTy {
+			v = reflect.MakeSlice(t.Type, len(elems), len(elems))
+		} else {
+			if len(elems) != t.Size {
+				return nil, fmt.Errorf("want %d elements, got %d", t.Size, len(elems))
+			}
+			v = reflect.New(t.Type).Elem()
+		}
+		for i, elem := range elems {
+			// Accept both quoted and bare values, e.g. ["0x01", 2]
+			s := string(elem)
+			if err := json.Unmarshal(elem, &s); err != nil {
+				s = string(elem)
+			}
+			value, err := parseArg(*t.Elem, s)
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %v", i, err)
+			}
+			v.Index(i).Set(reflect.ValueOf(value))
+		}
+		return v.Interface(), nil
+	}
+	return nil, fmt.Errorf("unsupported argument type %s", t)
+}
+
+// decodedArg is a decoded argument of a contract call.
+type decodedArg struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// decodedCall is a contract call decoded using its ABI.
+type decodedCall struct {
+	Method string       `json:"method"`
+	Args   []decodedArg `json:"args"`
+}
+
+// unpackCall decodes the transaction payload as a call to one of the ABI methods.
+func unpackCall(contract *abi.ABI, data []byte) (*decodedCall, error) {
+	if len(data) < 4 {
+		return nil, errors.New("payload too short for a method call")
+	}
+	method, err := contract.MethodById(data)
+	if err != nil {
+		return nil, err
+	}
+	values, err := method.Inputs.UnpackValues(data[4:])
+	if err != nil {
+		return nil, err
+	}
+	call := &decodedCall{Method: method.Sig()}
+	for i, input := range method.Inputs {
+		call.Args = append(call.Args, decodedArg{
+			Name:  input.Name,
+			Type:  input.Type.String(),
+			Value: formatArg(input.Type, values[i]),
+		})
+	}
+	return call, nil
+}
+
+// formatArg renders a decoded argument of the given type, printing byte values
+// as hex.
+func formatArg(t abi.Type, value interface{}) string {
+	v := reflect.ValueOf(value)
+	switch t.T {
+	case abi.BytesTy, abi.FixedBytesTy:
+		b := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(b), v)
+		return hexutil.Encode(b)
+
+	case abi.AddressTy:
+		return value.(common.Address).Hex()
+
+	case abi.SliceTy, abi.ArrayTy:
+		elems := make([]string, v.Len())
+		for i := range elems {
+			elems[i] = formatArg(*t.Elem, v.Index(i).Interface())
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,238 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// txArgs are the fields of a transaction to build. Missing numeric fields
+// default to zero, a missing recipient creates a contract.
+type txArgs struct {
+	Nonce               *math.HexOrDecimal64  `json:"nonce"`
+	GasPrice            *math.HexOrDecimal256 `json:"gasPrice"`
+	Gas                 *math.HexOrDecimal64  `json:"gas"`
+	FeeCurrency         *common.Address       `json:"feeCurrency"`
+	GatewayFeeRecipient *common.Address       `json:"gatewayFeeRecipient"`
+	GatewayFee          *math.HexOrDecimal256 `json:"gatewayFee"`
+	To                  *common.Address       `json:"to"`
+	Value               *math.HexOrDecimal256 `json:"value"`
+	Data                hexutil.Bytes         `json:"data"`
+}
+
+var (
+	txfileFlag = cli.StringFlag{
+		Name:  "txfile",
+		Usage: "JSON file containing the transaction fields, overridden by the flags",
+	}
+	nonceFlag = cli.StringFlag{
+		Name:  "nonce",
+		Usage: "nonce of the sending account",
+	}
+	gasPriceFlag = cli.StringFlag{
+		Name:  "gasprice",
+		Usage: "gas price, denominated in the fee currency",
+	}
+	gasFlag = cli.StringFlag{
+		Name:  "gas",
+		Usage: "gas limit",
+	}
+	feeCurrencyFlag = cli.StringFlag{
+		Name:  "feecurrency",
+		Usage: "address of the token fees are paid in (native token if unset)",
+	}
+	gatewayFeeRecipientFlag = cli.StringFlag{
+		Name:  "gatewayfeerecipient",
+		Usage: "address of the full node receiving the gateway fee",
+	}
+	gatewayFeeFlag = cli.StringFlag{
+		Name:  "gatewayfee",
+		Usage: "gateway fee paid to the recipient, denominated in the fee currency",
+	}
+	toFlag = cli.StringFlag{
+		Name:  "to",
+		Usage: "recipient address (contract creation if unset)",
+	}
+	valueFlag = cli.StringFlag{
+		Name:  "value",
+		Usage: "amount of native token to transfer, in wei",
+	}
+	dataFlag = cli.StringFlag{
+		Name:  "data",
+		Usage: "hex encoded transaction payload",
+	}
+	methodFlag = cli.StringFlag{
+		Name:  "method",
+		Usage: "name of the contract method to call, encoded using --abi",
+	}
+)
+
+var commandBuild = cli.Command{
+	Name:      "build",
+	Usage:     "build an unsigned transaction",
+	ArgsUsage: "[<method arguments>...]",
+	Description: `
+Build an unsigned transaction and print its hex encoded RLP.
+
+The transaction fields are read from the JSON file given with --txfile, where
+numbers are given as decimal or hex strings, and from the command line flags, which take
+precedence. To call a contract method, pass its ABI with --abi, the method with
+--method and the method arguments as command line arguments. Array arguments
+are given as JSON lists.
+`,
+	Flags: []cli.Flag{
+		txfileFlag,
+		nonceFlag,
+		gasPriceFlag,
+		gasFlag,
+		feeCurrencyFlag,
+		gatewayFeeRecipientFlag,
+		gatewayFeeFlag,
+		toFlag,
+		valueFlag,
+		dataFlag,
+		abiFlag,
+		methodFlag,
+	},
+	Action: func(ctx *cli.Context) error {
+		args := new(txArgs)
+		if file := ctx.String(txfileFlag.Name); file != "" {
+			content, err := ioutil.ReadFile(file)
+			if err != nil {
+				utils.Fatalf("Failed to read transaction file '%s': %v", file, err)
+			}
+			if err := json.Unmarshal(content, args); err != nil {
+				utils.Fatalf("Failed to parse transaction file: %v", err)
+			}
+		}
+		applyFlags(ctx, args)
+
+		if contract := loadABI(ctx); contract != nil {
+			if ctx.IsSet(dataFlag.Name) {
+				utils.Fatalf("Can't use --data and --abi at the same time.")
+			}
+			if !ctx.IsSet(methodFlag.Name) {
+				utils.Fatalf("The --method flag is required when using --abi.")
+			}
+			data, err := packCall(contract, ctx.String(methodFlag.Name), ctx.Args())
+			if err != nil {
+				utils.Fatalf("Failed to encode method call: %v", err)
+			}
+			args.Data = data
+		} else if len(ctx.Args()) > 0 {
+			utils.Fatalf("Method arguments given without --abi.")
+		}
+		fmt.Println(encodeTx(args.toTransaction()))
+		return nil
+	},
+}
+
+// applyFlags overrides the transaction fields with the command line flags.
+func applyFlags(ctx *cli.Context, args *txArgs) {
+	if ctx.IsSet(nonceFlag.Name) {
+		args.Nonce = (*math.HexOrDecimal64)(parseUint64Flag(ctx, nonceFlag.Name))
+	}
+	if ctx.IsSet(gasPriceFlag.Name) {
+		args.GasPrice = (*math.HexOrDecimal256)(parseBigFlag(ctx, gasPriceFlag.Name))
+	}
+	if ctx.IsSet(gasFlag.Name) {
+		args.Gas = (*math.HexOrDecimal64)(parseUint64Flag(ctx, gasFlag.Name))
+	}
+	if ctx.IsSet(feeCurrencyFlag.Name) {
+		args.FeeCurrency = parseAddressFlag(ctx, feeCurrencyFlag.Name)
+	}
+	if ctx.IsSet(gatewayFeeRecipientFlag.Name) {
+		args.GatewayFeeRecipient = parseAddressFlag(ctx, gatewayFeeRecipientFlag.Name)
+	}
+	if ctx.IsSet(gatewayFeeFlag.Name) {
+		args.GatewayFee = (*math.HexOrDecimal256)(parseBigFlag(ctx, gatewayFeeFlag.Name))
+	}
+	if ctx.IsSet(toFlag.Name) {
+		args.To = parseAddressFlag(ctx, toFlag.Name)
+	}
+	if ctx.IsSet(valueFlag.Name) {
+		args.Value = (*math.HexOrDecimal256)(parseBigFlag(ctx, valueFlag.Name))
+	}
+	if ctx.IsSet(dataFlag.Name) {
+		data, err := hexutil.Decode(ctx.String(dataFlag.Name))
+		if err != nil {
+			utils.Fatalf("Invalid --%s: %v", dataFlag.Name, err)
+		}
+		args.Data = data
+	}
+}
+
+// toTransaction assembles the unsigned transaction.
+func (args *txArgs) toTransaction() *types.Transaction {
+	var (
+		nonce, gas                  uint64
+		gasPrice, gatewayFee, value = new(big.Int), new(big.Int), new(big.Int)
+	)
+	if args.Nonce != nil {
+		nonce = uint64(*args.Nonce)
+	}
+	if args.Gas != nil {
+		gas = uint64(*args.Gas)
+	}
+	if args.GasPrice != nil {
+		gasPrice = (*big.Int)(args.GasPrice)
+	}
+	if args.GatewayFee != nil {
+		gatewayFee = (*big.Int)(args.GatewayFee)
+	}
+	if args.Value != nil {
+		value = (*big.Int)(args.Value)
+	}
+	if args.To == nil {
+		return types.NewContractCreation(nonce, value, gas, gasPrice, args.FeeCurrency, args.GatewayFeeRecipient, gatewayFee, args.Data)
+	}
+	return types.NewTransaction(nonce, *args.To, value, gas, gasPrice, args.FeeCurrency, args.GatewayFeeRecipient, gatewayFee, args.Data)
+}
+
+func parseUint64Flag(ctx *cli.Context, name string) *uint64 {
+	n, ok := math.ParseUint64(ctx.String(name))
+	if !ok {
+		utils.Fatalf("Invalid --%s: %s", name, ctx.String(name))
+	}
+	return &n
+}
+
+func parseBigFlag(ctx *cli.Context, name string) *big.Int {
+	n, ok := math.ParseBig256(ctx.String(name))
+	if !ok || n.Sign() < 0 {
+		utils.Fatalf("Invalid --%s: %s", name, ctx.String(name))
+	}
+	return n
+}
+
+func parseAddressFlag(ctx *cli.Context, name string) *common.Address {
+	if !common.IsHexAddress(ctx.String(name)) {
+		utils.Fatalf("Invalid --%s: %s", name, ctx.String(name))
+	}
+	address := common.HexToAddress(ctx.String(name))
+	return &address
+}
@@ -0,0 +1,219 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gopkg.in/urfave/cli.v1"
+)
+
+type outputInspect struct {
+	Hash                common.Hash
+	Nonce               uint64
+	GasPrice            *big.Int
+	Gas                 uint64
+	FeeCurrency         *common.Address
+	GatewayFeeRecipient *common.Address
+	GatewayFee          *big.Int
+	To                  *common.Address
+	Value               *big.Int
+	Data                hexutil.Bytes
+	Call                *decodedCall `json:",omitempty"`
+	Signed              bool
+	ChainID             *big.Int        `json:",omitempty"`
+	From                *common.Address `json:",omitempty"`
+}
+
+var commandInspect = cli.Command{
+	Name:      "inspect",
+	Usage:     "decode a raw transaction",
+	ArgsUsage: "<rawtx>",
+	Description: `
+Print the fields of a hex encoded raw transaction, signed or not. For signed
+transactions the chain id and the sender are recovered from the signature.
+
+To decode the payload as a contract call, pass the contract ABI with --abi.
+To read the transaction from a file, use the --rawfile flag.
+`,
+	Flags: []cli.Flag{
+		jsonFlag,
+		abiFlag,
+		rawfileFlag,
+	},
+	Action: func(ctx *cli.Context) error {
+		tx := getRawTx(ctx, 0)
+
+		out := outputInspect{
+			Hash:                tx.Hash(),
+			Nonce:               tx.Nonce(),
+			GasPrice:            tx.GasPrice(),
+			Gas:                 tx.Gas(),
+			FeeCurrency:         tx.FeeCurrency(),
+			GatewayFeeRecipient: tx.GatewayFeeRecipient(),
+			GatewayFee:          tx.GatewayFee(),
+			To:                  tx.To(),
+			Value:               tx.Value(),
+			Data:                tx.Data(),
+		}
+		if contract := loadABI(ctx); contract != nil {
+			call, err := unpackCall(contract, out.Data)
+			if err != nil {
+				utils.Fatalf("Failed to decode contract call: %v", err)
+			}
+			out.Call = call
+		}
+		if isSigned(tx) {
+			from, err := recoverSender(tx)
+			if err != nil {
+				utils.Fatalf("Failed to recover sender: %v", err)
+			}
+			out.Signed = true
+			out.From = &from
+			if tx.Protected() {
+				out.ChainID = tx.ChainId()
+			}
+		}
+		if ctx.Bool(jsonFlag.Name) {
+			mustPrintJSON(out)
+			return nil
+		}
+		fmt.Println("Hash:                 ", out.Hash.Hex())
+		fmt.Println("Nonce:                ", out.Nonce)
+		fmt.Println("Gas price:            ", out.GasPrice)
+		fmt.Println("Gas:                  ", out.Gas)
+		fmt.Println("Fee currency:         ", formatAddress(out.FeeCurrency, "native token"))
+		fmt.Println("Gateway fee recipient:", formatAddress(out.GatewayFeeRecipient, "none"))
+		fmt.Println("Gateway fee:          ", out.GatewayFee)
+		fmt.Println("To:                   ", formatAddress(out.To, "contract creation"))
+		fmt.Println("Value:                ", out.Value)
+		fmt.Println("Data:                 ", out.Data)
+		if out.Call != nil {
+			fmt.Println("Method:               ", out.Call.Method)
+			for _, arg := range out.Call.Args {
+				fmt.Printf("  %s %s: %s\n", arg.Type, arg.Name, arg.Value)
+			}
+		}
+		if !out.Signed {
+			fmt.Println("Signed:                false")
+			return nil
+		}
+		fmt.Println("Signed:                true")
+		if out.ChainID != nil {
+			fmt.Println("Chain id:             ", out.ChainID)
+		} else {
+			fmt.Println("Chain id:              none (replayable)")
+		}
+		fmt.Println("From:                 ", out.From.Hex())
+		return nil
+	},
+}
+
+type outputVerify struct {
+	Success          bool
+	RecoveredAddress string
+	ChainID          *big.Int `json:",omitempty"`
+}
+
+var commandVerify = cli.Command{
+	Name:      "verify",
+	Usage:     "verify the signature of a raw transaction",
+	ArgsUsage: "<address> <rawtx>",
+	Description: `
+Verify that the raw transaction is signed by the given address. If --chainid
+is set, the signature must also be replay protected for that chain.
+
+To read the transaction from a file, use the --rawfile flag.
+`,
+	Flags: []cli.Flag{
+		jsonFlag,
+		chainIDFlag,
+		rawfileFlag,
+	},
+	Action: func(ctx *cli.Context) error {
+		addressStr := ctx.Args().First()
+		if !common.IsHexAddress(addressStr) {
+			utils.Fatalf("Invalid address: %s", addressStr)
+		}
+		address := common.HexToAddress(addressStr)
+		tx := getRawTx(ctx, 1)
+
+		if !isSigned(tx) {
+			utils.Fatalf("Transaction is not signed")
+		}
+		recovered, err := recoverSender(tx)
+		if err != nil {
+			utils.Fatalf("Signature verification failed: %v", err)
+		}
+		out := outputVerify{
+			Success:          recovered == address,
+			RecoveredAddress: recovered.Hex(),
+		}
+		if tx.Protected() {
+			out.ChainID = tx.ChainId()
+		}
+		if ctx.IsSet(chainIDFlag.Name) {
+			chainID := new(big.Int).SetUint64(ctx.Uint64(chainIDFlag.Name))
+			out.Success = out.Success && out.ChainID != nil && out.ChainID.Cmp(chainID) == 0
+		}
+		if ctx.Bool(jsonFlag.Name) {
+			mustPrintJSON(out)
+		} else {
+			if out.Success {
+				fmt.Println("Signature verification successful!")
+			} else {
+				fmt.Println("Signature verification failed!")
+			}
+			fmt.Println("Recovered address:", out.RecoveredAddress)
+			if out.ChainID != nil {
+				fmt.Println("Chain id:", out.ChainID)
+			} else {
+				fmt.Println("Chain id: none (replayable)")
+			}
+		}
+		return nil
+	},
+}
+
+// isSigned reports whether the transaction carries a signature. Unsigned
+// transactions, as produced by the build command, have all values zero.
+func isSigned(tx *types.Transaction) bool {
+	v, r, s := tx.RawSignatureValues()
+	return v.Sign() != 0 || r.Sign() != 0 || s.Sign() != 0
+}
+
+// recoverSender recovers the sender of a signed transaction, taking replay
+// protection into account.
+func recoverSender(tx *types.Transaction) (common.Address, error) {
+	var signer types.Signer = types.HomesteadSigner{}
+	if tx.Protected() {
+		signer = types.NewEIP155Signer(tx.ChainId())
+	}
+	return types.Sender(signer, tx)
+}
+
+func formatAddress(address *common.Address, fallback string) string {
+	if address == nil {
+		return fallback
+	}
+	return address.Hex()
+}
@@ -0,0 +1,74 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+// celotx is an offline tool to build, sign and inspect Celo transactions.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// Git SHA1 commit hash of the release (set via linker flags)
+var gitCommit = ""
+var gitDate = ""
+
+var app *cli.App
+
+func init() {
+	app = utils.NewApp(gitCommit, gitDate, "an offline Celo transaction builder and signer")
+	app.Commands = []cli.Command{
+		commandBuild,
+		commandSign,
+		commandInspect,
+		commandVerify,
+	}
+	cli.CommandHelpTemplate = utils.OriginCommandHelpTemplate
+}
+
+// Commonly used command line flags.
+var (
+	passphraseFlag = cli.StringFlag{
+		Name:  "passwordfile",
+		Usage: "the file that contains the password for the key",
+	}
+	jsonFlag = cli.BoolFlag{
+		Name:  "json",
+		Usage: "output JSON instead of human-readable format",
+	}
+	abiFlag = cli.StringFlag{
+		Name:  "abi",
+		Usage: "file containing the JSON ABI of the called contract",
+	}
+	rawfileFlag = cli.StringFlag{
+		Name:  "rawfile",
+		Usage: "file containing the hex encoded raw transaction",
+	}
+	chainIDFlag = cli.Uint64Flag{
+		Name:  "chainid",
+		Usage: "chain id of the network the transaction is signed for",
+	}
+)
+
+func main() {
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	keyfileFlag = cli.StringFlag{
+		Name:  "keyfile",
+		Usage: "keyfile to sign with",
+	}
+	keystoreFlag = cli.StringFlag{
+		Name:  "keystore",
+		Usage: "keystore directory holding the signing account",
+	}
+	fromFlag = cli.StringFlag{
+		Name:  "from",
+		Usage: "address of the keystore account, or of the HD wallet when using --hdpath",
+	}
+	hdPathFlag = cli.StringFlag{
+		Name:  "hdpath",
+		Usage: "derivation path of the signing account within the HD wallet",
+	}
+)
+
+var commandSign = cli.Command{
+	Name:      "sign",
+	Usage:     "sign a raw transaction",
+	ArgsUsage: "<rawtx>",
+	Description: `
+Sign the hex encoded raw transaction, as printed by the build command, for the
+chain given with --chainid and print the signed raw transaction.
+
+The key is read either from an encrypted keyfile given with --keyfile, or from
+the keystore directory given with --keystore, in which case --from selects the
+account. To sign with an account of an HD wallet imported from a mnemonic,
+set --from to any address of the wallet and --hdpath to the derivation path;
+the derived account is pinned in the wallet.
+
+To read the transaction from a file, use the --rawfile flag.
+`,
+	Flags: []cli.Flag{
+		passphraseFlag,
+		chainIDFlag,
+		keyfileFlag,
+		keystoreFlag,
+		fromFlag,
+		hdPathFlag,
+		rawfileFlag,
+	},
+	Action: func(ctx *cli.Context) error {
+		tx := getRawTx(ctx, 0)
+		if !ctx.IsSet(chainIDFlag.Name) {
+			utils.Fatalf("The --chainid flag is required.")
+		}
+		chainID := new(big.Int).SetUint64(ctx.Uint64(chainIDFlag.Name))
+
+		var (
+			signed *types.Transaction
+			err    error
+		)
+		switch {
+		case ctx.IsSet(keyfileFlag.Name) && ctx.IsSet(keystoreFlag.Name):
+			utils.Fatalf("Can't use --keyfile and --keystore at the same time.")
+
+		case ctx.IsSet(keyfileFlag.Name):
+			signed, err = signWithKeyfile(ctx, tx, chainID)
+
+		case ctx.IsSet(keystoreFlag.Name):
+			signed, err = signWithKeystore(ctx, tx, chainID)
+
+		default:
+			utils.Fatalf("Either --keyfile or --keystore is required.")
+		}
+		if err != nil {
+			utils.Fatalf("Failed to sign transaction: %v", err)
+		}
+		fmt.Println(encodeTx(signed))
+		return nil
+	},
+}
+
+// signWithKeyfile signs the transaction with the keyfile given with --keyfile.
+func signWithKeyfile(ctx *cli.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	keyfilepath := ctx.String(keyfileFlag.Name)
+	keyjson, err := ioutil.ReadFile(keyfilepath)
+	if err != nil {
+		utils.Fatalf("Failed to read the keyfile at '%s': %v", keyfilepath, err)
+	}
+	key, err := keystore.DecryptKey(keyjson, getPassphrase(ctx))
+	if err != nil {
+		utils.Fatalf("Error decrypting key: %v", err)
+	}
+	return types.SignTx(tx, types.NewEIP155Signer(chainID), key.PrivateKey)
+}
+
+// signWithKeystore signs the transaction with an account of the keystore given
+// with --keystore, deriving it first if --hdpath is set.
+func signWithKeystore(ctx *cli.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	from := ctx.String(fromFlag.Name)
+	if !common.IsHexAddress(from) {
+		utils.Fatalf("Invalid --%s: %q", fromFlag.Name, from)
+	}
+	ks := keystore.NewKeyStore(ctx.String(keystoreFlag.Name), keystore.StandardScryptN, keystore.StandardScryptP)
+	account := accounts.Account{Address: common.HexToAddress(from)}
+	passphrase := getPassphrase(ctx)
+
+	if ctx.IsSet(hdPathFlag.Name) {
+		path, err := accounts.ParseDerivationPath(ctx.String(hdPathFlag.Name))
+		if err != nil {
+			utils.Fatalf("Invalid --%s: %v", hdPathFlag.Name, err)
+		}
+		if account, err = ks.DeriveHDAccount(account, path, passphrase); err != nil {
+			utils.Fatalf("Failed to derive account: %v", err)
+		}
+	}
+	return ks.SignTxWithPassphrase(account, passphrase, tx, chainID)
+}
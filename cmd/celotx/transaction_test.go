@@ -0,0 +1,195 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pborman/uuid"
+)
+
+const testABI = `[
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"multi","inputs":[{"name":"a","type":"uint8[2]"},{"name":"b","type":"bytes4"},{"name":"c","type":"address[]"}],"outputs":[]}
+]`
+
+func TestBuildSignVerify(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "celotx-test")
+	if err != nil {
+		t.Fatal("Can't create temporary directory:", err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	// Create the keyfile and the contract ABI.
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := &keystore.Key{
+		Id:         uuid.NewRandom(),
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+	keyjson, err := keystore.EncryptKey(key, "foobar", keystore.LightScryptN, keystore.LightScryptP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyfile := filepath.Join(tmpdir, "the-keyfile")
+	abifile := filepath.Join(tmpdir, "the-abi")
+	if err := ioutil.WriteFile(keyfile, keyjson, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(abifile, []byte(testABI), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Build a stable token transfer paying fees in the token.
+	build := runCelotx(t, "build",
+		"--nonce", "3", "--gasprice", "1000000000", "--gas", "100000",
+		"--to", "0x765DE816845861e75A25fCA122bb6898B8B1282a",
+		"--feecurrency", "0x765DE816845861e75A25fCA122bb6898B8B1282a",
+		"--gatewayfeerecipient", "0x1111111111111111111111111111111111111111",
+		"--gatewayfee", "5000",
+		"--abi", abifile, "--method", "transfer",
+		"0x2222222222222222222222222222222222222222", "1000000000000000000")
+	_, matches := build.ExpectRegexp(`(0x[0-9a-f]+)\n`)
+	unsigned := matches[1]
+	build.ExpectExit()
+
+	inspect := runCelotx(t, "inspect", "--abi", abifile, unsigned)
+	inspect.ExpectRegexp(`Fee currency: +0x765DE816845861e75A25fCA122bb6898B8B1282a
+Gateway fee recipient: 0x1111111111111111111111111111111111111111
+Gateway fee: +5000
+(?s:.*)Method: +transfer\(address,uint256\)
+  address to: 0x2222222222222222222222222222222222222222
+  uint256 value: 1000000000000000000
+Signed: +false
+`)
+	inspect.ExpectExit()
+
+	// Sign it.
+	sign := runCelotx(t, "sign", "--chainid", "42220", "--keyfile", keyfile, unsigned)
+	sign.Expect(`
+!! Unsupported terminal, password will be echoed.
+Password: {{.InputLine "foobar"}}
+`)
+	_, matches = sign.ExpectRegexp(`(0x[0-9a-f]+)\n`)
+	signed := matches[1]
+	sign.ExpectExit()
+
+	// Verify the signature, for the right and for the wrong chain.
+	verify := runCelotx(t, "verify", "--chainid", "42220", key.Address.Hex(), signed)
+	verify.Expect(`
+Signature verification successful!
+Recovered address: ` + key.Address.Hex() + `
+Chain id: 42220
+`)
+	verify.ExpectExit()
+
+	verify = runCelotx(t, "verify", "--chainid", "44787", key.Address.Hex(), signed)
+	verify.Expect(`
+Signature verification failed!
+Recovered address: ` + key.Address.Hex() + `
+Chain id: 42220
+`)
+	verify.ExpectExit()
+}
+
+func TestSignWithHDPath(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "celotx-test")
+	if err != nil {
+		t.Fatal("Can't create temporary directory:", err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	// Import the wallet of the test mnemonic.
+	ks := keystore.NewKeyStore(tmpdir, keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.ImportMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "", "foobar")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	build := runCelotx(t, "build",
+		"--nonce", "0", "--gasprice", "1000000000", "--gas", "21000",
+		"--to", "0x2222222222222222222222222222222222222222", "--value", "1")
+	_, matches := build.ExpectRegexp(`(0x[0-9a-f]+)\n`)
+	unsigned := matches[1]
+	build.ExpectExit()
+
+	// Sign it with the account on the Ethereum derivation path.
+	sign := runCelotx(t, "sign", "--chainid", "42220",
+		"--keystore", tmpdir, "--from", account.Address.Hex(), "--hdpath", "m/44'/60'/0'/0/0", unsigned)
+	sign.Expect(`
+!! Unsupported terminal, password will be echoed.
+Password: {{.InputLine "foobar"}}
+`)
+	_, matches = sign.ExpectRegexp(`(0x[0-9a-f]+)\n`)
+	signed := matches[1]
+	sign.ExpectExit()
+
+	// Known address of the test mnemonic on the Ethereum derivation path
+	derived := "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"
+	verify := runCelotx(t, "verify", "--chainid", "42220", derived, signed)
+	verify.Expect(`
+Signature verification successful!
+Recovered address: ` + derived + `
+Chain id: 42220
+`)
+	verify.ExpectExit()
+}
+
+func TestPackCall(t *testing.T) {
+	contract, err := abi.JSON(strings.NewReader(testABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := packCall(&contract, "multi", []string{`[1, "0x02"]`, "0x01020304", `["0x2222222222222222222222222222222222222222"]`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	call, err := unpackCall(&contract, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"[1, 2]", "0x01020304", "[0x2222222222222222222222222222222222222222]"}
+	for i, arg := range call.Args {
+		if arg.Value != want[i] {
+			t.Errorf("argument %d: have %s, want %s", i, arg.Value, want[i])
+		}
+	}
+
+	// Invalid arguments must be rejected
+	for _, args := range [][]string{
+		{`[1, 256]`, "0x01020304", `[]`},
+		{`[1]`, "0x01020304", `[]`},
+		{`[1, 2]`, "0x0102", `[]`},
+		{`[1, 2]`, "0x01020304", `["0x22"]`},
+		{`[1, 2]`, "0x01020304"},
+	} {
+		if data, err := packCall(&contract, "multi", args); err == nil {
+			t.Errorf("%v: expected error, got %s", args, hexutil.Encode(data))
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/console"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// getPassphrase obtains a passphrase given by the user.  It first checks the
+// --passwordfile command line flag and ultimately prompts the user for a
+// passphrase.
+func getPassphrase(ctx *cli.Context) string {
+	// Look for the --passwordfile flag.
+	passphraseFile := ctx.String(passphraseFlag.Name)
+	if passphraseFile != "" {
+		content, err := ioutil.ReadFile(passphraseFile)
+		if err != nil {
+			utils.Fatalf("Failed to read password file '%s': %v",
+				passphraseFile, err)
+		}
+		return strings.TrimRight(string(content), "\r\n")
+	}
+
+	// Otherwise prompt the user for the passphrase.
+	passphrase, err := console.Stdin.PromptPassword("Password: ")
+	if err != nil {
+		utils.Fatalf("Failed to read password: %v", err)
+	}
+	return passphrase
+}
+
+// getRawTx decodes the raw transaction given either in the --rawfile file or
+// as the command line argument at index txarg.
+func getRawTx(ctx *cli.Context, txarg int) *types.Transaction {
+	var input string
+	if file := ctx.String(rawfileFlag.Name); file != "" {
+		if len(ctx.Args()) > txarg {
+			utils.Fatalf("Can't use --rawfile and transaction argument at the same time.")
+		}
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			utils.Fatalf("Can't read transaction file: %v", err)
+		}
+		input = string(content)
+	} else if len(ctx.Args()) == txarg+1 {
+		input = ctx.Args().Get(txarg)
+	} else {
+		utils.Fatalf("Invalid number of arguments: want %d, got %d", txarg+1, len(ctx.Args()))
+	}
+	input = strings.TrimSpace(input)
+	if !strings.HasPrefix(input, "0x") {
+		input = "0x" + input
+	}
+	raw, err := hexutil.Decode(input)
+	if err != nil {
+		utils.Fatalf("Transaction encoding is not hexadecimal: %v", err)
+	}
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(raw, tx); err != nil {
+		utils.Fatalf("Failed to decode transaction: %v", err)
+	}
+	return tx
+}
+
+// encodeTx returns the hex encoded RLP of the transaction.
+func encodeTx(tx *types.Transaction) string {
+	raw, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		utils.Fatalf("Failed to encode transaction: %v", err)
+	}
+	return hexutil.Encode(raw)
+}
+
+// loadABI reads the contract ABI from the file given with --abi, returning
+// nil if the flag is not set.
+func loadABI(ctx *cli.Context) *abi.ABI {
+	file := ctx.String(abiFlag.Name)
+	if file == "" {
+		return nil
+	}
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		utils.Fatalf("Failed to read ABI file '%s': %v", file, err)
+	}
+	parsed, err := abi.JSON(bytes.NewReader(content))
+	if err != nil {
+		utils.Fatalf("Failed to parse ABI: %v", err)
+	}
+	return &parsed
+}
+
+// mustPrintJSON prints the JSON encoding of the given object and
+// exits the program with an error message when the marshaling fails.
+func mustPrintJSON(jsonObject interface{}) {
+	str, err := json.MarshalIndent(jsonObject, "", "  ")
+	if err != nil {
+		utils.Fatalf("Failed to marshal JSON object: %v", err)
+	}
+	fmt.Println(string(str))
+}
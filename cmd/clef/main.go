@@ -40,6 +40,7 @@ import (
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/console"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -130,6 +131,14 @@ var (
 		Name:  "stdio-ui-test",
 		Usage: "Mechanism to test interface between Clef and UI. Requires 'stdio-ui'.",
 	}
+	stableTokensFlag = cli.StringFlag{
+		Name:  "stabletokens",
+		Usage: "Comma separated whitelist of stable tokens as symbol=address, e.g. cUSD=0x765DE816845861e75A25fCA122bb6898B8B1282a",
+	}
+	maxGatewayFeeFlag = cli.StringFlag{
+		Name:  "maxgatewayfee",
+		Usage: "Gateway fee above which transactions are warned about (default = the maximum gas fee of the transaction)",
+	}
 	app         = cli.NewApp()
 	initCommand = cli.Command{
 		Action:    utils.MigrateFlags(initializeSecrets),
@@ -220,6 +229,8 @@ func init() {
 		stdiouiFlag,
 		testFlag,
 		advancedMode,
+		stableTokensFlag,
+		maxGatewayFeeFlag,
 	}
 	app.Action = signer
 	app.Commands = []cli.Command{initCommand, attestCommand, setCredentialCommand, delCredentialCommand, gendocCommand}
@@ -426,6 +437,22 @@ func ipcEndpoint(ipcPath, datadir string) string {
 	return ipcPath
 }
 
+// parseStableTokens parses a comma separated list of symbol=address pairs.
+func parseStableTokens(list string) (core.StableTokens, error) {
+	tokens := make(core.StableTokens)
+	if list == "" {
+		return tokens, nil
+	}
+	for _, entry := range strings.Split(list, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), "=")
+		if len(parts) != 2 || parts[0] == "" || !common.IsHexAddress(parts[1]) {
+			return nil, fmt.Errorf("invalid entry %q, want symbol=address", entry)
+		}
+		tokens[common.HexToAddress(parts[1])] = parts[0]
+	}
+	return tokens, nil
+}
+
 func signer(c *cli.Context) error {
 	// If we have some unrecognized command, bail out
 	if args := c.Args(); len(args) > 0 {
@@ -453,6 +480,20 @@ func signer(c *cli.Context) error {
 	embeds, locals := db.Size()
 	log.Info("Loaded 4byte database", "embeds", embeds, "locals", locals, "local", fourByteLocal)
 
+	// Celo specific validation settings
+	tokens, err := parseStableTokens(c.GlobalString(stableTokensFlag.Name))
+	if err != nil {
+		utils.Fatalf("Invalid stable tokens: %v", err)
+	}
+	var maxGatewayFee *big.Int
+	if fee := c.GlobalString(maxGatewayFeeFlag.Name); fee != "" {
+		var ok bool
+		if maxGatewayFee, ok = math.ParseBig256(fee); !ok {
+			utils.Fatalf("Invalid max gateway fee: %s", fee)
+		}
+	}
+	validator := core.NewCeloValidator(db, tokens, maxGatewayFee)
+
 	var (
 		api       core.ExternalAPI
 		pwStorage storage.Storage = &storage.NoStorage{}
@@ -492,6 +533,7 @@ func signer(c *cli.Context) error {
 						utils.Fatalf(err.Error())
 					}
 					ruleEngine.Init(string(ruleJS))
+					ruleEngine.SetStableTokens(tokens)
 					ui = ruleEngine
 					log.Info("Rule engine configured", "file", c.String(ruleFlag.Name))
 				}
@@ -509,7 +551,7 @@ func signer(c *cli.Context) error {
 	log.Info("Starting signer", "chainid", chainId, "keystore", ksLoc,
 		"light-kdf", lightKdf, "advanced", advanced)
 	am := core.StartClefAccountManager(ksLoc, nousb, lightKdf, scpath)
-	apiImpl := core.NewSignerAPI(am, chainId, nousb, ui, db, advanced, pwStorage)
+	apiImpl := core.NewSignerAPI(am, chainId, nousb, ui, validator, advanced, pwStorage)
 
 	// Establish the bidirectional communication, by creating a new UI backend and registering
 	// it with the UI.
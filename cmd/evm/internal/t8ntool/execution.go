@@ -0,0 +1,273 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package t8ntool
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/tests"
+	"golang.org/x/crypto/sha3"
+)
+
+// Prestate is the state and block environment the transactions are applied on.
+type Prestate struct {
+	Env stEnv             `json:"env"`
+	Pre core.GenesisAlloc `json:"pre"`
+}
+
+// ExecutionResult is the outcome of applying the transactions to the prestate,
+// along with the commitments of the block that would include them.
+type ExecutionResult struct {
+	StateRoot   common.Hash         `json:"stateRoot"`
+	TxRoot      common.Hash         `json:"txRoot"`
+	ReceiptRoot common.Hash         `json:"receiptRoot"`
+	LogsHash    common.Hash         `json:"logsHash"`
+	Bloom       types.Bloom         `json:"logsBloom"`
+	GasUsed     math.HexOrDecimal64 `json:"gasUsed"`
+	Receipts    types.Receipts      `json:"receipts"`
+	Rejected    []int               `json:"rejected,omitempty"`
+}
+
+// stEnv is the environment of the block the transactions are applied in. The
+// gas limit defaults to the one of the blockchain parameters contract of the
+// prestate, if deployed, and the epoch size to the istanbul default. The block
+// hashes are the ones of the ancestors available to the BLOCKHASH opcode.
+type stEnv struct {
+	Coinbase    common.Address                      `json:"currentCoinbase"`
+	GasLimit    *math.HexOrDecimal64                `json:"currentGasLimit,omitempty"`
+	Number      math.HexOrDecimal64                 `json:"currentNumber"`
+	Timestamp   math.HexOrDecimal64                 `json:"currentTimestamp"`
+	EpochSize   math.HexOrDecimal64                 `json:"epochSize,omitempty"`
+	BlockHashes map[math.HexOrDecimal64]common.Hash `json:"blockHashes,omitempty"`
+}
+
+// Apply applies a set of transactions to a pre-state through the same path as
+// block processing, with the registry and token contracts of the pre-state.
+// Transactions that can't be applied are skipped and reported as rejected.
+func (pre *Prestate) Apply(vmConfig vm.Config, chainConfig *params.ChainConfig, txs types.Transactions, getTracerFn func(txIndex int, txHash common.Hash) (vm.Tracer, error)) (*state.StateDB, *ExecutionResult, error) {
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		statedb = tests.MakePreState(db, pre.Pre)
+		number  = uint64(pre.Env.Number)
+		header  = &types.Header{
+			Coinbase: pre.Env.Coinbase,
+			Number:   new(big.Int).SetUint64(number),
+			Time:     uint64(pre.Env.Timestamp),
+		}
+		chain = &chainContext{config: chainConfig, vmConfig: &vmConfig, header: header, statedb: statedb, env: &pre.Env}
+	)
+	if number > 0 {
+		header.ParentHash = pre.Env.BlockHashes[math.HexOrDecimal64(number-1)]
+	}
+	// System calls of the state transition, like the fee currency handling, are
+	// made on the prestate through the chain
+	contract_comm.BindInternalEVMHandler(db, chain)
+	defer contract_comm.UnbindInternalEVMHandler(db)
+
+	gasLimit := core.CalcGasLimit(types.NewBlockWithHeader(header), statedb)
+	if pre.Env.GasLimit != nil {
+		gasLimit = uint64(*pre.Env.GasLimit)
+	}
+	var (
+		gaspool     = new(core.GasPool).AddGas(gasLimit)
+		gasUsed     = uint64(0)
+		includedTxs types.Transactions
+		receipts    types.Receipts
+		rejected    []int
+	)
+	for i, tx := range txs {
+		tracer, err := getTracerFn(len(receipts), tx.Hash())
+		if err != nil {
+			return nil, nil, err
+		}
+		vmConfig.Tracer, vmConfig.Debug = tracer, tracer != nil
+
+		statedb.Prepare(tx.Hash(), common.Hash{}, len(receipts))
+		snapshot := statedb.Snapshot()
+
+		receipt, err := core.ApplyTransaction(chainConfig, chain, &header.Coinbase, gaspool, statedb, header, tx, &gasUsed, vmConfig, nil)
+		if err != nil {
+			statedb.RevertToSnapshot(snapshot)
+			log.Info("Rejected transaction", "index", i, "hash", tx.Hash(), "error", err)
+			rejected = append(rejected, i)
+			continue
+		}
+		includedTxs = append(includedTxs, tx)
+		receipts = append(receipts, receipt)
+	}
+	root, err := statedb.Commit(chainConfig.IsEIP158(header.Number))
+	if err != nil {
+		return nil, nil, err
+	}
+	var logs []*types.Log
+	for _, receipt := range receipts {
+		logs = append(logs, receipt.Logs...)
+	}
+	result := &ExecutionResult{
+		StateRoot:   root,
+		TxRoot:      types.DeriveSha(includedTxs),
+		ReceiptRoot: types.DeriveSha(receipts),
+		LogsHash:    rlpHash(logs),
+		Bloom:       types.CreateBloom(receipts),
+		GasUsed:     math.HexOrDecimal64(gasUsed),
+		Receipts:    receipts,
+		Rejected:    rejected,
+	}
+	return statedb, result, nil
+}
+
+// chainContext is the chain the transactions are applied on, made up of the
+// block environment only.
+type chainContext struct {
+	config   *params.ChainConfig
+	vmConfig *vm.Config
+	header   *types.Header
+	statedb  *state.StateDB
+	env      *stEnv
+}
+
+// Engine returns an engine without any validators.
+func (c *chainContext) Engine() consensus.Engine {
+	epochSize := uint64(c.env.EpochSize)
+	if epochSize == 0 {
+		epochSize = istanbul.DefaultConfig.Epoch
+	}
+	return &engine{epochSize: epochSize}
+}
+
+// GetHeader returns the header of the block the transactions are applied in, or
+// a header made up of the number and parent hash, if the hash is the one of the
+// block with the given number in the environment.
+func (c *chainContext) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if number == c.header.Number.Uint64() && hash == c.header.Hash() {
+		return c.header
+	}
+	if c.env.BlockHashes[math.HexOrDecimal64(number)] != hash {
+		return nil
+	}
+	return c.GetHeaderByNumber(number)
+}
+
+// GetHeaderByNumber returns a header made up of the number and parent hash, if
+// the latter is in the environment.
+func (c *chainContext) GetHeaderByNumber(number uint64) *types.Header {
+	if number == 0 {
+		return nil
+	}
+	parent, ok := c.env.BlockHashes[math.HexOrDecimal64(number-1)]
+	if !ok {
+		return nil
+	}
+	return &types.Header{ParentHash: parent, Number: new(big.Int).SetUint64(number)}
+}
+
+func (c *chainContext) GetVMConfig() *vm.Config        { return c.vmConfig }
+func (c *chainContext) CurrentHeader() *types.Header   { return c.header }
+func (c *chainContext) State() (*state.StateDB, error) { return c.statedb, nil }
+func (c *chainContext) Config() *params.ChainConfig    { return c.config }
+
+// errUnsupported is returned by the methods of the consensus engine that the
+// transition tool doesn't need, as there is no chain to verify or seal.
+var errUnsupported = errors.New("not supported by the transition tool")
+
+// engine is the consensus engine of the chain context. The validator set
+// precompiles see an empty set of validators, blocks can't be verified, sealed
+// or finalized.
+type engine struct {
+	epochSize uint64
+}
+
+func (e *engine) Author(header *types.Header) (common.Address, error) {
+	return header.Coinbase, nil
+}
+
+func (e *engine) VerifyHeader(chain consensus.ChainReader, header *types.Header, seal bool) error {
+	return errUnsupported
+}
+
+func (e *engine) VerifyHeaders(chain consensus.ChainReader, headers []*types.Header, seals []bool) (chan<- struct{}, <-chan error) {
+	results := make(chan error, len(headers))
+	for range headers {
+		results <- errUnsupported
+	}
+	return make(chan struct{}), results
+}
+
+func (e *engine) VerifySeal(chain consensus.ChainReader, header *types.Header) error {
+	return errUnsupported
+}
+
+func (e *engine) Prepare(chain consensus.ChainReader, header *types.Header) error {
+	return errUnsupported
+}
+
+// Finalize panics, the transition tool commits the state of the transactions
+// without finalizing any block.
+func (e *engine) Finalize(chain consensus.ChainReader, header *types.Header, state *state.StateDB, txs []*types.Transaction) {
+	panic(errUnsupported)
+}
+
+func (e *engine) FinalizeAndAssemble(chain consensus.ChainReader, header *types.Header, state *state.StateDB, txs []*types.Transaction, receipts []*types.Receipt, randomness *types.Randomness) (*types.Block, error) {
+	return nil, errUnsupported
+}
+
+func (e *engine) Seal(chain consensus.ChainReader, block *types.Block, results chan<- *types.Block, stop <-chan struct{}) error {
+	return errUnsupported
+}
+
+// SealHash panics, no block is sealed by the transition tool.
+func (e *engine) SealHash(header *types.Header) common.Hash {
+	panic(errUnsupported)
+}
+
+func (e *engine) GetValidators(blockNumber *big.Int, headerHash common.Hash) []istanbul.Validator {
+	return nil
+}
+
+func (e *engine) EpochSize() uint64 {
+	return e.epochSize
+}
+
+func (e *engine) APIs(chain consensus.ChainReader) []rpc.API {
+	return nil
+}
+
+func (e *engine) Close() error {
+	return nil
+}
+
+func rlpHash(x interface{}) (h common.Hash) {
+	hw := sha3.NewLegacyKeccak256()
+	rlp.Encode(hw, x)
+	hw.Sum(h[:0])
+	return h
+}
@@ -0,0 +1,194 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package t8ntool
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/contract_comm/errors"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/tests"
+)
+
+// Tests that transactions are applied to the prestate, skipping the ones that
+// can't be included.
+func TestApply(t *testing.T) {
+	key, _ := crypto.HexToECDSA("45a915e4d060149eb4365960e6a7a45f334393093061116b197e3240065ff2d8")
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	recipient := common.HexToAddress("0x1000000000000000000000000000000000000001")
+
+	var prestate Prestate
+	if err := json.Unmarshal([]byte(`{
+		"env": {
+			"currentCoinbase": "0x2adc25665018aa1fe0e6bc666dac8fc2697ff9ba",
+			"currentGasLimit": "0x750a163df65e8a",
+			"currentNumber": "1",
+			"currentTimestamp": "1000",
+			"blockHashes": {"0": "0x5e20a0453cecd065ea59c37ac63e079ee08998b6045136a8ce6635c7912ec0b6"}
+		},
+		"pre": {
+			"`+sender.Hex()+`": {"balance": "0x5ffd4878be161d74", "nonce": "0x0"}
+		}
+	}`), &prestate); err != nil {
+		t.Fatalf("failed to decode prestate: %v", err)
+	}
+	config := *tests.Forks["Istanbul"]
+	signer := types.MakeSigner(&config, big.NewInt(1))
+
+	var txs types.Transactions
+	for _, nonce := range []uint64{0, 0} { // The second one has a reused nonce
+		tx, _ := types.SignTx(types.NewTransaction(nonce, recipient, big.NewInt(1000), 21000, big.NewInt(1), nil, nil, nil, nil), signer, key)
+		txs = append(txs, tx)
+	}
+	noTracer := func(int, common.Hash) (vm.Tracer, error) { return nil, nil }
+
+	statedb, result, err := prestate.Apply(vm.Config{}, &config, txs, noTracer)
+	if err != nil {
+		t.Fatalf("failed to apply transactions: %v", err)
+	}
+	if len(result.Receipts) != 1 || result.Receipts[0].TxHash != txs[0].Hash() {
+		t.Fatalf("receipts mismatch: have %v, want the first transaction only", result.Receipts)
+	}
+	if len(result.Rejected) != 1 || result.Rejected[0] != 1 {
+		t.Errorf("rejected transactions mismatch: have %v, want [1]", result.Rejected)
+	}
+	if result.GasUsed != 21000 {
+		t.Errorf("gas used mismatch: have %d, want 21000", result.GasUsed)
+	}
+	if result.StateRoot != statedb.IntermediateRoot(true) {
+		t.Errorf("state root mismatch: have %x, want %x", result.StateRoot, statedb.IntermediateRoot(true))
+	}
+	alloc := collectAlloc(statedb)
+	if balance := alloc[recipient].Balance; balance == nil || balance.Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf("recipient balance mismatch: have %v, want 1000", balance)
+	}
+	if nonce := alloc[sender].Nonce; nonce != 1 {
+		t.Errorf("sender nonce mismatch: have %d, want 1", nonce)
+	}
+}
+
+// Tests that the fees of transactions are paid in their fee currency, along
+// with their gateway fees, through the registry and token contracts of the
+// prestate.
+func TestApplyFeeCurrency(t *testing.T) {
+	key, _ := crypto.HexToECDSA("45a915e4d060149eb4365960e6a7a45f334393093061116b197e3240065ff2d8")
+	var (
+		sender    = crypto.PubkeyToAddress(key.PublicKey)
+		recipient = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		gateway   = common.HexToAddress("0x1000000000000000000000000000000000000002")
+		coinbase  = common.HexToAddress("0x2adc25665018aa1fe0e6bc666dac8fc2697ff9ba")
+		stable    = backends.StableTokenAddress
+		unknown   = common.HexToAddress("0xdead")
+
+		celoBalance   = big.NewInt(params.Ether)
+		stableBalance = big.NewInt(params.Ether)
+		gasPrice      = big.NewInt(10)
+		value         = big.NewInt(1000)
+	)
+	// The StableToken balances are a mapping at slot 2
+	stableSlot := func(account common.Address) common.Hash {
+		return crypto.Keccak256Hash(account.Hash().Bytes(), common.BigToHash(big.NewInt(2)).Bytes())
+	}
+	alloc := backends.CeloGenesisAlloc(common.HexToAddress("0x0wner"))
+	alloc[sender] = core.GenesisAccount{Balance: celoBalance}
+	alloc[stable].Storage[stableSlot(sender)] = common.BigToHash(stableBalance)
+
+	prestate := Prestate{
+		Env: stEnv{Coinbase: coinbase, Number: 1, Timestamp: 1000},
+		Pre: alloc,
+	}
+	prestate.Env.BlockHashes = map[math.HexOrDecimal64]common.Hash{0: common.HexToHash("0x5e20a0453cecd065ea59c37ac63e079ee08998b6045136a8ce6635c7912ec0b6")}
+	config := *tests.Forks["Istanbul"]
+	signer := types.MakeSigner(&config, big.NewInt(1))
+
+	var txs types.Transactions
+	for i, fees := range []struct {
+		currency   *common.Address
+		gatewayFee *big.Int
+	}{
+		{&stable, big.NewInt(7)},
+		{nil, big.NewInt(5)},
+		{&unknown, nil}, // Not a whitelisted fee currency
+	} {
+		var gatewayFeeRecipient *common.Address
+		if fees.gatewayFee != nil {
+			gatewayFeeRecipient = &gateway
+		}
+		tx := types.NewTransaction(uint64(i), recipient, value, 100000, gasPrice, fees.currency, gatewayFeeRecipient, fees.gatewayFee, nil)
+		tx, _ = types.SignTx(tx, signer, key)
+		txs = append(txs, tx)
+	}
+	noTracer := func(int, common.Hash) (vm.Tracer, error) { return nil, nil }
+
+	statedb, result, err := prestate.Apply(vm.Config{}, &config, txs, noTracer)
+	if err != nil {
+		t.Fatalf("failed to apply transactions: %v", err)
+	}
+	if len(result.Receipts) != 2 {
+		t.Fatalf("receipt count mismatch: have %d, want 2", len(result.Receipts))
+	}
+	if len(result.Rejected) != 1 || result.Rejected[0] != 2 {
+		t.Errorf("rejected transactions mismatch: have %v, want [2]", result.Rejected)
+	}
+	// Without a Governance contract the base fee is refunded, the gas price
+	// minimum is 1 in both currencies
+	tip := func(receipt *types.Receipt) *big.Int {
+		return new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), new(big.Int).Sub(gasPrice, common.Big1))
+	}
+	stableTip, celoTip := tip(result.Receipts[0]), tip(result.Receipts[1])
+
+	stableBalanceOf := func(account common.Address) *big.Int {
+		return statedb.GetState(stable, stableSlot(account)).Big()
+	}
+	want := new(big.Int).Sub(stableBalance, stableTip)
+	if balance := stableBalanceOf(sender); balance.Cmp(want.Sub(want, big.NewInt(7))) != 0 {
+		t.Errorf("sender StableToken balance mismatch: have %v, want %v", balance, want)
+	}
+	if balance := stableBalanceOf(gateway); balance.Cmp(big.NewInt(7)) != 0 {
+		t.Errorf("gateway StableToken balance mismatch: have %v, want 7", balance)
+	}
+	if balance := stableBalanceOf(coinbase); balance.Cmp(stableTip) != 0 {
+		t.Errorf("coinbase StableToken balance mismatch: have %v, want %v", balance, stableTip)
+	}
+	want = new(big.Int).Sub(celoBalance, new(big.Int).Mul(value, big.NewInt(2)))
+	if balance := statedb.GetBalance(sender); balance.Cmp(want.Sub(want, celoTip).Sub(want, big.NewInt(5))) != 0 {
+		t.Errorf("sender CELO balance mismatch: have %v, want %v", balance, want)
+	}
+	if balance := statedb.GetBalance(gateway); balance.Cmp(big.NewInt(5)) != 0 {
+		t.Errorf("gateway CELO balance mismatch: have %v, want 5", balance)
+	}
+	if balance := statedb.GetBalance(coinbase); balance.Cmp(celoTip) != 0 {
+		t.Errorf("coinbase CELO balance mismatch: have %v, want %v", balance, celoTip)
+	}
+	if balance := statedb.GetBalance(recipient); balance.Cmp(new(big.Int).Mul(value, big.NewInt(2))) != 0 {
+		t.Errorf("recipient CELO balance mismatch: have %v, want %v", balance, 2*value.Int64())
+	}
+	// The chain of the prestate is no longer used for system calls
+	if _, err := contract_comm.GetRegisteredAddress(params.StableTokenRegistryId, nil, statedb); err != errors.ErrNoInternalEvmHandlerSingleton {
+		t.Errorf("system call error mismatch: have %v, want %v", err, errors.ErrNoInternalEvmHandlerSingleton)
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package t8ntool
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/tests"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// Error codes returned to the shell, so the failures of a state transition can
+// be told apart by scripts.
+const (
+	ErrorVMConfig = 3
+	ErrorJson     = 4
+	ErrorIO       = 5
+)
+
+// NumberedError is an error carrying the exit code the tool should return.
+type NumberedError struct {
+	errorCode int
+	err       error
+}
+
+func NewError(errorCode int, err error) *NumberedError {
+	return &NumberedError{errorCode, err}
+}
+
+func (n *NumberedError) Error() string {
+	return fmt.Sprintf("ERROR(%d): %v", n.errorCode, n.err.Error())
+}
+
+func (n *NumberedError) Code() int {
+	return n.errorCode
+}
+
+// input is the whole input of a state transition when read from stdin.
+type input struct {
+	Alloc core.GenesisAlloc `json:"alloc,omitempty"`
+	Env   *stEnv            `json:"env,omitempty"`
+	Txs   []*txWithKey      `json:"txs,omitempty"`
+}
+
+// Main applies the transactions given as input to the prestate and writes the
+// resulting post-state alloc and execution result.
+func Main(ctx *cli.Context) error {
+	// Configure the go-ethereum logger
+	glogger := log.NewGlogHandler(log.StreamHandler(os.Stderr, log.TerminalFormat(false)))
+	glogger.Verbosity(log.Lvl(ctx.Int(VerbosityFlag.Name)))
+	log.Root().SetHandler(glogger)
+
+	var getTracer func(txIndex int, txHash common.Hash) (vm.Tracer, error)
+
+	if ctx.Bool(TraceFlag.Name) {
+		// Configure the EVM logger
+		logConfig := &vm.LogConfig{
+			DisableStack:  ctx.Bool(TraceDisableStackFlag.Name),
+			DisableMemory: ctx.Bool(TraceDisableMemoryFlag.Name),
+			Debug:         true,
+		}
+		var prevFile *os.File
+		// This one closes the last file
+		defer func() {
+			if prevFile != nil {
+				prevFile.Close()
+			}
+		}()
+		getTracer = func(txIndex int, txHash common.Hash) (vm.Tracer, error) {
+			if prevFile != nil {
+				prevFile.Close()
+			}
+			traceFile, err := os.Create(fmt.Sprintf("trace-%d-%v.jsonl", txIndex, txHash.String()))
+			if err != nil {
+				return nil, NewError(ErrorIO, fmt.Errorf("failed creating trace-file: %v", err))
+			}
+			prevFile = traceFile
+			return vm.NewJSONLogger(logConfig, traceFile), nil
+		}
+	} else {
+		getTracer = func(txIndex int, txHash common.Hash) (vm.Tracer, error) {
+			return nil, nil
+		}
+	}
+	// We need to load three things: alloc, env and transactions. May be either in
+	// stdin input or in files.
+	// Check if anything needs to be read from stdin
+	var (
+		prestate Prestate
+		txs      types.Transactions // txs to apply
+		allocStr = ctx.String(InputAllocFlag.Name)
+
+		envStr    = ctx.String(InputEnvFlag.Name)
+		txStr     = ctx.String(InputTxsFlag.Name)
+		inputData = &input{}
+	)
+
+	if allocStr == "stdin" || envStr == "stdin" || txStr == "stdin" {
+		decoder := json.NewDecoder(os.Stdin)
+		if err := decoder.Decode(inputData); err != nil {
+			return NewError(ErrorJson, fmt.Errorf("failed unmarshaling stdin: %v", err))
+		}
+	}
+	if allocStr != "stdin" {
+		inFile, err := os.Open(allocStr)
+		if err != nil {
+			return NewError(ErrorIO, fmt.Errorf("failed reading alloc file: %v", err))
+		}
+		defer inFile.Close()
+		decoder := json.NewDecoder(inFile)
+		if err := decoder.Decode(&inputData.Alloc); err != nil {
+			return NewError(ErrorJson, fmt.Errorf("failed unmarshaling alloc-file: %v", err))
+		}
+	}
+	if envStr != "stdin" {
+		inFile, err := os.Open(envStr)
+		if err != nil {
+			return NewError(ErrorIO, fmt.Errorf("failed reading env file: %v", err))
+		}
+		defer inFile.Close()
+		decoder := json.NewDecoder(inFile)
+		var env stEnv
+		if err := decoder.Decode(&env); err != nil {
+			return NewError(ErrorJson, fmt.Errorf("failed unmarshaling env-file: %v", err))
+		}
+		inputData.Env = &env
+	}
+	if inputData.Env == nil {
+		return NewError(ErrorJson, errors.New("env is missing"))
+	}
+	prestate.Pre = inputData.Alloc
+	prestate.Env = *inputData.Env
+
+	// Construct the chainconfig
+	chainConfig, ok := tests.Forks[ctx.String(ForknameFlag.Name)]
+	if !ok {
+		return NewError(ErrorVMConfig, fmt.Errorf("failed constructing chain configuration: unsupported fork %q", ctx.String(ForknameFlag.Name)))
+	}
+	// Set the chain id
+	config := *chainConfig
+	config.ChainID = big.NewInt(ctx.Int64(ChainIDFlag.Name))
+	signer := types.MakeSigner(&config, new(big.Int).SetUint64(uint64(prestate.Env.Number)))
+
+	if txStr != "stdin" {
+		inFile, err := os.Open(txStr)
+		if err != nil {
+			return NewError(ErrorIO, fmt.Errorf("failed reading txs file: %v", err))
+		}
+		defer inFile.Close()
+		decoder := json.NewDecoder(inFile)
+		if err := decoder.Decode(&inputData.Txs); err != nil {
+			return NewError(ErrorJson, fmt.Errorf("failed unmarshaling txs-file: %v", err))
+		}
+	}
+	// Sign the transactions given with a secret key
+	for i, tx := range inputData.Txs {
+		if tx.key == nil {
+			txs = append(txs, tx.tx)
+			continue
+		}
+		signed, err := types.SignTx(tx.tx, signer, tx.key)
+		if err != nil {
+			return NewError(ErrorJson, fmt.Errorf("tx %d: failed to sign tx: %v", i, err))
+		}
+		txs = append(txs, signed)
+	}
+	// Apply the transactions and write out the post-state
+	state, result, err := prestate.Apply(vm.Config{}, &config, txs, getTracer)
+	if err != nil {
+		return err
+	}
+	return dispatchOutput(ctx, result, collectAlloc(state))
+}
+
+// txWithKey is a transaction of the input, along with the key to sign it with
+// if it isn't signed yet.
+type txWithKey struct {
+	key *ecdsa.PrivateKey
+	tx  *types.Transaction
+}
+
+func (t *txWithKey) UnmarshalJSON(input []byte) error {
+	// Read the secretKey, if present
+	type sKey struct {
+		Key *common.Hash `json:"secretKey"`
+	}
+	var key sKey
+	if err := json.Unmarshal(input, &key); err != nil {
+		return err
+	}
+	if key.Key != nil {
+		ecdsaKey, err := crypto.ToECDSA(key.Key.Bytes())
+		if err != nil {
+			return err
+		}
+		t.key = ecdsaKey
+	}
+	// Now, read the transaction itself
+	var tx types.Transaction
+	if err := json.Unmarshal(input, &tx); err != nil {
+		return err
+	}
+	t.tx = &tx
+	return nil
+}
+
+// collectAlloc converts the post-state into the alloc format of the prestate.
+func collectAlloc(statedb *state.StateDB) core.GenesisAlloc {
+	alloc := make(core.GenesisAlloc)
+	for addr, account := range statedb.RawDump(false, false, true).Accounts {
+		balance, _ := new(big.Int).SetString(account.Balance, 10)
+		genesisAccount := core.GenesisAccount{
+			Balance: balance,
+			Nonce:   account.Nonce,
+			Code:    common.FromHex(account.Code),
+		}
+		if len(account.Storage) > 0 {
+			genesisAccount.Storage = make(map[common.Hash]common.Hash, len(account.Storage))
+			for key, value := range account.Storage {
+				genesisAccount.Storage[key] = common.HexToHash(value)
+			}
+		}
+		alloc[addr] = genesisAccount
+	}
+	return alloc
+}
+
+// saveFile marshals the object to the given file.
+func saveFile(filename string, data interface{}) error {
+	b, err := json.MarshalIndent(data, "", " ")
+	if err != nil {
+		return NewError(ErrorJson, fmt.Errorf("failed marshalling output: %v", err))
+	}
+	if err = ioutil.WriteFile(filename, b, 0644); err != nil {
+		return NewError(ErrorIO, fmt.Errorf("failed writing output: %v", err))
+	}
+	return nil
+}
+
+// dispatchOutput writes the output data to either stderr or stdout, or to the
+// specified files.
+func dispatchOutput(ctx *cli.Context, result *ExecutionResult, alloc core.GenesisAlloc) error {
+	stdOutObject := make(map[string]interface{})
+	stdErrObject := make(map[string]interface{})
+	dispatch := func(fName, name string, obj interface{}) error {
+		switch fName {
+		case "stdout":
+			stdOutObject[name] = obj
+		case "stderr":
+			stdErrObject[name] = obj
+		default: // save to file
+			if err := saveFile(fName, obj); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := dispatch(ctx.String(OutputAllocFlag.Name), "alloc", alloc); err != nil {
+		return err
+	}
+	if err := dispatch(ctx.String(OutputResultFlag.Name), "result", result); err != nil {
+		return err
+	}
+	if len(stdOutObject) > 0 {
+		b, err := json.MarshalIndent(stdOutObject, "", " ")
+		if err != nil {
+			return NewError(ErrorJson, fmt.Errorf("failed marshalling output: %v", err))
+		}
+		os.Stdout.Write(b)
+	}
+	if len(stdErrObject) > 0 {
+		b, err := json.MarshalIndent(stdErrObject, "", " ")
+		if err != nil {
+			return NewError(ErrorJson, fmt.Errorf("failed marshalling output: %v", err))
+		}
+		os.Stderr.Write(b)
+	}
+	return nil
+}
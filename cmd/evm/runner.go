@@ -110,13 +110,17 @@ func runCmd(ctx *cli.Context) error {
 	var (
 		tracer        vm.Tracer
 		debugLogger   *vm.StructLogger
+		profiler      *vm.GasProfiler
 		statedb       *state.StateDB
 		chainConfig   *params.ChainConfig
 		sender        = common.BytesToAddress([]byte("sender"))
 		receiver      = common.BytesToAddress([]byte("receiver"))
 		genesisConfig *core.Genesis
 	)
-	if ctx.GlobalBool(MachineFlag.Name) {
+	if ctx.GlobalString(ProfileFlag.Name) != "" {
+		profiler = vm.NewGasProfiler()
+		tracer = profiler
+	} else if ctx.GlobalBool(MachineFlag.Name) {
 		tracer = vm.NewJSONLogger(logconfig, os.Stdout)
 	} else if ctx.GlobalBool(DebugFlag.Name) {
 		debugLogger = vm.NewStructLogger(logconfig)
@@ -200,7 +204,7 @@ func runCmd(ctx *cli.Context) error {
 		BlockNumber: new(big.Int).SetUint64(genesisConfig.Number),
 		EVMConfig: vm.Config{
 			Tracer:         tracer,
-			Debug:          ctx.GlobalBool(DebugFlag.Name) || ctx.GlobalBool(MachineFlag.Name),
+			Debug:          ctx.GlobalBool(DebugFlag.Name) || ctx.GlobalBool(MachineFlag.Name) || profiler != nil,
 			EVMInterpreter: ctx.GlobalString(EVMInterpreterFlag.Name),
 		},
 	}
@@ -273,6 +277,19 @@ func runCmd(ctx *cli.Context) error {
 		f.Close()
 	}
 
+	if profiler != nil {
+		f, err := os.Create(ctx.GlobalString(ProfileFlag.Name))
+		if err != nil {
+			fmt.Println("could not create gas profile: ", err)
+			os.Exit(1)
+		}
+		if err := profiler.Profile().Write(f); err != nil {
+			fmt.Println("could not write gas profile: ", err)
+			os.Exit(1)
+		}
+		f.Close()
+	}
+
 	if ctx.GlobalBool(DebugFlag.Name) {
 		if debugLogger != nil {
 			fmt.Fprintln(os.Stderr, "#### TRACE ####")
@@ -294,7 +311,7 @@ Gas used:           %d
 
 `, execTime, mem.HeapObjects, mem.Alloc, mem.TotalAlloc, mem.NumGC, initialGas-leftOverGas)
 	}
-	if tracer == nil {
+	if tracer == nil || profiler != nil {
 		fmt.Printf("0x%x\n", output)
 		if err != nil {
 			fmt.Printf(" error: %v\n", err)
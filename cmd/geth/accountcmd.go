@@ -20,6 +20,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io/ioutil"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
@@ -37,6 +38,10 @@ var (
 		Name:  "bls",
 		Usage: "Set to specify generation of proof-of-possession of a BLS key.",
 	}
+	mnemonicPasswordFlag = cli.StringFlag{
+		Name:  "mnemonicpassword",
+		Usage: "File containing the optional BIP-39 passphrase of the mnemonic",
+	}
 	walletCommand = cli.Command{
 		Name:      "wallet",
 		Usage:     "Manage Ethereum presale wallets",
@@ -232,6 +237,57 @@ Note:
 As you can directly copy your encrypted accounts to another ethereum instance,
 this import mechanism is not needed when you transfer an account between
 nodes.
+`,
+			},
+			{
+				Name:   "import-mnemonic",
+				Usage:  "Import a BIP-39 mnemonic into a new HD wallet",
+				Action: utils.MigrateFlags(accountImportMnemonic),
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.KeyStoreDirFlag,
+					utils.PasswordFileFlag,
+					utils.LightKDFFlag,
+					mnemonicPasswordFlag,
+				},
+				ArgsUsage: "<mnemonicFile>",
+				Description: `
+    geth account import-mnemonic <mnemonicfile>
+
+Imports the BIP-39 mnemonic from <mnemonicfile> into a new HD wallet and prints
+the address of its first account, derived at m/44'/52752'/0'/0/0.
+
+If the mnemonic is protected by a BIP-39 passphrase, it can be given in a file
+with the --mnemonicpassword flag.
+
+The wallet is saved in encrypted format under <KEYSTORE>/hd, you are prompted
+for a password. Further accounts can be derived with 'geth account derive'.
+
+For non-interactive use the password can be specified with the --password flag:
+
+    geth account import-mnemonic [options] <mnemonicfile>
+`,
+			},
+			{
+				Name:   "derive",
+				Usage:  "Derive a new account from an HD wallet",
+				Action: utils.MigrateFlags(accountDerive),
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.KeyStoreDirFlag,
+					utils.PasswordFileFlag,
+					utils.LightKDFFlag,
+				},
+				ArgsUsage: "<address> <path>",
+				Description: `
+    geth account derive <address> <path>
+
+Derives the account at <path> from the HD wallet containing <address>, adds it
+to the wallet and prints its address.
+
+The path is either absolute, e.g. m/44'/52752'/0'/0/1, or relative to the Celo
+root path m/44'/52752'/0'/0, e.g. 1. You are prompted for the password of the
+wallet, for non-interactive use it can be specified with the --password flag.
 `,
 			},
 		},
@@ -553,3 +609,58 @@ func accountImport(ctx *cli.Context) error {
 	fmt.Printf("Address: {%x}\n", acct.Address)
 	return nil
 }
+
+// accountImportMnemonic imports a BIP-39 mnemonic into a new HD wallet.
+func accountImportMnemonic(ctx *cli.Context) error {
+	mnemonicFile := ctx.Args().First()
+	if len(mnemonicFile) == 0 {
+		utils.Fatalf("mnemonic file must be given as argument")
+	}
+	mnemonic, err := ioutil.ReadFile(mnemonicFile)
+	if err != nil {
+		utils.Fatalf("Failed to read the mnemonic: %v", err)
+	}
+	var mnemonicPassphrase string
+	if file := ctx.String(mnemonicPasswordFlag.Name); file != "" {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			utils.Fatalf("Failed to read the mnemonic passphrase: %v", err)
+		}
+		mnemonicPassphrase = strings.TrimRight(string(content), "\r\n")
+	}
+	stack, _ := makeConfigNode(ctx)
+	passphrase := getPassPhrase("Your new wallet is locked with a password. Please give a password. Do not forget this password.", true, 0, utils.MakePasswordList(ctx))
+
+	ks := stack.AccountManager().Backends(keystore.KeyStoreType)[0].(*keystore.KeyStore)
+	acct, err := ks.ImportMnemonic(strings.TrimSpace(string(mnemonic)), mnemonicPassphrase, passphrase)
+	if err != nil {
+		utils.Fatalf("Could not create the wallet: %v", err)
+	}
+	fmt.Printf("Address: {%x}\n", acct.Address)
+	return nil
+}
+
+// accountDerive derives a new account from the HD wallet containing the given
+// account and pins it into the wallet.
+func accountDerive(ctx *cli.Context) error {
+	if len(ctx.Args()) != 2 {
+		utils.Fatalf("Please specify an account of the HD wallet and the derivation path.")
+	}
+	if !common.IsHexAddress(ctx.Args()[0]) {
+		utils.Fatalf("Invalid account address %q", ctx.Args()[0])
+	}
+	path, err := accounts.ParseDerivationPath(ctx.Args()[1])
+	if err != nil {
+		utils.Fatalf("Invalid derivation path: %v", err)
+	}
+	stack, _ := makeConfigNode(ctx)
+	passphrase := getPassPhrase("Please give the password of the HD wallet.", false, 0, utils.MakePasswordList(ctx))
+
+	ks := stack.AccountManager().Backends(keystore.KeyStoreType)[0].(*keystore.KeyStore)
+	acct, err := ks.DeriveHDAccount(accounts.Account{Address: common.HexToAddress(ctx.Args()[0])}, path, passphrase)
+	if err != nil {
+		utils.Fatalf("Could not derive the account: %v", err)
+	}
+	fmt.Printf("Address: {%x}\n", acct.Address)
+	return nil
+}
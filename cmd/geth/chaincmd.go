@@ -19,6 +19,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -34,6 +35,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/trie"
@@ -81,6 +83,7 @@ The dumpgenesis command dumps the genesis block configuration in JSON format to
 			utils.CacheFlag,
 			utils.SyncModeFlag,
 			utils.GCModeFlag,
+			utils.ChainArchiveFlag,
 			utils.CacheDatabaseFlag,
 			utils.CacheGCFlag,
 		},
@@ -90,7 +93,12 @@ The import command imports blocks from an RLP-encoded form. The form can be one
 with several RLP-encoded blocks, or several files can be used.
 
 If only one file is used, import error will result in failure. If several files are used,
-processing will proceed even if an individual RLP-file import failure occurs.`,
+processing will proceed even if an individual RLP-file import failure occurs.
+
+With --archive, every argument is a directory of segment files written by
+'export --archive'. All segments are checksummed and verified concurrently before
+any block is executed, which allows bootstrapping a node from a locally mirrored
+archive instead of the p2p network.`,
 	}
 	exportCommand = cli.Command{
 		Action:    utils.MigrateFlags(exportChain),
@@ -103,6 +111,8 @@ processing will proceed even if an individual RLP-file import failure occurs.`,
 			utils.BaklavaFlag,
 			utils.CacheFlag,
 			utils.SyncModeFlag,
+			utils.ChainArchiveFlag,
+			utils.ChainArchiveEpochsFlag,
 		},
 		Category: "BLOCKCHAIN COMMANDS",
 		Description: `
@@ -110,7 +120,12 @@ Requires a first argument of the file to write to.
 Optional second and third arguments control the first and
 last block to write. In this mode, the file will be appended
 if already existing. If the file ends with .gz, the output will
-be gzipped.`,
+be gzipped.
+
+With --archive, the first argument is a directory that receives one segment
+file per --archive.epochs epochs. Each segment holds the headers, bodies,
+receipts and total difficulties of its blocks, followed by an accumulator root
+and a checksum. Existing segments in the range are overwritten.`,
 	}
 	importPreimagesCommand = cli.Command{
 		Action:    utils.MigrateFlags(importPreimages),
@@ -195,6 +210,26 @@ Remove blockchain and state databases`,
 		Description: `
 The arguments are interpreted as block numbers or hashes.
 Use "ethereum dump 0" to dump the genesis block.`,
+	}
+	dumpDiffsCommand = cli.Command{
+		Action:    utils.MigrateFlags(dumpDiffs),
+		Name:      "dump-diffs",
+		Usage:     "Dump the state changes of a range of blocks as JSON lines",
+		ArgsUsage: "<firstBlockNum> [<lastBlockNum>]",
+		Flags: []cli.Flag{
+			utils.DataDirFlag,
+			utils.AlfajoresFlag,
+			utils.BaklavaFlag,
+			utils.CacheFlag,
+			utils.SyncModeFlag,
+		},
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+Re-executes the given range of canonical blocks on top of the state of the
+first block's parent, which must be available (e.g. on an archive node), and
+prints one JSON object per block with the accounts and storage slots the block
+changed, along with their values before and after it. Changes made when
+finalizing the block, such as epoch rewards, are included.`,
 	}
 	inspectCommand = cli.Command{
 		Action:    utils.MigrateFlags(inspect),
@@ -289,13 +324,17 @@ func importChain(ctx *cli.Context) error {
 	// Import the chain
 	start := time.Now()
 
+	importFn := utils.ImportChain
+	if ctx.Bool(utils.ChainArchiveFlag.Name) {
+		importFn = utils.ImportArchive
+	}
 	if len(ctx.Args()) == 1 {
-		if err := utils.ImportChain(chain, ctx.Args().First()); err != nil {
+		if err := importFn(chain, ctx.Args().First()); err != nil {
 			log.Error("Import error", "err", err)
 		}
 	} else {
 		for _, arg := range ctx.Args() {
-			if err := utils.ImportChain(chain, arg); err != nil {
+			if err := importFn(chain, arg); err != nil {
 				log.Error("Import error", "file", arg, "err", err)
 			}
 		}
@@ -363,7 +402,18 @@ func exportChain(ctx *cli.Context) error {
 
 	var err error
 	fp := ctx.Args().First()
-	if len(ctx.Args()) < 3 {
+	if ctx.Bool(utils.ChainArchiveFlag.Name) {
+		first, last := uint64(0), chain.CurrentBlock().NumberU64()
+		if len(ctx.Args()) >= 3 {
+			var ferr, lerr error
+			first, ferr = strconv.ParseUint(ctx.Args().Get(1), 10, 64)
+			last, lerr = strconv.ParseUint(ctx.Args().Get(2), 10, 64)
+			if ferr != nil || lerr != nil {
+				utils.Fatalf("Export error in parsing parameters: block number not an integer\n")
+			}
+		}
+		err = utils.ExportArchive(chain, fp, first, last, ctx.Uint64(utils.ChainArchiveEpochsFlag.Name))
+	} else if len(ctx.Args()) < 3 {
 		err = utils.ExportChain(chain, fp)
 	} else {
 		// This can be improved to allow for numbers larger than 9223372036854775807
@@ -437,7 +487,7 @@ func copyDb(ctx *cli.Context) error {
 	syncMode := *utils.GlobalTextMarshaler(ctx, utils.SyncModeFlag.Name).(*downloader.SyncMode)
 
 	var syncBloom *trie.SyncBloom
-	if syncMode == downloader.FastSync {
+	if syncMode.SyncPivotState() {
 		syncBloom = trie.NewSyncBloom(uint64(ctx.GlobalInt(utils.CacheFlag.Name)/2), chainDb)
 	}
 	dl := downloader.New(0, chainDb, syncBloom, new(event.TypeMux), chain, nil, nil)
@@ -576,6 +626,85 @@ func dump(ctx *cli.Context) error {
 	return nil
 }
 
+func dumpDiffs(ctx *cli.Context) error {
+	if len(ctx.Args()) < 1 || len(ctx.Args()) > 2 {
+		utils.Fatalf("This command requires one or two block number arguments.")
+	}
+	first, err := strconv.ParseUint(ctx.Args().First(), 10, 64)
+	if err != nil || first == 0 {
+		utils.Fatalf("Invalid first block number %q", ctx.Args().First())
+	}
+	last := first
+	if len(ctx.Args()) == 2 {
+		if last, err = strconv.ParseUint(ctx.Args().Get(1), 10, 64); err != nil || last < first {
+			utils.Fatalf("Invalid last block number %q", ctx.Args().Get(1))
+		}
+	}
+	stack := makeFullNode(ctx)
+	defer stack.Close()
+
+	chain, chainDb := utils.MakeChain(ctx, stack)
+	defer chainDb.Close()
+
+	if err := writeStateDiffs(os.Stdout, chain, chainDb, first, last); err != nil {
+		utils.Fatalf("%v", err)
+	}
+	return nil
+}
+
+// writeStateDiffs re-executes the canonical blocks between first and last on
+// top of the state of the first block's parent, writing their state diffs to w
+// as JSON lines.
+func writeStateDiffs(w io.Writer, chain *core.BlockChain, chainDb ethdb.Database, first, last uint64) error {
+	parent := chain.GetBlockByNumber(first - 1)
+	if parent == nil {
+		return fmt.Errorf("block %d not found", first-1)
+	}
+	database := state.NewDatabaseWithCache(chainDb, 16)
+	statedb, err := state.New(parent.Root(), database)
+	if err != nil {
+		return fmt.Errorf("state of block %d unavailable: %v", first-1, err)
+	}
+	var (
+		enc    = json.NewEncoder(w)
+		start  = time.Now()
+		logged time.Time
+		proot  common.Hash
+	)
+	for number := first; number <= last; number++ {
+		block := chain.GetBlockByNumber(number)
+		if block == nil {
+			return fmt.Errorf("block %d not found", number)
+		}
+		diff, err := core.DiffBlock(chain.Config(), chain.Processor(), block, statedb)
+		if err != nil {
+			return fmt.Errorf("could not compute state diff: %v", err)
+		}
+		if err := enc.Encode(diff); err != nil {
+			return err
+		}
+		// Move on to the state of the block, releasing the previous one
+		root, err := statedb.Commit(chain.Config().IsEIP158(block.Number()))
+		if err != nil {
+			return err
+		}
+		if err := statedb.Reset(root); err != nil {
+			return err
+		}
+		database.TrieDB().Reference(root, common.Hash{})
+		if proot != (common.Hash{}) {
+			database.TrieDB().Dereference(proot)
+		}
+		proot = root
+
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Dumping state diffs", "block", number, "last", last, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	return nil
+}
+
 func inspect(ctx *cli.Context) error {
 	node, _ := makeConfigNode(ctx)
 	defer node.Close()
@@ -0,0 +1,124 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/consensustest"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that dump-diffs writes one state diff per block, chained on the states
+// of the previous blocks.
+func TestWriteStateDiffs(t *testing.T) {
+	var (
+		key, _    = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender    = crypto.PubkeyToAddress(key.PublicKey)
+		recipient = common.Address{0x01}
+		value     = big.NewInt(1000)
+		db        = rawdb.NewMemoryDatabase()
+		gspec     = &core.Genesis{Config: params.IstanbulTestChainConfig, Alloc: core.GenesisAlloc{sender: {Balance: big.NewInt(params.Ether)}}}
+		genesis   = gspec.MustCommit(db)
+		signer    = types.NewEIP155Signer(gspec.Config.ChainID)
+	)
+	// The states of the diffed blocks' parents must be available, like on an archive node
+	cacheConfig := &core.CacheConfig{TrieCleanLimit: 16, TrieDirtyDisabled: true}
+	chain, err := core.NewBlockChain(db, cacheConfig, gspec.Config, consensustest.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	gendb := rawdb.NewMemoryDatabase()
+	gspec.MustCommit(gendb)
+	blocks, _ := core.GenerateChain(gspec.Config, genesis, consensustest.NewFaker(), gendb, 3, func(i int, gen *core.BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(sender), recipient, value, params.TxGas, nil, nil, nil, nil, nil), signer, key)
+		if err != nil {
+			t.Fatalf("failed to sign transaction: %v", err)
+		}
+		gen.AddTx(tx)
+	})
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := writeStateDiffs(&out, chain, db, 2, 3); err != nil {
+		t.Fatalf("failed to write state diffs: %v", err)
+	}
+	var (
+		scanner = bufio.NewScanner(&out)
+		number  = uint64(2)
+	)
+	for ; scanner.Scan(); number++ {
+		var diff core.BlockStateDiff
+		if err := json.Unmarshal(scanner.Bytes(), &diff); err != nil {
+			t.Fatalf("block %d: failed to decode state diff: %v", number, err)
+		}
+		block := blocks[number-1]
+		if uint64(diff.Number) != number || diff.Hash != block.Hash() || diff.Root != block.Root() {
+			t.Errorf("block %d: block mismatch: have #%d %x, want #%d %x", number, diff.Number, diff.Hash, number, block.Hash())
+		}
+		account := diff.Accounts[recipient]
+		if account == nil || account.Balance == nil {
+			t.Fatalf("block %d: recipient balance diff missing", number)
+		}
+		if account.Created {
+			t.Errorf("block %d: recipient reported as created", number)
+		}
+		from, to := new(big.Int).Mul(value, new(big.Int).SetUint64(number-1)), new(big.Int).Mul(value, new(big.Int).SetUint64(number))
+		if account.Balance.From.ToInt().Cmp(from) != 0 || account.Balance.To.ToInt().Cmp(to) != 0 {
+			t.Errorf("block %d: recipient balance diff mismatch: have %v -> %v, want %v -> %v", number, account.Balance.From, account.Balance.To, from, to)
+		}
+		if nonce := diff.Accounts[sender].Nonce; nonce == nil || uint64(nonce.From) != number-1 || uint64(nonce.To) != number {
+			t.Errorf("block %d: sender nonce diff mismatch: have %+v, want %d -> %d", number, nonce, number-1, number)
+		}
+	}
+	if number != 4 {
+		t.Errorf("state diff count mismatch: have %d, want 2", number-2)
+	}
+	// The first block diffed creates the recipient
+	out.Reset()
+	if err := writeStateDiffs(&out, chain, db, 1, 1); err != nil {
+		t.Fatalf("failed to write state diffs: %v", err)
+	}
+	var diff core.BlockStateDiff
+	if err := json.Unmarshal(out.Bytes(), &diff); err != nil {
+		t.Fatalf("failed to decode state diff: %v", err)
+	}
+	if account := diff.Accounts[recipient]; account == nil || !account.Created {
+		t.Errorf("recipient not reported as created: %+v", account)
+	}
+	// Blocks past the head can't be diffed
+	if err := writeStateDiffs(&out, chain, db, 3, 4); err == nil {
+		t.Error("expected missing block to be reported")
+	}
+	if err := writeStateDiffs(&out, chain, db, 5, 5); err == nil {
+		t.Error("expected missing parent to be reported")
+	}
+}
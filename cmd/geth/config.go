@@ -28,6 +28,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/relay"
 	whisper "github.com/ethereum/go-ethereum/whisper/whisperv6"
 	"github.com/naoina/toml"
 	cli "gopkg.in/urfave/cli.v1"
@@ -76,6 +77,7 @@ type gethConfig struct {
 	Shh      whisper.Config
 	Node     node.Config
 	Ethstats ethstatsConfig
+	Relay    relay.Config
 }
 
 func loadConfig(file string, cfg *gethConfig) error {
@@ -106,9 +108,10 @@ func defaultNodeConfig() node.Config {
 func makeConfigNode(ctx *cli.Context) (*node.Node, gethConfig) {
 	// Load defaults.
 	cfg := gethConfig{
-		Eth:  eth.DefaultConfig,
-		Shh:  whisper.DefaultConfig,
-		Node: defaultNodeConfig(),
+		Eth:   eth.DefaultConfig,
+		Shh:   whisper.DefaultConfig,
+		Node:  defaultNodeConfig(),
+		Relay: relay.DefaultConfig,
 	}
 
 	// Load config file.
@@ -132,6 +135,7 @@ func makeConfigNode(ctx *cli.Context) (*node.Node, gethConfig) {
 		cfg.Ethstats.URL = ctx.GlobalString(utils.EthStatsLegacyURLFlag.Name)
 	}
 	utils.SetShhConfig(ctx, stack, &cfg.Shh)
+	utils.SetRelayConfig(ctx, &cfg.Relay)
 
 	return stack, cfg
 }
@@ -173,6 +177,10 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 	if cfg.Ethstats.URL != "" || cfg.Eth.Istanbul.Proxied {
 		utils.RegisterEthStatsService(stack, cfg.Ethstats.URL)
 	}
+	// Add the meta-transaction relay if a forwarder is configured
+	if cfg.Relay.Enabled() {
+		utils.RegisterRelayService(stack, &cfg.Relay)
+	}
 	return stack
 }
 
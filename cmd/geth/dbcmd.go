@@ -0,0 +1,343 @@
+// Copyright 2020 The celo Authors
+// This file is part of celo.
+//
+// celo is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// celo is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with celo. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/istanbul/backend"
+	"github.com/ethereum/go-ethereum/console"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/olekukonko/tablewriter"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+var (
+	dbStoreFlag = cli.StringFlag{
+		Name:  "store",
+		Usage: "Database to operate on (chaindata, validatorenodes, versioncertificates, replicastate)",
+		Value: "chaindata",
+	}
+	dbPrefixFlag = cli.StringFlag{
+		Name:  "prefix",
+		Usage: "Only consider keys starting with this prefix (0x-prefixed hex or plain text)",
+	}
+	dbLimitFlag = cli.IntFlag{
+		Name:  "limit",
+		Usage: "Maximum number of entries to print (0 = no limit)",
+	}
+	dbPrefixLenFlag = cli.IntFlag{
+		Name:  "prefixlen",
+		Usage: "Number of leading key bytes used to group keys not known to the schema",
+		Value: 1,
+	}
+	dbDryRunFlag = cli.BoolFlag{
+		Name:  "dryrun",
+		Usage: "Print the change a mutation would make without writing it",
+	}
+
+	dbFlags = []cli.Flag{
+		utils.DataDirFlag,
+		utils.AncientFlag,
+		utils.AlfajoresFlag,
+		utils.BaklavaFlag,
+		utils.SyncModeFlag,
+		dbStoreFlag,
+	}
+
+	dbCommand = cli.Command{
+		Name:      "db",
+		Usage:     "Low level database operations",
+		ArgsUsage: "",
+		Category:  "DATABASE COMMANDS",
+		Description: `
+Low level access to the node's databases, meant for repairing a node whose
+database got into a bad state. The node must not be running.
+
+Keys and values are given as 0x-prefixed hex, keys may also be given as plain
+text. Values of keys known to the chain database schema, as well as entries of
+the validator enode, version certificate and replica state databases selected
+with --store, are decoded and printed as JSON.
+
+Mutations print the current and new value and ask for confirmation before
+writing, or only print with --dryrun.`,
+		Subcommands: []cli.Command{
+			{
+				Name:      "get",
+				Usage:     "Print the value stored under a key",
+				ArgsUsage: "<key>",
+				Action:    utils.MigrateFlags(dbGet),
+				Flags:     dbFlags,
+			},
+			{
+				Name:      "put",
+				Usage:     "Store a value under a key",
+				ArgsUsage: "<key> <hex value>",
+				Action:    utils.MigrateFlags(dbPut),
+				Flags:     append(dbFlags, dbDryRunFlag),
+			},
+			{
+				Name:      "delete",
+				Usage:     "Delete the value stored under a key",
+				ArgsUsage: "<key>",
+				Action:    utils.MigrateFlags(dbDelete),
+				Flags:     append(dbFlags, dbDryRunFlag),
+			},
+			{
+				Name:   "iterate",
+				Usage:  "Print all entries whose key starts with a prefix as JSON lines",
+				Action: utils.MigrateFlags(dbIterate),
+				Flags:  append(dbFlags, dbPrefixFlag, dbLimitFlag),
+			},
+			{
+				Name:   "stats-by-prefix",
+				Usage:  "Print the number and size of entries grouped by key prefix",
+				Action: utils.MigrateFlags(dbStatsByPrefix),
+				Flags:  append(dbFlags, dbPrefixFlag, dbPrefixLenFlag),
+				Description: `
+Groups keys of the chain database by schema entry (headers, receipts, uptime,
+istanbul snapshots, ...). Keys not known to the schema, and all keys of the
+auxiliary databases, are grouped by their first --prefixlen bytes.`,
+			},
+		},
+	}
+)
+
+// dbEntryDecoder decodes a raw key/value pair of a database into a value that
+// can be printed as JSON.
+type dbEntryDecoder func(key []byte, value []byte) (interface{}, error)
+
+// openDBStore opens the database selected with --store, together with the
+// decoder and key categorizer for its entries. The categorizer is nil for
+// databases without a known schema.
+func openDBStore(ctx *cli.Context) (ethdb.Database, dbEntryDecoder, func([]byte) string) {
+	stack, cfg := makeConfigNode(ctx)
+
+	store := ctx.String(dbStoreFlag.Name)
+	if store == "chaindata" {
+		return utils.MakeChainDatabase(ctx, stack), rawdb.DecodeEntry, rawdb.KeyCategory
+	}
+	for _, aux := range backend.AuxiliaryDBs(&cfg.Eth.Istanbul) {
+		if aux.Name != store {
+			continue
+		}
+		// Opening a missing database would silently create an empty one
+		if !common.FileExist(aux.Path) {
+			utils.Fatalf("Database %s not found at %s", store, aux.Path)
+		}
+		db, err := rawdb.NewLevelDBDatabase(aux.Path, 16, 16, "")
+		if err != nil {
+			utils.Fatalf("Could not open database %s: %v", store, err)
+		}
+		return db, aux.Decode, nil
+	}
+	utils.Fatalf("Unknown database %q", store)
+	return nil, nil, nil
+}
+
+// parseDBKey parses a key given on the command line, either as 0x-prefixed hex
+// or as plain text.
+func parseDBKey(arg string) ([]byte, error) {
+	if strings.HasPrefix(arg, "0x") || strings.HasPrefix(arg, "0X") {
+		return hexutil.Decode(arg)
+	}
+	return []byte(arg), nil
+}
+
+// dbEntryJSON is the printed form of a database entry.
+type dbEntryJSON struct {
+	Key     hexutil.Bytes `json:"key"`
+	Value   hexutil.Bytes `json:"value"`
+	Decoded interface{}   `json:"decoded,omitempty"`
+	Error   string        `json:"decodeError,omitempty"`
+}
+
+func newDBEntryJSON(decode dbEntryDecoder, key []byte, value []byte) *dbEntryJSON {
+	entry := &dbEntryJSON{Key: common.CopyBytes(key), Value: common.CopyBytes(value)}
+	decoded, err := decode(key, value)
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Decoded = decoded
+	}
+	return entry
+}
+
+func printDBEntry(decode dbEntryDecoder, key []byte, value []byte) {
+	out, err := json.MarshalIndent(newDBEntryJSON(decode, key, value), "", "  ")
+	if err != nil {
+		utils.Fatalf("Could not encode entry: %v", err)
+	}
+	fmt.Println(string(out))
+}
+
+// confirmDBMutation prints the effect of a mutation and reports whether it
+// should be carried out.
+func confirmDBMutation(ctx *cli.Context, description string) bool {
+	fmt.Println(description)
+	if ctx.Bool(dbDryRunFlag.Name) {
+		fmt.Println("Dry run, database left unchanged")
+		return false
+	}
+	confirm, err := console.Stdin.PromptConfirm("Apply the change?")
+	if err != nil {
+		utils.Fatalf("%v", err)
+	}
+	if !confirm {
+		fmt.Println("Aborted, database left unchanged")
+	}
+	return confirm
+}
+
+func dbGet(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		utils.Fatalf("This command requires a key argument.")
+	}
+	key, err := parseDBKey(ctx.Args().First())
+	if err != nil {
+		utils.Fatalf("Invalid key: %v", err)
+	}
+	db, decode, _ := openDBStore(ctx)
+	defer db.Close()
+
+	value, err := db.Get(key)
+	if err != nil {
+		return err
+	}
+	printDBEntry(decode, key, value)
+	return nil
+}
+
+func dbPut(ctx *cli.Context) error {
+	if len(ctx.Args()) != 2 {
+		utils.Fatalf("This command requires a key and a value argument.")
+	}
+	key, err := parseDBKey(ctx.Args().First())
+	if err != nil {
+		utils.Fatalf("Invalid key: %v", err)
+	}
+	value, err := hexutil.Decode(ctx.Args().Get(1))
+	if err != nil {
+		utils.Fatalf("Invalid value, expected 0x-prefixed hex: %v", err)
+	}
+	db, decode, _ := openDBStore(ctx)
+	defer db.Close()
+
+	if old, err := db.Get(key); err == nil {
+		fmt.Println("Current entry:")
+		printDBEntry(decode, key, old)
+	} else {
+		fmt.Println("No current entry")
+	}
+	fmt.Println("New entry:")
+	printDBEntry(decode, key, value)
+
+	if !confirmDBMutation(ctx, fmt.Sprintf("Put %d bytes under key %#x", len(value), key)) {
+		return nil
+	}
+	if err := db.Put(key, value); err != nil {
+		return err
+	}
+	log.Info("Stored database entry", "store", ctx.String(dbStoreFlag.Name), "key", hexutil.Encode(key))
+	return nil
+}
+
+func dbDelete(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		utils.Fatalf("This command requires a key argument.")
+	}
+	key, err := parseDBKey(ctx.Args().First())
+	if err != nil {
+		utils.Fatalf("Invalid key: %v", err)
+	}
+	db, decode, _ := openDBStore(ctx)
+	defer db.Close()
+
+	old, err := db.Get(key)
+	if err != nil {
+		return errors.New("key not found")
+	}
+	fmt.Println("Current entry:")
+	printDBEntry(decode, key, old)
+
+	if !confirmDBMutation(ctx, fmt.Sprintf("Delete key %#x", key)) {
+		return nil
+	}
+	if err := db.Delete(key); err != nil {
+		return err
+	}
+	log.Info("Deleted database entry", "store", ctx.String(dbStoreFlag.Name), "key", hexutil.Encode(key))
+	return nil
+}
+
+func dbIterate(ctx *cli.Context) error {
+	prefix, err := parseDBKey(ctx.String(dbPrefixFlag.Name))
+	if err != nil {
+		utils.Fatalf("Invalid prefix: %v", err)
+	}
+	db, decode, _ := openDBStore(ctx)
+	defer db.Close()
+
+	it := db.NewIteratorWithPrefix(prefix)
+	defer it.Release()
+
+	var (
+		limit = ctx.Int(dbLimitFlag.Name)
+		enc   = json.NewEncoder(os.Stdout)
+	)
+	for count := 0; it.Next() && (limit == 0 || count < limit); count++ {
+		if err := enc.Encode(newDBEntryJSON(decode, it.Key(), it.Value())); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+func dbStatsByPrefix(ctx *cli.Context) error {
+	prefix, err := parseDBKey(ctx.String(dbPrefixFlag.Name))
+	if err != nil {
+		utils.Fatalf("Invalid prefix: %v", err)
+	}
+	db, _, categorize := openDBStore(ctx)
+	defer db.Close()
+
+	var (
+		count uint64
+		total common.StorageSize
+		rows  [][]string
+	)
+	for _, stat := range rawdb.InspectPrefixes(db, prefix, ctx.Int(dbPrefixLenFlag.Name), categorize) {
+		rows = append(rows, []string{stat.Group, fmt.Sprint(stat.Count), stat.Size.String()})
+		count += stat.Count
+		total += stat.Size
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Group", "Entries", "Size"})
+	table.SetFooter([]string{"Total", fmt.Sprint(count), total.String()})
+	table.AppendBulk(rows)
+	table.Render()
+	return nil
+}
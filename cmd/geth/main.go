@@ -173,6 +173,18 @@ var (
 		utils.WSPortFlag,
 		utils.WSApiFlag,
 		utils.WSAllowedOriginsFlag,
+		utils.RPCAllowFlag,
+		utils.RPCDenyFlag,
+		utils.WSAllowFlag,
+		utils.WSDenyFlag,
+		utils.IPCAllowFlag,
+		utils.IPCDenyFlag,
+		utils.RPCMethodTimeoutsFlag,
+		utils.RPCRateLimitFlag,
+		utils.RPCRateBurstFlag,
+		utils.RPCAPIKeyHeaderFlag,
+		utils.RPCBatchLimitFlag,
+		utils.RPCResponseLimitFlag,
 		utils.IPCDisabledFlag,
 		utils.IPCPathFlag,
 		utils.InsecureUnlockAllowedFlag,
@@ -186,6 +198,14 @@ var (
 		utils.WhisperRestrictConnectionBetweenLightClientsFlag,
 	}
 
+	relayFlags = []cli.Flag{
+		utils.RelayForwarderFlag,
+		utils.RelayAccountFlag,
+		utils.RelayFeeCurrencyFlag,
+		utils.RelayMaxGasFlag,
+		utils.RelayMaxQueuedFlag,
+	}
+
 	metricsFlags = []cli.Flag{
 		utils.MetricsEnabledFlag,
 		utils.MetricsEnabledExpensiveFlag,
@@ -213,8 +233,11 @@ func init() {
 		copydbCommand,
 		removedbCommand,
 		dumpCommand,
+		dumpDiffsCommand,
 		dumpGenesisCommand,
 		inspectCommand,
+		// See dbcmd.go:
+		dbCommand,
 		// See accountcmd.go:
 		accountCommand,
 		walletCommand,
@@ -238,6 +261,7 @@ func init() {
 	app.Flags = append(app.Flags, debug.Flags...)
 	app.Flags = append(app.Flags, whisperFlags...)
 	app.Flags = append(app.Flags, metricsFlags...)
+	app.Flags = append(app.Flags, relayFlags...)
 
 	app.Before = func(ctx *cli.Context) error {
 		return debug.Setup(ctx)
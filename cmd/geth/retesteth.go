@@ -500,7 +500,7 @@ func (api *RetestethAPI) mineBlock() error {
 					gasPool,
 					statedb,
 					header,
-					tx, &header.GasUsed, *api.blockchain.GetVMConfig(),
+					tx, &header.GasUsed, *api.blockchain.GetVMConfig(), nil,
 				)
 				if err != nil {
 					statedb.RevertToSnapshot(snap)
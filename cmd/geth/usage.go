@@ -157,6 +157,18 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.WSPortFlag,
 			utils.WSApiFlag,
 			utils.WSAllowedOriginsFlag,
+			utils.RPCAllowFlag,
+			utils.RPCDenyFlag,
+			utils.WSAllowFlag,
+			utils.WSDenyFlag,
+			utils.IPCAllowFlag,
+			utils.IPCDenyFlag,
+			utils.RPCMethodTimeoutsFlag,
+			utils.RPCRateLimitFlag,
+			utils.RPCRateBurstFlag,
+			utils.RPCAPIKeyHeaderFlag,
+			utils.RPCBatchLimitFlag,
+			utils.RPCResponseLimitFlag,
 			utils.GraphQLEnabledFlag,
 			utils.GraphQLListenAddrFlag,
 			utils.GraphQLPortFlag,
@@ -224,6 +236,10 @@ var AppHelpFlagGroups = []flagGroup{
 		Name:  "WHISPER (EXPERIMENTAL)",
 		Flags: whisperFlags,
 	},
+	{
+		Name:  "META-TRANSACTION RELAY (EXPERIMENTAL)",
+		Flags: relayFlags,
+	},
 	{
 		Name: "ISTANBUL",
 		Flags: []cli.Flag{
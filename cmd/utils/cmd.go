@@ -18,21 +18,27 @@
 package utils
 
 import (
+	"bufio"
+	"bytes"
 	"compress/gzip"
 	"fmt"
 	"io"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"syscall"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/internal/chainarchive"
 	"github.com/ethereum/go-ethereum/internal/debug"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/node"
@@ -85,21 +91,20 @@ func StartNode(stack *node.Node) {
 	}()
 }
 
-func ImportChain(chain *core.BlockChain, fn string) error {
-	// Watch for Ctrl-C while the import is running.
-	// If a signal is received, the import will stop at the next batch.
+// watchInterrupt watches for Ctrl-C while an import is running, the returned
+// check function reports whether a signal was received. The release function
+// must be called once the import is done.
+func watchInterrupt() (check func() bool, release func()) {
 	interrupt := make(chan os.Signal, 1)
 	stop := make(chan struct{})
 	signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
-	defer signal.Stop(interrupt)
-	defer close(interrupt)
 	go func() {
 		if _, ok := <-interrupt; ok {
 			log.Info("Interrupted during import, stopping at next batch")
 		}
 		close(stop)
 	}()
-	checkInterrupt := func() bool {
+	check = func() bool {
 		select {
 		case <-stop:
 			return true
@@ -107,6 +112,17 @@ func ImportChain(chain *core.BlockChain, fn string) error {
 			return false
 		}
 	}
+	release = func() {
+		signal.Stop(interrupt)
+		close(interrupt)
+	}
+	return check, release
+}
+
+func ImportChain(chain *core.BlockChain, fn string) error {
+	// If a signal is received, the import will stop at the next batch.
+	checkInterrupt, release := watchInterrupt()
+	defer release()
 
 	log.Info("Importing blockchain", "file", fn)
 
@@ -237,6 +253,275 @@ func ExportAppendChain(blockchain *core.BlockChain, fn string, first uint64, las
 	return nil
 }
 
+// sealVerifier is implemented by consensus engines able to check the seals of a
+// batch of headers ahead of executing the blocks.
+type sealVerifier interface {
+	VerifySeals(chain consensus.ChainReader, headers []*types.Header) error
+	ForgetSeals(headers []*types.Header)
+}
+
+// ExportArchive exports the blocks between first and last into a directory of
+// epoch aligned segment files, each holding the given number of epochs. The
+// range is widened to the enclosing segment boundaries, except for a trailing
+// segment that is not complete yet.
+func ExportArchive(blockchain *core.BlockChain, dir string, first uint64, last uint64, epochs uint64) error {
+	epochSize := blockchain.Engine().EpochSize()
+	if epochSize == 0 || epochs == 0 {
+		return fmt.Errorf("invalid segment size: %d epochs of %d blocks", epochs, epochSize)
+	}
+	if head := blockchain.CurrentBlock().NumberU64(); last > head {
+		last = head
+	}
+	if first == 0 {
+		first = 1
+	}
+	if first > last {
+		return fmt.Errorf("export range %d-%d is empty", first, last)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	size := epochs * epochSize
+	log.Info("Exporting blockchain archive", "dir", dir, "first", first, "last", last, "segment", size)
+
+	for index := chainarchive.SegmentIndex(first, size); index <= chainarchive.SegmentIndex(last, size); index++ {
+		segFirst, segLast := chainarchive.SegmentRange(index, size)
+		if segLast > last {
+			segLast = last
+		}
+		header := chainarchive.Header{
+			Version:   chainarchive.Version,
+			Index:     index,
+			First:     segFirst,
+			Count:     segLast - segFirst + 1,
+			EpochSize: epochSize,
+		}
+		if err := exportSegment(blockchain, filepath.Join(dir, chainarchive.FileName(index)), header); err != nil {
+			return fmt.Errorf("segment %d: %v", index, err)
+		}
+	}
+	log.Info("Exported blockchain archive", "dir", dir)
+	return nil
+}
+
+// exportSegment writes a single segment file. The file is renamed into place
+// only once complete, so an interrupted export never leaves a partial segment.
+func exportSegment(blockchain *core.BlockChain, fn string, header chainarchive.Header) error {
+	fh, err := os.OpenFile(fn+".tmp", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(fh.Name())
+	defer fh.Close()
+
+	buffer := bufio.NewWriter(fh)
+	writer, err := chainarchive.NewWriter(buffer, header)
+	if err != nil {
+		return err
+	}
+	for nr := header.First; nr < header.First+header.Count; nr++ {
+		block := blockchain.GetBlockByNumber(nr)
+		if block == nil {
+			return fmt.Errorf("export failed on #%d: not found", nr)
+		}
+		td := blockchain.GetTd(block.Hash(), nr)
+		if td == nil {
+			return fmt.Errorf("export failed on #%d: total difficulty not found", nr)
+		}
+		if err := writer.Append(block, blockchain.GetReceiptsByHash(block.Hash()), td); err != nil {
+			return err
+		}
+	}
+	trailer, err := writer.Finish()
+	if err != nil {
+		return err
+	}
+	if err := buffer.Flush(); err != nil {
+		return err
+	}
+	if err := fh.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(fh.Name(), fn); err != nil {
+		return err
+	}
+	log.Info("Exported archive segment", "file", fn, "first", header.First, "count", header.Count, "root", trailer.Root, "checksum", trailer.Checksum)
+	return nil
+}
+
+// ImportArchive imports a directory of segment files written by ExportArchive.
+// The archive must extend the local chain, possibly past the genesis. All
+// segments are verified concurrently before any block is executed, and the
+// seals of every batch are checked in parallel if the consensus engine allows.
+func ImportArchive(chain *core.BlockChain, dir string) error {
+	checkInterrupt, release := watchInterrupt()
+	defer release()
+
+	files, err := chainarchive.Files(dir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no archive segments found in %s", dir)
+	}
+	log.Info("Verifying blockchain archive", "dir", dir, "segments", len(files))
+	summaries, err := verifyArchive(files)
+	if err != nil {
+		return err
+	}
+	for i, summary := range summaries {
+		if i == 0 {
+			// The archive may start past the genesis, but it must extend the local chain
+			parent := chain.GetHeaderByNumber(summary.Header.First - 1)
+			if parent == nil {
+				return fmt.Errorf("archive parent block %d not in local chain", summary.Header.First-1)
+			}
+			if summary.ParentHash != parent.Hash() {
+				return fmt.Errorf("archive parent mismatch at block %d: have %x, want %x", parent.Number, summary.ParentHash, parent.Hash())
+			}
+			continue
+		}
+		prev := summaries[i-1]
+		if summary.Header.First != prev.Header.First+prev.Header.Count || summary.ParentHash != prev.LastHash {
+			return fmt.Errorf("archive gap between %s and %s", filepath.Base(prev.Path), filepath.Base(summary.Path))
+		}
+	}
+	verifier, _ := chain.Engine().(sealVerifier)
+
+	for _, summary := range summaries {
+		log.Info("Importing archive segment", "file", summary.Path, "first", summary.Header.First, "count", summary.Header.Count)
+		reader, err := chainarchive.Open(summary.Path)
+		if err != nil {
+			return err
+		}
+		err = importSegment(chain, reader, verifier, checkInterrupt)
+		reader.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %v", filepath.Base(summary.Path), err)
+		}
+	}
+	return nil
+}
+
+// verifyArchive runs chainarchive.Verify over all segment files concurrently.
+func verifyArchive(files []string) ([]*chainarchive.Summary, error) {
+	var (
+		pending   = make(chan int, len(files))
+		summaries = make([]*chainarchive.Summary, len(files))
+		errs      = make([]error, len(files))
+		pend      sync.WaitGroup
+	)
+	for i := range files {
+		pending <- i
+	}
+	close(pending)
+
+	for w := 0; w < runtime.NumCPU(); w++ {
+		pend.Add(1)
+		go func() {
+			defer pend.Done()
+			for i := range pending {
+				summaries[i], errs[i] = chainarchive.Verify(files[i])
+			}
+		}()
+	}
+	pend.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", filepath.Base(files[i]), err)
+		}
+	}
+	return summaries, nil
+}
+
+// importSegment inserts the blocks of a single verified segment in batches. The
+// seals of every batch are checked up front if the engine allows, and the total
+// difficulties and receipts of the inserted blocks are checked against the ones
+// stored in the archive.
+func importSegment(chain *core.BlockChain, reader *chainarchive.Reader, verifier sealVerifier, checkInterrupt func() bool) error {
+	entries := make([]*chainarchive.Entry, 0, importBatchSize)
+	blocks := make(types.Blocks, 0, importBatchSize)
+	for done := false; !done; {
+		if checkInterrupt() {
+			return fmt.Errorf("interrupted")
+		}
+		entries, blocks = entries[:0], blocks[:0]
+		for len(blocks) < importBatchSize {
+			entry, err := reader.Next()
+			if err == io.EOF {
+				done = true
+				break
+			} else if err != nil {
+				return err
+			}
+			entries = append(entries, entry)
+			blocks = append(blocks, entry.Block())
+		}
+		missing := missingBlocks(chain, blocks)
+		if len(missing) == 0 {
+			continue
+		}
+		headers := make([]*types.Header, len(missing))
+		for i, block := range missing {
+			headers[i] = block.Header()
+		}
+		if verifier != nil {
+			if err := verifier.VerifySeals(chain, headers); err != nil {
+				return err
+			}
+		}
+		if checkInterrupt() {
+			if verifier != nil {
+				verifier.ForgetSeals(headers)
+			}
+			return fmt.Errorf("interrupted")
+		}
+		if index, err := chain.InsertChain(missing); err != nil {
+			if verifier != nil {
+				verifier.ForgetSeals(headers[index:])
+			}
+			return fmt.Errorf("invalid block %d: %v", missing[index].NumberU64(), err)
+		}
+		if err := checkImported(chain, entries[len(entries)-len(missing):]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkImported compares the total difficulties and receipts of freshly inserted
+// blocks with the ones stored in the archive.
+func checkImported(chain *core.BlockChain, entries []*chainarchive.Entry) error {
+	for _, entry := range entries {
+		var (
+			hash   = entry.Header.Hash()
+			number = entry.Header.Number.Uint64()
+		)
+		if td := chain.GetTd(hash, number); td == nil || td.Cmp(entry.TD) != 0 {
+			return fmt.Errorf("block %d: total difficulty mismatch: have %v, want %v", number, td, entry.TD)
+		}
+		receipts := chain.GetReceiptsByHash(hash)
+		if len(receipts) != len(entry.Receipts) {
+			return fmt.Errorf("block %d: receipt count mismatch: have %d, want %d", number, len(receipts), len(entry.Receipts))
+		}
+		for i, receipt := range receipts {
+			have, err := rlp.EncodeToBytes((*types.ReceiptForStorage)(receipt))
+			if err != nil {
+				return err
+			}
+			want, err := rlp.EncodeToBytes(entry.Receipts[i])
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(have, want) {
+				return fmt.Errorf("block %d: receipt %d mismatch", number, i)
+			}
+		}
+	}
+	return nil
+}
+
 // ImportPreimages imports a batch of exported hash preimages into the database.
 func ImportPreimages(db ethdb.Database, fn string) error {
 	log.Info("Importing preimages", "file", fn)
@@ -0,0 +1,262 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/consensustest"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/internal/chainarchive"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	archiveKey, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	archiveAddress = crypto.PubkeyToAddress(archiveKey.PublicKey)
+	archiveGenesis = &core.Genesis{
+		Config: params.IstanbulTestChainConfig,
+		Alloc:  core.GenesisAlloc{archiveAddress: {Balance: big.NewInt(params.Ether)}},
+	}
+
+	// testSeal is the extra data accepted as a valid seal by sealEngine
+	testSeal = []byte("sealed")
+)
+
+// sealEngine is a fake engine checking the seals of imported batches ahead of
+// their execution, seals are valid if the extra data is testSeal.
+type sealEngine struct {
+	*consensustest.MockEngine
+}
+
+func (e sealEngine) VerifySeals(chain consensus.ChainReader, headers []*types.Header) error {
+	for _, header := range headers {
+		if !bytes.Equal(header.Extra, testSeal) {
+			return fmt.Errorf("block %d: invalid seal", header.Number)
+		}
+	}
+	return nil
+}
+
+func (e sealEngine) ForgetSeals(headers []*types.Header) {}
+
+// newArchiveChain creates an empty chain from the archive genesis.
+func newArchiveChain(t *testing.T, engine consensus.Engine) *core.BlockChain {
+	db := rawdb.NewMemoryDatabase()
+	archiveGenesis.MustCommit(db)
+	chain, err := core.NewBlockChain(db, nil, archiveGenesis.Config, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	return chain
+}
+
+// exportTestArchive creates a chain of n sealed blocks with a transaction each,
+// with an epoch size of 4, and exports it to a temporary directory with one
+// epoch per segment.
+func exportTestArchive(t *testing.T, n int) (*core.BlockChain, string) {
+	engine := consensustest.NewFakerWithValidators(nil, 4, nil)
+	chain := newArchiveChain(t, engine)
+
+	gendb := rawdb.NewMemoryDatabase()
+	archiveGenesis.MustCommit(gendb)
+
+	signer := types.NewEIP155Signer(archiveGenesis.Config.ChainID)
+	blocks, _ := core.GenerateChain(archiveGenesis.Config, chain.Genesis(), engine, gendb, n, func(i int, gen *core.BlockGen) {
+		gen.SetExtra(testSeal)
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(archiveAddress), common.Address{0x01}, big.NewInt(1), params.TxGas, nil, nil, nil, nil, nil), signer, archiveKey)
+		if err != nil {
+			t.Fatalf("failed to sign transaction: %v", err)
+		}
+		gen.AddTx(tx)
+	})
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	dir, err := ioutil.TempDir("", "chainarchive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ExportArchive(chain, dir, 0, uint64(n), 1); err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("failed to export archive: %v", err)
+	}
+	return chain, dir
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	source, dir := exportTestArchive(t, 10)
+	defer source.Stop()
+	defer os.RemoveAll(dir)
+
+	files, err := chainarchive.Files(dir)
+	if err != nil {
+		t.Fatalf("failed to list segments: %v", err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("segment count mismatch: have %d, want 3", len(files))
+	}
+	chain := newArchiveChain(t, sealEngine{consensustest.NewFaker()})
+	defer chain.Stop()
+
+	if err := ImportArchive(chain, dir); err != nil {
+		t.Fatalf("failed to import archive: %v", err)
+	}
+	if have, want := chain.CurrentBlock().Hash(), source.CurrentBlock().Hash(); have != want {
+		t.Fatalf("head mismatch: have %x, want %x", have, want)
+	}
+	for nr := uint64(1); nr <= 10; nr++ {
+		hash := source.GetCanonicalHash(nr)
+		if have, want := len(chain.GetReceiptsByHash(hash)), len(source.GetReceiptsByHash(hash)); have != want {
+			t.Errorf("block %d: receipt count mismatch: have %d, want %d", nr, have, want)
+		}
+	}
+	// Importing again is a no-op
+	if err := ImportArchive(chain, dir); err != nil {
+		t.Fatalf("failed to import archive again: %v", err)
+	}
+}
+
+func TestArchiveImportUnknownParent(t *testing.T) {
+	source, dir := exportTestArchive(t, 10)
+	defer source.Stop()
+	defer os.RemoveAll(dir)
+
+	// Drop the first segment, the archive now starts at block 5
+	if err := os.Remove(filepath.Join(dir, chainarchive.FileName(0))); err != nil {
+		t.Fatal(err)
+	}
+	chain := newArchiveChain(t, sealEngine{consensustest.NewFaker()})
+	defer chain.Stop()
+
+	err := ImportArchive(chain, dir)
+	if err == nil || !strings.Contains(err.Error(), "not in local chain") {
+		t.Fatalf("import error mismatch: have %v, want parent not in local chain", err)
+	}
+	// Once the local chain reaches the parent, the archive extends it
+	blocks := make(types.Blocks, 0, 4)
+	for nr := uint64(1); nr <= 4; nr++ {
+		blocks = append(blocks, source.GetBlockByNumber(nr))
+	}
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	if err := ImportArchive(chain, dir); err != nil {
+		t.Fatalf("failed to import archive: %v", err)
+	}
+	if have, want := chain.CurrentBlock().Hash(), source.CurrentBlock().Hash(); have != want {
+		t.Fatalf("head mismatch: have %x, want %x", have, want)
+	}
+}
+
+func TestArchiveImportTamperedSeal(t *testing.T) {
+	source, dir := exportTestArchive(t, 10)
+	defer source.Stop()
+	defer os.RemoveAll(dir)
+
+	// Rewrite the last segment with a forged seal on its last block, keeping
+	// the segment checksum and accumulator root consistent
+	path := filepath.Join(dir, chainarchive.FileName(2))
+	reader, err := chainarchive.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open segment: %v", err)
+	}
+	var buf bytes.Buffer
+	writer, err := chainarchive.NewWriter(&buf, reader.Header())
+	if err != nil {
+		t.Fatalf("failed to create segment writer: %v", err)
+	}
+	for {
+		entry, err := reader.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("failed to read segment: %v", err)
+		}
+		block := entry.Block()
+		if block.NumberU64() == 10 {
+			header := block.Header()
+			header.Extra = []byte("forged")
+			block = block.WithSeal(header)
+		}
+		receipts := make(types.Receipts, len(entry.Receipts))
+		for i, receipt := range entry.Receipts {
+			receipts[i] = (*types.Receipt)(receipt)
+		}
+		if err := writer.Append(block, receipts, entry.TD); err != nil {
+			t.Fatalf("failed to append block: %v", err)
+		}
+	}
+	reader.Close()
+	if _, err := writer.Finish(); err != nil {
+		t.Fatalf("failed to finish segment: %v", err)
+	}
+	if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	chain := newArchiveChain(t, sealEngine{consensustest.NewFaker()})
+	defer chain.Stop()
+
+	err = ImportArchive(chain, dir)
+	if err == nil || !strings.Contains(err.Error(), "block 10: invalid seal") {
+		t.Fatalf("import error mismatch: have %v, want invalid seal of block 10", err)
+	}
+	// The batch holding the forged block is rejected before any of it executes
+	if head := chain.CurrentBlock().NumberU64(); head != 8 {
+		t.Errorf("head mismatch: have %d, want 8", head)
+	}
+}
+
+func TestArchiveImportCorruptedSegment(t *testing.T) {
+	source, dir := exportTestArchive(t, 10)
+	defer source.Stop()
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, chainarchive.FileName(1))
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[len(data)/2] ^= 0xff
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	chain := newArchiveChain(t, sealEngine{consensustest.NewFaker()})
+	defer chain.Stop()
+
+	if err := ImportArchive(chain, dir); err == nil {
+		t.Fatal("corrupted archive imported")
+	}
+	// All segments are verified before any block is executed
+	if head := chain.CurrentBlock().NumberU64(); head != 0 {
+		t.Errorf("head mismatch: have %d, want 0", head)
+	}
+}
@@ -58,6 +58,7 @@ import (
 	"github.com/ethereum/go-ethereum/p2p/nat"
 	"github.com/ethereum/go-ethereum/p2p/netutil"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/relay"
 	"github.com/ethereum/go-ethereum/rpc"
 	whisper "github.com/ethereum/go-ethereum/whisper/whisperv6"
 	pcsclite "github.com/gballet/go-libpcsclite"
@@ -211,7 +212,7 @@ var (
 	defaultSyncMode = eth.DefaultConfig.SyncMode
 	SyncModeFlag    = TextMarshalerFlag{
 		Name:  "syncmode",
-		Usage: `Blockchain sync mode ("fast", "full", "light", or "lightest")`,
+		Usage: `Blockchain sync mode ("fast", "full", "light", "lightest" or "epoch")`,
 		Value: &defaultSyncMode,
 	}
 	GCModeFlag = cli.StringFlag{
@@ -472,6 +473,15 @@ var (
 		Name:  "nocompaction",
 		Usage: "Disables db compaction after import",
 	}
+	ChainArchiveFlag = cli.BoolFlag{
+		Name:  "archive",
+		Usage: "Import from or export to a directory of checksummed, epoch aligned segment files",
+	}
+	ChainArchiveEpochsFlag = cli.Uint64Flag{
+		Name:  "archive.epochs",
+		Usage: "Number of epochs stored in each exported archive segment",
+		Value: 1,
+	}
 	// RPC settings
 	IPCDisabledFlag = cli.BoolFlag{
 		Name:  "ipcdisable",
@@ -534,6 +544,54 @@ var (
 		Usage: "Origins from which to accept websockets requests",
 		Value: "",
 	}
+	RPCAllowFlag = cli.StringFlag{
+		Name:  "rpc.allow",
+		Usage: "Comma separated list of the only methods allowed over the HTTP-RPC interface (accepts prefixes ending in '*')",
+	}
+	RPCDenyFlag = cli.StringFlag{
+		Name:  "rpc.deny",
+		Usage: "Comma separated list of methods denied over the HTTP-RPC interface (accepts prefixes ending in '*')",
+	}
+	WSAllowFlag = cli.StringFlag{
+		Name:  "ws.allow",
+		Usage: "Comma separated list of the only methods allowed over the WS-RPC interface (accepts prefixes ending in '*')",
+	}
+	WSDenyFlag = cli.StringFlag{
+		Name:  "ws.deny",
+		Usage: "Comma separated list of methods denied over the WS-RPC interface (accepts prefixes ending in '*')",
+	}
+	IPCAllowFlag = cli.StringFlag{
+		Name:  "ipc.allow",
+		Usage: "Comma separated list of the only methods allowed over the IPC-RPC interface (accepts prefixes ending in '*')",
+	}
+	IPCDenyFlag = cli.StringFlag{
+		Name:  "ipc.deny",
+		Usage: "Comma separated list of methods denied over the IPC-RPC interface (accepts prefixes ending in '*')",
+	}
+	RPCMethodTimeoutsFlag = cli.StringFlag{
+		Name:  "rpc.methodtimeouts",
+		Usage: "Comma separated list of method=duration time limits of the RPC methods (accepts prefixes ending in '*', e.g. eth_call=5s,debug_*=1m)",
+	}
+	RPCRateLimitFlag = cli.Float64Flag{
+		Name:  "rpc.ratelimit",
+		Usage: "Requests per second allowed per HTTP and WS-RPC client (0 = no limit)",
+	}
+	RPCRateBurstFlag = cli.IntFlag{
+		Name:  "rpc.rateburst",
+		Usage: "Requests an HTTP or WS-RPC client may make at once (defaults to the rate limit)",
+	}
+	RPCAPIKeyHeaderFlag = cli.StringFlag{
+		Name:  "rpc.apikeyheader",
+		Usage: "HTTP header identifying the RPC clients by API key for rate limiting",
+	}
+	RPCBatchLimitFlag = cli.IntFlag{
+		Name:  "rpc.batchlimit",
+		Usage: "Maximum number of requests in an RPC batch (0 = no limit)",
+	}
+	RPCResponseLimitFlag = cli.IntFlag{
+		Name:  "rpc.responselimit",
+		Usage: "Maximum size in bytes of an RPC result (0 = no limit)",
+	}
 	GraphQLEnabledFlag = cli.BoolFlag{
 		Name:  "graphql",
 		Usage: "Enable the GraphQL server",
@@ -667,6 +725,30 @@ var (
 		Usage: "Restrict connection between two whisper light clients",
 	}
 
+	// Meta-transaction relay settings
+	RelayForwarderFlag = cli.StringFlag{
+		Name:  "relay.forwarder",
+		Usage: "Address of the trusted forwarder contract meta-transactions are relayed through (enables the relay API)",
+	}
+	RelayAccountFlag = cli.StringFlag{
+		Name:  "relay.account",
+		Usage: "Unlocked account relaying the meta-transactions and paying for their fees",
+	}
+	RelayFeeCurrencyFlag = cli.StringFlag{
+		Name:  "relay.feecurrency",
+		Usage: "Address of the token the relay fees are paid in (native token if unset)",
+	}
+	RelayMaxGasFlag = cli.Uint64Flag{
+		Name:  "relay.maxgas",
+		Usage: "Maximum gas limit of a relayed meta-transaction",
+		Value: relay.DefaultConfig.MaxGas,
+	}
+	RelayMaxQueuedFlag = cli.IntFlag{
+		Name:  "relay.maxqueued",
+		Usage: "Maximum number of meta-transactions waiting to be relayed or mined",
+		Value: relay.DefaultConfig.MaxQueued,
+	}
+
 	// Metrics flags
 	MetricsEnabledFlag = cli.BoolFlag{
 		Name:  "metrics",
@@ -1003,6 +1085,67 @@ func setWS(ctx *cli.Context, cfg *node.Config) {
 	}
 }
 
+// setRPCPolicy configures the request policy of the RPC endpoints from the set
+// command line flags, on top of the one given in the config file if any.
+func setRPCPolicy(ctx *cli.Context, cfg *node.Config) {
+	flags := []cli.Flag{RPCAllowFlag, RPCDenyFlag, WSAllowFlag, WSDenyFlag, IPCAllowFlag, IPCDenyFlag, RPCMethodTimeoutsFlag, RPCRateLimitFlag, RPCRateBurstFlag, RPCAPIKeyHeaderFlag, RPCBatchLimitFlag, RPCResponseLimitFlag}
+	for _, flag := range flags {
+		if ctx.GlobalIsSet(flag.GetName()) {
+			if cfg.RPCPolicy == nil {
+				cfg.RPCPolicy = new(rpc.PolicyConfig)
+			}
+			break
+		}
+	}
+	if ctx.GlobalIsSet(RPCAllowFlag.Name) {
+		cfg.RPCPolicy.HTTP.Allow = splitAndTrim(ctx.GlobalString(RPCAllowFlag.Name))
+	}
+	if ctx.GlobalIsSet(RPCDenyFlag.Name) {
+		cfg.RPCPolicy.HTTP.Deny = splitAndTrim(ctx.GlobalString(RPCDenyFlag.Name))
+	}
+	if ctx.GlobalIsSet(WSAllowFlag.Name) {
+		cfg.RPCPolicy.WS.Allow = splitAndTrim(ctx.GlobalString(WSAllowFlag.Name))
+	}
+	if ctx.GlobalIsSet(WSDenyFlag.Name) {
+		cfg.RPCPolicy.WS.Deny = splitAndTrim(ctx.GlobalString(WSDenyFlag.Name))
+	}
+	if ctx.GlobalIsSet(IPCAllowFlag.Name) {
+		cfg.RPCPolicy.IPC.Allow = splitAndTrim(ctx.GlobalString(IPCAllowFlag.Name))
+	}
+	if ctx.GlobalIsSet(IPCDenyFlag.Name) {
+		cfg.RPCPolicy.IPC.Deny = splitAndTrim(ctx.GlobalString(IPCDenyFlag.Name))
+	}
+	if ctx.GlobalIsSet(RPCMethodTimeoutsFlag.Name) {
+		cfg.RPCPolicy.MethodTimeouts = make(map[string]time.Duration)
+		for _, entry := range splitAndTrim(ctx.GlobalString(RPCMethodTimeoutsFlag.Name)) {
+			parts := strings.SplitN(entry, "=", 2)
+			if len(parts) != 2 {
+				Fatalf("Invalid method timeout %q, expected method=duration", entry)
+			}
+			timeout, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+			if err != nil {
+				Fatalf("Invalid method timeout %q: %v", entry, err)
+			}
+			cfg.RPCPolicy.MethodTimeouts[strings.TrimSpace(parts[0])] = timeout
+		}
+	}
+	if ctx.GlobalIsSet(RPCRateLimitFlag.Name) {
+		cfg.RPCPolicy.RateLimit = ctx.GlobalFloat64(RPCRateLimitFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCRateBurstFlag.Name) {
+		cfg.RPCPolicy.RateBurst = ctx.GlobalInt(RPCRateBurstFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCAPIKeyHeaderFlag.Name) {
+		cfg.RPCPolicy.APIKeyHeader = ctx.GlobalString(RPCAPIKeyHeaderFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCBatchLimitFlag.Name) {
+		cfg.RPCPolicy.MaxBatchSize = ctx.GlobalInt(RPCBatchLimitFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCResponseLimitFlag.Name) {
+		cfg.RPCPolicy.MaxResponseSize = ctx.GlobalInt(RPCResponseLimitFlag.Name)
+	}
+}
+
 // setIPC creates an IPC path configuration from the set command line flags,
 // returning an empty string if IPC was explicitly disabled, or the set path.
 func setIPC(ctx *cli.Context, cfg *node.Config) {
@@ -1247,6 +1390,7 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 	setHTTP(ctx, cfg)
 	setGraphQL(ctx, cfg)
 	setWS(ctx, cfg)
+	setRPCPolicy(ctx, cfg)
 	setNodeUserIdent(ctx, cfg)
 	setDataDir(ctx, cfg)
 	setSmartCard(ctx, cfg)
@@ -1582,6 +1726,32 @@ func SetShhConfig(ctx *cli.Context, stack *node.Node, cfg *whisper.Config) {
 	}
 }
 
+// SetRelayConfig applies relay-related command line flags to the config.
+func SetRelayConfig(ctx *cli.Context, cfg *relay.Config) {
+	parseAddress := func(flag cli.StringFlag) common.Address {
+		value := ctx.GlobalString(flag.Name)
+		if !common.IsHexAddress(value) {
+			Fatalf("Invalid address for --%s: %q", flag.Name, value)
+		}
+		return common.HexToAddress(value)
+	}
+	if ctx.GlobalIsSet(RelayForwarderFlag.Name) {
+		cfg.Forwarder = parseAddress(RelayForwarderFlag)
+	}
+	if ctx.GlobalIsSet(RelayAccountFlag.Name) {
+		cfg.Account = parseAddress(RelayAccountFlag)
+	}
+	if ctx.GlobalIsSet(RelayFeeCurrencyFlag.Name) {
+		cfg.FeeCurrency = parseAddress(RelayFeeCurrencyFlag)
+	}
+	if ctx.GlobalIsSet(RelayMaxGasFlag.Name) {
+		cfg.MaxGas = ctx.GlobalUint64(RelayMaxGasFlag.Name)
+	}
+	if ctx.GlobalIsSet(RelayMaxQueuedFlag.Name) {
+		cfg.MaxQueued = ctx.GlobalInt(RelayMaxQueuedFlag.Name)
+	}
+}
+
 func getNetworkId(ctx *cli.Context) uint64 {
 	if ctx.GlobalIsSet(NetworkIdFlag.Name) {
 		return ctx.GlobalUint64(NetworkIdFlag.Name)
@@ -1803,6 +1973,30 @@ func RegisterGraphQLService(stack *node.Node, endpoint string, cors, vhosts []st
 	}
 }
 
+// RegisterRelayService configures the meta-transaction relay and adds it to
+// the given node.
+func RegisterRelayService(stack *node.Node, cfg *relay.Config) {
+	if err := stack.Register(func(ctx *node.ServiceContext) (node.Service, error) {
+		// The relay needs a full node to execute calls and track receipts
+		var ethServ *eth.Ethereum
+		if err := ctx.Service(&ethServ); err != nil {
+			return nil, errors.New("the relay requires a full node")
+		}
+		db, err := ctx.OpenDatabase("relay", 16, 16, "relay/db/")
+		if err != nil {
+			return nil, err
+		}
+		relayer, err := relay.New(*cfg, relay.NewEthBackend(ethServ.APIBackend), ctx.AccountManager, db)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		return relayer, nil
+	}); err != nil {
+		Fatalf("Failed to register the relay service: %v", err)
+	}
+}
+
 func SetupMetrics(ctx *cli.Context) {
 	if metrics.Enabled {
 		log.Info("Enabling metrics collection")
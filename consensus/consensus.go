@@ -178,6 +178,10 @@ type Istanbul interface {
 	// SetChain injects the blockchain and related functions to the istanbul consensus engine
 	SetChain(chain ChainReader, currentBlock func() *types.Block, stateAt func(common.Hash) (*state.StateDB, error))
 
+	// VerifyEpochHeaders verifies a chain of consecutive epoch block headers,
+	// following the last epoch block known to the chain.
+	VerifyEpochHeaders(chain ChainReader, headers []*types.Header) error
+
 	// SetBlockProcessors sets block processors
 	SetBlockProcessors(hasBadBlock func(common.Hash) bool,
 		processBlock func(*types.Block, *state.StateDB) (types.Receipts, []*types.Log, uint64, error),
@@ -23,11 +23,13 @@ import (
 	"math/big"
 	"net"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -133,6 +135,13 @@ type MockEngine struct {
 
 	fakeFail  uint64        // Block number which fails consensus even in fake mode
 	fakeDelay time.Duration // Time delay to sleep for before returning from verify
+
+	validators  []istanbul.Validator            // Validator set of the first epoch
+	elections   map[uint64][]istanbul.Validator // Validator sets elected for the later epochs, by epoch
+	elected     []istanbul.Validator            // Validator set elected at the end of every epoch, if set
+	epochSize   uint64                          // Epoch size reported to the EVM
+	epochReward *big.Int                        // CELO paid to every validator at the end of an epoch
+	lock        sync.RWMutex                    // Protects the elections
 }
 
 const (
@@ -156,6 +165,21 @@ func NewFaker() *MockEngine {
 	}
 }
 
+// NewFakerWithValidators creates a MockEngine consensus engine like NewFaker,
+// that additionally reports the given validator set and epoch size, so the
+// validator and epoch precompiles can be used. At the last block of every
+// epoch, the validators of the epoch are paid the epoch reward, if any, and the
+// validator set set with Elect takes effect for the following epochs.
+func NewFakerWithValidators(validators []istanbul.Validator, epochSize uint64, epochReward *big.Int) *MockEngine {
+	return &MockEngine{
+		mode:        Fake,
+		validators:  validators,
+		elections:   make(map[uint64][]istanbul.Validator),
+		epochSize:   epochSize,
+		epochReward: epochReward,
+	}
+}
+
 // NewFakeFailer creates a MockEngine consensus engine that
 // accepts all blocks as valid apart from the single one specified, though they
 // still have to conform to the Ethereum consensus rules.
@@ -188,10 +212,39 @@ func (e *MockEngine) accumulateRewards(config *params.ChainConfig, state *state.
 	// Simply touch coinbase account
 	reward := big.NewInt(1)
 	state.AddBalance(header.Coinbase, reward)
+
+	if e.epochReward != nil && e.isLastBlockOfEpoch(header.Number.Uint64()) {
+		for _, validator := range e.GetValidators(header.Number, header.Hash()) {
+			state.AddBalance(validator.Address(), e.epochReward)
+		}
+	}
+}
+
+// isLastBlockOfEpoch returns whether epoch processing happens at the block.
+func (e *MockEngine) isLastBlockOfEpoch(number uint64) bool {
+	return e.epochSize != 0 && number != 0 && istanbul.IsLastBlockOfEpoch(number, e.epochSize)
+}
+
+// Elect sets the validator set elected at the end of every epoch from the
+// current one on, which makes up the validator set of the following epochs.
+func (e *MockEngine) Elect(validators []istanbul.Validator) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.elected = validators
 }
 
 func (e *MockEngine) Finalize(chain consensus.ChainReader, header *types.Header, statedb *state.StateDB, txs []*types.Transaction) {
 	e.accumulateRewards(chain.Config(), statedb, header)
+
+	// Only imported blocks run the election, assembled ones may be discarded
+	if number := header.Number.Uint64(); e.isLastBlockOfEpoch(number) {
+		e.lock.Lock()
+		if e.elected != nil {
+			e.elections[istanbul.GetEpochNumber(number, e.epochSize)+1] = e.elected
+		}
+		e.lock.Unlock()
+	}
 	header.Root = statedb.IntermediateRoot(chain.Config().IsEIP158(header.Number))
 }
 
@@ -380,6 +433,28 @@ func (e *MockEngine) Seal(chain consensus.ChainReader, block *types.Block, resul
 	return nil
 }
 
+// GetValidators returns the validator set of the epoch of the block, the last
+// one elected before it or the one the engine was created with.
+func (e *MockEngine) GetValidators(blockNumber *big.Int, headerHash common.Hash) []istanbul.Validator {
+	if e.epochSize == 0 {
+		return e.validators
+	}
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	for epoch := istanbul.GetEpochNumber(blockNumber.Uint64(), e.epochSize); epoch > 1; epoch-- {
+		if validators, ok := e.elections[epoch]; ok {
+			return validators
+		}
+	}
+	return e.validators
+}
+
+// EpochSize returns the epoch size the engine was created with.
+func (e *MockEngine) EpochSize() uint64 {
+	return e.epochSize
+}
+
 // APIs implements consensus.Engine, returning the user facing RPC APIs.
 func (e *MockEngine) APIs(chain consensus.ChainReader) []rpc.API {
 	return []rpc.API{}
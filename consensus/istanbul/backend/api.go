@@ -17,6 +17,7 @@
 package backend
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -24,8 +25,6 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/istanbul"
-	vet "github.com/ethereum/go-ethereum/consensus/istanbul/backend/internal/enodes"
-	"github.com/ethereum/go-ethereum/consensus/istanbul/backend/internal/replica"
 	"github.com/ethereum/go-ethereum/consensus/istanbul/core"
 	"github.com/ethereum/go-ethereum/consensus/istanbul/proxy"
 	"github.com/ethereum/go-ethereum/consensus/istanbul/validator"
@@ -35,6 +34,10 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
+// consensusEventChanSize is the size of the channels buffering the consensus
+// events of a subscription.
+const consensusEventChanSize = 16
+
 // API is a user facing RPC API to dump Istanbul state
 type API struct {
 	chain    consensus.ChainReader
@@ -161,11 +164,11 @@ func (api *API) RemoveProxy(url string) (bool, error) {
 }
 
 // Retrieve the Validator Enode Table
-func (api *API) GetValEnodeTable() (map[string]*vet.ValEnodeEntryInfo, error) {
+func (api *API) GetValEnodeTable() (map[string]*istanbul.ValEnodeEntryInfo, error) {
 	return api.istanbul.valEnodeTable.ValEnodeTableInfo()
 }
 
-func (api *API) GetVersionCertificateTableInfo() (map[string]*vet.VersionCertificateEntryInfo, error) {
+func (api *API) GetVersionCertificateTableInfo() (map[string]*istanbul.VersionCertificateEntryInfo, error) {
 	return api.istanbul.versionCertificateTable.Info()
 }
 
@@ -249,9 +252,125 @@ func (api *API) IsValidating() bool {
 }
 
 // GetCurrentRoundState retrieves the current replica state
-func (api *API) GetCurrentReplicaState() (*replica.ReplicaStateSummary, error) {
+func (api *API) GetCurrentReplicaState() (*istanbul.ReplicaStateSummary, error) {
 	if api.istanbul.replicaState != nil {
 		return api.istanbul.replicaState.Summary(), nil
 	}
-	return &replica.ReplicaStateSummary{State: "Not a validator"}, nil
+	return &istanbul.ReplicaStateSummary{State: "Not a validator"}, nil
+}
+
+// RoundChange creates a subscription, served as the istanbul_subscribe topic
+// "roundChange", that is notified of the node moving to a higher consensus round, along with the
+// reason for the round change.
+func (api *API) RoundChange(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		roundChanges := make(chan istanbul.RoundChangeEvent, consensusEventChanSize)
+		roundChangesSub := api.istanbul.SubscribeRoundChangeEvent(roundChanges)
+		defer roundChangesSub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-roundChanges:
+				notifier.Notify(rpcSub.ID, ev)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// FinalCommitted creates a subscription, served as the istanbul_subscribe topic
+// "finalCommitted", that is notified of blocks being inserted into the chain, along with the round they
+// were agreed upon in and the bitmap of their signers.
+func (api *API) FinalCommitted(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		blocks := make(chan istanbul.FinalCommittedBlockEvent, consensusEventChanSize)
+		blocksSub := api.istanbul.SubscribeFinalCommittedBlockEvent(blocks)
+		defer blocksSub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-blocks:
+				notifier.Notify(rpcSub.ID, ev)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// ValidatorSetChange creates a subscription, served as the istanbul_subscribe topic
+// "validatorSetChange", that is notified of the validators added and removed at the end of each epoch.
+func (api *API) ValidatorSetChange(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		changes := make(chan istanbul.ValidatorSetChangeEvent, consensusEventChanSize)
+		changesSub := api.istanbul.SubscribeValidatorSetChangeEvent(changes)
+		defer changesSub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-changes:
+				notifier.Notify(rpcSub.ID, ev)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// EpochRewards creates a subscription, served as the istanbul_subscribe topic
+// "epochRewards", that is notified of the itemized epoch rewards paid out at the end of each epoch.
+// Rewards are only reported for blocks this node has executed itself, i.e. not
+// for blocks imported during fast sync.
+func (api *API) EpochRewards(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		rewards := make(chan istanbul.EpochRewardsEvent, consensusEventChanSize)
+		rewardsSub := api.istanbul.SubscribeEpochRewardsEvent(rewards)
+		defer rewardsSub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-rewards:
+				notifier.Notify(rpcSub.ID, ev)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
 }
@@ -79,6 +79,14 @@ func New(config *istanbul.Config, db ethdb.Database) consensus.Istanbul {
 	if err != nil {
 		logger.Crit("Failed to create known messages cache", "err", err)
 	}
+	epochRewardPayouts, err := lru.NewARC(inmemoryEpochRewards)
+	if err != nil {
+		logger.Crit("Failed to create epoch reward payouts cache", "err", err)
+	}
+	verifiedSeals, err := lru.NewARC(inmemoryVerifiedSeals)
+	if err != nil {
+		logger.Crit("Failed to create verified seals cache", "err", err)
+	}
 	backend := &Backend{
 		config:                             config,
 		istanbulEventMux:                   new(event.TypeMux),
@@ -90,6 +98,8 @@ func New(config *istanbul.Config, db ethdb.Database) consensus.Istanbul {
 		announceRunning:                    false,
 		peerRecentMessages:                 peerRecentMessages,
 		selfRecentMessages:                 selfRecentMessages,
+		epochRewardPayouts:                 epochRewardPayouts,
+		verifiedSeals:                      verifiedSeals,
 		announceThreadWg:                   new(sync.WaitGroup),
 		generateAndGossipQueryEnodeCh:      make(chan struct{}, 1),
 		updateAnnounceVersionCh:            make(chan struct{}, 1),
@@ -239,6 +249,19 @@ type Backend struct {
 	delegateSignFeed  event.Feed
 	delegateSignScope event.SubscriptionScope
 
+	// Feeds for the consensus events served over istanbul_subscribe
+	finalCommittedFeed     event.Feed
+	validatorSetChangeFeed event.Feed
+	epochRewardsFeed       event.Feed
+	consensusEventScope    event.SubscriptionScope
+
+	// Payouts of the epoch rewards distributed when finalizing the last block
+	// of an epoch, keyed by the state root of the block
+	epochRewardPayouts *lru.ARCCache
+
+	// Headers whose seals were checked by VerifySeals ahead of their insertion
+	verifiedSeals *lru.ARCCache
+
 	// Metric timer used to record block finalization times.
 	finalizationTimer metrics.Timer
 	// Metric timer used to record epoch reward distribution times.
@@ -379,6 +402,7 @@ func (sb *Backend) SelfNode() *enode.Node {
 // Close the backend
 func (sb *Backend) Close() error {
 	sb.delegateSignScope.Close()
+	sb.consensusEventScope.Close()
 	var errs []error
 	if err := sb.valEnodeTable.Close(); err != nil {
 		errs = append(errs, err)
@@ -0,0 +1,40 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	"github.com/ethereum/go-ethereum/consensus/istanbul/backend/internal/enodes"
+	"github.com/ethereum/go-ethereum/consensus/istanbul/backend/internal/replica"
+)
+
+// AuxiliaryDB describes one of the databases the istanbul backend keeps next to
+// the chain database, for use by offline inspection tools.
+type AuxiliaryDB struct {
+	Name   string                                              // Name used to select the database
+	Path   string                                              // Location of the database on disk
+	Decode func(key []byte, value []byte) (interface{}, error) // Decoder for raw entries
+}
+
+// AuxiliaryDBs returns the auxiliary databases configured in config.
+func AuxiliaryDBs(config *istanbul.Config) []AuxiliaryDB {
+	return []AuxiliaryDB{
+		{Name: "validatorenodes", Path: config.ValidatorEnodeDBPath, Decode: enodes.DecodeValEnodeDBEntry},
+		{Name: "versioncertificates", Path: config.VersionCertificateDBPath, Decode: enodes.DecodeVersionCertificateDBEntry},
+		{Name: "replicastate", Path: config.ReplicaStateDBPath, Decode: replica.DecodeReplicaStateDBEntry},
+	}
+}
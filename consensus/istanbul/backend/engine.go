@@ -21,6 +21,8 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"runtime"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -28,10 +30,14 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/istanbul"
 	istanbulCore "github.com/ethereum/go-ethereum/consensus/istanbul/core"
 	"github.com/ethereum/go-ethereum/consensus/istanbul/validator"
+	"github.com/ethereum/go-ethereum/contract_comm"
 	gpm "github.com/ethereum/go-ethereum/contract_comm/gasprice_minimum"
 	ethCore "github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
 	blscrypto "github.com/ethereum/go-ethereum/crypto/bls"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -44,6 +50,8 @@ const (
 	inmemorySnapshots             = 128 // Number of recent vote snapshots to keep in memory
 	inmemoryPeers                 = 40
 	inmemoryMessages              = 1024
+	inmemoryEpochRewards          = 16   // Number of recent epoch reward payouts to keep in memory
+	inmemoryVerifiedSeals         = 4096 // Number of headers with seals checked ahead of insertion to keep in memory
 	mobileAllowedClockSkew uint64 = 5
 )
 
@@ -83,6 +91,8 @@ var (
 	errUnauthorizedAnnounceMessage = errors.New("unauthorized announce message")
 	// errNotAValidator is returned when the node is not configured as a validator
 	errNotAValidator = errors.New("Not configured as a validator")
+	// errSnapshotNotFound is returned if no validator set snapshot is stored for a block
+	errSnapshotNotFound = errors.New("snapshot not found")
 )
 
 var (
@@ -222,6 +232,144 @@ func (sb *Backend) VerifyHeaders(chain consensus.ChainReader, headers []*types.H
 	return abort, results
 }
 
+// VerifySeals checks the aggregated seals and parent seals of a contiguous batch
+// of headers, spreading the BLS signature checks over all available CPUs.
+// Unlike VerifyHeaders it does not check the cascading header fields, it is
+// meant to reject a batch of imported blocks before any of them is executed.
+// The parent of the first header must already be known to the chain. Headers
+// passing the check have their seals skipped by the next verification of the
+// cascading fields, so inserting the batch doesn't check the seals again. The
+// headers failing to be inserted must be passed to ForgetSeals.
+func (sb *Backend) VerifySeals(chain consensus.ChainReader, headers []*types.Header) error {
+	type sealTask struct {
+		number     uint64
+		hash       common.Hash
+		validators istanbul.ValidatorSet
+		seal       types.IstanbulAggregatedSeal
+	}
+	// Resolving the validator sets is cheap and has to walk the epochs in order
+	tasks := make([]sealTask, 0, 2*len(headers))
+	for i, header := range headers {
+		number := header.Number.Uint64()
+		if number == 0 {
+			continue
+		}
+		extra, err := types.ExtractIstanbulExtra(header)
+		if err != nil {
+			return errInvalidExtraDataFormat
+		}
+		if len(extra.AggregatedSeal.Signature) == 0 {
+			return errEmptyAggregatedSeal
+		}
+		snap, err := sb.snapshot(chain, number-1, header.ParentHash, headers[:i])
+		if err != nil {
+			return err
+		}
+		tasks = append(tasks, sealTask{number, header.Hash(), snap.ValSet.Copy(), extra.AggregatedSeal})
+
+		if number > 1 && chain.Config().FullHeaderChainAvailable {
+			parentValidators := snap.ValSet.Copy()
+			if number%sb.config.Epoch == 1 {
+				parentSnap, err := sb.snapshot(chain, number-2, common.Hash{}, headers[:i])
+				if err != nil {
+					return err
+				}
+				parentValidators = parentSnap.ValSet.Copy()
+			}
+			tasks = append(tasks, sealTask{number, header.ParentHash, parentValidators, extra.ParentAggregatedSeal})
+		}
+	}
+	// Verify the signatures concurrently and report the lowest failing block
+	var (
+		pending = make(chan int, len(tasks))
+		errs    = make([]error, len(tasks))
+		pend    sync.WaitGroup
+	)
+	for i := range tasks {
+		pending <- i
+	}
+	close(pending)
+
+	for w := 0; w < runtime.NumCPU(); w++ {
+		pend.Add(1)
+		go func() {
+			defer pend.Done()
+			for i := range pending {
+				errs[i] = sb.verifyAggregatedSeal(tasks[i].hash, tasks[i].validators, tasks[i].seal)
+			}
+		}()
+	}
+	pend.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("block %d: %v", tasks[i].number, err)
+		}
+	}
+	for _, header := range headers {
+		sb.verifiedSeals.Add(verifiedSealKey(header), struct{}{})
+	}
+	return nil
+}
+
+// ForgetSeals discards the seals of headers checked by VerifySeals that failed
+// to be inserted, so that they don't linger until evicted.
+func (sb *Backend) ForgetSeals(headers []*types.Header) {
+	for _, header := range headers {
+		sb.verifiedSeals.Remove(verifiedSealKey(header))
+	}
+}
+
+// verifiedSealKey identifies a header whose seals were checked by VerifySeals.
+// Unlike the header hash it covers the aggregated seals, so that a header only
+// differing by its seals from a verified one has them checked.
+func verifiedSealKey(header *types.Header) common.Hash {
+	return crypto.Keccak256Hash(sigHash(header).Bytes(), header.Extra)
+}
+
+// VerifyEpochHeaders verifies a chain of consecutive epoch block headers, as
+// fetched when syncing one header per epoch. The aggregated seal of each header
+// is checked against the validator set resulting from the previous epoch
+// headers. The epoch block preceding the first header must already be known to
+// the chain. Nothing is persisted: the validator set snapshots of the headers
+// are stored as they get imported and verified by the chain.
+func (sb *Backend) VerifyEpochHeaders(chain consensus.ChainReader, headers []*types.Header) error {
+	if len(headers) == 0 {
+		return nil
+	}
+	first := headers[0].Number.Uint64()
+	if !istanbul.IsLastBlockOfEpoch(first, sb.config.Epoch) || first < sb.config.Epoch {
+		return errInvalidVotingChain
+	}
+	snap, err := sb.snapshot(chain, first-sb.config.Epoch, common.Hash{}, nil)
+	if err != nil {
+		return err
+	}
+	// Apply the validator set transitions to a throwaway database, so that
+	// nothing of the unimported headers ends up on disk
+	scratch := rawdb.NewMemoryDatabase()
+	for _, header := range headers {
+		number := header.Number.Uint64()
+		if number != snap.Number+sb.config.Epoch {
+			return errInvalidVotingChain
+		}
+		extra, err := types.ExtractIstanbulExtra(header)
+		if err != nil {
+			return errInvalidExtraDataFormat
+		}
+		if len(extra.AggregatedSeal.Signature) == 0 {
+			return fmt.Errorf("epoch block %d: %v", number, errEmptyAggregatedSeal)
+		}
+		if err := sb.verifyAggregatedSeal(header.Hash(), snap.ValSet.Copy(), extra.AggregatedSeal); err != nil {
+			return fmt.Errorf("epoch block %d: %v", number, err)
+		}
+		if snap, err = snap.apply([]*types.Header{header}, scratch); err != nil {
+			return fmt.Errorf("epoch block %d: %v", number, err)
+		}
+	}
+	return nil
+}
+
 // verifySigner checks whether the signer is in parent's validator set
 func (sb *Backend) verifySigner(chain consensus.ChainReader, header *types.Header, parents []*types.Header) error {
 	// Verifying the genesis block is not supported
@@ -257,6 +405,11 @@ func (sb *Backend) verifyAggregatedSeals(chain consensus.ChainReader, header *ty
 	if number == 0 {
 		return nil
 	}
+	// Nor the seals already checked by VerifySeals, once
+	if key := verifiedSealKey(header); sb.verifiedSeals.Contains(key) {
+		sb.verifiedSeals.Remove(key)
+		return nil
+	}
 
 	extra, err := types.ExtractIstanbulExtra(header)
 	if err != nil {
@@ -437,36 +590,59 @@ func (sb *Backend) LookbackWindow() uint64 {
 // Note: The block header and state database might be updated to reflect any
 // consensus rules that happen at finalization (e.g. block rewards).
 func (sb *Backend) Finalize(chain consensus.ChainReader, header *types.Header, state *state.StateDB, txs []*types.Transaction) {
+	sb.finalize(chain, header, state, state)
+}
+
+// FinalizeTraced finalizes the block like Finalize, tracing the state changing
+// system calls it makes with tracers created by the given function.
+func (sb *Backend) FinalizeTraced(chain consensus.ChainReader, header *types.Header, state *state.StateDB, txs []*types.Transaction, tracer func(origin string) (vm.Tracer, error)) {
+	sb.finalize(chain, header, state, contract_comm.NewTracedState(state, tracer))
+}
+
+// finalize runs the post-transaction state modifications, making the system
+// calls on systemState, which must be backed by state.
+func (sb *Backend) finalize(chain consensus.ChainReader, header *types.Header, state *state.StateDB, systemState vm.StateDB) {
 	start := time.Now()
 	defer sb.finalizationTimer.UpdateSince(start)
 
 	logger := sb.logger.New("func", "Finalize", "block", header.Number.Uint64(), "epochSize", sb.config.Epoch)
 	logger.Trace("Finalizing")
 
-	snapshot := state.Snapshot()
-	err := sb.setInitialGoldTokenTotalSupplyIfUnset(header, state)
+	defer contract_comm.StartSystemCall(systemState, vm.SystemCallBlockFinalize)()
+
+	snapshot := systemState.Snapshot()
+	err := sb.setInitialGoldTokenTotalSupplyIfUnset(header, systemState)
 	if err != nil {
-		state.RevertToSnapshot(snapshot)
+		systemState.RevertToSnapshot(snapshot)
 	}
 
 	// Trigger an update to the gas price minimum in the GasPriceMinimum contract based on block congestion
-	snapshot = state.Snapshot()
-	_, err = gpm.UpdateGasPriceMinimum(header, state)
+	snapshot = systemState.Snapshot()
+	_, err = gpm.UpdateGasPriceMinimum(header, systemState)
 	if err != nil {
-		state.RevertToSnapshot(snapshot)
+		systemState.RevertToSnapshot(snapshot)
 	}
 
+	var payouts []istanbul.EpochRewardPayout
 	lastBlockOfEpoch := istanbul.IsLastBlockOfEpoch(header.Number.Uint64(), sb.config.Epoch)
 	if lastBlockOfEpoch {
-		snapshot = state.Snapshot()
-		err = sb.distributeEpochRewards(header, state)
+		snapshot = systemState.Snapshot()
+		endRewards := contract_comm.StartSystemCall(systemState, vm.SystemCallEpochRewards)
+		payouts, err = sb.distributeEpochRewards(header, systemState)
+		endRewards()
 		if err != nil {
 			sb.logger.Error("Failed to distribute epoch rewards", "blockNumber", header.Number, "err", err)
-			state.RevertToSnapshot(snapshot)
+			systemState.RevertToSnapshot(snapshot)
+			payouts = nil
 		}
 	}
 
 	header.Root = state.IntermediateRoot(chain.Config().IsEIP158(header.Number))
+	if lastBlockOfEpoch {
+		// Keep the payouts until the block is inserted into the chain, as the
+		// same block may be finalized more than once before being accepted
+		sb.epochRewardPayouts.Add(header.Root, payouts)
+	}
 	logger.Debug("Finalized", "duration", now().Sub(start), "lastInEpoch", lastBlockOfEpoch)
 }
 
@@ -590,6 +766,7 @@ func (sb *Backend) SetChain(chain consensus.ChainReader, currentBlock func() *ty
 	if bc, ok := chain.(*ethCore.BlockChain); ok {
 		go sb.newChainHeadLoop(bc)
 		go sb.updateReplicaStateLoop(bc)
+		go sb.consensusEventLoop(bc)
 	}
 
 }
@@ -350,6 +350,67 @@ OUT3:
 	}
 }
 
+func TestVerifySeals(t *testing.T) {
+	genesisCfg, nodeKeys := getGenesisAndKeys(1, true)
+	chain, engine, _ := newBlockChainWithKeys(false, common.Address{}, false, genesisCfg, nodeKeys[0])
+	genesis := chain.Genesis()
+
+	block := makeBlockWithoutSeal(chain, engine, genesis)
+	block, _ = engine.updateBlock(genesis.Header(), block)
+	header := block.Header()
+	if err := writeAggregatedSeal(header, signBlock(nodeKeys, block), false); err != nil {
+		t.Fatalf("failed to write seal: %v", err)
+	}
+	block = block.WithSeal(header)
+
+	// modify seal bitmap and expect to fail the quorum check
+	tampered := block.Header()
+	extra, err := types.ExtractIstanbulExtra(tampered)
+	if err != nil {
+		t.Fatalf("failed to extract istanbul data: %v", err)
+	}
+	extra.AggregatedSeal.Bitmap = big.NewInt(0)
+	encoded, err := rlp.EncodeToBytes(extra)
+	if err != nil {
+		t.Fatalf("failed to encode istanbul data: %v", err)
+	}
+	tampered.Extra = append(tampered.Extra[:types.IstanbulExtraVanity], encoded...)
+	if err := engine.VerifySeals(chain, []*types.Header{tampered}); err == nil {
+		t.Errorf("tampered seal verified")
+	}
+	if engine.verifiedSeals.Contains(verifiedSealKey(tampered)) {
+		t.Errorf("tampered seal recorded as verified")
+	}
+
+	if err := engine.VerifySeals(chain, []*types.Header{block.Header()}); err != nil {
+		t.Fatalf("error mismatch: have %v, want nil", err)
+	}
+	if !engine.verifiedSeals.Contains(verifiedSealKey(block.Header())) {
+		t.Fatalf("verified seal not recorded")
+	}
+	// a header only differing by its seals must not pass for the verified one
+	if tampered.Hash() != block.Hash() {
+		t.Fatalf("tampered header hash mismatch: have %x, want %x", tampered.Hash(), block.Hash())
+	}
+	if engine.verifiedSeals.Contains(verifiedSealKey(tampered)) {
+		t.Errorf("tampered seal taken as verified")
+	}
+	engine.ForgetSeals([]*types.Header{block.Header()})
+	if engine.verifiedSeals.Contains(verifiedSealKey(block.Header())) {
+		t.Fatalf("forgotten seal still recorded")
+	}
+	if err := engine.VerifySeals(chain, []*types.Header{block.Header()}); err != nil {
+		t.Fatalf("error mismatch: have %v, want nil", err)
+	}
+	// inserting the block consumes the verified seal instead of checking it again
+	if _, err := chain.InsertChain(types.Blocks{block}); err != nil {
+		t.Fatalf("failed to insert block: %v", err)
+	}
+	if engine.verifiedSeals.Contains(verifiedSealKey(block.Header())) {
+		t.Errorf("verified seal not consumed by the insertion")
+	}
+}
+
 func TestVerifyHeaderWithoutFullChain(t *testing.T) {
 	chain, engine := newBlockChain(1, false)
 
@@ -0,0 +1,121 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	ethCore "github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// SubscribeRoundChangeEvent subscribes a channel to the node moving to a higher
+// consensus round
+func (sb *Backend) SubscribeRoundChangeEvent(ch chan<- istanbul.RoundChangeEvent) event.Subscription {
+	return sb.consensusEventScope.Track(sb.core.SubscribeRoundChangeEvent(ch))
+}
+
+// SubscribeFinalCommittedBlockEvent subscribes a channel to blocks being inserted
+// into the chain
+func (sb *Backend) SubscribeFinalCommittedBlockEvent(ch chan<- istanbul.FinalCommittedBlockEvent) event.Subscription {
+	return sb.consensusEventScope.Track(sb.finalCommittedFeed.Subscribe(ch))
+}
+
+// SubscribeValidatorSetChangeEvent subscribes a channel to the validator set
+// changes at the end of each epoch
+func (sb *Backend) SubscribeValidatorSetChangeEvent(ch chan<- istanbul.ValidatorSetChangeEvent) event.Subscription {
+	return sb.consensusEventScope.Track(sb.validatorSetChangeFeed.Subscribe(ch))
+}
+
+// SubscribeEpochRewardsEvent subscribes a channel to the epoch rewards paid out
+// at the end of each epoch
+func (sb *Backend) SubscribeEpochRewardsEvent(ch chan<- istanbul.EpochRewardsEvent) event.Subscription {
+	return sb.consensusEventScope.Track(sb.epochRewardsFeed.Subscribe(ch))
+}
+
+// Loop to post the consensus events of blocks inserted into the chain. Listens
+// to chain events to avoid batching.
+func (sb *Backend) consensusEventLoop(bc *ethCore.BlockChain) {
+	chainEventCh := make(chan ethCore.ChainEvent, 10)
+	chainEventSub := bc.SubscribeChainEvent(chainEventCh)
+	defer chainEventSub.Unsubscribe()
+
+	for {
+		select {
+		case chainEvent := <-chainEventCh:
+			sb.postConsensusEvents(chainEvent.Block)
+		case err := <-chainEventSub.Err():
+			log.Error("Error in istanbul's subscription to the blockchain's chain event", "err", err)
+			return
+		}
+	}
+}
+
+// postConsensusEvents posts the events for the given block being inserted into
+// the chain: the block being committed and, for the last block of an epoch, the
+// validator set change and the epoch rewards paid out.
+func (sb *Backend) postConsensusEvents(block *types.Block) {
+	header := block.Header()
+	number := header.Number.Uint64()
+	logger := sb.logger.New("func", "postConsensusEvents", "number", number, "hash", header.Hash())
+
+	extra, err := types.ExtractIstanbulExtra(header)
+	if err != nil {
+		logger.Warn("Failed to extract istanbul extra", "err", err)
+		return
+	}
+	sb.finalCommittedFeed.Send(istanbul.FinalCommittedBlockEvent{
+		Number: number,
+		Hash:   header.Hash(),
+		Round:  extra.AggregatedSeal.Round,
+		Bitmap: (*hexutil.Big)(extra.AggregatedSeal.Bitmap),
+	})
+	if !istanbul.IsLastBlockOfEpoch(number, sb.config.Epoch) {
+		return
+	}
+	epoch := istanbul.GetEpochNumber(number, sb.config.Epoch)
+
+	// The removed validators are a bitmap over the validators of the parent
+	removed := []common.Address{}
+	for i, val := range sb.GetValidators(new(big.Int).SetUint64(number-1), header.ParentHash) {
+		if extra.RemovedValidators.Bit(i) == 1 {
+			removed = append(removed, val.Address())
+		}
+	}
+	sb.validatorSetChangeFeed.Send(istanbul.ValidatorSetChangeEvent{
+		Epoch:   epoch,
+		Number:  number,
+		Hash:    header.Hash(),
+		Added:   extra.AddedValidators,
+		Removed: removed,
+	})
+
+	// Payouts are only known if this node finalized the block itself
+	if payouts, ok := sb.epochRewardPayouts.Get(header.Root); ok {
+		sb.epochRewardsFeed.Send(istanbul.EpochRewardsEvent{
+			Epoch:   epoch,
+			Number:  number,
+			Hash:    header.Hash(),
+			Payouts: payouts.([]istanbul.EpochRewardPayout),
+		})
+	}
+}
@@ -134,6 +134,16 @@ func NewDB(dbVersion int64, path string, logger log.Logger) (*leveldb.DB, error)
 	return NewPersistentDB(dbVersion, path, logger)
 }
 
+// DecodeVersion decodes the value stored under the database version key. The
+// second return value reports whether key is the version key.
+func DecodeVersion(key []byte, value []byte) (int64, bool) {
+	if string(key) != dbVersionKey {
+		return 0, false
+	}
+	version, _ := binary.Varint(value)
+	return version, true
+}
+
 // newMemoryDB creates a new in-memory node database without a persistent backend.
 func NewMemoryDB() (*leveldb.DB, error) {
 	db, err := leveldb.Open(storage.NewMemStorage(), nil)
@@ -25,6 +25,7 @@ import (
 
 var (
 	errIncorrectEntryType = errors.New("Incorrect entry type")
+	errUnknownEntryKey    = errors.New("Unknown entry key")
 )
 
 const (
@@ -17,6 +17,7 @@
 package enodes
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 	"sync"
@@ -527,45 +528,58 @@ func (vet *ValidatorEnodeDB) iterateOverAddressEntries(onEntry func(common.Addre
 	return nil
 }
 
-// ValEnodeEntryInfo contains information for an entry of the val enode table
-type ValEnodeEntryInfo struct {
-	PublicKey                    string `json:"publicKey"`
-	Enode                        string `json:"enode"`
-	Version                      uint   `json:"version"`
-	HighestKnownVersion          uint   `json:"highestKnownVersion"`
-	NumQueryAttemptsForHKVersion uint   `json:"numQueryAttemptsForHKVersion"`
-	LastQueryTimestamp           string `json:"lastQueryTimestamp"` // Unix timestamp
-}
-
 // ValEnodeTableInfo gives basic information for each entry of the table
-func (vet *ValidatorEnodeDB) ValEnodeTableInfo() (map[string]*ValEnodeEntryInfo, error) {
+func (vet *ValidatorEnodeDB) ValEnodeTableInfo() (map[string]*istanbul.ValEnodeEntryInfo, error) {
 	vet.lock.RLock()
 	defer vet.lock.RUnlock()
 
-	valEnodeTableInfo := make(map[string]*ValEnodeEntryInfo)
+	valEnodeTableInfo := make(map[string]*istanbul.ValEnodeEntryInfo)
 
 	valEnodeTable, err := vet.GetValEnodes(nil)
 	if err == nil {
 		for address, valEnodeEntry := range valEnodeTable {
-			entryInfo := &ValEnodeEntryInfo{
-				Version:                      valEnodeEntry.Version,
-				HighestKnownVersion:          valEnodeEntry.HighestKnownVersion,
-				NumQueryAttemptsForHKVersion: valEnodeEntry.NumQueryAttemptsForHKVersion,
-			}
-			if valEnodeEntry.PublicKey != nil {
-				publicKeyBytes := crypto.CompressPubkey(valEnodeEntry.PublicKey)
-				entryInfo.PublicKey = hexutil.Encode(publicKeyBytes)
-			}
-			if valEnodeEntry.Node != nil {
-				entryInfo.Enode = valEnodeEntry.Node.String()
-			}
-			if valEnodeEntry.LastQueryTimestamp != nil {
-				entryInfo.LastQueryTimestamp = valEnodeEntry.LastQueryTimestamp.String()
-			}
-
-			valEnodeTableInfo[address.Hex()] = entryInfo
+			valEnodeTableInfo[address.Hex()] = newValEnodeEntryInfo(valEnodeEntry)
 		}
 	}
 
 	return valEnodeTableInfo, err
 }
+
+func newValEnodeEntryInfo(valEnodeEntry *istanbul.AddressEntry) *istanbul.ValEnodeEntryInfo {
+	entryInfo := &istanbul.ValEnodeEntryInfo{
+		Version:                      valEnodeEntry.Version,
+		HighestKnownVersion:          valEnodeEntry.HighestKnownVersion,
+		NumQueryAttemptsForHKVersion: valEnodeEntry.NumQueryAttemptsForHKVersion,
+	}
+	if valEnodeEntry.PublicKey != nil {
+		publicKeyBytes := crypto.CompressPubkey(valEnodeEntry.PublicKey)
+		entryInfo.PublicKey = hexutil.Encode(publicKeyBytes)
+	}
+	if valEnodeEntry.Node != nil {
+		entryInfo.Enode = valEnodeEntry.Node.String()
+	}
+	if valEnodeEntry.LastQueryTimestamp != nil {
+		entryInfo.LastQueryTimestamp = valEnodeEntry.LastQueryTimestamp.String()
+	}
+	return entryInfo
+}
+
+// DecodeValEnodeDBEntry decodes a raw key/value pair of the validator enode
+// database for inspection tools. Address entries decode to an istanbul.ValEnodeEntryInfo
+// and node ID entries to the validator address they point to.
+func DecodeValEnodeDBEntry(key []byte, value []byte) (interface{}, error) {
+	switch {
+	case bytes.HasPrefix(key, []byte(dbAddressPrefix)):
+		var entry istanbul.AddressEntry
+		if err := rlp.DecodeBytes(value, &entry); err != nil {
+			return nil, err
+		}
+		return newValEnodeEntryInfo(&entry), nil
+	case bytes.HasPrefix(key, []byte(dbNodeIDPrefix)):
+		return common.BytesToAddress(value), nil
+	}
+	if version, ok := db.DecodeVersion(key, value); ok {
+		return version, nil
+	}
+	return nil, errUnknownEntryKey
+}
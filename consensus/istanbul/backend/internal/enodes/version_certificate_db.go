@@ -17,6 +17,7 @@
 package enodes
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"encoding/hex"
 	"fmt"
@@ -27,6 +28,7 @@ import (
 	"github.com/syndtr/goleveldb/leveldb/opt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
 	"github.com/ethereum/go-ethereum/consensus/istanbul/backend/internal/db"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
@@ -275,18 +277,12 @@ func (svdb *VersionCertificateDB) iterate(onEntry func(common.Address, *VersionC
 	return nil
 }
 
-// VersionCertificateEntryInfo gives basic information for an entry in the DB
-type VersionCertificateEntryInfo struct {
-	Address string `json:"address"`
-	Version uint   `json:"version"`
-}
-
-// Info gives a map VersionCertificateEntryInfo where each key is the address.
+// Info gives a map of istanbul.VersionCertificateEntryInfo where each key is the address.
 // Intended for RPC use
-func (svdb *VersionCertificateDB) Info() (map[string]*VersionCertificateEntryInfo, error) {
-	dbInfo := make(map[string]*VersionCertificateEntryInfo)
+func (svdb *VersionCertificateDB) Info() (map[string]*istanbul.VersionCertificateEntryInfo, error) {
+	dbInfo := make(map[string]*istanbul.VersionCertificateEntryInfo)
 	err := svdb.iterate(func(address common.Address, entry *VersionCertificateEntry) error {
-		dbInfo[address.Hex()] = &VersionCertificateEntryInfo{
+		dbInfo[address.Hex()] = &istanbul.VersionCertificateEntryInfo{
 			Address: entry.Address.Hex(),
 			Version: entry.Version,
 		}
@@ -294,3 +290,22 @@ func (svdb *VersionCertificateDB) Info() (map[string]*VersionCertificateEntryInf
 	})
 	return dbInfo, err
 }
+
+// DecodeVersionCertificateDBEntry decodes a raw key/value pair of the version
+// certificate database for inspection tools.
+func DecodeVersionCertificateDBEntry(key []byte, value []byte) (interface{}, error) {
+	if bytes.HasPrefix(key, []byte(dbAddressPrefix)) {
+		var entry VersionCertificateEntry
+		if err := rlp.DecodeBytes(value, &entry); err != nil {
+			return nil, err
+		}
+		return &istanbul.VersionCertificateEntryInfo{
+			Address: entry.Address.Hex(),
+			Version: entry.Version,
+		}, nil
+	}
+	if version, ok := db.DecodeVersion(key, value); ok {
+		return version, nil
+	}
+	return nil, errUnknownEntryKey
+}
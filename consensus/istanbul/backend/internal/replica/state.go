@@ -24,6 +24,7 @@ import (
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 	lvlerrors "github.com/syndtr/goleveldb/leveldb/errors"
@@ -73,7 +74,7 @@ type State interface {
 	// view functions
 	IsPrimary() bool
 	IsPrimaryForSeq(blockNumber *big.Int) bool
-	Summary() *ReplicaStateSummary
+	Summary() *istanbul.ReplicaStateSummary
 }
 
 // ReplicaState stores info on this node being a primary or replica
@@ -362,18 +363,11 @@ func (rs *replicaStateImpl) IsPrimaryForSeq(seq *big.Int) bool {
 	return false
 }
 
-type ReplicaStateSummary struct {
-	State                string   `json:"state"`
-	IsPrimary            bool     `json:"isPrimary"`
-	StartValidatingBlock *big.Int `json:"startValidatingBlock"`
-	StopValidatingBlock  *big.Int `json:"stopValidatingBlock"`
-}
-
-func (rs *replicaStateImpl) Summary() *ReplicaStateSummary {
+func (rs *replicaStateImpl) Summary() *istanbul.ReplicaStateSummary {
 	rs.mu.RLock()
 	defer rs.mu.RUnlock()
 
-	summary := &ReplicaStateSummary{
+	summary := &istanbul.ReplicaStateSummary{
 		State:                rs.state.String(),
 		IsPrimary:            rs.state == primaryPermanent || rs.state == primaryInRange,
 		StartValidatingBlock: rs.startValidatingBlock,
@@ -17,6 +17,7 @@
 package replica
 
 import (
+	"errors"
 	"sync"
 
 	"github.com/syndtr/goleveldb/leveldb"
@@ -34,6 +35,8 @@ const (
 
 )
 
+var errUnknownEntryKey = errors.New("Unknown entry key")
+
 // ReplicaStateDB represents a Map that can be accessed either
 // by address or enode
 type ReplicaStateDB struct {
@@ -103,3 +106,19 @@ func (rsdb *ReplicaStateDB) StoreReplicaState(rs State) error {
 
 	return err
 }
+
+// DecodeReplicaStateDBEntry decodes a raw key/value pair of the replica state
+// database for inspection tools.
+func DecodeReplicaStateDBEntry(key []byte, value []byte) (interface{}, error) {
+	if string(key) == replicaStateKey {
+		var entry replicaStateImpl
+		if err := rlp.DecodeBytes(value, &entry); err != nil {
+			return nil, err
+		}
+		return entry.Summary(), nil
+	}
+	if version, ok := db.DecodeVersion(key, value); ok {
+		return version, nil
+	}
+	return nil, errUnknownEntryKey
+}
@@ -33,13 +33,15 @@ import (
 	"github.com/ethereum/go-ethereum/contract_comm/validators"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
-	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 )
 
-func (sb *Backend) distributeEpochRewards(header *types.Header, state *state.StateDB) error {
+// distributeEpochRewards distributes the epoch rewards at the end of an epoch,
+// returning the itemized payouts made.
+func (sb *Backend) distributeEpochRewards(header *types.Header, state vm.StateDB) ([]istanbul.EpochRewardPayout, error) {
 	start := time.Now()
 	defer sb.rewardDistributionTimer.UpdateSince(start)
 	logger := sb.logger.New("func", "Backend.distributeEpochPaymentsAndRewards", "blocknum", header.Number.Uint64())
@@ -49,32 +51,32 @@ func (sb *Backend) distributeEpochRewards(header *types.Header, state *state.Sta
 		logger.Warn("Failed to determine if epoch rewards are frozen", "err", err)
 	} else if frozen {
 		logger.Debug("Epoch rewards are frozen, skipping distribution")
-		return nil
+		return nil, nil
 	}
 
 	// Get necessary Addresses First
 	reserveAddress, err := contract_comm.GetRegisteredAddress(params.ReserveRegistryId, header, state)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	stableTokenAddress, err := contract_comm.GetRegisteredAddress(params.StableTokenRegistryId, header, state)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	carbonOffsettingPartnerAddress, err := epoch_rewards.GetCarbonOffsettingPartnerAddress(header, state)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	err = epoch_rewards.UpdateTargetVotingYield(header, state)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	validatorReward, totalVoterRewards, communityReward, carbonOffsettingPartnerReward, err := epoch_rewards.CalculateTargetEpochRewards(header, state)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if carbonOffsettingPartnerAddress == common.ZeroAddress {
@@ -90,47 +92,53 @@ func (sb *Backend) distributeEpochRewards(header *types.Header, state *state.Sta
 
 		err := errors.New("Unable to fetch validator set to update scores and distribute rewards")
 		logger.Error(err.Error())
-		return err
+		return nil, err
 	}
 
 	uptimes, err := sb.updateValidatorScores(header, state, valSet)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	totalValidatorRewards, err := sb.distributeValidatorRewards(header, state, valSet, validatorReward)
+	totalValidatorRewards, payouts, err := sb.distributeValidatorRewards(header, state, valSet, validatorReward)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Validator rewards were paid in cUSD, convert that amount to cGLD and add it to the Reserve
 	totalValidatorRewardsConvertedToGold, err := currency.Convert(totalValidatorRewards, stableTokenAddress, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if err = gold_token.Mint(header, state, *reserveAddress, totalValidatorRewardsConvertedToGold); err != nil {
-		return err
+		return nil, err
 	}
+	payouts = append(payouts, istanbul.EpochRewardPayout{Kind: istanbul.EpochRewardReserve, Recipient: *reserveAddress, Amount: totalValidatorRewardsConvertedToGold})
 
-	if err := sb.distributeCommunityRewards(header, state, communityReward); err != nil {
-		return err
+	communityPayouts, err := sb.distributeCommunityRewards(header, state, communityReward)
+	if err != nil {
+		return nil, err
 	}
+	payouts = append(payouts, communityPayouts...)
 
-	if err := sb.distributeVoterRewards(header, state, valSet, totalVoterRewards, uptimes); err != nil {
-		return err
+	voterPayouts, err := sb.distributeVoterRewards(header, state, valSet, totalVoterRewards, uptimes)
+	if err != nil {
+		return nil, err
 	}
+	payouts = append(payouts, voterPayouts...)
 
 	if carbonOffsettingPartnerReward.Cmp(new(big.Int)) != 0 {
 		if err = gold_token.Mint(header, state, carbonOffsettingPartnerAddress, carbonOffsettingPartnerReward); err != nil {
-			return err
+			return nil, err
 		}
+		payouts = append(payouts, istanbul.EpochRewardPayout{Kind: istanbul.EpochRewardCarbonOffsetting, Recipient: carbonOffsettingPartnerAddress, Amount: carbonOffsettingPartnerReward})
 	}
 
-	return nil
+	return payouts, nil
 }
 
-func (sb *Backend) updateValidatorScores(header *types.Header, state *state.StateDB, valSet []istanbul.Validator) ([]*big.Int, error) {
+func (sb *Backend) updateValidatorScores(header *types.Header, state vm.StateDB, valSet []istanbul.Validator) ([]*big.Int, error) {
 	epoch := istanbul.GetEpochNumber(header.Number.Uint64(), sb.EpochSize())
 	logger := sb.logger.New("func", "Backend.updateValidatorScores", "blocknum", header.Number.Uint64(), "epoch", epoch, "epochsize", sb.EpochSize(), "window", sb.LookbackWindow())
 	logger.Trace("Updating validator scores")
@@ -179,8 +187,9 @@ func (sb *Backend) updateValidatorScores(header *types.Header, state *state.Stat
 	return uptimes, nil
 }
 
-func (sb *Backend) distributeValidatorRewards(header *types.Header, state *state.StateDB, valSet []istanbul.Validator, maxReward *big.Int) (*big.Int, error) {
+func (sb *Backend) distributeValidatorRewards(header *types.Header, state vm.StateDB, valSet []istanbul.Validator, maxReward *big.Int) (*big.Int, []istanbul.EpochRewardPayout, error) {
 	totalValidatorRewards := big.NewInt(0)
+	payouts := make([]istanbul.EpochRewardPayout, 0, len(valSet))
 	for _, val := range valSet {
 		sb.logger.Debug("Distributing epoch reward for validator", "address", val.Address())
 		validatorReward, err := validators.DistributeEpochReward(header, state, val.Address(), maxReward)
@@ -189,40 +198,47 @@ func (sb *Backend) distributeValidatorRewards(header *types.Header, state *state
 			continue
 		}
 		totalValidatorRewards.Add(totalValidatorRewards, validatorReward)
+		payouts = append(payouts, istanbul.EpochRewardPayout{Kind: istanbul.EpochRewardValidator, Recipient: val.Address(), Amount: validatorReward})
 	}
-	return totalValidatorRewards, nil
+	return totalValidatorRewards, payouts, nil
 }
 
-func (sb *Backend) distributeCommunityRewards(header *types.Header, state *state.StateDB, communityReward *big.Int) error {
+func (sb *Backend) distributeCommunityRewards(header *types.Header, state vm.StateDB, communityReward *big.Int) ([]istanbul.EpochRewardPayout, error) {
 	governanceAddress, err := contract_comm.GetRegisteredAddress(params.GovernanceRegistryId, header, state)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	reserveAddress, err := contract_comm.GetRegisteredAddress(params.ReserveRegistryId, header, state)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	lowReserve, err := epoch_rewards.IsReserveLow(header, state)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	var recipient *common.Address
 	if lowReserve && reserveAddress != nil {
-		return gold_token.Mint(header, state, *reserveAddress, communityReward)
+		recipient = reserveAddress
 	} else if governanceAddress != nil {
 		// TODO: How to split eco fund here
-		return gold_token.Mint(header, state, *governanceAddress, communityReward)
+		recipient = governanceAddress
+	} else {
+		return nil, nil
 	}
-	return nil
+	if err := gold_token.Mint(header, state, *recipient, communityReward); err != nil {
+		return nil, err
+	}
+	return []istanbul.EpochRewardPayout{{Kind: istanbul.EpochRewardCommunity, Recipient: *recipient, Amount: communityReward}}, nil
 }
 
-func (sb *Backend) distributeVoterRewards(header *types.Header, state *state.StateDB, valSet []istanbul.Validator, maxTotalRewards *big.Int, uptimes []*big.Int) error {
+func (sb *Backend) distributeVoterRewards(header *types.Header, state vm.StateDB, valSet []istanbul.Validator, maxTotalRewards *big.Int, uptimes []*big.Int) ([]istanbul.EpochRewardPayout, error) {
 
 	lockedGoldAddress, err := contract_comm.GetRegisteredAddress(params.LockedGoldRegistryId, header, state)
 	if err != nil {
-		return err
+		return nil, err
 	} else if lockedGoldAddress == nil {
-		return errors.New("Unable to fetch locked gold address for epoch rewards distribution")
+		return nil, errors.New("Unable to fetch locked gold address for epoch rewards distribution")
 	}
 
 	// Select groups that elected at least one validator aggregate their uptimes.
@@ -232,7 +248,7 @@ func (sb *Backend) distributeVoterRewards(header *types.Header, state *state.Sta
 	for i, val := range valSet {
 		group, err := validators.GetMembershipInLastEpoch(header, state, val.Address())
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if _, ok := groupElectedValidator[group]; !ok {
 			groups = append(groups, group)
@@ -242,15 +258,23 @@ func (sb *Backend) distributeVoterRewards(header *types.Header, state *state.Sta
 		groupUptimes[group] = append(groupUptimes[group], uptimes[i])
 	}
 
-	electionRewards, err := election.DistributeEpochRewards(header, state, groups, maxTotalRewards, groupUptimes)
+	electionRewards, groupRewards, err := election.DistributeEpochRewards(header, state, groups, maxTotalRewards, groupUptimes)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return gold_token.Mint(header, state, *lockedGoldAddress, electionRewards)
+	if err := gold_token.Mint(header, state, *lockedGoldAddress, electionRewards); err != nil {
+		return nil, err
+	}
+	// The rewards are all held by LockedGold, itemize them by the group voted for
+	payouts := make([]istanbul.EpochRewardPayout, len(groups))
+	for i, group := range groups {
+		payouts[i] = istanbul.EpochRewardPayout{Kind: istanbul.EpochRewardVoters, Recipient: group, Amount: groupRewards[i]}
+	}
+	return payouts, nil
 }
 
-func (sb *Backend) setInitialGoldTokenTotalSupplyIfUnset(header *types.Header, state *state.StateDB) error {
+func (sb *Backend) setInitialGoldTokenTotalSupplyIfUnset(header *types.Header, state vm.StateDB) error {
 	totalSupply, err := gold_token.GetTotalSupply(header, state)
 	if err != nil {
 		return err
@@ -22,15 +22,12 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/istanbul"
 	"github.com/ethereum/go-ethereum/consensus/istanbul/validator"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
 )
 
-const (
-	dbKeySnapshotPrefix = "istanbul-snapshot"
-)
-
 // Snapshot is the state of the authorization voting at a given point in time.
 type Snapshot struct {
 	Epoch uint64 // The number of blocks for each epoch
@@ -55,9 +52,9 @@ func newSnapshot(epoch uint64, number uint64, hash common.Hash, valSet istanbul.
 
 // loadSnapshot loads an existing snapshot from the database.
 func loadSnapshot(epoch uint64, db ethdb.Database, hash common.Hash) (*Snapshot, error) {
-	blob, err := db.Get(append([]byte(dbKeySnapshotPrefix), hash[:]...))
-	if err != nil {
-		return nil, err
+	blob := rawdb.ReadIstanbulSnapshot(db, epoch, hash)
+	if len(blob) == 0 {
+		return nil, errSnapshotNotFound
 	}
 	snap := new(Snapshot)
 	if err := json.Unmarshal(blob, snap); err != nil {
@@ -74,7 +71,8 @@ func (s *Snapshot) store(db ethdb.Database) error {
 	if err != nil {
 		return err
 	}
-	return db.Put(append([]byte(dbKeySnapshotPrefix), s.Hash[:]...), blob)
+	rawdb.WriteIstanbulSnapshot(db, s.Hash, blob)
+	return nil
 }
 
 // copy creates a deep copy of the snapshot, though not the individual votes.
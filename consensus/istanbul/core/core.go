@@ -129,8 +129,19 @@ type core struct {
 
 	// the timer to record consensus duration (from accepting a preprepare to final committed stage)
 	consensusTimer metrics.Timer
+
+	// round changes are queued for a separate goroutine to post them to the
+	// subscribers, so that slow subscribers can't stall consensus
+	roundChangeFeed     event.Feed
+	roundChangeQueue    chan istanbul.RoundChangeEvent
+	roundChangeQuit     chan struct{}
+	roundChangesDropped metrics.Counter
 }
 
+// roundChangeQueueSize is the number of round change events that can be queued
+// for the subscribers before further events are dropped.
+const roundChangeQueueSize = 64
+
 // New creates an Istanbul consensus core
 func New(backend CoreBackend, config *istanbul.Config) Engine {
 	rsdb, err := newRoundStateDB(config.RoundStateDBPath, nil)
@@ -139,17 +150,19 @@ func New(backend CoreBackend, config *istanbul.Config) Engine {
 	}
 
 	c := &core{
-		config:             config,
-		address:            backend.Address(),
-		logger:             log.New(),
-		selectProposer:     validator.GetProposerSelector(config.ProposerPolicy),
-		handlerWg:          new(sync.WaitGroup),
-		backend:            backend,
-		pendingRequests:    prque.New(nil),
-		pendingRequestsMu:  new(sync.Mutex),
-		consensusTimestamp: time.Time{},
-		rsdb:               rsdb,
-		consensusTimer:     metrics.NewRegisteredTimer("consensus/istanbul/core/consensus", nil),
+		config:              config,
+		address:             backend.Address(),
+		logger:              log.New(),
+		selectProposer:      validator.GetProposerSelector(config.ProposerPolicy),
+		handlerWg:           new(sync.WaitGroup),
+		backend:             backend,
+		pendingRequests:     prque.New(nil),
+		pendingRequestsMu:   new(sync.Mutex),
+		consensusTimestamp:  time.Time{},
+		rsdb:                rsdb,
+		consensusTimer:      metrics.NewRegisteredTimer("consensus/istanbul/core/consensus", nil),
+		roundChangeQueue:    make(chan istanbul.RoundChangeEvent, roundChangeQueueSize),
+		roundChangesDropped: metrics.NewRegisteredCounter("consensus/istanbul/core/roundchanges/dropped", nil),
 	}
 	msgBacklog := newMsgBacklog(
 		func(msg *istanbul.Message) {
@@ -193,6 +206,36 @@ func (c *core) ParentCommits() MessageSet {
 	return c.current.ParentCommits()
 }
 
+// SubscribeRoundChangeEvent subscribes a channel to the node moving to a higher round
+func (c *core) SubscribeRoundChangeEvent(ch chan<- istanbul.RoundChangeEvent) event.Subscription {
+	return c.roundChangeFeed.Subscribe(ch)
+}
+
+// postRoundChangeEvent queues a round change event for the subscribers. The
+// event is dropped instead of blocking consensus if the queue is full.
+func (c *core) postRoundChangeEvent(sequence, round *big.Int, reason string) {
+	ev := istanbul.RoundChangeEvent{Sequence: new(big.Int).Set(sequence), Round: new(big.Int).Set(round), Reason: reason}
+	select {
+	case c.roundChangeQueue <- ev:
+	default:
+		c.roundChangesDropped.Inc(1)
+		c.logger.Debug("Dropped round change event for slow subscribers", "sequence", sequence, "round", round)
+	}
+}
+
+// sendRoundChangeEvents posts the queued round change events to the subscribers
+// until quit is closed.
+func (c *core) sendRoundChangeEvents(quit chan struct{}) {
+	for {
+		select {
+		case ev := <-c.roundChangeQueue:
+			c.roundChangeFeed.Send(ev)
+		case <-quit:
+			return
+		}
+	}
+}
+
 func (c *core) ForceRoundChange() {
 	// timeout current DesiredView
 	view := &istanbul.View{Sequence: c.current.Sequence(), Round: c.current.DesiredRound()}
@@ -360,20 +403,20 @@ func (c *core) commit() error {
 		if err != nil {
 			nextRound := new(big.Int).Add(c.current.Round(), common.Big1)
 			logger.Warn("Error on commit, waiting for desired round", "reason", "getAggregatedSeal", "err", err, "desired_round", nextRound)
-			c.waitForDesiredRound(nextRound)
+			c.waitForDesiredRound(nextRound, istanbul.RoundChangeReasonCommitFailed)
 			return nil
 		}
 		aggregatedEpochValidatorSetSeal, err := GetAggregatedEpochValidatorSetSeal(proposal.Number().Uint64(), c.config.Epoch, c.current.Commits())
 		if err != nil {
 			nextRound := new(big.Int).Add(c.current.Round(), common.Big1)
 			c.logger.Warn("Error on commit, waiting for desired round", "reason", "GetAggregatedEpochValidatorSetSeal", "err", err, "desired_round", nextRound)
-			c.waitForDesiredRound(nextRound)
+			c.waitForDesiredRound(nextRound, istanbul.RoundChangeReasonCommitFailed)
 			return nil
 		}
 		if err := c.backend.Commit(proposal, aggregatedSeal, aggregatedEpochValidatorSetSeal); err != nil {
 			nextRound := new(big.Int).Add(c.current.Round(), common.Big1)
 			logger.Warn("Error on commit, waiting for desired round", "reason", "backend.Commit", "err", err, "desired_round", nextRound)
-			c.waitForDesiredRound(nextRound)
+			c.waitForDesiredRound(nextRound, istanbul.RoundChangeReasonCommitFailed)
 			return nil
 		}
 	}
@@ -455,8 +498,10 @@ func (c *core) getPreprepareWithRoundChangeCertificate(round *big.Int) (*istanbu
 	return request, roundChangeCertificate, nil
 }
 
-// startNewRound starts a new round. if round equals to 0, it means to starts a new sequence
-func (c *core) startNewRound(round *big.Int) error {
+// startNewRound starts a new round. if round equals to 0, it means to starts a new sequence.
+// The reason is reported to round change subscribers if the node had not yet been waiting
+// for the new round.
+func (c *core) startNewRound(round *big.Int, reason string) error {
 	roundChange := false
 	// Try to get most recent block
 	headBlock, headAuthor := c.backend.GetCurrentHeadBlockAndAuthor()
@@ -528,11 +573,15 @@ func (c *core) startNewRound(round *big.Int) error {
 
 	// Calculate new proposer
 	nextProposer := c.selectProposer(valSet, headAuthor, newView.Round.Uint64())
+	skippedWaiting := roundChange && newView.Round.Cmp(c.current.DesiredRound()) > 0
 	err := c.resetRoundState(newView, valSet, nextProposer, roundChange)
 
 	if err != nil {
 		return err
 	}
+	if skippedWaiting {
+		c.postRoundChangeEvent(newView.Sequence, newView.Round, reason)
+	}
 
 	// Process backlog
 	c.processPendingRequests()
@@ -550,7 +599,8 @@ func (c *core) startNewRound(round *big.Int) error {
 }
 
 // All actions that occur when transitioning to waiting for round change state.
-func (c *core) waitForDesiredRound(r *big.Int) error {
+// The reason is reported to round change subscribers.
+func (c *core) waitForDesiredRound(r *big.Int, reason string) error {
 	logger := c.newLogger("func", "waitForDesiredRound", "new_desired_round", r)
 
 	// Don't wait for an older round
@@ -568,6 +618,7 @@ func (c *core) waitForDesiredRound(r *big.Int) error {
 	if err != nil {
 		return err
 	}
+	c.postRoundChangeEvent(c.current.Sequence(), r, reason)
 
 	c.resetRoundChangeTimer()
 
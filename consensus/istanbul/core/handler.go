@@ -49,6 +49,9 @@ func (c *core) Start() error {
 	c.subscribeEvents()
 	go c.handleEvents()
 
+	c.roundChangeQuit = make(chan struct{})
+	go c.sendRoundChangeEvents(c.roundChangeQuit)
+
 	return nil
 }
 
@@ -56,6 +59,10 @@ func (c *core) Start() error {
 func (c *core) Stop() error {
 	c.stopAllTimers()
 	c.unsubscribeEvents()
+	if c.roundChangeQuit != nil {
+		close(c.roundChangeQuit)
+		c.roundChangeQuit = nil
+	}
 
 	// Make sure the handler goroutine exits
 	c.handlerWg.Wait()
@@ -224,7 +231,7 @@ func (c *core) handleTimeoutAndMoveToNextRound(timedOutView *istanbul.View) erro
 
 	logger.Debug("Timed out, trying to wait for next round")
 	nextRound := new(big.Int).Add(timedOutView.Round, common.Big1)
-	return c.waitForDesiredRound(nextRound)
+	return c.waitForDesiredRound(nextRound, istanbul.RoundChangeReasonTimeout)
 }
 
 func (c *core) handleResendRoundChangeEvent(desiredView *istanbul.View) error {
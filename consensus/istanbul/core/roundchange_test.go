@@ -593,7 +593,7 @@ func TestPeriodicRoundChanges(t *testing.T) {
 	go sys.distributeIstMsgs(t, sys, istMsgDistribution)
 
 	for _, b := range sys.backends {
-		b.engine.(*core).waitForDesiredRound(big.NewInt(5))
+		b.engine.(*core).waitForDesiredRound(big.NewInt(5), istanbul.RoundChangeReasonTimeout)
 	}
 
 	// Expect at least one repeat RC before move to next round.
@@ -654,3 +654,66 @@ loop2:
 	}
 	close(sys.quit)
 }
+
+func TestRoundChangeEvents(t *testing.T) {
+	sys := NewTestSystemWithBackend(4, 1)
+	closer := sys.Run(true)
+	defer closer()
+
+	c := sys.backends[0].engine.(*core)
+	roundChanges := make(chan istanbul.RoundChangeEvent, 10)
+	sub := c.SubscribeRoundChangeEvent(roundChanges)
+	defer sub.Unsubscribe()
+
+	c.waitForDesiredRound(big.NewInt(2), istanbul.RoundChangeReasonTimeout)
+
+	select {
+	case ev := <-roundChanges:
+		if ev.Sequence.Cmp(common.Big1) != 0 || ev.Round.Cmp(big.NewInt(2)) != 0 || ev.Reason != istanbul.RoundChangeReasonTimeout {
+			t.Errorf("round change event mismatch: have %+v, want sequence 1, round 2, reason %s", ev, istanbul.RoundChangeReasonTimeout)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no round change event")
+	}
+}
+
+// Tests that a subscriber not reading its round change events doesn't stall
+// consensus, the events it can't keep up with are dropped instead.
+func TestRoundChangeEventsSlowSubscriber(t *testing.T) {
+	sys := NewTestSystemWithBackend(4, 1)
+	closer := sys.Run(true)
+	defer closer()
+
+	c := sys.backends[0].engine.(*core)
+	roundChanges := make(chan istanbul.RoundChangeEvent)
+	sub := c.SubscribeRoundChangeEvent(roundChanges)
+	defer sub.Unsubscribe()
+
+	const rounds = 2 * roundChangeQueueSize
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for round := int64(2); round < rounds+2; round++ {
+			c.waitForDesiredRound(big.NewInt(round), istanbul.RoundChangeReasonTimeout)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("round changes blocked by the subscriber")
+	}
+	// Only the events fitting into the queue, plus the one being sent, are left
+	received := 0
+	for {
+		select {
+		case <-roundChanges:
+			received++
+			continue
+		case <-time.After(100 * time.Millisecond):
+		}
+		break
+	}
+	if received == 0 || received > roundChangeQueueSize+1 {
+		t.Errorf("received events mismatch: have %d, want 1 to %d out of %d", received, roundChangeQueueSize+1, rounds)
+	}
+}
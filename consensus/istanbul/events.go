@@ -16,7 +16,13 @@
 
 package istanbul
 
-import "github.com/ethereum/go-ethereum/p2p/enode"
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
 
 // RequestEvent is posted to propose a proposal
 type RequestEvent struct {
@@ -37,3 +43,66 @@ type MessageWithPeerIDEvent struct {
 // FinalCommittedEvent is posted when a proposal is committed
 type FinalCommittedEvent struct {
 }
+
+// Reasons for a node to move to a higher round, as reported by RoundChangeEvent
+const (
+	RoundChangeReasonTimeout      = "timeout"      // The round timer of the previous round expired
+	RoundChangeReasonCommitFailed = "commitFailed" // The proposal could not be committed
+	RoundChangeReasonFastForward  = "fastForward"  // F+1 validators are at a higher round
+	RoundChangeReasonQuorum       = "quorum"       // A quorum of validators are at a higher round
+	RoundChangeReasonCertificate  = "certificate"  // A proposal carried a round change certificate
+)
+
+// RoundChangeEvent is posted when the node moves to a higher round of the
+// current sequence
+type RoundChangeEvent struct {
+	Sequence *big.Int `json:"sequence"`
+	Round    *big.Int `json:"round"`
+	Reason   string   `json:"reason"`
+}
+
+// FinalCommittedBlockEvent is posted when a block is inserted into the chain,
+// with the round it was agreed upon in and the bitmap of the validators that
+// signed it
+type FinalCommittedBlockEvent struct {
+	Number uint64       `json:"number"`
+	Hash   common.Hash  `json:"hash"`
+	Round  *big.Int     `json:"round"`
+	Bitmap *hexutil.Big `json:"bitmap"`
+}
+
+// ValidatorSetChangeEvent is posted when the last block of an epoch is inserted
+// into the chain, with the validators elected and unelected for the next epoch
+type ValidatorSetChangeEvent struct {
+	Epoch   uint64           `json:"epoch"`
+	Number  uint64           `json:"number"`
+	Hash    common.Hash      `json:"hash"`
+	Added   []common.Address `json:"added"`
+	Removed []common.Address `json:"removed"`
+}
+
+// Kinds of payouts made when distributing epoch rewards
+const (
+	EpochRewardValidator        = "validator"        // Validator payment, in stable token
+	EpochRewardReserve          = "reserve"          // Backing for the validator payments, in gold
+	EpochRewardCommunity        = "community"        // Community fund, in gold
+	EpochRewardVoters           = "voters"           // Rewards of the voters for a group, held by LockedGold, in gold
+	EpochRewardCarbonOffsetting = "carbonOffsetting" // Carbon offsetting partner, in gold
+)
+
+// EpochRewardPayout is a single payout of the epoch rewards. Voter rewards are
+// itemized per validator group, with the group as the recipient.
+type EpochRewardPayout struct {
+	Kind      string         `json:"kind"`
+	Recipient common.Address `json:"recipient"`
+	Amount    *big.Int       `json:"amount"`
+}
+
+// EpochRewardsEvent is posted when the last block of an epoch, and with it the
+// epoch rewards distributed at its end, is inserted into the chain
+type EpochRewardsEvent struct {
+	Epoch   uint64              `json:"epoch"`
+	Number  uint64              `json:"number"`
+	Hash    common.Hash         `json:"hash"`
+	Payouts []EpochRewardPayout `json:"payouts"`
+}
@@ -582,3 +582,29 @@ func (ae *AddressEntry) GetVersion() uint {
 func (ae *AddressEntry) GetAddress() common.Address {
 	return ae.Address
 }
+
+// ValEnodeEntryInfo contains information for an entry of the val enode table
+type ValEnodeEntryInfo struct {
+	PublicKey                    string `json:"publicKey"`
+	Enode                        string `json:"enode"`
+	Version                      uint   `json:"version"`
+	HighestKnownVersion          uint   `json:"highestKnownVersion"`
+	NumQueryAttemptsForHKVersion uint   `json:"numQueryAttemptsForHKVersion"`
+	LastQueryTimestamp           string `json:"lastQueryTimestamp"` // Unix timestamp
+}
+
+// VersionCertificateEntryInfo gives basic information for an entry in the version
+// certificate table
+type VersionCertificateEntryInfo struct {
+	Address string `json:"address"`
+	Version uint   `json:"version"`
+}
+
+// ReplicaStateSummary describes whether a validator is currently the primary
+// and the block range it is scheduled to validate in
+type ReplicaStateSummary struct {
+	State                string   `json:"state"`
+	IsPrimary            bool     `json:"isPrimary"`
+	StartValidatingBlock *big.Int `json:"startValidatingBlock"`
+	StopValidatingBlock  *big.Int `json:"stopValidatingBlock"`
+}
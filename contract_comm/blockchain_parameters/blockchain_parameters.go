@@ -18,12 +18,11 @@ package blockchain_parameters
 
 import (
 	"math/big"
-	"strings"
 	"time"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/contract_comm/contracts"
 	"github.com/ethereum/go-ethereum/contract_comm/errors"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -31,104 +30,32 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 )
 
-const (
-	blockchainParametersABIString = `[{
-			"constant": true,
-			"inputs": [],
-			"name": "getMinimumClientVersion",
-			"outputs": [
-			  {
-				"name": "major",
-				"type": "uint256"
-			  },
-			  {
-				"name": "minor",
-				"type": "uint256"
-			  },
-			  {
-				"name": "patch",
-				"type": "uint256"
-			  }
-			],
-			"payable": false,
-			"stateMutability": "view",
-			"type": "function"
-	},
-	{
-		"constant": true,
-		"inputs": [],
-		"name": "blockGasLimit",
-		"outputs": [
-		  {
-			"name": "",
-			"type": "uint256"
-		  }
-		],
-		"payable": false,
-		"stateMutability": "view",
-		"type": "function"
-	  },
-	  {
-		"constant": true,
-		"inputs": [],
-		"name": "intrinsicGasForAlternativeFeeCurrency",
-		"outputs": [
-		  {
-			"name": "",
-			"type": "uint256"
-		  }
-		],
-		"payable": false,
-		"stateMutability": "view",
-		"type": "function"
-	  }
-]`
-)
-
-var blockchainParametersABI abi.ABI
+var blockchainParameters *contracts.BlockchainParameters
 
 func init() {
 	var err error
-	blockchainParametersABI, err = abi.JSON(strings.NewReader(blockchainParametersABIString))
+	blockchainParameters, err = contracts.NewBlockchainParameters(params.BlockchainParametersRegistryId)
 	if err != nil {
 		log.Crit("Error reading ABI for BlockchainParameters", "err", err)
 	}
 }
 
 func GetMinimumVersion(header *types.Header, state vm.StateDB) (*params.VersionInfo, error) {
-	version := [3]*big.Int{big.NewInt(0), big.NewInt(0), big.NewInt(0)}
-	var err error
-	_, err = contract_comm.MakeStaticCall(
-		params.BlockchainParametersRegistryId,
-		blockchainParametersABI,
-		"getMinimumClientVersion",
-		[]interface{}{},
-		&version,
-		params.MaxGasForReadBlockchainParameter,
-		header,
-		state,
-	)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForReadBlockchainParameter}
+	version, _, err := blockchainParameters.GetMinimumClientVersion(opts)
 	if err != nil {
 		return nil, err
 	}
-	return &params.VersionInfo{Major: version[0].Uint64(), Minor: version[1].Uint64(), Patch: version[2].Uint64()}, nil
+	return &params.VersionInfo{Major: version.Major.Uint64(), Minor: version.Minor.Uint64(), Patch: version.Patch.Uint64()}, nil
 }
 
-func GetGasCost(header *types.Header, state vm.StateDB, defaultGas uint64, method string) uint64 {
-	var gas *big.Int
-	var err error
-	_, err = contract_comm.MakeStaticCall(
-		params.BlockchainParametersRegistryId,
-		blockchainParametersABI,
-		method,
-		[]interface{}{},
-		&gas,
-		params.MaxGasForReadBlockchainParameter,
-		header,
-		state,
-	)
+// GetGasCost reads a gas cost through the given getter of the BlockchainParameters
+// binding, falling back to defaultGas if it can't be read.
+func GetGasCost(header *types.Header, state vm.StateDB, defaultGas uint64, getter func(*contract_comm.SystemCallOpts) (*big.Int, uint64, error)) uint64 {
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForReadBlockchainParameter}
+	gas, _, err := getter(opts)
 	if err != nil {
-		log.Trace("Default gas", "gas", defaultGas, "method", method)
+		log.Trace("Default gas", "gas", defaultGas, "err", err)
 		return defaultGas
 	}
 	log.Trace("Reading gas", "gas", gas)
@@ -136,7 +63,7 @@ func GetGasCost(header *types.Header, state vm.StateDB, defaultGas uint64, metho
 }
 
 func GetIntrinsicGasForAlternativeFeeCurrency(header *types.Header, state vm.StateDB) uint64 {
-	return GetGasCost(header, state, params.IntrinsicGasForAlternativeFeeCurrency, "intrinsicGasForAlternativeFeeCurrency")
+	return GetGasCost(header, state, params.IntrinsicGasForAlternativeFeeCurrency, blockchainParameters.IntrinsicGasForAlternativeFeeCurrency)
 }
 
 func CheckMinimumVersion(header *types.Header, state vm.StateDB) {
@@ -168,17 +95,8 @@ func SpawnCheck() {
 }
 
 func GetBlockGasLimit(header *types.Header, state vm.StateDB) (uint64, error) {
-	var gasLimit *big.Int
-	_, err := contract_comm.MakeStaticCall(
-		params.BlockchainParametersRegistryId,
-		blockchainParametersABI,
-		"blockGasLimit",
-		[]interface{}{},
-		&gasLimit,
-		params.MaxGasForReadBlockchainParameter,
-		header,
-		state,
-	)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForReadBlockchainParameter}
+	gasLimit, _, err := blockchainParameters.BlockGasLimit(opts)
 	if err != nil {
 		if err == errors.ErrRegistryContractNotDeployed {
 			log.Debug("Error obtaining block gas limit", "err", err, "contract", hexutil.Encode(params.BlockchainParametersRegistryId[:]))
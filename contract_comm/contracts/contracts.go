@@ -0,0 +1,34 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package contracts contains the system call bindings around the core
+// contracts, generated with abigen from the ABIs in the abi directory. These
+// are taken from the build artifacts of celo-monorepo/packages/protocol and
+// trimmed to the methods the node calls.
+package contracts
+
+//go:generate abigen --syscall --abi abi/BlockchainParameters.json --type BlockchainParameters --pkg contracts --out blockchain_parameters.go
+//go:generate abigen --syscall --abi abi/Election.json --type Election --pkg contracts --out election.go
+//go:generate abigen --syscall --abi abi/EpochRewards.json --type EpochRewards --pkg contracts --out epoch_rewards.go
+//go:generate abigen --syscall --abi abi/ERC20.json --type ERC20 --pkg contracts --out erc20.go
+//go:generate abigen --syscall --abi abi/FeeCurrencyWhitelist.json --type FeeCurrencyWhitelist --pkg contracts --out fee_currency_whitelist.go
+//go:generate abigen --syscall --abi abi/Freezer.json --type Freezer --pkg contracts --out freezer.go
+//go:generate abigen --syscall --abi abi/GasPriceMinimum.json --type GasPriceMinimum --pkg contracts --out gas_price_minimum.go
+//go:generate abigen --syscall --abi abi/GoldToken.json --type GoldToken --pkg contracts --out gold_token.go
+//go:generate abigen --syscall --abi abi/Random.json --type Random --pkg contracts --out random.go
+//go:generate abigen --syscall --abi abi/SortedOracles.json --type SortedOracles --pkg contracts --out sorted_oracles.go
+//go:generate abigen --syscall --abi abi/TransferWhitelist.json --type TransferWhitelist --pkg contracts --out transfer_whitelist.go
+//go:generate abigen --syscall --abi abi/Validators.json --type Validators --pkg contracts --out validators.go
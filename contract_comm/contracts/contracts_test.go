@@ -0,0 +1,59 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package contracts_test
+
+import (
+	"io/ioutil"
+	"regexp"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+var generateRe = regexp.MustCompile(`//go:generate abigen --syscall --abi (\S+) --type (\S+) --pkg (\S+) --out (\S+)`)
+
+// Tests that the checked in bindings are up to date with their ABIs, i.e. that
+// go generate was run after the last change to the abi directory or abigen.
+func TestBindingsUpToDate(t *testing.T) {
+	source, err := ioutil.ReadFile("contracts.go")
+	if err != nil {
+		t.Fatalf("failed to read contracts.go: %v", err)
+	}
+	generates := generateRe.FindAllStringSubmatch(string(source), -1)
+	if len(generates) == 0 {
+		t.Fatal("no go:generate directives found")
+	}
+	for _, generate := range generates {
+		abiFile, typ, pkg, out := generate[1], generate[2], generate[3], generate[4]
+
+		abi, err := ioutil.ReadFile(abiFile)
+		if err != nil {
+			t.Fatalf("%s: failed to read ABI: %v", typ, err)
+		}
+		want, err := bind.Bind([]string{typ}, []string{string(abi)}, []string{""}, nil, pkg, bind.LangGoSystemCall, nil, nil)
+		if err != nil {
+			t.Fatalf("%s: failed to generate binding: %v", typ, err)
+		}
+		have, err := ioutil.ReadFile(out)
+		if err != nil {
+			t.Fatalf("%s: failed to read binding: %v", typ, err)
+		}
+		if string(have) != want {
+			t.Errorf("%s: binding %s is out of date, run go generate", typ, out)
+		}
+	}
+}
@@ -18,11 +18,10 @@ package currency
 
 import (
 	"math/big"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/contract_comm/contracts"
 	"github.com/ethereum/go-ethereum/contract_comm/errors"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -30,78 +29,31 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 )
 
-const (
-	// This is taken from celo-monorepo/packages/protocol/build/<env>/contracts/SortedOracles.json
-	medianRateABI = `[
-    {
-      "constant": true,
-      "inputs": [
-        {
-          "name": "token",
-          "type": "address"
-        }
-      ],
-      "name": "medianRate",
-      "outputs": [
-        {
-          "name": "",
-          "type": "uint128"
-        },
-        {
-          "name": "",
-          "type": "uint128"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    }]`
-
-	// This is taken from celo-monorepo/packages/protocol/build/<env>/contracts/ERC20.json
-	balanceOfABI = `[{"constant": true,
-                          "inputs": [
-                               {
-                                   "name": "who",
-                                   "type": "address"
-                               }
-                          ],
-                          "name": "balanceOf",
-                          "outputs": [
-                               {
-                                   "name": "",
-                                   "type": "uint256"
-                               }
-                          ],
-                          "payable": false,
-                          "stateMutability": "view",
-                          "type": "function"
-                         }]`
-
-	// This is taken from celo-monorepo/packages/protocol/build/<env>/contracts/FeeCurrency.json
-	getWhitelistABI = `[{"constant": true,
-	                     "inputs": [],
-	                     "name": "getWhitelist",
-	                     "outputs": [
-			          {
-			              "name": "",
-				      "type": "address[]"
-				  }
-			     ],
-			     "payable": false,
-			     "stateMutability": "view",
-			     "type": "function"
-			    }]`
-)
-
 var (
 	cgExchangeRateNum = big.NewInt(1)
 	cgExchangeRateDen = big.NewInt(1)
 
-	medianRateFuncABI, _   = abi.JSON(strings.NewReader(medianRateABI))
-	balanceOfFuncABI, _    = abi.JSON(strings.NewReader(balanceOfABI))
-	getWhitelistFuncABI, _ = abi.JSON(strings.NewReader(getWhitelistABI))
+	sortedOracles        *contracts.SortedOracles
+	erc20                *contracts.ERC20 // Bound to the token with At before calling
+	feeCurrencyWhitelist *contracts.FeeCurrencyWhitelist
 )
 
+func init() {
+	var err error
+	sortedOracles, err = contracts.NewSortedOracles(params.SortedOraclesRegistryId)
+	if err != nil {
+		log.Crit("Error reading ABI for SortedOracles", "err", err)
+	}
+	erc20, err = contracts.NewERC20At(common.ZeroAddress)
+	if err != nil {
+		log.Crit("Error reading ABI for ERC20", "err", err)
+	}
+	feeCurrencyWhitelist, err = contracts.NewFeeCurrencyWhitelist(params.FeeCurrencyWhitelistRegistryId)
+	if err != nil {
+		log.Crit("Error reading ABI for FeeCurrencyWhitelist", "err", err)
+	}
+}
+
 type exchangeRate struct {
 	Numerator   *big.Int
 	Denominator *big.Int
@@ -177,33 +129,30 @@ func Cmp(val1 *big.Int, currency1 *common.Address, val2 *big.Int, currency2 *com
 }
 
 func getExchangeRate(currencyAddress *common.Address) (*exchangeRate, error) {
-	var (
-		returnArray [2]*big.Int
-		leftoverGas uint64
-	)
-
 	if currencyAddress == nil {
 		return &exchangeRate{cgExchangeRateNum, cgExchangeRateDen}, nil
-	} else {
-		if leftoverGas, err := contract_comm.MakeStaticCall(params.SortedOraclesRegistryId, medianRateFuncABI, "medianRate", []interface{}{currencyAddress}, &returnArray, params.MaxGasForMedianRate, nil, nil); err != nil {
-			if err == errors.ErrSmartContractNotDeployed {
-				log.Warn("Registry address lookup failed", "err", err)
-				return &exchangeRate{big.NewInt(1), big.NewInt(1)}, err
-			} else {
-				log.Error("medianRate invocation error", "feeCurrencyAddress", currencyAddress.Hex(), "leftoverGas", leftoverGas, "err", err)
-				return &exchangeRate{big.NewInt(1), big.NewInt(1)}, err
-			}
+	}
+
+	opts := &contract_comm.SystemCallOpts{Gas: params.MaxGasForMedianRate}
+	numerator, denominator, leftoverGas, err := sortedOracles.MedianRate(opts, *currencyAddress)
+	if err != nil {
+		if err == errors.ErrSmartContractNotDeployed {
+			log.Warn("Registry address lookup failed", "err", err)
+		} else {
+			log.Error("medianRate invocation error", "feeCurrencyAddress", currencyAddress.Hex(), "leftoverGas", leftoverGas, "err", err)
 		}
+		return &exchangeRate{big.NewInt(1), big.NewInt(1)}, err
 	}
-	log.Trace("medianRate invocation success", "feeCurrencyAddress", currencyAddress, "returnArray", returnArray, "leftoverGas", leftoverGas)
-	return &exchangeRate{returnArray[0], returnArray[1]}, nil
+	log.Trace("medianRate invocation success", "feeCurrencyAddress", currencyAddress, "numerator", numerator, "denominator", denominator, "leftoverGas", leftoverGas)
+	return &exchangeRate{numerator, denominator}, nil
 }
 
 // This function will retrieve the balance of an ERC20 token.
 func GetBalanceOf(accountOwner common.Address, contractAddress common.Address, gas uint64, header *types.Header, state vm.StateDB) (result *big.Int, gasUsed uint64, err error) {
 	log.Trace("GetBalanceOf() Called", "accountOwner", accountOwner.Hex(), "contractAddress", contractAddress, "gas", gas)
 
-	leftoverGas, err := contract_comm.MakeStaticCallWithAddress(contractAddress, balanceOfFuncABI, "balanceOf", []interface{}{accountOwner}, &result, gas, header, state)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: gas}
+	result, leftoverGas, err := erc20.At(contractAddress).BalanceOf(opts, accountOwner)
 
 	if err != nil {
 		log.Error("GetBalanceOf evm invocation error", "leftoverGas", leftoverGas, "err", err)
@@ -220,9 +169,8 @@ func GetBalanceOf(accountOwner common.Address, contractAddress common.Address, g
 // FeeCurrencyWhiteList Functions
 //-------------------------------
 func retrieveWhitelist(header *types.Header, state vm.StateDB) ([]common.Address, error) {
-	returnList := []common.Address{}
-
-	_, err := contract_comm.MakeStaticCall(params.FeeCurrencyWhitelistRegistryId, getWhitelistFuncABI, "getWhitelist", []interface{}{}, &returnList, params.MaxGasForGetWhiteList, header, state)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForGetWhiteList}
+	returnList, _, err := feeCurrencyWhitelist.GetWhitelist(opts)
 	if err != nil {
 		if err == errors.ErrSmartContractNotDeployed {
 			log.Warn("Registry address lookup failed", "err", err)
@@ -18,152 +18,30 @@ package election
 import (
 	"math/big"
 	"sort"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/contract_comm/contracts"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 )
 
-// This is taken from celo-monorepo/packages/protocol/build/<env>/contracts/Election.json
-const electionABIString string = `[
-  {"constant": true,
-              "inputs": [],
-        "name": "electValidatorSigners",
-        "outputs": [
-       {
-            "name": "",
-      "type": "address[]"
-       }
-        ],
-        "payable": false,
-        "stateMutability": "view",
-        "type": "function"
-       },
-			     {
-      "constant": true,
-      "inputs": [],
-      "name": "getTotalVotesForEligibleValidatorGroups",
-      "outputs": [
-        {
-          "name": "groups",
-          "type": "address[]"
-        },
-        {
-          "name": "values",
-          "type": "uint256[]"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    },
-		    {
-      "constant": false,
-      "inputs": [
-        {
-          "name": "group",
-          "type": "address"
-        },
-        {
-          "name": "value",
-          "type": "uint256"
-        },
-        {
-          "name": "lesser",
-          "type": "address"
-        },
-        {
-          "name": "greater",
-          "type": "address"
-        }
-      ],
-      "name": "distributeEpochRewards",
-      "outputs": [],
-      "payable": false,
-      "stateMutability": "nonpayable",
-      "type": "function"
-    },
-		    {
-      "constant": true,
-      "inputs": [
-        {
-          "name": "group",
-          "type": "address"
-        },
-        {
-          "name": "maxTotalRewards",
-          "type": "uint256"
-        },
-        {
-          "name": "uptimes",
-          "type": "uint256[]"
-        }
-      ],
-      "name": "getGroupEpochRewards",
-      "outputs": [
-        {
-          "name": "",
-          "type": "uint256"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    },
-    {
-      "constant": true,
-      "inputs": [
-        {
-          "name": "minElectableValidators",
-          "type": "uint256"
-        },
-        {
-          "name": "maxElectableValidators",
-          "type": "uint256"
-        }
-      ],
-      "name": "electNValidatorSigners",
-      "outputs": [
-        {
-          "name": "",
-          "type": "address[]"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    },
-    {
-      "constant": true,
-      "inputs": [],
-      "name": "getElectableValidators",
-      "outputs": [
-        {
-          "name": "",
-          "type": "uint256"
-        },
-        {
-          "name": "",
-          "type": "uint256"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    }
-]`
+var election *contracts.Election
 
-var electionABI, _ = abi.JSON(strings.NewReader(electionABIString))
+func init() {
+	var err error
+	election, err = contracts.NewElection(params.ElectionRegistryId)
+	if err != nil {
+		log.Crit("Error reading ABI for Election", "err", err)
+	}
+}
 
 func GetElectedValidators(header *types.Header, state vm.StateDB) ([]common.Address, error) {
-	var newValSet []common.Address
 	// Get the new epoch's validator set
-	_, err := contract_comm.MakeStaticCall(params.ElectionRegistryId, electionABI, "electValidatorSigners", []interface{}{}, &newValSet, params.MaxGasForElectValidators, header, state)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForElectValidators}
+	newValSet, _, err := election.ElectValidatorSigners(opts)
 	if err != nil {
 		return nil, err
 	}
@@ -171,18 +49,16 @@ func GetElectedValidators(header *types.Header, state vm.StateDB) ([]common.Addr
 }
 
 func ElectNValidatorSigners(header *types.Header, state vm.StateDB, additionalAboveMaxElectable int64) ([]common.Address, error) {
-	var minElectableValidators *big.Int
-	var maxElectableValidators *big.Int
-
 	// Get the electable min and max
-	_, err := contract_comm.MakeStaticCall(params.ElectionRegistryId, electionABI, "getElectableValidators", []interface{}{}, &[]interface{}{&minElectableValidators, &maxElectableValidators}, params.MaxGasForGetElectableValidators, header, state)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForGetElectableValidators}
+	minElectableValidators, maxElectableValidators, _, err := election.GetElectableValidators(opts)
 	if err != nil {
 		return nil, err
 	}
 
-	var electedValidators []common.Address
 	// Run the validator election for up to maxElectable + getTotalVotesForEligibleValidatorGroup
-	_, err = contract_comm.MakeStaticCall(params.ElectionRegistryId, electionABI, "electNValidatorSigners", []interface{}{minElectableValidators, maxElectableValidators.Add(maxElectableValidators, big.NewInt(additionalAboveMaxElectable))}, &electedValidators, params.MaxGasForElectNValidatorSigners, header, state)
+	opts.Gas = params.MaxGasForElectNValidatorSigners
+	electedValidators, _, err := election.ElectNValidatorSigners(opts, minElectableValidators, maxElectableValidators.Add(maxElectableValidators, big.NewInt(additionalAboveMaxElectable)))
 	if err != nil {
 		return nil, err
 	}
@@ -197,43 +73,44 @@ type voteTotal struct {
 }
 
 func getTotalVotesForEligibleValidatorGroups(header *types.Header, state vm.StateDB) ([]voteTotal, error) {
-	var groups []common.Address
-	var values []*big.Int
-	_, err := contract_comm.MakeStaticCall(params.ElectionRegistryId, electionABI, "getTotalVotesForEligibleValidatorGroups", []interface{}{}, &[]interface{}{&groups, &values}, params.MaxGasForGetEligibleValidatorGroupsVoteTotals, header, state)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForGetEligibleValidatorGroupsVoteTotals}
+	votes, _, err := election.GetTotalVotesForEligibleValidatorGroups(opts)
 	if err != nil {
 		return nil, err
 	}
 
-	voteTotals := make([]voteTotal, len(groups))
-	for i, group := range groups {
-		log.Trace("Got group vote total", "group", group, "value", values[i])
+	voteTotals := make([]voteTotal, len(votes.Groups))
+	for i, group := range votes.Groups {
+		log.Trace("Got group vote total", "group", group, "value", votes.Values[i])
 		voteTotals[i].Group = group
-		voteTotals[i].Value = values[i]
+		voteTotals[i].Value = votes.Values[i]
 	}
 	return voteTotals, err
 }
 
 func getGroupEpochRewards(header *types.Header, state vm.StateDB, group common.Address, maxRewards *big.Int, uptimes []*big.Int) (*big.Int, error) {
-	var groupEpochRewards *big.Int
-	_, err := contract_comm.MakeStaticCall(params.ElectionRegistryId, electionABI, "getGroupEpochRewards", []interface{}{group, maxRewards, uptimes}, &groupEpochRewards, params.MaxGasForGetGroupEpochRewards, header, state)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForGetGroupEpochRewards}
+	groupEpochRewards, _, err := election.GetGroupEpochRewards(opts, group, maxRewards, uptimes)
 	if err != nil {
 		return nil, err
 	}
 	return groupEpochRewards, nil
 }
 
-func DistributeEpochRewards(header *types.Header, state vm.StateDB, groups []common.Address, maxTotalRewards *big.Int, uptimes map[common.Address][]*big.Int) (*big.Int, error) {
+// DistributeEpochRewards distributes the epoch rewards of the voters of the given
+// groups, returning the total as well as the rewards of each group.
+func DistributeEpochRewards(header *types.Header, state vm.StateDB, groups []common.Address, maxTotalRewards *big.Int, uptimes map[common.Address][]*big.Int) (*big.Int, []*big.Int, error) {
 	totalRewards := big.NewInt(0)
 	voteTotals, err := getTotalVotesForEligibleValidatorGroups(header, state)
 	if err != nil {
-		return totalRewards, err
+		return totalRewards, nil, err
 	}
 
 	rewards := make([]*big.Int, len(groups))
 	for i, group := range groups {
 		reward, err := getGroupEpochRewards(header, state, group, maxTotalRewards, uptimes[group])
 		if err != nil {
-			return totalRewards, err
+			return totalRewards, nil, err
 		}
 		rewards[i] = reward
 		log.Debug("Reward for group voters", "reward", reward, "group", group.String())
@@ -267,11 +144,12 @@ func DistributeEpochRewards(header *types.Header, state vm.StateDB, groups []com
 				break
 			}
 		}
-		_, err := contract_comm.MakeCall(params.ElectionRegistryId, electionABI, "distributeEpochRewards", []interface{}{group, reward, lesser, greater}, nil, params.MaxGasForDistributeEpochRewards, common.Big0, header, state, false)
+		opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForDistributeEpochRewards}
+		_, err := election.DistributeEpochRewards(opts, group, reward, lesser, greater)
 		if err != nil {
-			return totalRewards, err
+			return totalRewards, nil, err
 		}
 		totalRewards.Add(totalRewards, reward)
 	}
-	return totalRewards, nil
+	return totalRewards, rewards, nil
 }
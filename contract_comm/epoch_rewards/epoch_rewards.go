@@ -17,113 +17,37 @@ package epoch_rewards
 
 import (
 	"math/big"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/contract_comm/contracts"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 )
 
-// This is taken from celo-monorepo/packages/protocol/build/<env>/contracts/Election.json
-const epochRewardsABIString string = `[
-    {
-      "constant": true,
-      "inputs": [],
-      "name": "calculateTargetEpochRewards",
-      "outputs": [
-        {
-          "name": "",
-          "type": "uint256"
-        },
-        {
-          "name": "",
-          "type": "uint256"
-        },
-        {
-          "name": "",
-          "type": "uint256"
-        },
-        {
-          "name": "",
-          "type": "uint256"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    },
-    { 
-      "constant": true,
-      "inputs": [],
-      "name": "carbonOffsettingPartner",
-      "outputs": [
-        { 
-          "name": "",
-          "type": "address"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    },
-    {
-      "constant": false,
-      "inputs": [],
-      "name": "updateTargetVotingYield",
-      "outputs": [],
-      "payable": false,
-      "stateMutability": "nonpayable",
-      "type": "function"
-    },
-    {
-      "constant": true,
-      "inputs": [],
-      "name": "isReserveLow",
-      "outputs": [
-        {
-          "name": "",
-          "type": "bool"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    },
-    {
-      "constant": true,
-      "inputs": [],
-      "name": "frozen",
-      "outputs": [
-        {
-          "name": "",
-          "type": "bool"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    }
-]
-`
+var epochRewards *contracts.EpochRewards
 
-var epochRewardsABI, _ = abi.JSON(strings.NewReader(epochRewardsABIString))
+func init() {
+	var err error
+	epochRewards, err = contracts.NewEpochRewards(params.EpochRewardsRegistryId)
+	if err != nil {
+		log.Crit("Error reading ABI for EpochRewards", "err", err)
+	}
+}
 
 func UpdateTargetVotingYield(header *types.Header, state vm.StateDB) error {
-	_, err := contract_comm.MakeCall(params.EpochRewardsRegistryId, epochRewardsABI, "updateTargetVotingYield", []interface{}{}, nil, params.MaxGasForUpdateTargetVotingYield, common.Big0, header, state, false)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForUpdateTargetVotingYield}
+	_, err := epochRewards.UpdateTargetVotingYield(opts)
 	return err
 }
 
 // Returns the per validator epoch reward, the total voter reward, the total community reward, and
 // the total carbon offsetting partner award, for the epoch.
 func CalculateTargetEpochRewards(header *types.Header, state vm.StateDB) (*big.Int, *big.Int, *big.Int, *big.Int, error) {
-	var validatorEpochReward *big.Int
-	var totalVoterRewards *big.Int
-	var totalCommunityReward *big.Int
-	var totalCarbonOffsettingPartnerReward *big.Int
-	_, err := contract_comm.MakeStaticCall(params.EpochRewardsRegistryId, epochRewardsABI, "calculateTargetEpochRewards", []interface{}{}, &[]interface{}{&validatorEpochReward, &totalVoterRewards, &totalCommunityReward, &totalCarbonOffsettingPartnerReward}, params.MaxGasForCalculateTargetEpochPaymentAndRewards, header, state)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForCalculateTargetEpochPaymentAndRewards}
+	validatorEpochReward, totalVoterRewards, totalCommunityReward, totalCarbonOffsettingPartnerReward, _, err := epochRewards.CalculateTargetEpochRewards(opts)
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
@@ -132,8 +56,8 @@ func CalculateTargetEpochRewards(header *types.Header, state vm.StateDB) (*big.I
 
 // Determines if the reserve is below it's critical threshold
 func IsReserveLow(header *types.Header, state vm.StateDB) (bool, error) {
-	var isLow bool
-	_, err := contract_comm.MakeStaticCall(params.EpochRewardsRegistryId, epochRewardsABI, "isReserveLow", []interface{}{}, &isLow, params.MaxGasForIsReserveLow, header, state)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForIsReserveLow}
+	isLow, _, err := epochRewards.IsReserveLow(opts)
 	if err != nil {
 		return false, err
 	}
@@ -142,8 +66,8 @@ func IsReserveLow(header *types.Header, state vm.StateDB) (bool, error) {
 
 // Returns the address of the carbon offsetting partner
 func GetCarbonOffsettingPartnerAddress(header *types.Header, state vm.StateDB) (common.Address, error) {
-	var carbonOffsettingPartner common.Address
-	_, err := contract_comm.MakeStaticCall(params.EpochRewardsRegistryId, epochRewardsABI, "carbonOffsettingPartner", []interface{}{}, &carbonOffsettingPartner, params.MaxGasForGetCarbonOffsettingPartner, header, state)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForGetCarbonOffsettingPartner}
+	carbonOffsettingPartner, _, err := epochRewards.CarbonOffsettingPartner(opts)
 	if err != nil {
 		return common.ZeroAddress, err
 	}
@@ -19,14 +19,17 @@ package contract_comm
 import (
 	"math/big"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/contract_comm/errors"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 )
@@ -34,8 +37,29 @@ import (
 var (
 	emptyMessage                = types.NewMessage(common.HexToAddress("0x0"), nil, 0, common.Big0, 0, common.Big0, nil, nil, common.Big0, []byte{}, false)
 	internalEvmHandlerSingleton *InternalEVMHandler
+
+	// Handlers bound to the databases of the states of their chains, taking
+	// precedence over the singleton for the calls made on these states
+	boundEvmHandlers     = make(map[ethdb.KeyValueReader]*InternalEVMHandler)
+	boundEvmHandlersLock sync.RWMutex
 )
 
+// TracedState is a state whose state changing system calls are traced. Only the
+// calls made between StartSystemCall and the function it returns are traced,
+// each by a new tracer labelled with the origin.
+type TracedState struct {
+	vm.StateDB
+
+	tracer func(origin string) (vm.Tracer, error) // Creates the tracer of every system call
+	origin string                                 // Origin of the system calls currently being made
+}
+
+// NewTracedState wraps the state to trace the system calls made on it with
+// tracers created by the given function.
+func NewTracedState(state vm.StateDB, tracer func(origin string) (vm.Tracer, error)) *TracedState {
+	return &TracedState{StateDB: state, tracer: tracer}
+}
+
 // An EVM handler to make calls to smart contracts from within geth
 type InternalEVMHandler struct {
 	chain vm.ChainContext
@@ -70,17 +94,18 @@ func createEVM(header *types.Header, state vm.StateDB) (*vm.EVM, error) {
 	// there are times (e.g. retrieving the set of validators when an epoch ends) that we need
 	// to call the evm using the currently mined block.  In that case, the header and state params
 	// will be non nil.
-	if internalEvmHandlerSingleton == nil {
+	handler := internalEVMHandlerOf(state)
+	if handler == nil {
 		return nil, errors.ErrNoInternalEvmHandlerSingleton
 	}
 
 	if header == nil {
-		header = internalEvmHandlerSingleton.chain.CurrentHeader()
+		header = handler.chain.CurrentHeader()
 	}
 
 	if state == nil || reflect.ValueOf(state).IsNil() {
 		var err error
-		state, err = internalEvmHandlerSingleton.chain.State()
+		state, err = handler.chain.State()
 		if err != nil {
 			log.Error("Error in retrieving the state from the blockchain", "err", err)
 			return nil, err
@@ -89,8 +114,13 @@ func createEVM(header *types.Header, state vm.StateDB) (*vm.EVM, error) {
 
 	// The EVM Context requires a msg, but the actual field values don't really matter for this case.
 	// Putting in zero values.
-	context := vm.NewEVMContext(emptyMessage, header, internalEvmHandlerSingleton.chain, nil)
-	evm := vm.NewEVM(context, state, internalEvmHandlerSingleton.chain.Config(), *internalEvmHandlerSingleton.chain.GetVMConfig())
+	context := vm.NewEVMContext(emptyMessage, header, handler.chain, nil)
+	config := *handler.chain.GetVMConfig()
+	if traced, ok := state.(*TracedState); ok {
+		config.SystemCallTracer = traced.tracer
+		state = traced.StateDB
+	}
+	evm := vm.NewEVM(context, state, handler.chain.Config(), config)
 
 	return evm, nil
 }
@@ -108,6 +138,10 @@ func makeCallFromSystem(scAddress common.Address, abi abi.ABI, funcName string,
 
 	var gasLeft uint64
 
+	// Only calls that may change the state are of interest to system call tracers
+	if traced, ok := state.(*TracedState); ok && !static && traced.origin != "" {
+		defer vmevm.StartSystemCall(traced.origin)()
+	}
 	if static {
 		gasLeft, err = vmevm.StaticCallFromSystem(scAddress, abi, funcName, args, returnObj, gas)
 	} else {
@@ -121,6 +155,36 @@ func makeCallFromSystem(scAddress common.Address, abi abi.ABI, funcName string,
 	return gasLeft, nil
 }
 
+// StartSystemCall labels the system calls made on the given state with the
+// origin until the returned function is invoked, if the state is traced.
+func StartSystemCall(state vm.StateDB, origin string) func() {
+	traced, ok := state.(*TracedState)
+	if !ok {
+		return func() {}
+	}
+	parent := traced.origin
+	traced.origin = origin
+	return func() { traced.origin = parent }
+}
+
+// internalEVMHandlerOf returns the handler bound to the database of the state,
+// if any, or the singleton.
+func internalEVMHandlerOf(s vm.StateDB) *InternalEVMHandler {
+	if traced, ok := s.(*TracedState); ok {
+		s = traced.StateDB
+	}
+	if statedb, ok := s.(*state.StateDB); ok && statedb != nil {
+		boundEvmHandlersLock.RLock()
+		handler := boundEvmHandlers[statedb.Database().TrieDB().DiskDB()]
+		boundEvmHandlersLock.RUnlock()
+
+		if handler != nil {
+			return handler
+		}
+	}
+	return internalEvmHandlerSingleton
+}
+
 func SetInternalEVMHandler(chain vm.ChainContext) {
 	if internalEvmHandlerSingleton == nil {
 		log.Trace("Setting the InternalEVMHandler Singleton")
@@ -131,6 +195,25 @@ func SetInternalEVMHandler(chain vm.ChainContext) {
 	}
 }
 
+// BindInternalEVMHandler makes the system calls on the states stored in the
+// database through the chain rather than the singleton, until unbound. It lets
+// chains other than the one of the node, like simulated ones, make system calls
+// on their own states.
+func BindInternalEVMHandler(db ethdb.KeyValueReader, chain vm.ChainContext) {
+	boundEvmHandlersLock.Lock()
+	defer boundEvmHandlersLock.Unlock()
+
+	boundEvmHandlers[db] = &InternalEVMHandler{chain: chain}
+}
+
+// UnbindInternalEVMHandler removes the handler bound to the database.
+func UnbindInternalEVMHandler(db ethdb.KeyValueReader) {
+	boundEvmHandlersLock.Lock()
+	defer boundEvmHandlersLock.Unlock()
+
+	delete(boundEvmHandlers, db)
+}
+
 func makeCallWithContractId(registryId [32]byte, abi abi.ABI, funcName string, args []interface{}, returnObj interface{}, gas uint64, value *big.Int, header *types.Header, state vm.StateDB, static bool) (uint64, error) {
 	scAddress, err := GetRegisteredAddress(registryId, header, state)
 
@@ -17,49 +17,32 @@
 package freezer
 
 import (
-	"strings"
-
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/contract_comm/contracts"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 )
 
-const (
-	// This is taken from celo-monorepo/packages/protocol/build/<env>/contracts/Freezer.json
-	isFrozenABI = `[{
-      "constant": true,
-      "inputs": [
-        {
-          "name": "",
-          "type": "address"
-        }
-      ],
-      "name": "isFrozen",
-      "outputs": [
-        {
-          "name": "",
-          "type": "bool"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    }]`
-)
+var freezer *contracts.Freezer
 
-var (
-	isFrozenFuncABI, _ = abi.JSON(strings.NewReader(isFrozenABI))
-)
+func init() {
+	var err error
+	freezer, err = contracts.NewFreezer(params.FreezerRegistryId)
+	if err != nil {
+		log.Crit("Error reading ABI for Freezer", "err", err)
+	}
+}
 
 func IsFrozen(registryId [32]byte, header *types.Header, state vm.StateDB) (bool, error) {
 	address, err := contract_comm.GetRegisteredAddress(registryId, header, state)
 	if err != nil {
 		return false, err
 	}
-	var isFrozen bool
-	if _, err := contract_comm.MakeStaticCall(params.FreezerRegistryId, isFrozenFuncABI, "isFrozen", []interface{}{address}, &isFrozen, params.MaxGasForIsFrozen, header, state); err != nil {
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForIsFrozen}
+	isFrozen, _, err := freezer.IsFrozen(opts, *address)
+	if err != nil {
 		return false, err
 	}
 
@@ -18,73 +18,33 @@ package gasprice_minimum
 
 import (
 	"math/big"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contract_comm"
 	"github.com/ethereum/go-ethereum/contract_comm/blockchain_parameters"
+	"github.com/ethereum/go-ethereum/contract_comm/contracts"
 	"github.com/ethereum/go-ethereum/contract_comm/errors"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 )
 
-// TODO (jarmg 5/22/19): Store ABIs in a central location
-const (
-	gasPriceMinimumABIString = `[
-    {
-      "constant": true,
-      "inputs": [
-        {
-          "name": "_tokenAddress",
-          "type": "address"
-        }
-      ],
-      "name": "getGasPriceMinimum",
-      "outputs": [
-        {
-          "name": "",
-          "type": "uint256"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    },
-    {
-      "constant": false,
-      "inputs": [
-        {
-          "name": "_blockGasTotal",
-          "type": "uint256"
-        },
-        {
-          "name": "_blockGasLimit",
-          "type": "uint256"
-        }
-      ],
-      "name": "updateGasPriceMinimum",
-      "outputs": [
-        {
-          "name": "",
-          "type": "uint256"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "nonpayable",
-      "type": "function"
-      } 
-    
-  ]`
-)
-
 var (
-	gasPriceMinimumABI, _            = abi.JSON(strings.NewReader(gasPriceMinimumABIString))
 	FallbackGasPriceMinimum *big.Int = big.NewInt(0) // gas price minimum to return if unable to fetch from contract
 	suggestionMultiplier    *big.Int = big.NewInt(5) // The multiplier that we apply to the minimum when suggesting gas price
+
+	gasPriceMinimum *contracts.GasPriceMinimum
 )
 
+func init() {
+	var err error
+	gasPriceMinimum, err = contracts.NewGasPriceMinimum(params.GasPriceMinimumRegistryId)
+	if err != nil {
+		log.Crit("Error reading ABI for GasPriceMinimum", "err", err)
+	}
+}
+
 func GetGasPriceSuggestion(currency *common.Address, header *types.Header, state vm.StateDB) (*big.Int, error) {
 	gasPriceMinimum, err := GetGasPriceMinimum(currency, header, state)
 	return new(big.Int).Mul(gasPriceMinimum, suggestionMultiplier), err
@@ -110,17 +70,8 @@ func GetGasPriceMinimum(currency *common.Address, header *types.Header, state vm
 		currencyAddress = currency
 	}
 
-	var gasPriceMinimum *big.Int
-	_, err = contract_comm.MakeStaticCall(
-		params.GasPriceMinimumRegistryId,
-		gasPriceMinimumABI,
-		"getGasPriceMinimum",
-		[]interface{}{currencyAddress},
-		&gasPriceMinimum,
-		params.MaxGasForGetGasPriceMinimum,
-		header,
-		state,
-	)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForGetGasPriceMinimum}
+	minimum, _, err := gasPriceMinimum.GetGasPriceMinimum(opts, *currencyAddress)
 
 	if err == errors.ErrSmartContractNotDeployed || err == errors.ErrRegistryContractNotDeployed {
 		return FallbackGasPriceMinimum, nil
@@ -132,28 +83,15 @@ func GetGasPriceMinimum(currency *common.Address, header *types.Header, state vm
 		return FallbackGasPriceMinimum, err
 	}
 
-	return gasPriceMinimum, err
+	return minimum, err
 }
 
 func UpdateGasPriceMinimum(header *types.Header, state vm.StateDB) (*big.Int, error) {
-	var updatedGasPriceMinimum *big.Int
-
 	// If an error occurs, the default block gas limit will be returned and a log statement will be produced by contract_comm
 	gasLimit, _ := blockchain_parameters.GetBlockGasLimit(header, state)
 
-	_, err := contract_comm.MakeCall(
-		params.GasPriceMinimumRegistryId,
-		gasPriceMinimumABI,
-		"updateGasPriceMinimum",
-		[]interface{}{big.NewInt(int64(header.GasUsed)),
-			big.NewInt(int64(gasLimit))},
-		&updatedGasPriceMinimum,
-		params.MaxGasForUpdateGasPriceMinimum,
-		big.NewInt(0),
-		header,
-		state,
-		false,
-	)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForUpdateGasPriceMinimum}
+	updatedGasPriceMinimum, _, err := gasPriceMinimum.UpdateGasPriceMinimum(opts, big.NewInt(int64(header.GasUsed)), big.NewInt(int64(gasLimit)))
 	if err != nil {
 		return nil, err
 	}
@@ -2,13 +2,12 @@ package random
 
 import (
 	"math/big"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/contract_comm/contracts"
 	"github.com/ethereum/go-ethereum/contract_comm/errors"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -18,124 +17,21 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 )
 
-const (
-	// This is taken from celo-monorepo/packages/protocol/build/<env>/contracts/Random.json
-	revealAndCommitABI = `[
-	{
-		"constant": false,
-		"inputs": [
-			{
-				"name": "randomness",
-				"type": "bytes32"
-			},
-			{
-				"name": "newCommitment",
-				"type": "bytes32"
-			},
-			{
-				"name": "proposer",
-				"type": "address"
-			}
-		],
-		"name": "revealAndCommit",
-		"outputs": [],
-		"payable": false,
-		"stateMutability": "nonpayable",
-		"type": "function"
-	}
-]`
-	commitmentsAbi = `[
-	{
-		"constant": true,
-		"inputs": [
-			{
-				"name": "",
-				"type": "address"
-			}
-		],
-		"name": "commitments",
-		"outputs": [
-			{
-				"name": "",
-				"type": "bytes32"
-			}
-		],
-		"payable": false,
-		"stateMutability": "view",
-		"type": "function"
-	}
-]`
-	computeCommitmentAbi = `[
-    {
-      "constant": true,
-      "inputs": [
-        {
-          "name": "randomness",
-          "type": "bytes32"
-        }
-      ],
-      "name": "computeCommitment",
-      "outputs": [
-        {
-          "name": "",
-          "type": "bytes32"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    }
-]`
-	randomAbi = `[
-    {
-      "constant": true,
-      "inputs": [],
-      "name": "random",
-      "outputs": [
-        {
-          "name": "",
-          "type": "bytes32"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    }
-]`
-
-	historicRandomAbi = `[
-    {
-      "constant": true,
-      "inputs": [
-		{
-			"name": "blockNumber",
-			"type": "uint256"
-		}
-	  ],
-      "name": "getBlockRandomness",
-      "outputs": [
-        {
-          "name": "",
-          "type": "bytes32"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    }
-]`
-)
-
 var (
-	revealAndCommitFuncABI, _   = abi.JSON(strings.NewReader(revealAndCommitABI))
-	commitmentsFuncABI, _       = abi.JSON(strings.NewReader(commitmentsAbi))
-	computeCommitmentFuncABI, _ = abi.JSON(strings.NewReader(computeCommitmentAbi))
-	randomFuncABI, _            = abi.JSON(strings.NewReader(randomAbi))
-	historicRandomFuncABI, _    = abi.JSON(strings.NewReader(historicRandomAbi))
-	zeroValue                   = common.Big0
-	dbRandomnessPrefix          = []byte("db-randomness-prefix")
+	zeroValue          = common.Big0
+	dbRandomnessPrefix = []byte("db-randomness-prefix")
+
+	randomContract *contracts.Random
 )
 
+func init() {
+	var err error
+	randomContract, err = contracts.NewRandom(params.RandomRegistryId)
+	if err != nil {
+		log.Crit("Error reading ABI for Random", "err", err)
+	}
+}
+
 func commitmentDbLocation(commitment common.Hash) []byte {
 	return append(dbRandomnessPrefix, commitment.Bytes()...)
 }
@@ -160,8 +56,9 @@ func IsRunning() bool {
 // corresponding preimage in a (commitment => randomness) mapping we keep in the
 // database.
 func GetLastRandomness(coinbase common.Address, db *ethdb.Database, header *types.Header, state vm.StateDB, chain consensus.ChainReader, seed []byte) (common.Hash, error) {
-	lastCommitment := common.Hash{}
-	_, err := contract_comm.MakeStaticCall(params.RandomRegistryId, commitmentsFuncABI, "commitments", []interface{}{coinbase}, &lastCommitment, params.MaxGasForCommitments, header, state)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForCommitments}
+	commitment, _, err := randomContract.Commitments(opts, coinbase)
+	lastCommitment := common.Hash(commitment)
 	if err != nil {
 		log.Error("Failed to get last commitment", "err", err)
 		return lastCommitment, err
@@ -191,10 +88,10 @@ func GetLastRandomness(coinbase common.Address, db *ethdb.Database, header *type
 // GenerateNewRandomnessAndCommitment generates a new random number and a corresponding commitment.
 // The random number is stored in the database, keyed by the corresponding commitment.
 func GenerateNewRandomnessAndCommitment(header *types.Header, state vm.StateDB, db *ethdb.Database, seed []byte) (common.Hash, error) {
-	commitment := common.Hash{}
 	randomness := crypto.Keccak256Hash(append(seed, header.ParentHash.Bytes()...))
 	// TODO(asa): Make an issue to not have to do this via StaticCall
-	_, err := contract_comm.MakeStaticCall(params.RandomRegistryId, computeCommitmentFuncABI, "computeCommitment", []interface{}{randomness}, &commitment, params.MaxGasForComputeCommitment, header, state)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForComputeCommitment}
+	commitment, _, err := randomContract.ComputeCommitment(opts, randomness)
 	if err != nil {
 		log.Error("Failed to call computeCommitment()", "err", err)
 		return common.Hash{}, err
@@ -211,21 +108,21 @@ func GenerateNewRandomnessAndCommitment(header *types.Header, state vm.StateDB,
 // proposer's previously committed to randomness, and commits new randomness for
 // a future block.
 func RevealAndCommit(randomness, newCommitment common.Hash, proposer common.Address, header *types.Header, state vm.StateDB) error {
-	args := []interface{}{randomness, newCommitment, proposer}
 	log.Trace("Revealing and committing randomness", "randomness", randomness.Hex(), "commitment", newCommitment.Hex())
-	_, err := contract_comm.MakeCall(params.RandomRegistryId, revealAndCommitFuncABI, "revealAndCommit", args, nil, params.MaxGasForRevealAndCommit, zeroValue, header, state, true)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForRevealAndCommit, Value: zeroValue, FinaliseState: true}
+	_, err := randomContract.RevealAndCommit(opts, randomness, newCommitment, proposer)
 	return err
 }
 
 // Random performs an internal call to the EVM to retrieve the current randomness from the official Random contract.
 func Random(header *types.Header, state vm.StateDB) (common.Hash, error) {
-	randomness := common.Hash{}
-	_, err := contract_comm.MakeStaticCall(params.RandomRegistryId, randomFuncABI, "random", []interface{}{}, &randomness, params.MaxGasForBlockRandomness, header, state)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForBlockRandomness}
+	randomness, _, err := randomContract.Random(opts)
 	return randomness, err
 }
 
 func BlockRandomness(header *types.Header, state vm.StateDB, blockNumber uint64) (common.Hash, error) {
-	randomness := common.Hash{}
-	_, err := contract_comm.MakeStaticCall(params.RandomRegistryId, historicRandomFuncABI, "getBlockRandomness", []interface{}{big.NewInt(int64(blockNumber))}, &randomness, params.MaxGasForBlockRandomness, header, state)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForBlockRandomness}
+	randomness, _, err := randomContract.GetBlockRandomness(opts, big.NewInt(int64(blockNumber)))
 	return randomness, err
 }
@@ -0,0 +1,94 @@
+// Copyright 2020 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package contract_comm
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// SystemCallOpts is the collection of options to fine tune a system call made
+// through a core contract binding.
+type SystemCallOpts struct {
+	Header        *types.Header // Header of the block to call at (nil = current head)
+	State         vm.StateDB    // State to call on (nil = state of the current head)
+	Gas           uint64        // Gas limit of the call
+	Value         *big.Int      // Funds to transfer along a state mutating call (nil = 0)
+	FinaliseState bool          // Whether to finalise the state after a successful state mutating call
+}
+
+// SystemContract is the generic wrapper the core contract bindings generated
+// by abigen --syscall make their system calls through. The contract is looked
+// up in the Registry on every call, unless it is bound to an address.
+type SystemContract struct {
+	abi        abi.ABI
+	registryId [32]byte
+	address    *common.Address
+}
+
+// NewRegisteredContract creates a wrapper around the core contract registered
+// under the identifier.
+func NewRegisteredContract(registryId [32]byte, abi abi.ABI) *SystemContract {
+	return &SystemContract{abi: abi, registryId: registryId}
+}
+
+// NewSystemContractAt creates a wrapper around the contract at the address.
+func NewSystemContractAt(address common.Address, abi abi.ABI) *SystemContract {
+	return &SystemContract{abi: abi, address: &address}
+}
+
+// At returns a wrapper with the same ABI around the contract at the address.
+func (c *SystemContract) At(address common.Address) *SystemContract {
+	return NewSystemContractAt(address, c.abi)
+}
+
+// StaticCall invokes the (constant) contract method with params as input values
+// and sets the output to result, returning the leftover gas. The result type
+// might be a single field for simple returns, a slice of interfaces for
+// anonymous returns and a struct for named returns.
+func (c *SystemContract) StaticCall(opts *SystemCallOpts, result interface{}, method string, params ...interface{}) (uint64, error) {
+	if c.address != nil {
+		return makeCallFromSystem(*c.address, c.abi, method, params, result, opts.Gas, nil, opts.Header, opts.State, true)
+	}
+	return makeCallWithContractId(c.registryId, c.abi, method, params, result, opts.Gas, nil, opts.Header, opts.State, true)
+}
+
+// Call invokes the (state mutating) contract method with params as input values
+// and sets the output to result, returning the leftover gas.
+func (c *SystemContract) Call(opts *SystemCallOpts, result interface{}, method string, params ...interface{}) (uint64, error) {
+	value := opts.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	var (
+		gasLeft uint64
+		err     error
+	)
+	if c.address != nil {
+		gasLeft, err = makeCallFromSystem(*c.address, c.abi, method, params, result, opts.Gas, value, opts.Header, opts.State, false)
+	} else {
+		gasLeft, err = makeCallWithContractId(c.registryId, c.abi, method, params, result, opts.Gas, value, opts.Header, opts.State, false)
+	}
+	if err == nil && opts.FinaliseState {
+		opts.State.Finalise(true)
+	}
+	return gasLeft, err
+}
@@ -17,11 +17,9 @@
 package transfer_whitelist
 
 import (
-	"strings"
-
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/contract_comm/contracts"
 	"github.com/ethereum/go-ethereum/contract_comm/errors"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -29,32 +27,20 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 )
 
-const (
-	// This is taken from celo-monorepo/packages/protocol/build/<env>/contracts/TransferWhitelist.json
-	getWhitelistABI = `[{
-      "constant": true,
-      "inputs": [],
-      "name": "getWhitelist",
-      "outputs": [
-        {
-          "name": "",
-          "type": "address[]"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    }]`
-)
+var transferWhitelist *contracts.TransferWhitelist
 
-var (
-	getWhitelistFuncABI, _ = abi.JSON(strings.NewReader(getWhitelistABI))
-)
+func init() {
+	var err error
+	transferWhitelist, err = contracts.NewTransferWhitelist(params.TransferWhitelistRegistryId)
+	if err != nil {
+		log.Crit("Error reading ABI for TransferWhitelist", "err", err)
+	}
+}
 
 func retrieveWhitelist(header *types.Header, state vm.StateDB) ([]common.Address, error) {
-	var whitelist []common.Address
-
-	if _, err := contract_comm.MakeStaticCall(params.TransferWhitelistRegistryId, getWhitelistFuncABI, "getWhitelist", []interface{}{}, &whitelist, params.MaxGasForGetTransferWhitelist, header, state); err != nil {
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForGetTransferWhitelist}
+	whitelist, _, err := transferWhitelist.GetWhitelist(opts)
+	if err != nil {
 		if err == errors.ErrSmartContractNotDeployed {
 			log.Warn("Registry address lookup failed", "err", err)
 		} else {
@@ -18,164 +18,18 @@ package validators
 import (
 	"fmt"
 	"math/big"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/istanbul"
 	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/contract_comm/contracts"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	blscrypto "github.com/ethereum/go-ethereum/crypto/bls"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 )
 
-// This is taken from celo-monorepo/packages/protocol/build/<env>/contracts/Validators.json
-const validatorsABIString string = `[
-    {
-        "constant": true,
-        "inputs": [],
-        "name": "getRegisteredValidatorSigners",
-        "outputs": [
-        {
-            "name": "",
-            "type": "address[]"
-        }
-        ],
-        "payable": false,
-        "stateMutability": "view",
-        "type": "function"
-    },
-    {
-      "constant": true,
-      "inputs": [],
-      "name": "getRegisteredValidators",
-      "outputs": [
-        {
-          "name": "",
-          "type": "address[]"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    },
-    {
-      "constant": true,
-      "inputs": [
-        {
-          "name": "signer",
-          "type": "address"
-        }
-      ],
-      "name": "getValidatorBlsPublicKeyFromSigner",
-      "outputs": [
-        {
-          "name": "blsKey",
-          "type": "bytes"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    },
-	  {
-      "constant": true,
-      "inputs": [
-        {
-          "name": "account",
-          "type": "address"
-        }
-      ],
-      "name": "getValidator",
-      "outputs": [
-        {
-          "name": "ecdsaPublicKey",
-          "type": "bytes"
-        },
-        {
-          "name": "blsPublicKey",
-          "type": "bytes"
-        },
-        {
-          "name": "affiliation",
-          "type": "address"
-        },
-        {
-          "name": "score",
-          "type": "uint256"
-        },
-        {
-          "name": "signer",
-          "type": "address"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    },
-    {
-      "constant": false,
-      "inputs": [
-        {
-          "name": "validator",
-          "type": "address"
-        },
-        {
-          "name": "maxPayment",
-          "type": "uint256"
-        }
-      ],
-      "name": "distributeEpochPaymentsFromSigner",
-      "outputs": [
-        {
-          "name": "",
-          "type": "uint256"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "nonpayable",
-      "type": "function"
-    },
-    {
-      "constant": false,
-      "inputs": [
-        {
-          "name": "validator",
-          "type": "address"
-        },
-        {
-          "name": "uptime",
-          "type": "uint256"
-        }
-      ],
-      "name": "updateValidatorScoreFromSigner",
-      "outputs": [],
-      "payable": false,
-      "stateMutability": "nonpayable",
-      "type": "function"
-    },
-    {
-      "constant": true,
-      "inputs": [
-        {
-          "name": "account",
-          "type": "address"
-        }
-      ],
-      "name": "getMembershipInLastEpochFromSigner",
-      "outputs": [
-        {
-          "name": "",
-          "type": "address"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    }
-]`
-
 type ValidatorContractData struct {
 	EcdsaPublicKey []byte
 	BlsPublicKey   []byte
@@ -184,13 +38,21 @@ type ValidatorContractData struct {
 	Signer         common.Address
 }
 
-var validatorsABI, _ = abi.JSON(strings.NewReader(validatorsABIString))
+var validators *contracts.Validators
 
-func RetrieveRegisteredValidatorSigners(header *types.Header, state vm.StateDB) ([]common.Address, error) {
-	var regVals []common.Address
+func init() {
+	var err error
+	validators, err = contracts.NewValidators(params.ValidatorsRegistryId)
+	if err != nil {
+		log.Crit("Error reading ABI for Validators", "err", err)
+	}
+}
 
+func RetrieveRegisteredValidatorSigners(header *types.Header, state vm.StateDB) ([]common.Address, error) {
 	// Get the new epoch's validator signer set
-	if _, err := contract_comm.MakeStaticCall(params.ValidatorsRegistryId, validatorsABI, "getRegisteredValidatorSigners", []interface{}{}, &regVals, params.MaxGasForGetRegisteredValidators, header, state); err != nil {
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForGetRegisteredValidators}
+	regVals, _, err := validators.GetRegisteredValidatorSigners(opts)
+	if err != nil {
 		return nil, err
 	}
 
@@ -198,10 +60,10 @@ func RetrieveRegisteredValidatorSigners(header *types.Header, state vm.StateDB)
 }
 
 func RetrieveRegisteredValidators(header *types.Header, state vm.StateDB) ([]common.Address, error) {
-	var regVals []common.Address
-
 	// Get the new epoch's validator set
-	if _, err := contract_comm.MakeStaticCall(params.ValidatorsRegistryId, validatorsABI, "getRegisteredValidators", []interface{}{}, &regVals, params.MaxGasForGetRegisteredValidators, header, state); err != nil {
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForGetRegisteredValidators}
+	regVals, _, err := validators.GetRegisteredValidators(opts)
+	if err != nil {
 		return nil, err
 	}
 
@@ -209,17 +71,9 @@ func RetrieveRegisteredValidators(header *types.Header, state vm.StateDB) ([]com
 }
 
 func GetValidator(header *types.Header, state vm.StateDB, validatorAddress common.Address) (ValidatorContractData, error) {
-	var validator ValidatorContractData
-	_, err := contract_comm.MakeStaticCall(
-		params.ValidatorsRegistryId,
-		validatorsABI,
-		"getValidator",
-		[]interface{}{validatorAddress},
-		&validator,
-		params.MaxGasForGetValidator,
-		header,
-		state,
-	)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForGetValidator}
+	data, _, err := validators.GetValidator(opts, validatorAddress)
+	validator := ValidatorContractData(data)
 	if err != nil {
 		return validator, err
 	}
@@ -231,9 +85,9 @@ func GetValidator(header *types.Header, state vm.StateDB, validatorAddress commo
 
 func GetValidatorData(header *types.Header, state vm.StateDB, validatorAddresses []common.Address) ([]istanbul.ValidatorData, error) {
 	var validatorData []istanbul.ValidatorData
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForGetValidator}
 	for _, addr := range validatorAddresses {
-		var blsKey []byte
-		_, err := contract_comm.MakeStaticCall(params.ValidatorsRegistryId, validatorsABI, "getValidatorBlsPublicKeyFromSigner", []interface{}{addr}, &blsKey, params.MaxGasForGetValidator, header, state)
+		blsKey, _, err := validators.GetValidatorBlsPublicKeyFromSigner(opts, addr)
 		if err != nil {
 			return nil, err
 		}
@@ -253,41 +107,20 @@ func GetValidatorData(header *types.Header, state vm.StateDB, validatorAddresses
 }
 
 func UpdateValidatorScore(header *types.Header, state vm.StateDB, address common.Address, uptime *big.Int) error {
-	_, err := contract_comm.MakeCall(
-		params.ValidatorsRegistryId,
-		validatorsABI,
-		"updateValidatorScoreFromSigner",
-		[]interface{}{address, uptime},
-		nil,
-		params.MaxGasForUpdateValidatorScore,
-		common.Big0,
-		header,
-		state,
-		false,
-	)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForUpdateValidatorScore}
+	_, err := validators.UpdateValidatorScoreFromSigner(opts, address, uptime)
 	return err
 }
 
 func DistributeEpochReward(header *types.Header, state vm.StateDB, address common.Address, maxReward *big.Int) (*big.Int, error) {
-	var epochReward *big.Int
-	_, err := contract_comm.MakeCall(
-		params.ValidatorsRegistryId,
-		validatorsABI,
-		"distributeEpochPaymentsFromSigner",
-		[]interface{}{address, maxReward},
-		&epochReward,
-		params.MaxGasForDistributeEpochPayment,
-		common.Big0,
-		header,
-		state,
-		false,
-	)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForDistributeEpochPayment}
+	epochReward, _, err := validators.DistributeEpochPaymentsFromSigner(opts, address, maxReward)
 	return epochReward, err
 }
 
 func GetMembershipInLastEpoch(header *types.Header, state vm.StateDB, validator common.Address) (common.Address, error) {
-	var group common.Address
-	_, err := contract_comm.MakeStaticCall(params.ValidatorsRegistryId, validatorsABI, "getMembershipInLastEpochFromSigner", []interface{}{validator}, &group, params.MaxGasForGetMembershipInLastEpoch, header, state)
+	opts := &contract_comm.SystemCallOpts{Header: header, State: state, Gas: params.MaxGasForGetMembershipInLastEpoch}
+	group, _, err := validators.GetMembershipInLastEpochFromSigner(opts, validator)
 	if err != nil {
 		return common.ZeroAddress, err
 	}
@@ -2201,6 +2201,17 @@ func (bc *BlockChain) InsertHeaderChain(chain []*types.Header, checkFreq int, co
 	return res, err
 }
 
+// VerifyEpochHeaders verifies a chain of consecutive epoch block headers that
+// follow the last epoch block known to the local chain, without inserting them.
+// It is only supported by the istanbul consensus engine.
+func (bc *BlockChain) VerifyEpochHeaders(headers []*types.Header) error {
+	engine, ok := bc.engine.(consensus.Istanbul)
+	if !ok {
+		return errors.New("epoch headers can only be verified by istanbul")
+	}
+	return engine.VerifyEpochHeaders(bc, headers)
+}
+
 // CurrentHeader retrieves the current head header of the canonical chain. The
 // header is retrieved from the HeaderChain's internal cache.
 func (bc *BlockChain) CurrentHeader() *types.Header {
@@ -422,9 +422,13 @@ func ReadTd(db ethdb.Reader, hash common.Hash, number uint64) *big.Int {
 	return td
 }
 
-// ReadAccumulatedEpochUptime retrieves the so-far accumulated uptime array for the validators of the specified epoch
+// ReadAccumulatedEpochUptime retrieves the so-far accumulated uptime array for the validators of the specified epoch,
+// either from the active database or, once the epoch is frozen, from the ancient store.
 func ReadAccumulatedEpochUptime(db ethdb.Reader, epoch uint64) *istanbul.Uptime {
 	data, _ := db.Get(uptimeKey(epoch))
+	if len(data) == 0 {
+		data, _ = db.Ancient(freezerUptimeTable, epoch)
+	}
 	if len(data) == 0 {
 		log.Trace("ReadAccumulatedEpochUptime EMPTY", "epoch", epoch)
 		return nil
@@ -455,6 +459,38 @@ func DeleteAccumulatedEpochUptime(db ethdb.KeyValueWriter, epoch uint64) {
 	}
 }
 
+// ReadIstanbulSnapshot retrieves the validator set snapshot taken at the given block, either from the active
+// database or, if the block is a frozen epoch block of the canonical chain, from the ancient store.
+func ReadIstanbulSnapshot(db ethdb.Reader, epochSize uint64, hash common.Hash) []byte {
+	data, _ := db.Get(istanbulSnapshotKey(hash))
+	if len(data) > 0 || epochSize == 0 {
+		return data
+	}
+	number := ReadHeaderNumber(db, hash)
+	if number == nil || *number%epochSize != 0 || ReadCanonicalHash(db, *number) != hash {
+		return nil
+	}
+	data, _ = db.Ancient(freezerIstanbulSnapshotTable, *number/epochSize)
+	if len(data) == 0 {
+		return nil
+	}
+	return data
+}
+
+// WriteIstanbulSnapshot stores the validator set snapshot taken at the given block.
+func WriteIstanbulSnapshot(db ethdb.KeyValueWriter, hash common.Hash, snapshot []byte) {
+	if err := db.Put(istanbulSnapshotKey(hash), snapshot); err != nil {
+		log.Crit("Failed to store istanbul snapshot", "err", err)
+	}
+}
+
+// DeleteIstanbulSnapshot removes the validator set snapshot taken at the given block from the active database.
+func DeleteIstanbulSnapshot(db ethdb.KeyValueWriter, hash common.Hash) {
+	if err := db.Delete(istanbulSnapshotKey(hash)); err != nil {
+		log.Crit("Failed to delete istanbul snapshot", "err", err)
+	}
+}
+
 // WriteTd stores the total difficulty of a block into the database.
 func WriteTd(db ethdb.KeyValueWriter, hash common.Hash, number uint64, td *big.Int) {
 	data, err := rlp.EncodeToBytes(td)
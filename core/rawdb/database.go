@@ -188,6 +188,13 @@ func NewDatabaseWithFreezer(db ethdb.KeyValueStore, freezer string, namespace st
 			// feezer.
 		}
 	}
+	// The epoch data tables can only be checked against the frozen blocks once
+	// the epoch size is known, which is the case if the genesis is stored.
+	if epochSize := readEpochSize(db); epochSize != 0 {
+		if err := frdb.setEpochSize(epochSize); err != nil {
+			return nil, err
+		}
+	}
 	// Freezer is consistent with the key-value database, permit combining the two
 	go frdb.freeze(db)
 
@@ -260,11 +267,13 @@ func InspectDatabase(db ethdb.Database) error {
 		bloomBitsSize  common.StorageSize
 
 		// Ancient store statistics
-		ancientHeaders  common.StorageSize
-		ancientBodies   common.StorageSize
-		ancientReceipts common.StorageSize
-		ancientHashes   common.StorageSize
-		ancientTds      common.StorageSize
+		ancientHeaders   common.StorageSize
+		ancientBodies    common.StorageSize
+		ancientReceipts  common.StorageSize
+		ancientHashes    common.StorageSize
+		ancientTds       common.StorageSize
+		ancientSnapshots common.StorageSize
+		ancientUptimes   common.StorageSize
 
 		// Les statistic
 		chtTrieNodes   common.StorageSize
@@ -326,8 +335,8 @@ func InspectDatabase(db ethdb.Database) error {
 		}
 	}
 	// Inspect append-only file store then.
-	ancients := []*common.StorageSize{&ancientHeaders, &ancientBodies, &ancientReceipts, &ancientHashes, &ancientTds}
-	for i, category := range []string{freezerHeaderTable, freezerBodiesTable, freezerReceiptTable, freezerHashTable, freezerDifficultyTable} {
+	ancients := []*common.StorageSize{&ancientHeaders, &ancientBodies, &ancientReceipts, &ancientHashes, &ancientTds, &ancientSnapshots, &ancientUptimes}
+	for i, category := range []string{freezerHeaderTable, freezerBodiesTable, freezerReceiptTable, freezerHashTable, freezerDifficultyTable, freezerIstanbulSnapshotTable, freezerUptimeTable} {
 		if size, err := db.AncientSize(category); err == nil {
 			*ancients[i] += common.StorageSize(size)
 			total += common.StorageSize(size)
@@ -351,6 +360,8 @@ func InspectDatabase(db ethdb.Database) error {
 		{"Ancient store", "Receipts", ancientReceipts.String()},
 		{"Ancient store", "Difficulties", ancientTds.String()},
 		{"Ancient store", "Block number->hash", ancientHashes.String()},
+		{"Ancient store", "Istanbul snapshots", ancientSnapshots.String()},
+		{"Ancient store", "Epoch uptimes", ancientUptimes.String()},
 		{"Light client", "CHT trie nodes", chtTrieNodes.String()},
 		{"Light client", "Bloom trie nodes", bloomTrieNodes.String()},
 	}
@@ -71,8 +71,10 @@ type freezer struct {
 	// so take advantage of that (https://golang.org/pkg/sync/atomic/#pkg-note-BUG).
 	frozen    uint64 // Number of blocks already frozen
 	threshold uint64 // Number of recent blocks not to freeze (params.FullImmutabilityThreshold apart from tests)
+	epochSize uint64 // Number of blocks per istanbul epoch, zero until known
 
 	tables       map[string]*freezerTable // Data tables for storing everything
+	epochTables  map[string]*freezerTable // Data tables for storing per-epoch data
 	instanceLock fileutil.Releaser        // File-system lock to prevent double opens
 
 	trigger chan chan struct{} // Manual blocking freeze trigger, test determinism
@@ -106,25 +108,27 @@ func newFreezer(datadir string, namespace string) (*freezer, error) {
 	freezer := &freezer{
 		threshold:    params.FullImmutabilityThreshold,
 		tables:       make(map[string]*freezerTable),
+		epochTables:  make(map[string]*freezerTable),
 		instanceLock: lock,
 		trigger:      make(chan chan struct{}),
 		quit:         make(chan struct{}),
 	}
-	for name, disableSnappy := range freezerNoSnappy {
-		table, err := newTable(datadir, name, readMeter, writeMeter, sizeGauge, disableSnappy)
-		if err != nil {
-			for _, table := range freezer.tables {
-				table.Close()
+	for _, group := range []struct {
+		noSnappy map[string]bool
+		tables   map[string]*freezerTable
+	}{{freezerNoSnappy, freezer.tables}, {freezerEpochNoSnappy, freezer.epochTables}} {
+		for name, disableSnappy := range group.noSnappy {
+			table, err := newTable(datadir, name, readMeter, writeMeter, sizeGauge, disableSnappy)
+			if err != nil {
+				freezer.closeTables()
+				lock.Release()
+				return nil, err
 			}
-			lock.Release()
-			return nil, err
+			group.tables[name] = table
 		}
-		freezer.tables[name] = table
 	}
 	if err := freezer.repair(); err != nil {
-		for _, table := range freezer.tables {
-			table.Close()
-		}
+		freezer.closeTables()
 		lock.Release()
 		return nil, err
 	}
@@ -134,12 +138,7 @@ func newFreezer(datadir string, namespace string) (*freezer, error) {
 
 // Close terminates the chain freezer, unmapping all the data files.
 func (f *freezer) Close() error {
-	var errs []error
-	for _, table := range f.tables {
-		if err := table.Close(); err != nil {
-			errs = append(errs, err)
-		}
-	}
+	errs := f.closeTables()
 	if err := f.instanceLock.Release(); err != nil {
 		errs = append(errs, err)
 	}
@@ -149,10 +148,32 @@ func (f *freezer) Close() error {
 	return nil
 }
 
+// closeTables closes all block and epoch data tables.
+func (f *freezer) closeTables() []error {
+	var errs []error
+	for _, tables := range []map[string]*freezerTable{f.tables, f.epochTables} {
+		for _, table := range tables {
+			if err := table.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
+// table returns the block or epoch data table of the given kind, or nil if the
+// kind is unknown.
+func (f *freezer) table(kind string) *freezerTable {
+	if table := f.tables[kind]; table != nil {
+		return table
+	}
+	return f.epochTables[kind]
+}
+
 // HasAncient returns an indicator whether the specified ancient data exists
 // in the freezer.
 func (f *freezer) HasAncient(kind string, number uint64) (bool, error) {
-	if table := f.tables[kind]; table != nil {
+	if table := f.table(kind); table != nil {
 		return table.has(number), nil
 	}
 	return false, nil
@@ -160,7 +181,7 @@ func (f *freezer) HasAncient(kind string, number uint64) (bool, error) {
 
 // Ancient retrieves an ancient binary blob from the append-only immutable files.
 func (f *freezer) Ancient(kind string, number uint64) ([]byte, error) {
-	if table := f.tables[kind]; table != nil {
+	if table := f.table(kind); table != nil {
 		return table.Retrieve(number)
 	}
 	return nil, errUnknownTable
@@ -173,7 +194,7 @@ func (f *freezer) Ancients() (uint64, error) {
 
 // AncientSize returns the ancient size of the specified category.
 func (f *freezer) AncientSize(kind string) (uint64, error) {
-	if table := f.tables[kind]; table != nil {
+	if table := f.table(kind); table != nil {
 		return table.size()
 	}
 	return 0, errUnknownTable
@@ -237,15 +258,17 @@ func (f *freezer) TruncateAncients(items uint64) error {
 		}
 	}
 	atomic.StoreUint64(&f.frozen, items)
-	return nil
+	return f.repairEpochs()
 }
 
 // sync flushes all data tables to disk.
 func (f *freezer) Sync() error {
 	var errs []error
-	for _, table := range f.tables {
-		if err := table.Sync(); err != nil {
-			errs = append(errs, err)
+	for _, tables := range []map[string]*freezerTable{f.tables, f.epochTables} {
+		for _, table := range tables {
+			if err := table.Sync(); err != nil {
+				errs = append(errs, err)
+			}
 		}
 	}
 	if errs != nil {
@@ -368,6 +391,8 @@ func (f *freezer) freeze(db ethdb.KeyValueStore) {
 		if err := f.Sync(); err != nil {
 			log.Crit("Failed to flush frozen tables", "err", err)
 		}
+		// Move the data of the epochs whose last block got frozen too
+		f.freezeEpochs(db)
 		// Wipe out all data from the active database
 		batch := db.NewBatch()
 		for i := 0; i < len(ancients); i++ {
@@ -448,7 +473,117 @@ func (f *freezer) freeze(db ethdb.KeyValueStore) {
 	}
 }
 
-// repair truncates all data tables to the same length.
+// freezeEpochs moves the istanbul snapshot and the final uptime of every epoch
+// whose last block has been frozen from the key-value store into the freezer.
+// Entries missing from the key-value store are frozen as empty blobs, to keep
+// the epoch tables indexed by epoch number.
+func (f *freezer) freezeEpochs(db ethdb.KeyValueStore) {
+	epochSize := atomic.LoadUint64(&f.epochSize)
+	if epochSize == 0 {
+		// The chain config may have been stored after the freezer was opened
+		if epochSize = readEpochSize(db); epochSize == 0 {
+			return
+		}
+		atomic.StoreUint64(&f.epochSize, epochSize)
+	}
+	var (
+		first = atomic.LoadUint64(&f.epochTables[freezerUptimeTable].items)
+		limit = frozenEpochs(atomic.LoadUint64(&f.frozen), epochSize)
+		batch = db.NewBatch()
+	)
+	for epoch := first; epoch < limit; epoch++ {
+		hash, err := f.Ancient(freezerHashTable, epoch*epochSize)
+		if err != nil {
+			log.Error("Epoch block hash missing, can't freeze epoch", "epoch", epoch, "err", err)
+			break
+		}
+		snapshot, _ := db.Get(istanbulSnapshotKey(common.BytesToHash(hash)))
+		uptime, _ := db.Get(uptimeKey(epoch))
+
+		if err := f.epochTables[freezerIstanbulSnapshotTable].Append(epoch, snapshot); err != nil {
+			log.Error("Failed to append ancient istanbul snapshot", "epoch", epoch, "err", err)
+			break
+		}
+		if err := f.epochTables[freezerUptimeTable].Append(epoch, uptime); err != nil {
+			log.Error("Failed to append ancient uptime", "epoch", epoch, "err", err)
+			break
+		}
+		if len(snapshot) > 0 {
+			batch.Delete(istanbulSnapshotKey(common.BytesToHash(hash)))
+		}
+		if len(uptime) > 0 {
+			batch.Delete(uptimeKey(epoch))
+		}
+		log.Trace("Deep froze ancient epoch", "epoch", epoch, "snapshot", len(snapshot), "uptime", len(uptime))
+	}
+	// Realign the epoch tables after a failed append, then flush the frozen
+	// epochs before wiping them from leveldb
+	if err := f.repairEpochs(); err != nil {
+		log.Crit("Failed to repair epoch tables", "err", err)
+	}
+	if err := f.Sync(); err != nil {
+		log.Crit("Failed to flush frozen tables", "err", err)
+	}
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to delete frozen epoch data", "err", err)
+	}
+	if frozen := atomic.LoadUint64(&f.epochTables[freezerUptimeTable].items); frozen > first {
+		log.Info("Deep froze epoch data", "epochs", frozen-first, "epoch", frozen-1)
+	}
+}
+
+// repairEpochs truncates the epoch data tables to the same length, and to the
+// epochs whose last block has been frozen, if the epoch size is known.
+func (f *freezer) repairEpochs() error {
+	min := uint64(math.MaxUint64)
+	if epochSize := atomic.LoadUint64(&f.epochSize); epochSize != 0 {
+		min = frozenEpochs(atomic.LoadUint64(&f.frozen), epochSize)
+	}
+	for _, table := range f.epochTables {
+		if items := atomic.LoadUint64(&table.items); min > items {
+			min = items
+		}
+	}
+	for _, table := range f.epochTables {
+		if err := table.truncate(min); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setEpochSize sets the epoch size of the chain and drops any epoch data left
+// over from frozen blocks that were truncated before it was known.
+func (f *freezer) setEpochSize(epochSize uint64) error {
+	atomic.StoreUint64(&f.epochSize, epochSize)
+	return f.repairEpochs()
+}
+
+// frozenEpochs returns the number of epochs whose last block is among the
+// given number of frozen blocks. The genesis block is the last block of epoch
+// zero.
+func frozenEpochs(frozen uint64, epochSize uint64) uint64 {
+	if frozen == 0 {
+		return 0
+	}
+	return (frozen-1)/epochSize + 1
+}
+
+// readEpochSize returns the istanbul epoch size from the chain config stored in
+// the key-value database, or zero if there is none.
+func readEpochSize(db ethdb.KeyValueStore) uint64 {
+	hash := ReadCanonicalHash(&nofreezedb{KeyValueStore: db}, 0)
+	if hash == (common.Hash{}) {
+		return 0
+	}
+	if config := ReadChainConfig(db, hash); config != nil && config.Istanbul != nil {
+		return config.Istanbul.Epoch
+	}
+	return 0
+}
+
+// repair truncates all block data tables to the same length, and the epoch
+// data tables to the frozen blocks.
 func (f *freezer) repair() error {
 	min := uint64(math.MaxUint64)
 	for _, table := range f.tables {
@@ -463,5 +598,5 @@ func (f *freezer) repair() error {
 		}
 	}
 	atomic.StoreUint64(&f.frozen, min)
-	return nil
+	return f.repairEpochs()
 }
@@ -0,0 +1,108 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that the snapshots and uptimes of epochs whose last block is frozen are
+// moved into the freezer, and are still readable through the accessors.
+func TestFreezeEpochs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freezer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	f, err := newFreezer(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var (
+		kvdb      = memorydb.New()
+		db        = &freezerdb{KeyValueStore: kvdb, AncientStore: f}
+		epochSize = uint64(4)
+		hashes    []common.Hash
+	)
+	for number := uint64(0); number <= 10; number++ {
+		hash := common.Hash{byte(number + 1)}
+		hashes = append(hashes, hash)
+		WriteCanonicalHash(kvdb, hash, number)
+		WriteHeaderNumber(kvdb, hash, number)
+	}
+	WriteChainConfig(kvdb, hashes[0], &params.ChainConfig{Istanbul: &params.IstanbulConfig{Epoch: epochSize}})
+
+	for epoch := uint64(0); epoch <= 2; epoch++ {
+		WriteIstanbulSnapshot(kvdb, hashes[epoch*epochSize], []byte{byte(epoch), 0xff})
+		WriteAccumulatedEpochUptime(kvdb, epoch+1, &istanbul.Uptime{LatestBlock: (epoch + 1) * epochSize})
+	}
+	// Freeze blocks 0..8, i.e. the last blocks of epochs 0, 1 and 2
+	for number := uint64(0); number <= 8; number++ {
+		if err := f.AppendAncient(number, hashes[number][:], []byte{}, []byte{}, []byte{}, []byte{}); err != nil {
+			t.Fatalf("failed to freeze block %d: %v", number, err)
+		}
+	}
+	f.freezeEpochs(kvdb)
+
+	if items := f.epochTables[freezerIstanbulSnapshotTable].items; items != 3 {
+		t.Fatalf("frozen epoch count mismatch: have %d, want 3", items)
+	}
+	for epoch := uint64(0); epoch <= 2; epoch++ {
+		hash := hashes[epoch*epochSize]
+		if has, _ := kvdb.Has(istanbulSnapshotKey(hash)); has {
+			t.Errorf("epoch %d: snapshot not removed from the key-value store", epoch)
+		}
+		if snapshot := ReadIstanbulSnapshot(db, epochSize, hash); !bytes.Equal(snapshot, []byte{byte(epoch), 0xff}) {
+			t.Errorf("epoch %d: snapshot mismatch: have %x", epoch, snapshot)
+		}
+	}
+	// Only the uptime of the completed epochs is frozen
+	for epoch := uint64(1); epoch <= 3; epoch++ {
+		has, _ := kvdb.Has(uptimeKey(epoch))
+		if frozen := epoch <= 2; has == frozen {
+			t.Errorf("epoch %d: uptime in key-value store %v, frozen %v", epoch, has, frozen)
+		}
+		if uptime := ReadAccumulatedEpochUptime(db, epoch); uptime == nil || uptime.LatestBlock != epoch*epochSize {
+			t.Errorf("epoch %d: uptime mismatch: have %v", epoch, uptime)
+		}
+	}
+	// Frozen snapshots must not be served for blocks that aren't canonical
+	WriteHeaderNumber(kvdb, common.Hash{0xaa}, epochSize)
+	if snapshot := ReadIstanbulSnapshot(db, epochSize, common.Hash{0xaa}); snapshot != nil {
+		t.Errorf("snapshot returned for non canonical block: %x", snapshot)
+	}
+	// Truncating the frozen blocks drops the epochs whose last block was removed
+	if err := f.TruncateAncients(5); err != nil {
+		t.Fatal(err)
+	}
+	for _, table := range f.epochTables {
+		if table.items != 2 {
+			t.Errorf("truncated epoch count mismatch: have %d, want 2", table.items)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Categories of the chain database schema, as reported by KeyCategory.
+const (
+	CategoryHeader           = "Headers"
+	CategoryTd               = "Difficulties"
+	CategoryNumberHash       = "Block number->hash"
+	CategoryHashNumber       = "Block hash->number"
+	CategoryBody             = "Bodies"
+	CategoryReceipts         = "Receipts"
+	CategoryTxLookup         = "Transaction index"
+	CategoryBloomBits        = "Bloombit index"
+	CategoryPreimage         = "Trie preimages"
+	CategoryTrieNode         = "Trie nodes"
+	CategoryUptime           = "Accumulated uptime"
+	CategoryIstanbulSnapshot = "Istanbul snapshots"
+	CategoryChainConfig      = "Chain config"
+	CategoryCHTTrieNode      = "CHT trie nodes"
+	CategoryBloomTrieNode    = "Bloom trie nodes"
+	CategoryMetadata         = "Singleton metadata"
+)
+
+var errUnknownKey = errors.New("key does not belong to a known schema entry")
+
+// KeyCategory classifies a raw chain database key by the schema entry it belongs
+// to. An empty string is returned for keys not known to the schema.
+func KeyCategory(key []byte) string {
+	switch {
+	case bytes.HasPrefix(key, headerPrefix) && bytes.HasSuffix(key, headerTDSuffix) && len(key) == len(headerPrefix)+8+common.HashLength+len(headerTDSuffix):
+		return CategoryTd
+	case bytes.HasPrefix(key, headerPrefix) && bytes.HasSuffix(key, headerHashSuffix) && len(key) == len(headerPrefix)+8+len(headerHashSuffix):
+		return CategoryNumberHash
+	case bytes.HasPrefix(key, headerPrefix) && len(key) == (len(headerPrefix)+8+common.HashLength):
+		return CategoryHeader
+	case bytes.HasPrefix(key, headerNumberPrefix) && len(key) == (len(headerNumberPrefix)+common.HashLength):
+		return CategoryHashNumber
+	case bytes.HasPrefix(key, blockBodyPrefix) && len(key) == (len(blockBodyPrefix)+8+common.HashLength):
+		return CategoryBody
+	case bytes.HasPrefix(key, blockReceiptsPrefix) && len(key) == (len(blockReceiptsPrefix)+8+common.HashLength):
+		return CategoryReceipts
+	case bytes.HasPrefix(key, txLookupPrefix) && len(key) == (len(txLookupPrefix)+common.HashLength):
+		return CategoryTxLookup
+	case bytes.HasPrefix(key, preimagePrefix) && len(key) == (len(preimagePrefix)+common.HashLength):
+		return CategoryPreimage
+	case bytes.HasPrefix(key, bloomBitsPrefix) && len(key) == (len(bloomBitsPrefix)+10+common.HashLength):
+		return CategoryBloomBits
+	case bytes.HasPrefix(key, uptimePrefix) && len(key) == len(uptimePrefix)+8:
+		return CategoryUptime
+	case bytes.HasPrefix(key, istanbulSnapshotPrefix) && len(key) == len(istanbulSnapshotPrefix)+common.HashLength:
+		return CategoryIstanbulSnapshot
+	case bytes.HasPrefix(key, configPrefix) && len(key) == len(configPrefix)+common.HashLength:
+		return CategoryChainConfig
+	case bytes.HasPrefix(key, []byte("cht-")) && len(key) == 4+common.HashLength:
+		return CategoryCHTTrieNode
+	case bytes.HasPrefix(key, []byte("blt-")) && len(key) == 4+common.HashLength:
+		return CategoryBloomTrieNode
+	case len(key) == common.HashLength:
+		return CategoryTrieNode
+	}
+	for _, meta := range [][]byte{databaseVerisionKey, headHeaderKey, headBlockKey, headFastBlockKey, lastPivotKey, fastTrieProgressKey} {
+		if bytes.Equal(key, meta) {
+			return CategoryMetadata
+		}
+	}
+	return ""
+}
+
+// DecodeEntry decodes the value stored under a chain database key into a value
+// suitable for JSON output, based on the schema entry the key belongs to.
+func DecodeEntry(key []byte, value []byte) (interface{}, error) {
+	switch KeyCategory(key) {
+	case CategoryHeader:
+		header := new(types.Header)
+		if err := rlp.DecodeBytes(value, header); err != nil {
+			return nil, err
+		}
+		return header, nil
+
+	case CategoryTd:
+		td := new(big.Int)
+		if err := rlp.DecodeBytes(value, td); err != nil {
+			return nil, err
+		}
+		return (*hexutil.Big)(td), nil
+
+	case CategoryNumberHash:
+		return common.BytesToHash(value), nil
+
+	case CategoryHashNumber:
+		if len(value) != 8 {
+			return nil, errors.New("invalid block number length")
+		}
+		return hexutil.Uint64(binary.BigEndian.Uint64(value)), nil
+
+	case CategoryBody:
+		body := new(types.Body)
+		if err := rlp.DecodeBytes(value, body); err != nil {
+			return nil, err
+		}
+		return body, nil
+
+	case CategoryReceipts:
+		var stored []*types.ReceiptForStorage
+		if err := rlp.DecodeBytes(value, &stored); err != nil {
+			return nil, err
+		}
+		receipts := make([]*types.Receipt, len(stored))
+		for i, receipt := range stored {
+			receipts[i] = (*types.Receipt)(receipt)
+		}
+		return receipts, nil
+
+	case CategoryTxLookup:
+		// Same formats as handled by ReadTxLookupEntry, newest first
+		if len(value) < common.HashLength {
+			return map[string]interface{}{"blockNumber": hexutil.Uint64(new(big.Int).SetBytes(value).Uint64())}, nil
+		}
+		if len(value) == common.HashLength {
+			return map[string]interface{}{"blockHash": common.BytesToHash(value)}, nil
+		}
+		var entry LegacyTxLookupEntry
+		if err := rlp.DecodeBytes(value, &entry); err != nil {
+			return nil, err
+		}
+		return entry, nil
+
+	case CategoryUptime:
+		uptime := new(istanbul.Uptime)
+		if err := rlp.DecodeBytes(value, uptime); err != nil {
+			return nil, err
+		}
+		return uptime, nil
+
+	case CategoryIstanbulSnapshot, CategoryChainConfig:
+		return json.RawMessage(value), nil
+
+	case CategoryMetadata:
+		switch {
+		case bytes.Equal(key, databaseVerisionKey), bytes.Equal(key, lastPivotKey):
+			var number uint64
+			if err := rlp.DecodeBytes(value, &number); err != nil {
+				return nil, err
+			}
+			return hexutil.Uint64(number), nil
+		case bytes.Equal(key, fastTrieProgressKey):
+			return hexutil.Uint64(new(big.Int).SetBytes(value).Uint64()), nil
+		default:
+			return common.BytesToHash(value), nil
+		}
+
+	case CategoryPreimage, CategoryTrieNode, CategoryCHTTrieNode, CategoryBloomTrieNode, CategoryBloomBits:
+		return hexutil.Bytes(value), nil
+	}
+	return nil, errUnknownKey
+}
+
+// PrefixStat is the number and total size of the entries of one group of keys.
+type PrefixStat struct {
+	Group string
+	Count uint64
+	Size  common.StorageSize
+}
+
+// InspectPrefixes iterates over all keys starting with prefix and aggregates
+// their count and size per category, as reported by categorize. Keys without a
+// category, or all keys if categorize is nil, are grouped by their first
+// prefixLen bytes.
+func InspectPrefixes(db ethdb.Iteratee, prefix []byte, prefixLen int, categorize func(key []byte) string) []PrefixStat {
+	it := db.NewIteratorWithPrefix(prefix)
+	defer it.Release()
+
+	groups := make(map[string]*PrefixStat)
+	for it.Next() {
+		key := it.Key()
+
+		var group string
+		if categorize != nil {
+			group = categorize(key)
+		}
+		if group == "" {
+			n := prefixLen
+			if n > len(key) {
+				n = len(key)
+			}
+			group = hexutil.Encode(key[:n])
+		}
+		stat, ok := groups[group]
+		if !ok {
+			stat = &PrefixStat{Group: group}
+			groups[group] = stat
+		}
+		stat.Count++
+		stat.Size += common.StorageSize(len(key) + len(it.Value()))
+	}
+	stats := make([]PrefixStat, 0, len(groups))
+	for _, stat := range groups {
+		stats = append(stats, *stat)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Size > stats[j].Size })
+	return stats
+}
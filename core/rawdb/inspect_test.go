@@ -0,0 +1,104 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Tests that known schema keys are classified and decoded.
+func TestDecodeEntry(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	header := &types.Header{Number: big.NewInt(42), Extra: []byte("test header")}
+	uptime := &istanbul.Uptime{LatestBlock: 42, Entries: []istanbul.UptimeEntry{{ScoreTally: 7, LastSignedBlock: 41}}}
+	WriteHeader(db, header)
+	WriteTd(db, header.Hash(), 42, big.NewInt(43))
+	WriteCanonicalHash(db, header.Hash(), 42)
+	WriteAccumulatedEpochUptime(db, 3, uptime)
+
+	tests := []struct {
+		key      []byte
+		category string
+		decoded  interface{}
+	}{
+		{headerKey(42, header.Hash()), CategoryHeader, nil},
+		{headerTDKey(42, header.Hash()), CategoryTd, (*hexutil.Big)(big.NewInt(43))},
+		{headerHashKey(42), CategoryNumberHash, header.Hash()},
+		{headerNumberKey(header.Hash()), CategoryHashNumber, hexutil.Uint64(42)},
+		{uptimeKey(3), CategoryUptime, uptime},
+	}
+	for i, tt := range tests {
+		if category := KeyCategory(tt.key); category != tt.category {
+			t.Errorf("test %d: category mismatch: have %q, want %q", i, category, tt.category)
+			continue
+		}
+		value, err := db.Get(tt.key)
+		if err != nil {
+			t.Fatalf("test %d: entry missing: %v", i, err)
+		}
+		decoded, err := DecodeEntry(tt.key, value)
+		if err != nil {
+			t.Errorf("test %d: failed to decode: %v", i, err)
+			continue
+		}
+		if tt.decoded == nil {
+			if decoded.(*types.Header).Hash() != header.Hash() {
+				t.Errorf("test %d: header mismatch", i)
+			}
+		} else if !reflect.DeepEqual(decoded, tt.decoded) {
+			t.Errorf("test %d: decoded mismatch: have %v, want %v", i, decoded, tt.decoded)
+		}
+	}
+	if _, err := DecodeEntry([]byte("unknown"), nil); err != errUnknownKey {
+		t.Errorf("unknown key decoded: %v", err)
+	}
+}
+
+// Tests that entries are grouped by category and unknown keys by prefix.
+func TestInspectPrefixes(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	for i := uint64(0); i < 3; i++ {
+		WriteHeader(db, &types.Header{Number: new(big.Int).SetUint64(i)})
+	}
+	db.Put([]byte("zz-one"), []byte{1})
+	db.Put([]byte("zz-two"), []byte{2})
+
+	stats := InspectPrefixes(db, nil, 2, KeyCategory)
+	counts := make(map[string]uint64)
+	for _, stat := range stats {
+		counts[stat.Group] = stat.Count
+	}
+	if counts[CategoryHeader] != 3 {
+		t.Errorf("header count mismatch: have %d, want 3", counts[CategoryHeader])
+	}
+	if group := hexutil.Encode([]byte("zz")); counts[group] != 2 {
+		t.Errorf("unknown key count mismatch: have %d, want 2", counts[group])
+	}
+	// Restricting the prefix must only count matching keys
+	stats = InspectPrefixes(db, []byte("zz"), 2, nil)
+	if len(stats) != 1 || stats[0].Count != 2 {
+		t.Errorf("prefix restricted stats mismatch: %v", stats)
+	}
+}
@@ -53,6 +53,12 @@ var (
 	blockBodyPrefix     = []byte("b") // blockBodyPrefix + num (uint64 big endian) + hash -> block body
 	blockReceiptsPrefix = []byte("r") // blockReceiptsPrefix + num (uint64 big endian) + hash -> block receipts
 
+	uptimePrefix = []byte("uptime") // uptimePrefix + epoch (uint64 big endian) -> accumulated epoch uptime
+
+	// istanbulSnapshotPrefix must match the key the istanbul backend used before
+	// its snapshots were stored through rawdb.
+	istanbulSnapshotPrefix = []byte("istanbul-snapshot") // istanbulSnapshotPrefix + hash -> validator set snapshot
+
 	txLookupPrefix  = []byte("l") // txLookupPrefix + hash -> transaction/receipt lookup metadata
 	bloomBitsPrefix = []byte("B") // bloomBitsPrefix + bit (uint16 big endian) + section (uint64 big endian) + hash -> bloom bits
 
@@ -81,6 +87,14 @@ const (
 
 	// freezerDifficultyTable indicates the name of the freezer total difficulty table.
 	freezerDifficultyTable = "diffs"
+
+	// freezerIstanbulSnapshotTable indicates the name of the freezer table of
+	// validator set snapshots, indexed by epoch number.
+	freezerIstanbulSnapshotTable = "snapshots"
+
+	// freezerUptimeTable indicates the name of the freezer table of final epoch
+	// uptimes, indexed by epoch number.
+	freezerUptimeTable = "uptimes"
 )
 
 // freezerNoSnappy configures whether compression is disabled for the ancient-tables.
@@ -93,6 +107,14 @@ var freezerNoSnappy = map[string]bool{
 	freezerDifficultyTable: true,
 }
 
+// freezerEpochNoSnappy configures whether compression is disabled for the
+// ancient-tables holding per-epoch data. They are frozen once the last block of
+// an epoch is, and are not aligned with the block tables.
+var freezerEpochNoSnappy = map[string]bool{
+	freezerIstanbulSnapshotTable: false,
+	freezerUptimeTable:           false,
+}
+
 // LegacyTxLookupEntry is the legacy TxLookupEntry definition with some unnecessary
 // fields.
 type LegacyTxLookupEntry struct {
@@ -123,10 +145,15 @@ func headerTDKey(number uint64, hash common.Hash) []byte {
 	return append(headerKey(number, hash), headerTDSuffix...)
 }
 
+// istanbulSnapshotKey = istanbulSnapshotPrefix + hash
+func istanbulSnapshotKey(hash common.Hash) []byte {
+	return append(istanbulSnapshotPrefix, hash.Bytes()...)
+}
+
 // uptimeKey = uptimePrefix + epoch number
 func uptimeKey(epoch uint64) []byte {
 	// abuse encodeBlockNumber for epochs
-	return append([]byte("uptime"), encodeBlockNumber(epoch)...)
+	return append(uptimePrefix, encodeBlockNumber(epoch)...)
 }
 
 // headerHashKey = headerPrefix + num (uint64 big endian) + headerHashSuffix
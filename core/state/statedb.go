@@ -94,6 +94,10 @@ type StateDB struct {
 	validRevisions []revision
 	nextRevisionId int
 
+	// Original values of the accounts changed since StartDiff, nil if
+	// changes are not tracked.
+	diff *diffTracker
+
 	// Measurements gathered during execution for debugging purposes
 	AccountReads   time.Duration
 	AccountHashes  time.Duration
@@ -660,6 +664,11 @@ func (s *StateDB) GetRefund() uint64 {
 // the journal as well as the refunds. Finalise, however, will not push any updates
 // into the tries just yet. Only IntermediateRoot or Commit will do that.
 func (s *StateDB) Finalise(deleteEmptyObjects bool) {
+	// Collect the changes before accounts are deleted and the journal is dropped
+	if s.diff != nil {
+		s.diff.record(s, s.journal.entries)
+		s.diff.skip = 0
+	}
 	for addr := range s.journal.dirties {
 		obj, exist := s.stateObjects[addr]
 		if !exist {
@@ -0,0 +1,264 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BalanceDiff is the balance of an account before and after a state transition.
+type BalanceDiff struct {
+	From *hexutil.Big `json:"from"`
+	To   *hexutil.Big `json:"to"`
+}
+
+// NonceDiff is the nonce of an account before and after a state transition.
+type NonceDiff struct {
+	From hexutil.Uint64 `json:"from"`
+	To   hexutil.Uint64 `json:"to"`
+}
+
+// CodeDiff is the code hash of an account before and after a state transition.
+type CodeDiff struct {
+	From common.Hash `json:"from"`
+	To   common.Hash `json:"to"`
+}
+
+// StorageDiff is the value of a storage slot before and after a state transition.
+type StorageDiff struct {
+	From common.Hash `json:"from"`
+	To   common.Hash `json:"to"`
+}
+
+// AccountDiff lists the fields of an account changed by a state transition.
+// Fields left unchanged are nil.
+type AccountDiff struct {
+	Created bool                         `json:"created,omitempty"`
+	Deleted bool                         `json:"deleted,omitempty"`
+	Balance *BalanceDiff                 `json:"balance,omitempty"`
+	Nonce   *NonceDiff                   `json:"nonce,omitempty"`
+	Code    *CodeDiff                    `json:"code,omitempty"`
+	Storage map[common.Hash]*StorageDiff `json:"storage,omitempty"`
+}
+
+// StateDiff is the set of accounts changed by a state transition.
+type StateDiff map[common.Address]*AccountDiff
+
+// diffOrigin is the value of an account before the first change tracked by a
+// diffTracker. Fields are only set once the corresponding value has been
+// changed, only the first (i.e. original) value is kept.
+type diffOrigin struct {
+	existed  bool
+	reset    *stateObject // Object replaced by a recreation of the account
+	balance  *big.Int
+	nonce    *uint64
+	codeHash *common.Hash
+	storage  map[common.Hash]common.Hash
+}
+
+// diffTracker collects the original values of all accounts changed since
+// StartDiff, from the journal entries that survive until the state is
+// finalised.
+type diffTracker struct {
+	origins map[common.Address]*diffOrigin
+	skip    int // Number of journal entries that predate the tracking
+}
+
+func newDiffTracker(skip int) *diffTracker {
+	return &diffTracker{origins: make(map[common.Address]*diffOrigin), skip: skip}
+}
+
+// origin returns the tracked origin of addr, creating it if needed. The
+// existence of a newly tracked account is taken from the pre-state, i.e. the
+// state before the recorded journal entries were applied.
+func (t *diffTracker) origin(addr common.Address, pre *preState) *diffOrigin {
+	origin, ok := t.origins[addr]
+	if !ok {
+		origin = &diffOrigin{existed: pre.exists(addr), storage: make(map[common.Hash]common.Hash)}
+		t.origins[addr] = origin
+	}
+	return origin
+}
+
+// preState answers whether an account existed before a range of journal
+// entries was applied. Accounts created or recreated in the range existed if
+// the first (re)creation replaced a live object, any other account existed if
+// it is still present in the state.
+type preState struct {
+	state   *StateDB
+	created map[common.Address]bool
+}
+
+func newPreState(state *StateDB, entries []journalEntry) *preState {
+	pre := &preState{state: state, created: make(map[common.Address]bool)}
+	for _, entry := range entries {
+		switch ch := entry.(type) {
+		case createObjectChange:
+			if _, ok := pre.created[*ch.account]; !ok {
+				pre.created[*ch.account] = false
+			}
+		case resetObjectChange:
+			if _, ok := pre.created[ch.prev.address]; !ok {
+				pre.created[ch.prev.address] = !ch.prev.deleted
+			}
+		}
+	}
+	return pre
+}
+
+func (pre *preState) exists(addr common.Address) bool {
+	if existed, ok := pre.created[addr]; ok {
+		return existed
+	}
+	obj := pre.state.getDeletedStateObject(addr)
+	return obj != nil && !obj.deleted
+}
+
+// record adds the original values of the given journal entries to the tracker.
+// Entries recorded before only add values not tracked yet, so the journal may
+// be recorded again until it is cleared.
+func (t *diffTracker) record(state *StateDB, entries []journalEntry) {
+	pre := newPreState(state, entries)
+	if t.skip > len(entries) {
+		t.skip = len(entries)
+	}
+	for _, entry := range entries[t.skip:] {
+		switch ch := entry.(type) {
+		case createObjectChange:
+			t.origin(*ch.account, pre)
+		case resetObjectChange:
+			if _, ok := t.origins[ch.prev.address]; ok {
+				continue
+			}
+			if origin := t.origin(ch.prev.address, pre); origin.existed {
+				nonce, codeHash := ch.prev.data.Nonce, common.BytesToHash(ch.prev.data.CodeHash)
+				origin.reset = ch.prev
+				origin.balance = new(big.Int).Set(ch.prev.data.Balance)
+				origin.nonce = &nonce
+				origin.codeHash = &codeHash
+			}
+		case suicideChange:
+			if origin := t.origin(*ch.account, pre); origin.balance == nil {
+				origin.balance = new(big.Int).Set(ch.prevbalance)
+			}
+		case balanceChange:
+			if origin := t.origin(*ch.account, pre); origin.balance == nil {
+				origin.balance = new(big.Int).Set(ch.prev)
+			}
+		case nonceChange:
+			if origin := t.origin(*ch.account, pre); origin.nonce == nil {
+				nonce := ch.prev
+				origin.nonce = &nonce
+			}
+		case codeChange:
+			if origin := t.origin(*ch.account, pre); origin.codeHash == nil {
+				codeHash := common.BytesToHash(ch.prevhash)
+				origin.codeHash = &codeHash
+			}
+		case storageChange:
+			origin := t.origin(*ch.account, pre)
+			if _, ok := origin.storage[ch.key]; ok {
+				continue
+			}
+			// Slots of a recreated account read as empty, the original value is
+			// the one held by the replaced object.
+			if origin.reset != nil {
+				origin.storage[ch.key] = origin.reset.GetState(state.db, ch.key)
+			} else {
+				origin.storage[ch.key] = ch.prevalue
+			}
+		case touchChange:
+			t.origin(*ch.account, pre)
+		}
+	}
+}
+
+// StartDiff starts tracking the changes made to the state, discarding any
+// previously tracked changes. The changes are collected by StateDiff.
+func (s *StateDB) StartDiff() {
+	s.diff = newDiffTracker(s.journal.length())
+}
+
+// StateDiff returns the accounts and storage slots changed since StartDiff was
+// called, along with their original and current values, including changes not
+// yet finalised. Accounts that were changed back to their original value are
+// omitted. It returns nil if StartDiff was not called.
+func (s *StateDB) StateDiff() StateDiff {
+	if s.diff == nil {
+		return nil
+	}
+	s.diff.record(s, s.journal.entries)
+
+	diff := make(StateDiff)
+	for addr, origin := range s.diff.origins {
+		obj := s.getDeletedStateObject(addr)
+		exists := obj != nil && !obj.deleted && !obj.suicided
+		if !origin.existed && !exists {
+			continue
+		}
+		account := &AccountDiff{
+			Created: !origin.existed || origin.reset != nil,
+			Deleted: origin.existed && !exists,
+		}
+		if origin.balance != nil {
+			balance := new(big.Int)
+			if exists {
+				balance = obj.Balance()
+			}
+			if origin.balance.Cmp(balance) != 0 {
+				account.Balance = &BalanceDiff{From: (*hexutil.Big)(origin.balance), To: (*hexutil.Big)(new(big.Int).Set(balance))}
+			}
+		}
+		if origin.nonce != nil {
+			var nonce uint64
+			if exists {
+				nonce = obj.Nonce()
+			}
+			if *origin.nonce != nonce {
+				account.Nonce = &NonceDiff{From: hexutil.Uint64(*origin.nonce), To: hexutil.Uint64(nonce)}
+			}
+		}
+		if origin.codeHash != nil {
+			codeHash := common.BytesToHash(emptyCodeHash)
+			if exists {
+				codeHash = common.BytesToHash(obj.CodeHash())
+			}
+			if *origin.codeHash != codeHash {
+				account.Code = &CodeDiff{From: *origin.codeHash, To: codeHash}
+			}
+		}
+		for key, from := range origin.storage {
+			var to common.Hash
+			if exists {
+				to = obj.GetState(s.db, key)
+			}
+			if from != to {
+				if account.Storage == nil {
+					account.Storage = make(map[common.Hash]*StorageDiff)
+				}
+				account.Storage[key] = &StorageDiff{From: from, To: to}
+			}
+		}
+		if account.Created || account.Deleted || account.Balance != nil || account.Nonce != nil || account.Code != nil || account.Storage != nil {
+			diff[addr] = account
+		}
+	}
+	return diff
+}
@@ -0,0 +1,141 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Tests that the state diff reports original and final values across multiple
+// finalised transactions, ignoring reverted and undone changes.
+func TestStateDiff(t *testing.T) {
+	state, _ := New(common.Hash{}, NewDatabase(rawdb.NewMemoryDatabase()))
+
+	var (
+		kept     = common.Address{0x01}
+		created  = common.Address{0x02}
+		killed   = common.Address{0x03}
+		restored = common.Address{0x04}
+		reverted = common.Address{0x05}
+		slot     = common.Hash{0xaa}
+	)
+	for _, addr := range []common.Address{kept, killed, restored} {
+		state.SetBalance(addr, big.NewInt(10))
+		state.SetNonce(addr, 1)
+	}
+	state.SetState(kept, slot, common.Hash{0x01})
+	root, _ := state.Commit(true)
+	state, _ = New(root, state.db)
+	state.StartDiff()
+
+	// First transaction
+	state.AddBalance(kept, big.NewInt(5))
+	state.SetState(kept, slot, common.Hash{0x02})
+	state.SetCode(created, []byte{0x60})
+	state.AddBalance(created, big.NewInt(1))
+	state.SetBalance(restored, big.NewInt(20))
+	state.Finalise(true)
+
+	// Second transaction
+	state.SetState(kept, slot, common.Hash{0x03})
+	state.SetNonce(kept, 2)
+	state.Suicide(killed)
+	state.SetBalance(restored, big.NewInt(10))
+	snapshot := state.Snapshot()
+	state.AddBalance(reverted, big.NewInt(1))
+	state.RevertToSnapshot(snapshot)
+
+	diff := state.StateDiff()
+	if len(diff) != 3 {
+		t.Fatalf("changed account count mismatch: have %d, want 3", len(diff))
+	}
+	account := diff[kept]
+	if account == nil || account.Created || account.Deleted || account.Code != nil {
+		t.Fatalf("kept account diff mismatch: %+v", account)
+	}
+	if account.Balance.From.ToInt().Int64() != 10 || account.Balance.To.ToInt().Int64() != 15 {
+		t.Errorf("balance diff mismatch: have %v -> %v, want 10 -> 15", account.Balance.From, account.Balance.To)
+	}
+	if account.Nonce == nil || account.Nonce.From != 1 || account.Nonce.To != 2 {
+		t.Errorf("nonce diff mismatch: have %+v", account.Nonce)
+	}
+	if storage := account.Storage[slot]; storage == nil || storage.From != (common.Hash{0x01}) || storage.To != (common.Hash{0x03}) {
+		t.Errorf("storage diff mismatch: have %+v", storage)
+	}
+	account = diff[created]
+	if account == nil || !account.Created || account.Balance == nil || account.Code == nil {
+		t.Fatalf("created account diff mismatch: %+v", account)
+	}
+	if account.Code.From != common.BytesToHash(emptyCodeHash) || account.Code.To != crypto.Keccak256Hash([]byte{0x60}) {
+		t.Errorf("code diff mismatch: have %+v", account.Code)
+	}
+	account = diff[killed]
+	if account == nil || !account.Deleted || account.Balance == nil || account.Balance.To.ToInt().Sign() != 0 {
+		t.Fatalf("deleted account diff mismatch: %+v", account)
+	}
+	// Tracking is restarted from the current state
+	state.StartDiff()
+	state.Finalise(true)
+	if diff := state.StateDiff(); len(diff) != 0 {
+		t.Errorf("restarted diff not empty: %v", diff)
+	}
+}
+
+// Tests that accounts only touched since tracking started are reported based
+// on whether they existed beforehand, rather than assumed to have existed.
+func TestStateDiffTouched(t *testing.T) {
+	state, _ := New(common.Hash{}, NewDatabase(rawdb.NewMemoryDatabase()))
+
+	var (
+		empty   = common.Address{0x01}
+		fresh   = common.Address{0x02}
+		ripemd  = common.BytesToAddress([]byte{0x03})
+		missing = common.Address{0x04}
+	)
+	// Keep an empty account in the trie
+	state.CreateAccount(empty)
+	root, _ := state.Commit(false)
+	state, _ = New(root, state.db)
+
+	// Create an account before tracking starts, then only touch it
+	state.CreateAccount(fresh)
+	state.StartDiff()
+	state.AddBalance(empty, new(big.Int))
+	state.AddBalance(fresh, new(big.Int))
+
+	// Touch a nonexistent account in a reverted call, the ripemd way
+	snapshot := state.Snapshot()
+	state.AddBalance(ripemd, new(big.Int))
+	state.RevertToSnapshot(snapshot)
+
+	// Read a nonexistent account without touching it
+	state.GetBalance(missing)
+	state.Finalise(true)
+
+	diff := state.StateDiff()
+	if len(diff) != 1 {
+		t.Fatalf("changed account count mismatch: have %d, want 1: %v", len(diff), diff)
+	}
+	if account := diff[empty]; account == nil || !account.Deleted || account.Created {
+		t.Errorf("empty account diff mismatch: %+v", account)
+	}
+}
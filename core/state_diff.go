@@ -0,0 +1,56 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// BlockStateDiff is the set of accounts and storage slots changed by a block,
+// including the changes made by the consensus engine when finalizing it.
+type BlockStateDiff struct {
+	Number   hexutil.Uint64  `json:"number"`
+	Hash     common.Hash     `json:"hash"`
+	Root     common.Hash     `json:"stateRoot"`
+	Accounts state.StateDiff `json:"accounts"`
+}
+
+// DiffBlock re-executes block on top of statedb, which must hold the state of
+// the block's parent, and returns the changes the block made. On success the
+// state has been advanced to the one of the block, with all changes finalised.
+func DiffBlock(config *params.ChainConfig, processor Processor, block *types.Block, statedb *state.StateDB) (*BlockStateDiff, error) {
+	statedb.StartDiff()
+	if _, _, _, err := processor.Process(block, statedb, vm.Config{}); err != nil {
+		return nil, fmt.Errorf("processing block %d failed: %v", block.NumberU64(), err)
+	}
+	if root := statedb.IntermediateRoot(config.IsEIP158(block.Number())); root != block.Root() {
+		return nil, fmt.Errorf("state root mismatch in block %d: have %x, want %x", block.NumberU64(), root, block.Root())
+	}
+	return &BlockStateDiff{
+		Number:   hexutil.Uint64(block.NumberU64()),
+		Hash:     block.Hash(),
+		Root:     block.Root(),
+		Accounts: statedb.StateDiff(),
+	}, nil
+}
@@ -68,6 +68,8 @@ type StateTransition struct {
 	state           vm.StateDB
 	evm             *vm.EVM
 	gasPriceMinimum *big.Int
+	vmerr           error    // Error the EVM execution ended with, if any
+	fee             *big.Int // Fee charged to the sender net of the refund, once distributed
 }
 
 // IntrinsicGas computes the 'intrinsic gas' for a message with the given data.
@@ -153,6 +155,16 @@ func ApplyMessage(evm *vm.EVM, msg vm.Message, gp *GasPool) ([]byte, uint64, boo
 	return NewStateTransition(evm, msg, gp).TransitionDb()
 }
 
+// ApplyMessageWithFee applies the message like ApplyMessage, additionally
+// returning the fee charged to the sender in the fee currency net of the
+// refund: the gas used at the gas price, less the base fee if it's refunded,
+// plus the gateway fee. It is nil if the message couldn't be applied.
+func ApplyMessageWithFee(evm *vm.EVM, msg vm.Message, gp *GasPool) ([]byte, uint64, bool, *big.Int, error) {
+	st := NewStateTransition(evm, msg, gp)
+	ret, gas, failed, err := st.TransitionDb()
+	return ret, gas, failed, st.fee, err
+}
+
 // NewStateTransitionGasEstimator returns a special state transition for estimating gas consumption.
 // Estimation runs the given message as if gas were free, which allows binary search under the
 // assumption that the execution is not dependent on gas limit or gas price, with the exception of
@@ -248,11 +260,8 @@ func (st *StateTransition) debitGas(address common.Address, amount *big.Int, fee
 	functionSelector := hexutil.MustDecode("0x58cf9672")
 	transactionData := common.GetEncodedAbi(functionSelector, [][]byte{common.AddressToAbi(address), common.AmountToAbi(amount)})
 
-	// Run only primary evm.Call() with tracer
-	if evm.GetDebug() {
-		evm.SetDebug(false)
-		defer func() { evm.SetDebug(true) }()
-	}
+	// Run only primary evm.Call() with tracer, the debit is a system call
+	defer evm.StartSystemCall(vm.SystemCallFeeDebit)()
 
 	rootCaller := vm.AccountRef(common.HexToAddress("0x0"))
 	// The caller was already charged for the cost of this operation via IntrinsicGas.
@@ -277,11 +286,8 @@ func (st *StateTransition) creditGasFees(
 	functionSelector := hexutil.MustDecode("0x6a30b253")
 	transactionData := common.GetEncodedAbi(functionSelector, [][]byte{common.AddressToAbi(from), common.AddressToAbi(feeRecipient), common.AddressToAbi(*gatewayFeeRecipient), common.AddressToAbi(*communityFund), common.AmountToAbi(refund), common.AmountToAbi(tipTxFee), common.AmountToAbi(gatewayFee), common.AmountToAbi(baseTxFee)})
 
-	// Run only primary evm.Call() with tracer
-	if evm.GetDebug() {
-		evm.SetDebug(false)
-		defer func() { evm.SetDebug(true) }()
-	}
+	// Run only primary evm.Call() with tracer, the credit is a system call
+	defer evm.StartSystemCall(vm.SystemCallFeeCredit)()
 
 	rootCaller := vm.AccountRef(common.HexToAddress("0x0"))
 	// The caller was already charged for the cost of this operation via IntrinsicGas.
@@ -375,6 +381,7 @@ func (st *StateTransition) TransitionDb() (ret []byte, usedGas uint64, failed bo
 		st.state.SetNonce(msg.From(), st.state.GetNonce(sender.Address())+1)
 		ret, st.gas, vmerr = evm.Call(sender, st.to(), st.data, st.gas, st.value)
 	}
+	st.vmerr = vmerr
 	if vmerr != nil {
 		log.Debug("VM returned with error", "err", vmerr)
 		// The only possible consensus-error would be if there wasn't
@@ -395,14 +402,15 @@ func (st *StateTransition) TransitionDb() (ret []byte, usedGas uint64, failed bo
 	return ret, st.gasUsed(), vmerr != nil, nil
 }
 
+// VMError returns the error the EVM execution of the message ended with after
+// TransitionDb, e.g. vm.ErrExecutionReverted if it hit a REVERT, in which case
+// the returned bytes are the revert data.
+func (st *StateTransition) VMError() error {
+	return st.vmerr
+}
+
 // distributeTxFees calculates the amounts and recipients of transaction fees and credits the accounts.
 func (st *StateTransition) distributeTxFees() error {
-	// Run only primary evm.Call() with tracer
-	if st.evm.GetDebug() {
-		st.evm.SetDebug(false)
-		defer func() { st.evm.SetDebug(true) }()
-	}
-
 	// Determine the refund and transaction fee to be distributed.
 	refund := new(big.Int).Mul(new(big.Int).SetUint64(st.gas), st.gasPrice)
 	gasUsed := new(big.Int).SetUint64(st.gasUsed())
@@ -419,7 +427,11 @@ func (st *StateTransition) distributeTxFees() error {
 		gatewayFeeRecipient = &common.ZeroAddress
 	}
 
+	// Run only primary evm.Call() with tracer
+	debug := st.evm.GetDebug()
+	st.evm.SetDebug(false)
 	governanceAddress, err := vm.GetRegisteredAddressWithEvm(params.GovernanceRegistryId, st.evm)
+	st.evm.SetDebug(debug)
 	if err != nil && err != commerrs.ErrSmartContractNotDeployed && err != commerrs.ErrRegistryContractNotDeployed {
 		return err
 	} else if err != nil {
@@ -449,6 +461,12 @@ func (st *StateTransition) distributeTxFees() error {
 		}
 
 	}
+	// The sender paid for the gas used, but the refunded base fee, and the
+	// gateway fee
+	st.fee = new(big.Int).Add(tipTxFee, baseTxFee)
+	if st.msg.GatewayFeeRecipient() != nil {
+		st.fee.Add(st.fee, st.msg.GatewayFee())
+	}
 	return nil
 }
 
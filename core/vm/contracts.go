@@ -17,6 +17,7 @@
 package vm
 
 import (
+	"crypto/ed25519"
 	"crypto/sha256"
 	"encoding/binary"
 	"errors"
@@ -37,8 +38,10 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 
+	"golang.org/x/crypto/blake2s"
 	//lint:ignore SA1019 Needed for precompile
 	"golang.org/x/crypto/ripemd160"
+	"golang.org/x/crypto/sha3"
 )
 
 // PrecompiledContract is the basic interface for native Go contracts. The implementation
@@ -75,6 +78,30 @@ var (
 	hashHeaderAddress            = celoPrecompileAddress(9)
 	getParentSealBitmapAddress   = celoPrecompileAddress(10)
 	getVerifiedSealBitmapAddress = celoPrecompileAddress(11)
+
+	// New in Donut hard fork
+	sha3_512Address           = celoPrecompileAddress(12)
+	keccak512Address          = celoPrecompileAddress(13)
+	blake2sAddress            = celoPrecompileAddress(14)
+	ed25519VerifyAddress      = celoPrecompileAddress(15)
+	bls12377G1AddAddress      = celoPrecompileAddress(16)
+	bls12377G1MulAddress      = celoPrecompileAddress(17)
+	bls12377G1MultiExpAddress = celoPrecompileAddress(18)
+	bls12377G2AddAddress      = celoPrecompileAddress(19)
+	bls12377G2MulAddress      = celoPrecompileAddress(20)
+	bls12377G2MultiExpAddress = celoPrecompileAddress(21)
+	bls12377PairingAddress    = celoPrecompileAddress(22)
+	bls12377MapG1Address      = celoPrecompileAddress(23)
+	bls12377MapG2Address      = celoPrecompileAddress(24)
+	bls12381G1AddAddress      = celoPrecompileAddress(25)
+	bls12381G1MulAddress      = celoPrecompileAddress(26)
+	bls12381G1MultiExpAddress = celoPrecompileAddress(27)
+	bls12381G2AddAddress      = celoPrecompileAddress(28)
+	bls12381G2MulAddress      = celoPrecompileAddress(29)
+	bls12381G2MultiExpAddress = celoPrecompileAddress(30)
+	bls12381PairingAddress    = celoPrecompileAddress(31)
+	bls12381MapG1Address      = celoPrecompileAddress(32)
+	bls12381MapG2Address      = celoPrecompileAddress(33)
 )
 
 // PrecompiledContractsByzantium contains the default set of pre-compiled Ethereum
@@ -128,6 +155,56 @@ var PrecompiledContractsIstanbul = map[common.Address]PrecompiledContract{
 	getVerifiedSealBitmapAddress: &getVerifiedSealBitmap{},
 }
 
+// PrecompiledContractsDonut contains the default set of pre-compiled contracts
+// used in the Donut release.
+var PrecompiledContractsDonut = map[common.Address]PrecompiledContract{
+	common.BytesToAddress([]byte{1}): &ecrecover{},
+	common.BytesToAddress([]byte{2}): &sha256hash{},
+	common.BytesToAddress([]byte{3}): &ripemd160hash{},
+	common.BytesToAddress([]byte{4}): &dataCopy{},
+	common.BytesToAddress([]byte{5}): &bigModExp{},
+	common.BytesToAddress([]byte{6}): &bn256AddIstanbul{},
+	common.BytesToAddress([]byte{7}): &bn256ScalarMulIstanbul{},
+	common.BytesToAddress([]byte{8}): &bn256PairingIstanbul{},
+	common.BytesToAddress([]byte{9}): &blake2F{},
+
+	// Celo Precompiled Contracts
+	transferAddress:              &transfer{},
+	fractionMulExpAddress:        &fractionMulExp{},
+	proofOfPossessionAddress:     &proofOfPossession{},
+	getValidatorAddress:          &getValidator{},
+	numberValidatorsAddress:      &numberValidators{},
+	epochSizeAddress:             &epochSize{},
+	blockNumberFromHeaderAddress: &blockNumberFromHeader{},
+	hashHeaderAddress:            &hashHeader{},
+	getParentSealBitmapAddress:   &getParentSealBitmap{},
+	getVerifiedSealBitmapAddress: &getVerifiedSealBitmap{},
+
+	// New in Donut hard fork
+	sha3_512Address:           &sha3_512hash{},
+	keccak512Address:          &keccak512hash{},
+	blake2sAddress:            &blake2shash{},
+	ed25519VerifyAddress:      &ed25519Verify{},
+	bls12377G1AddAddress:      &bls12377G1Add{},
+	bls12377G1MulAddress:      &bls12377G1Mul{},
+	bls12377G1MultiExpAddress: &bls12377G1MultiExp{},
+	bls12377G2AddAddress:      &bls12377G2Add{},
+	bls12377G2MulAddress:      &bls12377G2Mul{},
+	bls12377G2MultiExpAddress: &bls12377G2MultiExp{},
+	bls12377PairingAddress:    &bls12377Pairing{},
+	bls12377MapG1Address:      &bls12377MapG1{},
+	bls12377MapG2Address:      &bls12377MapG2{},
+	bls12381G1AddAddress:      &bls12381G1Add{},
+	bls12381G1MulAddress:      &bls12381G1Mul{},
+	bls12381G1MultiExpAddress: &bls12381G1MultiExp{},
+	bls12381G2AddAddress:      &bls12381G2Add{},
+	bls12381G2MulAddress:      &bls12381G2Mul{},
+	bls12381G2MultiExpAddress: &bls12381G2MultiExp{},
+	bls12381PairingAddress:    &bls12381Pairing{},
+	bls12381MapG1Address:      &bls12381MapG1{},
+	bls12381MapG2Address:      &bls12381MapG2{},
+}
+
 // RunPrecompiledContract runs and evaluates the output of a precompiled contract.
 func RunPrecompiledContract(p PrecompiledContract, input []byte, contract *Contract, evm *EVM) (ret []byte, err error) {
 	log.Trace("Running precompiled contract", "codeaddr", contract.CodeAddr, "input", input, "caller", contract.CallerAddress, "gas", contract.Gas)
@@ -1064,3 +1141,101 @@ func (c *getVerifiedSealBitmap) Run(input []byte, caller common.Address, evm *EV
 
 	return common.LeftPadBytes(extra.AggregatedSeal.Bitmap.Bytes()[:], 32), gas, nil
 }
+
+// SHA3-512 implemented as a native contract.
+type sha3_512hash struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+//
+// This method does not require any overflow checking as the input size gas costs
+// required for anything significant is so high it's impossible to pay for.
+func (c *sha3_512hash) RequiredGas(input []byte) uint64 {
+	return uint64(len(input)+31)/32*params.Sha3_512PerWordGas + params.Sha3_512BaseGas
+}
+
+func (c *sha3_512hash) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	h := sha3.Sum512(input)
+	return h[:], gas, nil
+}
+
+// Keccak-512 implemented as a native contract.
+type keccak512hash struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+//
+// This method does not require any overflow checking as the input size gas costs
+// required for anything significant is so high it's impossible to pay for.
+func (c *keccak512hash) RequiredGas(input []byte) uint64 {
+	return uint64(len(input)+31)/32*params.Keccak512PerWordGas + params.Keccak512BaseGas
+}
+
+func (c *keccak512hash) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	hasher := sha3.NewLegacyKeccak512()
+	hasher.Write(input)
+	return hasher.Sum(nil), gas, nil
+}
+
+// BLAKE2s-256 implemented as a native contract.
+type blake2shash struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+//
+// This method does not require any overflow checking as the input size gas costs
+// required for anything significant is so high it's impossible to pay for.
+func (c *blake2shash) RequiredGas(input []byte) uint64 {
+	return uint64(len(input)+31)/32*params.Blake2sPerWordGas + params.Blake2sBaseGas
+}
+
+func (c *blake2shash) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	h := blake2s.Sum256(input)
+	return h[:], gas, nil
+}
+
+// ed25519Verify is a precompile to verify an Ed25519 signature over a message.
+type ed25519Verify struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+//
+// The signed message gets hashed during verification, so its length is charged
+// on top of the base price.
+func (c *ed25519Verify) RequiredGas(input []byte) uint64 {
+	return uint64(len(input)+31)/32*params.Ed25519VerifyPerWordGas + params.Ed25519VerifyGas
+}
+
+func (c *ed25519Verify) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of 3 arguments:
+	//   publicKey: 32 bytes, the Ed25519 public key
+	//   signature: 64 bytes, the Ed25519 signature
+	//   message:   the remaining bytes, the signed message
+	if len(input) < ed25519.PublicKeySize+ed25519.SignatureSize {
+		return nil, gas, ErrInputLength
+	}
+	publicKey := input[:ed25519.PublicKeySize]
+	signature := input[ed25519.PublicKeySize : ed25519.PublicKeySize+ed25519.SignatureSize]
+	message := input[ed25519.PublicKeySize+ed25519.SignatureSize:]
+
+	if !ed25519.Verify(publicKey, message, signature) {
+		return false32Byte, gas, nil
+	}
+	return true32Byte, gas, nil
+}
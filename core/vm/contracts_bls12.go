@@ -0,0 +1,91 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// The BLS12-377 and BLS12-381 precompiles share the following input and output
+// encoding, all integers being big endian:
+//
+//	field element: 64 bytes, the top 16 of which must be zero, and a value
+//	               lower than the modulus of the base field
+//	Fp2 element:   128 bytes, the encodings of c0 and c1 of c0 + c1 * u, where
+//	               u^2 = 5 for BLS12-377 and u^2 = -1 for BLS12-381
+//	G1 point:      128 bytes, the encodings of the affine x and y coordinates
+//	G2 point:      256 bytes, the Fp2 encodings of the affine x and y coordinates
+//	scalar:        32 bytes, reduced modulo the order of the groups
+//
+// G2 is defined over the twists y^2 = x^3 + 1/u for BLS12-377 and
+// y^2 = x^3 + 4(1 + u) for BLS12-381. The point at infinity is encoded as all
+// zeros. Points must be on the curve, and points multiplied by a scalar or
+// paired must also be in the prime order subgroup.
+const (
+	bls12FieldElementLength  = 64
+	bls12FieldElementPadding = 16
+	bls12G1PointLength       = 2 * bls12FieldElementLength
+	bls12G2PointLength       = 4 * bls12FieldElementLength
+	bls12ScalarLength        = 32
+)
+
+var (
+	errBLS12InvalidFieldElementTopBytes = errors.New("invalid field element top bytes")
+	errBLS12InvalidFieldElement         = errors.New("invalid field element")
+	errBLS12PointNotOnCurve             = errors.New("point is not on curve")
+	errBLS12PointNotInSubgroup          = errors.New("point is not in the correct subgroup")
+)
+
+// decodeBLS12FieldElement strips the padding of an encoded field element of a
+// base field with the given modulus, checking it's in its canonical form.
+func decodeBLS12FieldElement(in []byte, modulus *big.Int) ([]byte, error) {
+	for _, b := range in[:bls12FieldElementPadding] {
+		if b != 0 {
+			return nil, errBLS12InvalidFieldElementTopBytes
+		}
+	}
+	out := in[bls12FieldElementPadding:bls12FieldElementLength]
+	if new(big.Int).SetBytes(out).Cmp(modulus) >= 0 {
+		return nil, errBLS12InvalidFieldElement
+	}
+	return out, nil
+}
+
+// encodeBLS12FieldElement pads a 48 byte field element to its encoded length.
+func encodeBLS12FieldElement(out []byte, in [48]byte) {
+	copy(out[bls12FieldElementPadding:bls12FieldElementLength], in[:])
+}
+
+// bls12MultiExpGas returns the gas required for a multi exponentiation over
+// input of pairs of the given length, each pair costing a discounted scalar
+// multiplication.
+func bls12MultiExpGas(input []byte, pairLength int, mulGas uint64) uint64 {
+	k := len(input) / pairLength
+	if k == 0 {
+		return 0
+	}
+	var discount uint64
+	if k <= len(params.Bls12MultiExpDiscountTable) {
+		discount = params.Bls12MultiExpDiscountTable[k-1]
+	} else {
+		discount = params.Bls12MultiExpDiscountTable[len(params.Bls12MultiExpDiscountTable)-1]
+	}
+	return uint64(k) * mulGas * discount / 1000
+}
@@ -0,0 +1,409 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"math/big"
+
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// decodeBLS12377FieldElement decodes an encoded BLS12-377 base field element.
+func decodeBLS12377FieldElement(z *fp.Element, in []byte) error {
+	b, err := decodeBLS12FieldElement(in, fp.Modulus())
+	if err != nil {
+		return err
+	}
+	z.SetBytes(b)
+	return nil
+}
+
+// decodeBLS12377G1 decodes an encoded BLS12-377 G1 point, checking it's on the
+// curve.
+func decodeBLS12377G1(in []byte) (*bls12377.G1Affine, error) {
+	p := new(bls12377.G1Affine)
+	if err := decodeBLS12377FieldElement(&p.X, in[:bls12FieldElementLength]); err != nil {
+		return nil, err
+	}
+	if err := decodeBLS12377FieldElement(&p.Y, in[bls12FieldElementLength:]); err != nil {
+		return nil, err
+	}
+	if !p.IsOnCurve() {
+		return nil, errBLS12PointNotOnCurve
+	}
+	return p, nil
+}
+
+// decodeBLS12377G2 decodes an encoded BLS12-377 G2 point, checking it's on the
+// curve.
+func decodeBLS12377G2(in []byte) (*bls12377.G2Affine, error) {
+	p := new(bls12377.G2Affine)
+	for i, z := range []*fp.Element{&p.X.A0, &p.X.A1, &p.Y.A0, &p.Y.A1} {
+		if err := decodeBLS12377FieldElement(z, in[i*bls12FieldElementLength:(i+1)*bls12FieldElementLength]); err != nil {
+			return nil, err
+		}
+	}
+	if !p.IsOnCurve() {
+		return nil, errBLS12PointNotOnCurve
+	}
+	return p, nil
+}
+
+// encodeBLS12377G1 encodes a BLS12-377 G1 point.
+func encodeBLS12377G1(p *bls12377.G1Affine) []byte {
+	out := make([]byte, bls12G1PointLength)
+	encodeBLS12FieldElement(out, p.X.Bytes())
+	encodeBLS12FieldElement(out[bls12FieldElementLength:], p.Y.Bytes())
+	return out
+}
+
+// encodeBLS12377G2 encodes a BLS12-377 G2 point.
+func encodeBLS12377G2(p *bls12377.G2Affine) []byte {
+	out := make([]byte, bls12G2PointLength)
+	for i, z := range []*fp.Element{&p.X.A0, &p.X.A1, &p.Y.A0, &p.Y.A1} {
+		encodeBLS12FieldElement(out[i*bls12FieldElementLength:], z.Bytes())
+	}
+	return out
+}
+
+// decodeBLS12377Scalar decodes a scalar, reducing it modulo the group order.
+func decodeBLS12377Scalar(in []byte) *big.Int {
+	s := new(big.Int).SetBytes(in[:bls12ScalarLength])
+	return s.Mod(s, fr.Modulus())
+}
+
+// bls12377G1Add implements BLS12-377 G1 point addition.
+type bls12377G1Add struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12377G1Add) RequiredGas(input []byte) uint64 {
+	return params.Bls12G1AddGas
+}
+
+func (c *bls12377G1Add) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of 2 G1 points
+	if len(input) != 2*bls12G1PointLength {
+		return nil, gas, ErrInputLength
+	}
+	p0, err := decodeBLS12377G1(input[:bls12G1PointLength])
+	if err != nil {
+		return nil, gas, err
+	}
+	p1, err := decodeBLS12377G1(input[bls12G1PointLength:])
+	if err != nil {
+		return nil, gas, err
+	}
+	var r, q bls12377.G1Jac
+	r.FromAffine(p0)
+	q.FromAffine(p1)
+	r.AddAssign(&q)
+
+	return encodeBLS12377G1(new(bls12377.G1Affine).FromJacobian(&r)), gas, nil
+}
+
+// bls12377G1Mul implements BLS12-377 G1 point scalar multiplication.
+type bls12377G1Mul struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12377G1Mul) RequiredGas(input []byte) uint64 {
+	return params.Bls12G1MulGas
+}
+
+func (c *bls12377G1Mul) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of a G1 point and a scalar
+	if len(input) != bls12G1PointLength+bls12ScalarLength {
+		return nil, gas, ErrInputLength
+	}
+	p, err := decodeBLS12377G1(input[:bls12G1PointLength])
+	if err != nil {
+		return nil, gas, err
+	}
+	if !p.IsInSubGroup() {
+		return nil, gas, errBLS12PointNotInSubgroup
+	}
+	p.ScalarMultiplication(p, decodeBLS12377Scalar(input[bls12G1PointLength:]))
+
+	return encodeBLS12377G1(p), gas, nil
+}
+
+// bls12377G1MultiExp implements BLS12-377 G1 multi exponentiation.
+type bls12377G1MultiExp struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12377G1MultiExp) RequiredGas(input []byte) uint64 {
+	return bls12MultiExpGas(input, bls12G1PointLength+bls12ScalarLength, params.Bls12G1MulGas)
+}
+
+func (c *bls12377G1MultiExp) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of k > 0 pairs of a G1 point and a scalar
+	const pairLength = bls12G1PointLength + bls12ScalarLength
+	k := len(input) / pairLength
+	if len(input) == 0 || len(input)%pairLength != 0 {
+		return nil, gas, ErrInputLength
+	}
+	points := make([]bls12377.G1Affine, k)
+	scalars := make([]fr.Element, k)
+	for i := 0; i < k; i++ {
+		offset := i * pairLength
+		p, err := decodeBLS12377G1(input[offset : offset+bls12G1PointLength])
+		if err != nil {
+			return nil, gas, err
+		}
+		if !p.IsInSubGroup() {
+			return nil, gas, errBLS12PointNotInSubgroup
+		}
+		points[i] = *p
+		// The multi exponentiation expects scalars in regular, non Montgomery form
+		scalars[i].SetBytes(input[offset+bls12G1PointLength : offset+pairLength]).FromMont()
+	}
+	r := new(bls12377.G1Affine).MultiExp(points, scalars)
+
+	return encodeBLS12377G1(r), gas, nil
+}
+
+// bls12377G2Add implements BLS12-377 G2 point addition.
+type bls12377G2Add struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12377G2Add) RequiredGas(input []byte) uint64 {
+	return params.Bls12G2AddGas
+}
+
+func (c *bls12377G2Add) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of 2 G2 points
+	if len(input) != 2*bls12G2PointLength {
+		return nil, gas, ErrInputLength
+	}
+	p0, err := decodeBLS12377G2(input[:bls12G2PointLength])
+	if err != nil {
+		return nil, gas, err
+	}
+	p1, err := decodeBLS12377G2(input[bls12G2PointLength:])
+	if err != nil {
+		return nil, gas, err
+	}
+	var r, q bls12377.G2Jac
+	r.FromAffine(p0)
+	q.FromAffine(p1)
+	r.AddAssign(&q)
+
+	return encodeBLS12377G2(new(bls12377.G2Affine).FromJacobian(&r)), gas, nil
+}
+
+// bls12377G2Mul implements BLS12-377 G2 point scalar multiplication.
+type bls12377G2Mul struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12377G2Mul) RequiredGas(input []byte) uint64 {
+	return params.Bls12G2MulGas
+}
+
+func (c *bls12377G2Mul) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of a G2 point and a scalar
+	if len(input) != bls12G2PointLength+bls12ScalarLength {
+		return nil, gas, ErrInputLength
+	}
+	p, err := decodeBLS12377G2(input[:bls12G2PointLength])
+	if err != nil {
+		return nil, gas, err
+	}
+	if !p.IsInSubGroup() {
+		return nil, gas, errBLS12PointNotInSubgroup
+	}
+	p.ScalarMultiplication(p, decodeBLS12377Scalar(input[bls12G2PointLength:]))
+
+	return encodeBLS12377G2(p), gas, nil
+}
+
+// bls12377G2MultiExp implements BLS12-377 G2 multi exponentiation.
+type bls12377G2MultiExp struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12377G2MultiExp) RequiredGas(input []byte) uint64 {
+	return bls12MultiExpGas(input, bls12G2PointLength+bls12ScalarLength, params.Bls12G2MulGas)
+}
+
+func (c *bls12377G2MultiExp) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of k > 0 pairs of a G2 point and a scalar
+	const pairLength = bls12G2PointLength + bls12ScalarLength
+	k := len(input) / pairLength
+	if len(input) == 0 || len(input)%pairLength != 0 {
+		return nil, gas, ErrInputLength
+	}
+	points := make([]bls12377.G2Affine, k)
+	scalars := make([]fr.Element, k)
+	for i := 0; i < k; i++ {
+		offset := i * pairLength
+		p, err := decodeBLS12377G2(input[offset : offset+bls12G2PointLength])
+		if err != nil {
+			return nil, gas, err
+		}
+		if !p.IsInSubGroup() {
+			return nil, gas, errBLS12PointNotInSubgroup
+		}
+		points[i] = *p
+		// The multi exponentiation expects scalars in regular, non Montgomery form
+		scalars[i].SetBytes(input[offset+bls12G2PointLength : offset+pairLength]).FromMont()
+	}
+	r := new(bls12377.G2Affine).MultiExp(points, scalars)
+
+	return encodeBLS12377G2(r), gas, nil
+}
+
+// bls12377Pairing implements a BLS12-377 pairing check.
+type bls12377Pairing struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12377Pairing) RequiredGas(input []byte) uint64 {
+	return params.Bls12PairingBaseGas + uint64(len(input)/(bls12G1PointLength+bls12G2PointLength))*params.Bls12PairingPerPairGas
+}
+
+func (c *bls12377Pairing) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of k > 0 pairs of a G1 and a G2 point, the result
+	// being whether the product of their pairings is one
+	const pairLength = bls12G1PointLength + bls12G2PointLength
+	k := len(input) / pairLength
+	if len(input) == 0 || len(input)%pairLength != 0 {
+		return nil, gas, ErrInputLength
+	}
+	g1 := make([]bls12377.G1Affine, k)
+	g2 := make([]bls12377.G2Affine, k)
+	for i := 0; i < k; i++ {
+		offset := i * pairLength
+		p1, err := decodeBLS12377G1(input[offset : offset+bls12G1PointLength])
+		if err != nil {
+			return nil, gas, err
+		}
+		p2, err := decodeBLS12377G2(input[offset+bls12G1PointLength : offset+pairLength])
+		if err != nil {
+			return nil, gas, err
+		}
+		if !p1.IsInSubGroup() || !p2.IsInSubGroup() {
+			return nil, gas, errBLS12PointNotInSubgroup
+		}
+		g1[i], g2[i] = *p1, *p2
+	}
+	ok, err := bls12377.PairingCheck(g1, g2)
+	if err != nil {
+		return nil, gas, err
+	}
+	if ok {
+		return true32Byte, gas, nil
+	}
+	return false32Byte, gas, nil
+}
+
+// bls12377MapG1 implements the mapping of a BLS12-377 base field element to a G1
+// point, using the Shallue-van de Woestijne method followed by clearing the
+// cofactor.
+type bls12377MapG1 struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12377MapG1) RequiredGas(input []byte) uint64 {
+	return params.Bls12MapG1Gas
+}
+
+func (c *bls12377MapG1) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of a single field element
+	if len(input) != bls12FieldElementLength {
+		return nil, gas, ErrInputLength
+	}
+	var u fp.Element
+	if err := decodeBLS12377FieldElement(&u, input); err != nil {
+		return nil, gas, err
+	}
+	p := bls12377.MapToCurveG1Svdw(u)
+	p.ClearCofactor(&p)
+
+	return encodeBLS12377G1(&p), gas, nil
+}
+
+// bls12377MapG2 implements the mapping of a BLS12-377 Fp2 element to a G2 point,
+// using the Shallue-van de Woestijne method followed by clearing the cofactor.
+type bls12377MapG2 struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12377MapG2) RequiredGas(input []byte) uint64 {
+	return params.Bls12MapG2Gas
+}
+
+func (c *bls12377MapG2) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of a single Fp2 element
+	if len(input) != 2*bls12FieldElementLength {
+		return nil, gas, ErrInputLength
+	}
+	// The Fp2 type isn't exported, so decode it into a point coordinate
+	var u bls12377.G2Affine
+	if err := decodeBLS12377FieldElement(&u.X.A0, input[:bls12FieldElementLength]); err != nil {
+		return nil, gas, err
+	}
+	if err := decodeBLS12377FieldElement(&u.X.A1, input[bls12FieldElementLength:]); err != nil {
+		return nil, gas, err
+	}
+	p := bls12377.MapToCurveG2Svdw(u.X)
+
+	return encodeBLS12377G2(&p), gas, nil
+}
@@ -0,0 +1,404 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// decodeBLS12381FieldElement decodes an encoded BLS12-381 base field element.
+func decodeBLS12381FieldElement(z *fp.Element, in []byte) error {
+	b, err := decodeBLS12FieldElement(in, fp.Modulus())
+	if err != nil {
+		return err
+	}
+	z.SetBytes(b)
+	return nil
+}
+
+// decodeBLS12381G1 decodes an encoded BLS12-381 G1 point, checking it's on the
+// curve.
+func decodeBLS12381G1(in []byte) (*bls12381.G1Affine, error) {
+	p := new(bls12381.G1Affine)
+	if err := decodeBLS12381FieldElement(&p.X, in[:bls12FieldElementLength]); err != nil {
+		return nil, err
+	}
+	if err := decodeBLS12381FieldElement(&p.Y, in[bls12FieldElementLength:]); err != nil {
+		return nil, err
+	}
+	if !p.IsOnCurve() {
+		return nil, errBLS12PointNotOnCurve
+	}
+	return p, nil
+}
+
+// decodeBLS12381G2 decodes an encoded BLS12-381 G2 point, checking it's on the
+// curve.
+func decodeBLS12381G2(in []byte) (*bls12381.G2Affine, error) {
+	p := new(bls12381.G2Affine)
+	for i, z := range []*fp.Element{&p.X.A0, &p.X.A1, &p.Y.A0, &p.Y.A1} {
+		if err := decodeBLS12381FieldElement(z, in[i*bls12FieldElementLength:(i+1)*bls12FieldElementLength]); err != nil {
+			return nil, err
+		}
+	}
+	if !p.IsOnCurve() {
+		return nil, errBLS12PointNotOnCurve
+	}
+	return p, nil
+}
+
+// encodeBLS12381G1 encodes a BLS12-381 G1 point.
+func encodeBLS12381G1(p *bls12381.G1Affine) []byte {
+	out := make([]byte, bls12G1PointLength)
+	encodeBLS12FieldElement(out, p.X.Bytes())
+	encodeBLS12FieldElement(out[bls12FieldElementLength:], p.Y.Bytes())
+	return out
+}
+
+// encodeBLS12381G2 encodes a BLS12-381 G2 point.
+func encodeBLS12381G2(p *bls12381.G2Affine) []byte {
+	out := make([]byte, bls12G2PointLength)
+	for i, z := range []*fp.Element{&p.X.A0, &p.X.A1, &p.Y.A0, &p.Y.A1} {
+		encodeBLS12FieldElement(out[i*bls12FieldElementLength:], z.Bytes())
+	}
+	return out
+}
+
+// decodeBLS12381Scalar decodes a scalar, reducing it modulo the group order.
+func decodeBLS12381Scalar(in []byte) *big.Int {
+	s := new(big.Int).SetBytes(in[:bls12ScalarLength])
+	return s.Mod(s, fr.Modulus())
+}
+
+// bls12381G1Add implements BLS12-381 G1 point addition.
+type bls12381G1Add struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381G1Add) RequiredGas(input []byte) uint64 {
+	return params.Bls12G1AddGas
+}
+
+func (c *bls12381G1Add) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of 2 G1 points
+	if len(input) != 2*bls12G1PointLength {
+		return nil, gas, ErrInputLength
+	}
+	p0, err := decodeBLS12381G1(input[:bls12G1PointLength])
+	if err != nil {
+		return nil, gas, err
+	}
+	p1, err := decodeBLS12381G1(input[bls12G1PointLength:])
+	if err != nil {
+		return nil, gas, err
+	}
+	var r, q bls12381.G1Jac
+	r.FromAffine(p0)
+	q.FromAffine(p1)
+	r.AddAssign(&q)
+
+	return encodeBLS12381G1(new(bls12381.G1Affine).FromJacobian(&r)), gas, nil
+}
+
+// bls12381G1Mul implements BLS12-381 G1 point scalar multiplication.
+type bls12381G1Mul struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381G1Mul) RequiredGas(input []byte) uint64 {
+	return params.Bls12G1MulGas
+}
+
+func (c *bls12381G1Mul) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of a G1 point and a scalar
+	if len(input) != bls12G1PointLength+bls12ScalarLength {
+		return nil, gas, ErrInputLength
+	}
+	p, err := decodeBLS12381G1(input[:bls12G1PointLength])
+	if err != nil {
+		return nil, gas, err
+	}
+	if !p.IsInSubGroup() {
+		return nil, gas, errBLS12PointNotInSubgroup
+	}
+	p.ScalarMultiplication(p, decodeBLS12381Scalar(input[bls12G1PointLength:]))
+
+	return encodeBLS12381G1(p), gas, nil
+}
+
+// bls12381G1MultiExp implements BLS12-381 G1 multi exponentiation.
+type bls12381G1MultiExp struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381G1MultiExp) RequiredGas(input []byte) uint64 {
+	return bls12MultiExpGas(input, bls12G1PointLength+bls12ScalarLength, params.Bls12G1MulGas)
+}
+
+func (c *bls12381G1MultiExp) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of k > 0 pairs of a G1 point and a scalar
+	const pairLength = bls12G1PointLength + bls12ScalarLength
+	k := len(input) / pairLength
+	if len(input) == 0 || len(input)%pairLength != 0 {
+		return nil, gas, ErrInputLength
+	}
+	points := make([]bls12381.G1Affine, k)
+	scalars := make([]fr.Element, k)
+	for i := 0; i < k; i++ {
+		offset := i * pairLength
+		p, err := decodeBLS12381G1(input[offset : offset+bls12G1PointLength])
+		if err != nil {
+			return nil, gas, err
+		}
+		if !p.IsInSubGroup() {
+			return nil, gas, errBLS12PointNotInSubgroup
+		}
+		points[i] = *p
+		// The multi exponentiation expects scalars in regular, non Montgomery form
+		scalars[i].SetBytes(input[offset+bls12G1PointLength : offset+pairLength]).FromMont()
+	}
+	r := new(bls12381.G1Affine).MultiExp(points, scalars)
+
+	return encodeBLS12381G1(r), gas, nil
+}
+
+// bls12381G2Add implements BLS12-381 G2 point addition.
+type bls12381G2Add struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381G2Add) RequiredGas(input []byte) uint64 {
+	return params.Bls12G2AddGas
+}
+
+func (c *bls12381G2Add) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of 2 G2 points
+	if len(input) != 2*bls12G2PointLength {
+		return nil, gas, ErrInputLength
+	}
+	p0, err := decodeBLS12381G2(input[:bls12G2PointLength])
+	if err != nil {
+		return nil, gas, err
+	}
+	p1, err := decodeBLS12381G2(input[bls12G2PointLength:])
+	if err != nil {
+		return nil, gas, err
+	}
+	var r, q bls12381.G2Jac
+	r.FromAffine(p0)
+	q.FromAffine(p1)
+	r.AddAssign(&q)
+
+	return encodeBLS12381G2(new(bls12381.G2Affine).FromJacobian(&r)), gas, nil
+}
+
+// bls12381G2Mul implements BLS12-381 G2 point scalar multiplication.
+type bls12381G2Mul struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381G2Mul) RequiredGas(input []byte) uint64 {
+	return params.Bls12G2MulGas
+}
+
+func (c *bls12381G2Mul) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of a G2 point and a scalar
+	if len(input) != bls12G2PointLength+bls12ScalarLength {
+		return nil, gas, ErrInputLength
+	}
+	p, err := decodeBLS12381G2(input[:bls12G2PointLength])
+	if err != nil {
+		return nil, gas, err
+	}
+	if !p.IsInSubGroup() {
+		return nil, gas, errBLS12PointNotInSubgroup
+	}
+	p.ScalarMultiplication(p, decodeBLS12381Scalar(input[bls12G2PointLength:]))
+
+	return encodeBLS12381G2(p), gas, nil
+}
+
+// bls12381G2MultiExp implements BLS12-381 G2 multi exponentiation.
+type bls12381G2MultiExp struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381G2MultiExp) RequiredGas(input []byte) uint64 {
+	return bls12MultiExpGas(input, bls12G2PointLength+bls12ScalarLength, params.Bls12G2MulGas)
+}
+
+func (c *bls12381G2MultiExp) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of k > 0 pairs of a G2 point and a scalar
+	const pairLength = bls12G2PointLength + bls12ScalarLength
+	k := len(input) / pairLength
+	if len(input) == 0 || len(input)%pairLength != 0 {
+		return nil, gas, ErrInputLength
+	}
+	points := make([]bls12381.G2Affine, k)
+	scalars := make([]fr.Element, k)
+	for i := 0; i < k; i++ {
+		offset := i * pairLength
+		p, err := decodeBLS12381G2(input[offset : offset+bls12G2PointLength])
+		if err != nil {
+			return nil, gas, err
+		}
+		if !p.IsInSubGroup() {
+			return nil, gas, errBLS12PointNotInSubgroup
+		}
+		points[i] = *p
+		// The multi exponentiation expects scalars in regular, non Montgomery form
+		scalars[i].SetBytes(input[offset+bls12G2PointLength : offset+pairLength]).FromMont()
+	}
+	r := new(bls12381.G2Affine).MultiExp(points, scalars)
+
+	return encodeBLS12381G2(r), gas, nil
+}
+
+// bls12381Pairing implements a BLS12-381 pairing check.
+type bls12381Pairing struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381Pairing) RequiredGas(input []byte) uint64 {
+	return params.Bls12PairingBaseGas + uint64(len(input)/(bls12G1PointLength+bls12G2PointLength))*params.Bls12PairingPerPairGas
+}
+
+func (c *bls12381Pairing) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of k > 0 pairs of a G1 and a G2 point, the result
+	// being whether the product of their pairings is one
+	const pairLength = bls12G1PointLength + bls12G2PointLength
+	k := len(input) / pairLength
+	if len(input) == 0 || len(input)%pairLength != 0 {
+		return nil, gas, ErrInputLength
+	}
+	g1 := make([]bls12381.G1Affine, k)
+	g2 := make([]bls12381.G2Affine, k)
+	for i := 0; i < k; i++ {
+		offset := i * pairLength
+		p1, err := decodeBLS12381G1(input[offset : offset+bls12G1PointLength])
+		if err != nil {
+			return nil, gas, err
+		}
+		p2, err := decodeBLS12381G2(input[offset+bls12G1PointLength : offset+pairLength])
+		if err != nil {
+			return nil, gas, err
+		}
+		if !p1.IsInSubGroup() || !p2.IsInSubGroup() {
+			return nil, gas, errBLS12PointNotInSubgroup
+		}
+		g1[i], g2[i] = *p1, *p2
+	}
+	ok, err := bls12381.PairingCheck(g1, g2)
+	if err != nil {
+		return nil, gas, err
+	}
+	if ok {
+		return true32Byte, gas, nil
+	}
+	return false32Byte, gas, nil
+}
+
+// bls12381MapG1 implements the mapping of a BLS12-381 base field element to a G1
+// point as specified by EIP-2537: the simplified SWU map onto an 11-isogenous
+// curve, followed by the isogeny and clearing the cofactor.
+type bls12381MapG1 struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381MapG1) RequiredGas(input []byte) uint64 {
+	return params.Bls12MapG1Gas
+}
+
+func (c *bls12381MapG1) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of a single field element
+	if len(input) != bls12FieldElementLength {
+		return nil, gas, ErrInputLength
+	}
+	var u fp.Element
+	if err := decodeBLS12381FieldElement(&u, input); err != nil {
+		return nil, gas, err
+	}
+	return encodeBLS12381G1(bls12381MapToG1(&u)), gas, nil
+}
+
+// bls12381MapG2 implements the mapping of a BLS12-381 Fp2 element to a G2 point
+// as specified by EIP-2537: the simplified SWU map onto a 3-isogenous curve,
+// followed by the isogeny and clearing the cofactor.
+type bls12381MapG2 struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381MapG2) RequiredGas(input []byte) uint64 {
+	return params.Bls12MapG2Gas
+}
+
+func (c *bls12381MapG2) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of a single Fp2 element
+	if len(input) != 2*bls12FieldElementLength {
+		return nil, gas, ErrInputLength
+	}
+	var u bls12381Fp2
+	if err := decodeBLS12381FieldElement(&u[0], input[:bls12FieldElementLength]); err != nil {
+		return nil, gas, err
+	}
+	if err := decodeBLS12381FieldElement(&u[1], input[bls12FieldElementLength:]); err != nil {
+		return nil, gas, err
+	}
+	return encodeBLS12381G2(bls12381MapToG2(&u)), gas, nil
+}
@@ -0,0 +1,321 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	fp377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	fr377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	fp381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	fr381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The tests in this file fuzz the BLS12 precompiles with random inputs and
+// compare their results against a naive affine implementation over big
+// integers. The field to curve mappings are checked against the EIP-2537
+// vectors in contracts_test.go instead.
+
+const bls12FuzzRounds = 16
+
+// refCurve is a naive implementation of the groups of a BLS12 curve. Elements
+// of Fp2 = Fp[u]/(u^2 - beta) are pairs of big integers, elements of Fp having
+// a zero second component.
+type refCurve struct {
+	p, r *big.Int
+	beta *big.Int
+	g1   refPoint
+	g2   refPoint
+}
+
+type refFp2 [2]*big.Int
+
+type refPoint struct {
+	x, y refFp2
+	inf  bool
+}
+
+func (c *refCurve) mod(a *big.Int) *big.Int {
+	return a.Mod(a, c.p)
+}
+
+func (c *refCurve) add(a, b refFp2) refFp2 {
+	return refFp2{c.mod(new(big.Int).Add(a[0], b[0])), c.mod(new(big.Int).Add(a[1], b[1]))}
+}
+
+func (c *refCurve) sub(a, b refFp2) refFp2 {
+	return refFp2{c.mod(new(big.Int).Sub(a[0], b[0])), c.mod(new(big.Int).Sub(a[1], b[1]))}
+}
+
+func (c *refCurve) mul(a, b refFp2) refFp2 {
+	// (a0 + a1 u)(b0 + b1 u) = a0 b0 + beta a1 b1 + (a0 b1 + a1 b0) u
+	c0 := new(big.Int).Mul(a[1], b[1])
+	c0.Mul(c0, c.beta).Add(c0, new(big.Int).Mul(a[0], b[0]))
+	c1 := new(big.Int).Mul(a[0], b[1])
+	c1.Add(c1, new(big.Int).Mul(a[1], b[0]))
+	return refFp2{c.mod(c0), c.mod(c1)}
+}
+
+func (c *refCurve) inv(a refFp2) refFp2 {
+	// 1 / (a0 + a1 u) = (a0 - a1 u) / (a0^2 - beta a1^2)
+	norm := new(big.Int).Mul(a[1], a[1])
+	norm.Mul(norm, c.beta).Sub(new(big.Int).Mul(a[0], a[0]), norm)
+	norm.ModInverse(c.mod(norm), c.p)
+	return refFp2{c.mod(new(big.Int).Mul(a[0], norm)), c.mod(new(big.Int).Neg(new(big.Int).Mul(a[1], norm)))}
+}
+
+func (c *refCurve) small(n int64) refFp2 {
+	return refFp2{big.NewInt(n), new(big.Int)}
+}
+
+func (a refFp2) equal(b refFp2) bool {
+	return a[0].Cmp(b[0]) == 0 && a[1].Cmp(b[1]) == 0
+}
+
+func (c *refCurve) addPoints(a, b refPoint) refPoint {
+	switch {
+	case a.inf:
+		return b
+	case b.inf:
+		return a
+	case a.x.equal(b.x):
+		if !a.y.equal(b.y) || (a.y[0].Sign() == 0 && a.y[1].Sign() == 0) {
+			return refPoint{inf: true}
+		}
+		return c.double(a)
+	}
+	lambda := c.mul(c.sub(b.y, a.y), c.inv(c.sub(b.x, a.x)))
+	return c.line(a, b, lambda)
+}
+
+func (c *refCurve) double(a refPoint) refPoint {
+	lambda := c.mul(c.mul(c.small(3), c.mul(a.x, a.x)), c.inv(c.mul(c.small(2), a.y)))
+	return c.line(a, a, lambda)
+}
+
+func (c *refCurve) line(a, b refPoint, lambda refFp2) refPoint {
+	x := c.sub(c.sub(c.mul(lambda, lambda), a.x), b.x)
+	y := c.sub(c.mul(lambda, c.sub(a.x, x)), a.y)
+	return refPoint{x: x, y: y}
+}
+
+func (c *refCurve) neg(a refPoint) refPoint {
+	if a.inf {
+		return a
+	}
+	return refPoint{x: a.x, y: c.sub(c.small(0), a.y)}
+}
+
+func (c *refCurve) scalarMul(a refPoint, s *big.Int) refPoint {
+	s = new(big.Int).Mod(s, c.r)
+	r := refPoint{inf: true}
+	for i := s.BitLen() - 1; i >= 0; i-- {
+		r = c.addPoints(r, r)
+		if s.Bit(i) == 1 {
+			r = c.addPoints(r, a)
+		}
+	}
+	return r
+}
+
+// encode encodes a point in the precompile format, the G1 ones in 128 bytes and
+// the G2 ones in 256 bytes.
+func (c *refCurve) encode(a refPoint, g2 bool) []byte {
+	var coords []*big.Int
+	if g2 {
+		coords = []*big.Int{a.x[0], a.x[1], a.y[0], a.y[1]}
+	} else {
+		coords = []*big.Int{a.x[0], a.y[0]}
+	}
+	out := make([]byte, len(coords)*bls12FieldElementLength)
+	if a.inf {
+		return out
+	}
+	for i, z := range coords {
+		fillBytes(out[i*bls12FieldElementLength:(i+1)*bls12FieldElementLength], z)
+	}
+	return out
+}
+
+// fillBytes writes a big endian integer right aligned into out.
+func fillBytes(out []byte, z *big.Int) {
+	b := z.Bytes()
+	copy(out[len(out)-len(b):], b)
+}
+
+func (c *refCurve) decode(in []byte, g2 bool) refPoint {
+	if bytes.Equal(in, make([]byte, len(in))) {
+		return refPoint{inf: true}
+	}
+	elem := func(i int) *big.Int {
+		return new(big.Int).SetBytes(in[i*bls12FieldElementLength : (i+1)*bls12FieldElementLength])
+	}
+	if g2 {
+		return refPoint{x: refFp2{elem(0), elem(1)}, y: refFp2{elem(2), elem(3)}}
+	}
+	return refPoint{x: refFp2{elem(0), new(big.Int)}, y: refFp2{elem(1), new(big.Int)}}
+}
+
+func newRefCurve(p, r *big.Int, beta int64, g1, g2 []byte) *refCurve {
+	c := &refCurve{p: p, r: r, beta: big.NewInt(beta)}
+	c.g1 = c.decode(g1, false)
+	c.g2 = c.decode(g2, true)
+	return c
+}
+
+func newRefBLS12377() *refCurve {
+	_, _, g1, g2 := bls12377.Generators()
+	return newRefCurve(fp377.Modulus(), fr377.Modulus(), 5, encodeBLS12377G1(&g1), encodeBLS12377G2(&g2))
+}
+
+func newRefBLS12381() *refCurve {
+	_, _, g1, g2 := bls12381.Generators()
+	return newRefCurve(fp381.Modulus(), fr381.Modulus(), -1, encodeBLS12381G1(&g1), encodeBLS12381G2(&g2))
+}
+
+// bls12FuzzAddresses are the addresses of the precompiles of one curve.
+type bls12FuzzAddresses struct {
+	g1Add, g1Mul, g1MultiExp, g2Add, g2Mul, g2MultiExp, pairing, mapG1, mapG2 common.Address
+}
+
+var (
+	bls12377FuzzAddresses = bls12FuzzAddresses{
+		bls12377G1AddAddress, bls12377G1MulAddress, bls12377G1MultiExpAddress,
+		bls12377G2AddAddress, bls12377G2MulAddress, bls12377G2MultiExpAddress,
+		bls12377PairingAddress, bls12377MapG1Address, bls12377MapG2Address,
+	}
+	bls12381FuzzAddresses = bls12FuzzAddresses{
+		bls12381G1AddAddress, bls12381G1MulAddress, bls12381G1MultiExpAddress,
+		bls12381G2AddAddress, bls12381G2MulAddress, bls12381G2MultiExpAddress,
+		bls12381PairingAddress, bls12381MapG1Address, bls12381MapG2Address,
+	}
+)
+
+func runBLS12Precompile(t *testing.T, addr common.Address, input []byte) []byte {
+	t.Helper()
+	p := PrecompiledContractsDonut[addr]
+	out, _, err := p.Run(input, common.Address{}, nil, p.RequiredGas(input))
+	if err != nil {
+		t.Fatalf("precompile %x failed on input %x: %v", addr, input, err)
+	}
+	return out
+}
+
+func randomScalar(rnd *rand.Rand) []byte {
+	s := make([]byte, bls12ScalarLength)
+	rnd.Read(s)
+	return s
+}
+
+// randomPoint returns a random point of the prime order subgroup, along with
+// its discrete logarithm.
+func (c *refCurve) randomPoint(rnd *rand.Rand, g2 bool) (refPoint, *big.Int) {
+	s := new(big.Int).SetBytes(randomScalar(rnd))
+	if g2 {
+		return c.scalarMul(c.g2, s), s
+	}
+	return c.scalarMul(c.g1, s), s
+}
+
+func fuzzBLS12AgainstReference(t *testing.T, c *refCurve, addrs bls12FuzzAddresses) {
+	seed := time.Now().UnixNano()
+	rnd := rand.New(rand.NewSource(seed))
+	t.Logf("seed %d", seed)
+
+	for i := 0; i < bls12FuzzRounds; i++ {
+		for _, g2 := range []bool{false, true} {
+			add, mul, multiExp := addrs.g1Add, addrs.g1Mul, addrs.g1MultiExp
+			if g2 {
+				add, mul, multiExp = addrs.g2Add, addrs.g2Mul, addrs.g2MultiExp
+			}
+			a, _ := c.randomPoint(rnd, g2)
+			b, _ := c.randomPoint(rnd, g2)
+
+			// Addition, including the doubling and cancelling corner cases
+			for _, pair := range [][2]refPoint{{a, b}, {a, a}, {a, c.neg(a)}, {a, {inf: true}}} {
+				input := append(c.encode(pair[0], g2), c.encode(pair[1], g2)...)
+				want := c.encode(c.addPoints(pair[0], pair[1]), g2)
+				if have := runBLS12Precompile(t, add, input); !bytes.Equal(have, want) {
+					t.Fatalf("add mismatch on input %x: have %x, want %x", input, have, want)
+				}
+			}
+			// Scalar multiplication
+			s := randomScalar(rnd)
+			input := append(c.encode(a, g2), s...)
+			want := c.encode(c.scalarMul(a, new(big.Int).SetBytes(s)), g2)
+			if have := runBLS12Precompile(t, mul, input); !bytes.Equal(have, want) {
+				t.Fatalf("mul mismatch on input %x: have %x, want %x", input, have, want)
+			}
+			// Multi exponentiation
+			input = nil
+			sum := refPoint{inf: true}
+			for k := 1 + rnd.Intn(4); k > 0; k-- {
+				p, _ := c.randomPoint(rnd, g2)
+				s := randomScalar(rnd)
+				input = append(append(input, c.encode(p, g2)...), s...)
+				sum = c.addPoints(sum, c.scalarMul(p, new(big.Int).SetBytes(s)))
+			}
+			want = c.encode(sum, g2)
+			if have := runBLS12Precompile(t, multiExp, input); !bytes.Equal(have, want) {
+				t.Fatalf("multiexp mismatch on input %x: have %x, want %x", input, have, want)
+			}
+		}
+		// Pairing, e(aP, bQ) * e(-abP, Q) = 1, which breaks if a scalar is off
+		sa, sb := new(big.Int).SetBytes(randomScalar(rnd)), new(big.Int).SetBytes(randomScalar(rnd))
+		sab := new(big.Int).Mul(sa, sb)
+		valid := rnd.Intn(2) == 0
+		if !valid {
+			sab.Add(sab, big.NewInt(1))
+		}
+		input := append(c.encode(c.scalarMul(c.g1, sa), false), c.encode(c.scalarMul(c.g2, sb), true)...)
+		input = append(input, c.encode(c.neg(c.scalarMul(c.g1, sab)), false)...)
+		input = append(input, c.encode(c.g2, true)...)
+		want := false32Byte
+		if valid {
+			want = true32Byte
+		}
+		if have := runBLS12Precompile(t, addrs.pairing, input); !bytes.Equal(have, want) {
+			t.Fatalf("pairing mismatch on input %x: have %x, want %x", input, have, want)
+		}
+		// Mapped points must be in the subgroup, which the multiplication checks
+		u := make([]byte, bls12FieldElementLength)
+		fillBytes(u, new(big.Int).Rand(rnd, c.p))
+		mapped := runBLS12Precompile(t, addrs.mapG1, u)
+		runBLS12Precompile(t, addrs.g1Mul, append(mapped, randomScalar(rnd)...))
+
+		u = make([]byte, 2*bls12FieldElementLength)
+		fillBytes(u[:bls12FieldElementLength], new(big.Int).Rand(rnd, c.p))
+		fillBytes(u[bls12FieldElementLength:], new(big.Int).Rand(rnd, c.p))
+		mapped = runBLS12Precompile(t, addrs.mapG2, u)
+		runBLS12Precompile(t, addrs.g2Mul, append(mapped, randomScalar(rnd)...))
+	}
+}
+
+func TestFuzzBLS12377AgainstReference(t *testing.T) {
+	fuzzBLS12AgainstReference(t, newRefBLS12377(), bls12377FuzzAddresses)
+}
+
+func TestFuzzBLS12381AgainstReference(t *testing.T) {
+	fuzzBLS12AgainstReference(t, newRefBLS12381(), bls12381FuzzAddresses)
+}
@@ -705,8 +705,288 @@ var getVerifiedSealBitmapTests = []precompiledTest{
 	},
 }
 
+// FIPS 202 test vectors
+var sha3_512Tests = []precompiledTest{
+	{
+		input:    "",
+		expected: "a69f73cca23a9ac5c8b567dc185a756e97c982164fe25859e0d1dcc1475c80a615b2123af1f5f94c11e3e9402c3ac558f500199d95b6d3e301758586281dcd26",
+		name:     "empty",
+	},
+	{
+		input:    "616263",
+		expected: "b751850b1a57168a5693cd924b6b096e08f621827444f70d884f5d0240d2712e10e116e9192af3c91a7ec57647e3934057340b4cf408d5a56592f8274eec53f0",
+		name:     "abc",
+	},
+}
+
+var keccak512Tests = []precompiledTest{
+	{
+		input:    "",
+		expected: "0eab42de4c3ceb9235fc91acffe746b29c29a8c366b7c60e4e67c466f36a4304c00fa9caf9d87976ba469bcbe06713b435f091ef2769fb160cdab33d3670680e",
+		name:     "empty",
+	},
+	{
+		input:    "616263",
+		expected: "18587dc2ea106b9a1563e32b3312421ca164c7f1f07bc922a9c83d77cea3a1e5d0c69910739025372dc14ac9642629379540c17e2a65b19d77aa511a9d00bb96",
+		name:     "abc",
+	},
+}
+
+// RFC 7693 test vectors
+var blake2sTests = []precompiledTest{
+	{
+		input:    "",
+		expected: "69217a3079908094e11121d042354a7c1f55b6482ca1a51e1b250dfd1ed0eef9",
+		name:     "empty",
+	},
+	{
+		input:    "616263",
+		expected: "508c5e8c327c14e2e1a72ba34eeb452f37458b209ed63a294d999b4c86675982",
+		name:     "abc",
+	},
+}
+
+// RFC 8032 test vectors
+var ed25519VerifyTests = []precompiledTest{
+	{
+		input:    "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511ae5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "vector_1",
+	},
+	{
+		input:    "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c0072",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "vector_2",
+	},
+	{
+		input:    "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c0073",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000",
+		name:     "vector_2_wrong_message",
+	},
+	{
+		input:         "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+		expected:      "invalid input length",
+		name:          "input_too_short",
+		errorExpected: true,
+		noBenchmark:   true,
+	},
+}
+
+var bls12377G1AddTests = []precompiledTest{
+	{
+		input:    "000000000000000000000000000000000071a7f904abdb1ce6bec6ef4a8d0549ce92708904da0d3ed6e0105afe6e26bac3231020830d64cb5c33e1ad0d1d202f000000000000000000000000000000000194d107b8c953f341a9e5bff0c7bc37b8ff2244c933205c1560097cde48b39fddacf9c0764dcc39612331359b78494b0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		expected: "000000000000000000000000000000000071a7f904abdb1ce6bec6ef4a8d0549ce92708904da0d3ed6e0105afe6e26bac3231020830d64cb5c33e1ad0d1d202f000000000000000000000000000000000194d107b8c953f341a9e5bff0c7bc37b8ff2244c933205c1560097cde48b39fddacf9c0764dcc39612331359b78494b",
+		name:     "generator_plus_infinity",
+	},
+	{
+		input:    "000000000000000000000000000000000071a7f904abdb1ce6bec6ef4a8d0549ce92708904da0d3ed6e0105afe6e26bac3231020830d64cb5c33e1ad0d1d202f000000000000000000000000000000000194d107b8c953f341a9e5bff0c7bc37b8ff2244c933205c1560097cde48b39fddacf9c0764dcc39612331359b78494b000000000000000000000000000000000071a7f904abdb1ce6bec6ef4a8d0549ce92708904da0d3ed6e0105afe6e26bac3231020830d64cb5c33e1ad0d1d202f000000000000000000000000000000000194d107b8c953f341a9e5bff0c7bc37b8ff2244c933205c1560097cde48b39fddacf9c0764dcc39612331359b78494b",
+		expected: "000000000000000000000000000000000168edffaba5a5648b35d3c7a3469777e7ba2ac82ac175315b909785cd65d01b51bfbe43ffb283b8dbea67b4460e9d98000000000000000000000000000000000088632a71244f51dc50a1467eb607e60f82115f0302c04580adb0425e3f0955ce5879d5c945ae92039d13c00ac45a03",
+		name:     "generator_doubling",
+	},
+	{
+		input:    "000000000000000000000000000000000071a7f904abdb1ce6bec6ef4a8d0549ce92708904da0d3ed6e0105afe6e26bac3231020830d64cb5c33e1ad0d1d202f000000000000000000000000000000000194d107b8c953f341a9e5bff0c7bc37b8ff2244c933205c1560097cde48b39fddacf9c0764dcc39612331359b78494b000000000000000000000000000000000071a7f904abdb1ce6bec6ef4a8d0549ce92708904da0d3ed6e0105afe6e26bac3231020830d64cb5c33e1ad0d1d202f000000000000000000000000000000000019693e5efbbcf7849120007bd98d036123b7ae37c1f333099358b2dbc09460395e6383b9b233c723e58eca6487b6b6",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		name:     "generator_minus_generator",
+	},
+	{
+		input:         "000000000000000000000000000000000071a7f904abdb1ce6bec6ef4a8d0549ce92708904da0d3ed6e0105afe6e26bac3231020830d64cb5c33e1ad0d1d202f000000000000000000000000000000000194d107b8c953f341a9e5bff0c7bc37b8ff2244c933205c1560097cde48b39fddacf9c0764dcc39612331359b78494b",
+		expected:      "invalid input length",
+		name:          "input_too_short",
+		errorExpected: true,
+	},
+	{
+		input:         "010000000000000000000000000000000071a7f904abdb1ce6bec6ef4a8d0549ce92708904da0d3ed6e0105afe6e26bac3231020830d64cb5c33e1ad0d1d202f000000000000000000000000000000000194d107b8c953f341a9e5bff0c7bc37b8ff2244c933205c1560097cde48b39fddacf9c0764dcc39612331359b78494b000000000000000000000000000000000071a7f904abdb1ce6bec6ef4a8d0549ce92708904da0d3ed6e0105afe6e26bac3231020830d64cb5c33e1ad0d1d202f000000000000000000000000000000000194d107b8c953f341a9e5bff0c7bc37b8ff2244c933205c1560097cde48b39fddacf9c0764dcc39612331359b78494b",
+		expected:      "invalid field element top bytes",
+		name:          "invalid_top_bytes",
+		errorExpected: true,
+	},
+	{
+		input:         "000000000000000000000000000000000071a7f904abdb1ce6bec6ef4a8d0549ce92708904da0d3ed6e0105afe6e26bac3231020830d64cb5c33e1ad0d1d202f000000000000000000000000000000000194d107b8c953f341a9e5bff0c7bc37b8ff2244c933205c1560097cde48b39fddacf9c0764dcc39612331359b784940000000000000000000000000000000000071a7f904abdb1ce6bec6ef4a8d0549ce92708904da0d3ed6e0105afe6e26bac3231020830d64cb5c33e1ad0d1d202f000000000000000000000000000000000194d107b8c953f341a9e5bff0c7bc37b8ff2244c933205c1560097cde48b39fddacf9c0764dcc39612331359b78494b",
+		expected:      "point is not on curve",
+		name:          "point_not_on_curve",
+		errorExpected: true,
+	},
+}
+
+var bls12377PairingTests = []precompiledTest{
+	{
+		input:    "000000000000000000000000000000000071a7f904abdb1ce6bec6ef4a8d0549ce92708904da0d3ed6e0105afe6e26bac3231020830d64cb5c33e1ad0d1d202f000000000000000000000000000000000194d107b8c953f341a9e5bff0c7bc37b8ff2244c933205c1560097cde48b39fddacf9c0764dcc39612331359b78494b0000000000000000000000000000000000d6e4da4a0afc2b2fba570ba07726cc67f26ed326934e0db8c994cffa6646f51a7655d1193c95f55a6bbce818af470600000000000000000000000000000000016add8c910cf38163ff2500875cb73ae2a4fecd64c1da86192efd234eabc42dea05d895d86436b59e8f0141b4a1c4d0000000000000000000000000000000000129636117deb70c49b7fc46d2023be10e77a7765ce83a5e53f8eb6ffb55c0de5c800f8802021c8dd51dc327d93a53470000000000000000000000000000000001997cb468b0c82363105a2d266cbf378189c67db2cd2c3cd1bbcbbb5045c273679b154db26921bc4545f7af532f0fb2000000000000000000000000000000000071a7f904abdb1ce6bec6ef4a8d0549ce92708904da0d3ed6e0105afe6e26bac3231020830d64cb5c33e1ad0d1d202f000000000000000000000000000000000019693e5efbbcf7849120007bd98d036123b7ae37c1f333099358b2dbc09460395e6383b9b233c723e58eca6487b6b60000000000000000000000000000000000d6e4da4a0afc2b2fba570ba07726cc67f26ed326934e0db8c994cffa6646f51a7655d1193c95f55a6bbce818af470600000000000000000000000000000000016add8c910cf38163ff2500875cb73ae2a4fecd64c1da86192efd234eabc42dea05d895d86436b59e8f0141b4a1c4d0000000000000000000000000000000000129636117deb70c49b7fc46d2023be10e77a7765ce83a5e53f8eb6ffb55c0de5c800f8802021c8dd51dc327d93a53470000000000000000000000000000000001997cb468b0c82363105a2d266cbf378189c67db2cd2c3cd1bbcbbb5045c273679b154db26921bc4545f7af532f0fb2",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "bilinearity",
+	},
+	{
+		input:    "000000000000000000000000000000000071a7f904abdb1ce6bec6ef4a8d0549ce92708904da0d3ed6e0105afe6e26bac3231020830d64cb5c33e1ad0d1d202f000000000000000000000000000000000194d107b8c953f341a9e5bff0c7bc37b8ff2244c933205c1560097cde48b39fddacf9c0764dcc39612331359b78494b0000000000000000000000000000000000d6e4da4a0afc2b2fba570ba07726cc67f26ed326934e0db8c994cffa6646f51a7655d1193c95f55a6bbce818af470600000000000000000000000000000000016add8c910cf38163ff2500875cb73ae2a4fecd64c1da86192efd234eabc42dea05d895d86436b59e8f0141b4a1c4d0000000000000000000000000000000000129636117deb70c49b7fc46d2023be10e77a7765ce83a5e53f8eb6ffb55c0de5c800f8802021c8dd51dc327d93a53470000000000000000000000000000000001997cb468b0c82363105a2d266cbf378189c67db2cd2c3cd1bbcbbb5045c273679b154db26921bc4545f7af532f0fb2",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000",
+		name:     "non_degeneracy",
+	},
+	{
+		input:         "",
+		expected:      "invalid input length",
+		name:          "empty_input",
+		errorExpected: true,
+		noBenchmark:   true,
+	},
+}
+
+var bls12381G1AddTests = []precompiledTest{
+	{
+		input:    "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e10000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		expected: "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1",
+		name:     "generator_plus_infinity",
+	},
+	{
+		input:    "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e10000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1",
+		expected: "000000000000000000000000000000000572cbea904d67468808c8eb50a9450c9721db309128012543902d0ac358a62ae28f75bb8f1c7c42c39a8c5529bf0f4e00000000000000000000000000000000166a9d8cabc673a322fda673779d8e3822ba3ecb8670e461f73bb9021d5fd76a4c56d9d4cd16bd1bba86881979749d28",
+		name:     "generator_doubling",
+	},
+	{
+		input:    "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e10000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb00000000000000000000000000000000114d1d6855d545a8aa7d76c8cf2e21f267816aef1db507c96655b9d5caac42364e6f38ba0ecb751bad54dcd6b939c2ca",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		name:     "generator_minus_generator",
+	},
+	{
+		input:         "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1",
+		expected:      "invalid input length",
+		name:          "input_too_short",
+		errorExpected: true,
+	},
+	{
+		input:         "0100000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e10000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1",
+		expected:      "invalid field element top bytes",
+		name:          "invalid_top_bytes",
+		errorExpected: true,
+	},
+	{
+		input:         "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e00000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1",
+		expected:      "point is not on curve",
+		name:          "point_not_on_curve",
+		errorExpected: true,
+	},
+}
+
+var bls12381PairingTests = []precompiledTest{
+	{
+		input:    "000000000000000000000000000000000572cbea904d67468808c8eb50a9450c9721db309128012543902d0ac358a62ae28f75bb8f1c7c42c39a8c5529bf0f4e00000000000000000000000000000000166a9d8cabc673a322fda673779d8e3822ba3ecb8670e461f73bb9021d5fd76a4c56d9d4cd16bd1bba86881979749d2800000000000000000000000000000000122915c824a0857e2ee414a3dccb23ae691ae54329781315a0c75df1c04d6d7a50a030fc866f09d516020ef82324afae0000000000000000000000000000000009380275bbc8e5dcea7dc4dd7e0550ff2ac480905396eda55062650f8d251c96eb480673937cc6d9d6a44aaa56ca66dc000000000000000000000000000000000b21da7955969e61010c7a1abc1a6f0136961d1e3b20b1a7326ac738fef5c721479dfd948b52fdf2455e44813ecfd8920000000000000000000000000000000008f239ba329b3967fe48d718a36cfe5f62a7e42e0bf1c1ed714150a166bfbd6bcf6b3b58b975b9edea56d53f23a0e8490000000000000000000000000000000006e82f6da4520f85c5d27d8f329eccfa05944fd1096b20734c894966d12a9e2a9a9744529d7212d33883113a0cadb9090000000000000000000000000000000017d81038f7d60bee9110d9c0d6d1102fe2d998c957f28e31ec284cc04134df8e47e8f82ff3af2e60a6d9688a4563477c00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000d1b3cc2c7027888be51d9ef691d77bcb679afda66c73f17f9ee3837a55024f78c71363275a75d75d86bab79f74782aa0000000000000000000000000000000013fa4d4a0ad8b1ce186ed5061789213d993923066dddaf1040bc3ff59f825c78df74f2d75467e25e0f55f8a00fa030ed",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "e(2*G1,3*G2)=e(6*G1,G2)",
+	},
+	{
+		input:    "000000000000000000000000000000000572cbea904d67468808c8eb50a9450c9721db309128012543902d0ac358a62ae28f75bb8f1c7c42c39a8c5529bf0f4e00000000000000000000000000000000166a9d8cabc673a322fda673779d8e3822ba3ecb8670e461f73bb9021d5fd76a4c56d9d4cd16bd1bba86881979749d2800000000000000000000000000000000122915c824a0857e2ee414a3dccb23ae691ae54329781315a0c75df1c04d6d7a50a030fc866f09d516020ef82324afae0000000000000000000000000000000009380275bbc8e5dcea7dc4dd7e0550ff2ac480905396eda55062650f8d251c96eb480673937cc6d9d6a44aaa56ca66dc000000000000000000000000000000000b21da7955969e61010c7a1abc1a6f0136961d1e3b20b1a7326ac738fef5c721479dfd948b52fdf2455e44813ecfd8920000000000000000000000000000000008f239ba329b3967fe48d718a36cfe5f62a7e42e0bf1c1ed714150a166bfbd6bcf6b3b58b975b9edea56d53f23a0e8490000000000000000000000000000000010e7791fb972fe014159aa33a98622da3cdc98ff707965e536d8636b5fcc5ac7a91a8c46e59a00dca575af0f18fb13dc0000000000000000000000000000000016ba437edcc6551e30c10512367494bfb6b01cc6681e8a4c3cd2501832ab5c4abc40b4578b85cbaffbf0bcd70d67c6e200000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000d1b3cc2c7027888be51d9ef691d77bcb679afda66c73f17f9ee3837a55024f78c71363275a75d75d86bab79f74782aa0000000000000000000000000000000013fa4d4a0ad8b1ce186ed5061789213d993923066dddaf1040bc3ff59f825c78df74f2d75467e25e0f55f8a00fa030ed",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000",
+		name:     "e(2*G1,3*G2)!=e(5*G1,G2)",
+	},
+	{
+		input:    "000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "e(0,G2)=1",
+	},
+	{
+		input:    "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e100000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb00000000000000000000000000000000114d1d6855d545a8aa7d76c8cf2e21f267816aef1db507c96655b9d5caac42364e6f38ba0ecb751bad54dcd6b939c2ca00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "bilinearity",
+	},
+	{
+		input:    "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e100000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000",
+		name:     "non_degeneracy",
+	},
+	{
+		input:         "",
+		expected:      "invalid input length",
+		name:          "empty_input",
+		errorExpected: true,
+		noBenchmark:   true,
+	},
+}
+
+var bls12381MapG1Tests = []precompiledTest{
+	{
+		input:    "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		expected: "0000000000000000000000000000000011a9a0372b8f332d5c30de9ad14e50372a73fa4c45d5f2fa5097f2d6fb93bcac592f2e1711ac43db0519870c7d0ea41500000000000000000000000000000000092c0f994164a0719f51c24ba3788de240ff926b55f58c445116e8bc6a47cd63392fd4e8e22bdf9feaa96ee773222133",
+		name:     "hash_to_curve_draft_vector_0",
+	},
+	{
+		input:    "0000000000000000000000000000000007fdf49ea58e96015d61f6b5c9d1c8f277146a533ae7fbca2a8ef4c41055cd961fbc6e26979b5554e4b4f22330c0e16d",
+		expected: "000000000000000000000000000000001223effdbb2d38152495a864d78eee14cb0992d89a241707abb03819a91a6d2fd65854ab9a69e9aacb0cbebfd490732c000000000000000000000000000000000f925d61e0b235ecd945cbf0309291878df0d06e5d80d6b84aa4ff3e00633b26f9a7cb3523ef737d90e6d71e8b98b2d5",
+		name:     "hash_to_curve_draft_vector_1",
+	},
+	{
+		input:    "000000000000000000000000000000001275ab3adbf824a169ed4b1fd669b49cf406d822f7fe90d6b2f8c601b5348436f89761bb1ad89a6fb1137cd91810e5d2",
+		expected: "00000000000000000000000000000000179d3fd0b4fb1da43aad06cea1fb3f828806ddb1b1fa9424b1e3944dfdbab6e763c42636404017da03099af0dcca0fd6000000000000000000000000000000000d037cb1c6d495c0f5f22b061d23f1be3d7fe64d3c6820cfcd99b6b36fa69f7b4c1f4addba2ae7aa46fb25901ab483e4",
+		name:     "hash_to_curve_draft_vector_2",
+	},
+	{
+		input:    "000000000000000000000000000000000e93d11d30de6d84b8578827856f5c05feef36083eef0b7b263e35ecb9b56e86299614a042e57d467fa20948e8564909",
+		expected: "0000000000000000000000000000000015aa66c77eded1209db694e8b1ba49daf8b686733afaa7b68c683d0b01788dfb0617a2e2d04c0856db4981921d3004af000000000000000000000000000000000952bb2f61739dd1d201dd0a79d74cda3285403d47655ee886afe860593a8a4e51c5b77a22d2133e3a4280eaaaa8b788",
+		name:     "hash_to_curve_draft_vector_3",
+	},
+	{
+		input:    "00000000000000000000000000000000015a41481155d17074d20be6d8ec4d46632a51521cd9c916e265bd9b47343b3689979b50708c8546cbc2916b86cb1a3a",
+		expected: "0000000000000000000000000000000006328ce5106e837935e8da84bd9af473422e62492930aa5f460369baad9545defa468d9399854c23a75495d2a80487ee00000000000000000000000000000000094bfdfe3e552447433b5a00967498a3f1314b86ce7a7164c8a8f4131f99333b30a574607e301d5f774172c627fd0bca",
+		name:     "hash_to_curve_draft_vector_4",
+	},
+	{
+		input:         "000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		expected:      "invalid input length",
+		name:          "input_too_short",
+		errorExpected: true,
+	},
+	{
+		input:         "1000000000000000000000000000000007fdf49ea58e96015d61f6b5c9d1c8f277146a533ae7fbca2a8ef4c41055cd961fbc6e26979b5554e4b4f22330c0e16d",
+		expected:      "invalid field element top bytes",
+		name:          "invalid_top_bytes",
+		errorExpected: true,
+	},
+	{
+		input:         "000000000000000000000000000000001a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab",
+		expected:      "invalid field element",
+		name:          "element_not_reduced",
+		errorExpected: true,
+	},
+}
+
+var bls12381MapG2Tests = []precompiledTest{
+	{
+		input:    "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		expected: "00000000000000000000000000000000018320896ec9eef9d5e619848dc29ce266f413d02dd31d9b9d44ec0c79cd61f18b075ddba6d7bd20b7ff27a4b324bfce000000000000000000000000000000000a67d12118b5a35bb02d2e86b3ebfa7e23410db93de39fb06d7025fa95e96ffa428a7a27c3ae4dd4b40bd251ac658892000000000000000000000000000000000260e03644d1a2c321256b3246bad2b895cad13890cbe6f85df55106a0d334604fb143c7a042d878006271865bc359410000000000000000000000000000000004c69777a43f0bda07679d5805e63f18cf4e0e7c6112ac7f70266d199b4f76ae27c6269a3ceebdae30806e9a76aadf5c",
+		name:     "hash_to_curve_draft_vector_0",
+	},
+	{
+		input:    "000000000000000000000000000000000e775d7827adf385b83e20e4445bd3fab21d7b4498426daf3c1d608b9d41e9edb5eda0df022e753b8bb4bc3bb7db491400000000000000000000000000000000025fbc07711ba267b7e70c82caa70a16fbb1d470ae24ceef307f5e2000751677820b7013ad4e25492dcf30052d3e5eca",
+		expected: "00000000000000000000000000000000027e4bfada0b47f9f07e04aec463c7371e68f2fd0c738cd517932ea3801a35acf09db018deda57387b0f270f7a219e4d000000000000000000000000000000000d4333b77becbf9f9dfa3ca928002233d1ecc854b1447e5a71f751c9042d000f42db91c1d6649a5e0ad22bd7bf7398b800000000000000000000000000000000053674cba9ef516ddc218fedb37324e6c47de27f88ab7ef123b006127d738293c0277187f7e2f80a299a24d84ed03da7000000000000000000000000000000000cc76dc777ea0d447e02a41004f37a0a7b1fafb6746884e8d9fc276716ccf47e4e0899548a2ec71c2bdf1a2a50e876db",
+		name:     "hash_to_curve_draft_vector_1",
+	},
+	{
+		input:    "00000000000000000000000000000000045ab31ce4b5a8ba7c4b2851b64f063a66cd1223d3c85005b78e1beee65e33c90ceef0244e45fc45a5e1d6eab6644fdb000000000000000000000000000000001870a7dbfd2a1deb74015a3546b20f598041bf5d5202997956a94a368d30d3f70f18cdaa1d33ce970a4e16af961cbdcb",
+		expected: "0000000000000000000000000000000009349f1cb5b2e55489dcd45a38545343451cc30a1681c57acd4fb0a6db125f8352c09f4a67eb7d1d8242cb7d3405f97b0000000000000000000000000000000018f0f87b40af67c056915dbaf48534c592524e82c1c2b50c3734d02c0172c80df780a60b5683759298a3303c5d9427780000000000000000000000000000000002f2d9deb2c7742512f5b8230bf0fd83ea42279d7d39779543c1a43b61c885982b611f6a7a24b514995e8a098496b8110000000000000000000000000000000010a2ba341bc689ab947b7941ce6ef39be17acaab067bd32bd652b471ab0792c53a2bd03bdac47f96aaafe96e441f63c0",
+		name:     "hash_to_curve_draft_vector_2",
+	},
+	{
+		input:    "000000000000000000000000000000000b6e6135a4cd31ba980ddbd115ac48abef7ec60e226f264d7befe002c165f3a496f36f76dd524efd75d17422558d10b400000000000000000000000000000000088fe329b054db8a6474f21a7fbfdf17b4c18044db299d9007af582c3d5f17d00e56d99921d4b5640fce44b05219b5de",
+		expected: "00000000000000000000000000000000149fe43777d34f0d25430dea463889bd9393bdfb4932946db23671727081c629ebb98a89604f3433fba1c67d356a4af70000000000000000000000000000000019808ec5930a53c7cf5912ccce1cc33f1b3dcff24a53ce1cc4cba41fd6996dbed4843ccdd2eaf6a0cd801e562718d1630000000000000000000000000000000004c0d6793a766233b2982087b5f4a254f261003ccb3262ea7c50903eecef3e871d1502c293f9e063d7d293f6384f45510000000000000000000000000000000004783e391c30c83f805ca271e353582fdf19d159f6a4c39b73acbb637a9b8ac820cfbe2738d683368a7c07ad020e3e33",
+		name:     "hash_to_curve_draft_vector_3",
+	},
+	{
+		input:    "000000000000000000000000000000000f45b50647d67485295aa9eb2d91a877b44813677c67c8d35b2173ff3ba95f7bd0806f9ca8a1436b8b9d14ee81da4d7e0000000000000000000000000000000003df16a66a05e4c1188c234788f43896e0565bfb64ac49b9639e6b284cc47dad73c47bb4ea7e677db8d496beb907fbb6",
+		expected: "000000000000000000000000000000000804152cbf8474669ad7d1796ab92d7ca21f32d8bed70898a748ed4e4e0ec557069003732fc86866d938538a2ae95552000000000000000000000000000000000b8e0094c886487870372eb6264613a6a087c7eb9804fab789be4e47a57b29eb19b1983a51165a1b5eb025865e9fc63a0000000000000000000000000000000009e5c8242dd7281ad32c03fe4af3f19167770016255fb25ad9b67ec51d62fade31a1af101e8f6172ec2ee8857662be3a0000000000000000000000000000000014c80f068ece15a3936bb00c3c883966f75b4e8d9ddde809c11f781ab92d23a2d1d103ad48f6f3bb158bf3e3a4063449",
+		name:     "hash_to_curve_draft_vector_4",
+	},
+	{
+		input:         "0000000000000000000000000000000007fdf49ea58e96015d61f6b5c9d1c8f277146a533ae7fbca2a8ef4c41055cd961fbc6e26979b5554e4b4f22330c0e16d",
+		expected:      "invalid input length",
+		name:          "input_too_short",
+		errorExpected: true,
+	},
+	{
+		input:         "0000000000000000000000000000000007fdf49ea58e96015d61f6b5c9d1c8f277146a533ae7fbca2a8ef4c41055cd961fbc6e26979b5554e4b4f22330c0e16d000000000000000000000000000000001a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab",
+		expected:      "invalid field element",
+		name:          "element_not_reduced",
+		errorExpected: true,
+	},
+}
+
 func testPrecompiled(addr string, test precompiledTest, t *testing.T) {
-	p := PrecompiledContractsIstanbul[common.HexToAddress(addr)]
+	p := PrecompiledContractsDonut[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
 	contract := NewContract(AccountRef(common.HexToAddress("1337")),
 		nil, new(big.Int), p.RequiredGas(in))
@@ -734,7 +1014,7 @@ func testPrecompiled(addr string, test precompiledTest, t *testing.T) {
 }
 
 func testPrecompiledOOG(addr string, test precompiledTest, t *testing.T) {
-	p := PrecompiledContractsIstanbul[common.HexToAddress(addr)]
+	p := PrecompiledContractsDonut[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
 	contract := NewContract(AccountRef(common.HexToAddress("1337")),
 		nil, new(big.Int), p.RequiredGas(in)-1)
@@ -752,7 +1032,7 @@ func testPrecompiledOOG(addr string, test precompiledTest, t *testing.T) {
 }
 
 func testPrecompiledFailure(addr string, test precompiledFailureTest, t *testing.T) {
-	p := PrecompiledContractsIstanbul[common.HexToAddress(addr)]
+	p := PrecompiledContractsDonut[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
 	contract := NewContract(AccountRef(common.HexToAddress("31337")),
 		nil, new(big.Int), p.RequiredGas(in))
@@ -774,7 +1054,7 @@ func benchmarkPrecompiled(addr string, test precompiledTest, bench *testing.B) {
 	if test.noBenchmark {
 		return
 	}
-	p := PrecompiledContractsIstanbul[common.HexToAddress(addr)]
+	p := PrecompiledContractsDonut[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
 	reqGas := p.RequiredGas(in)
 	contract := NewContract(AccountRef(common.HexToAddress("1337")),
@@ -983,3 +1263,101 @@ func TestGetVerifiedSealBitmap(t *testing.T) {
 		testPrecompiled("f4", test, t)
 	}
 }
+
+// Tests the sample inputs for sha3_512
+func TestPrecompiledSha3_512(t *testing.T) {
+	for _, test := range sha3_512Tests {
+		testPrecompiled("f3", test, t)
+	}
+}
+
+// Tests the sample inputs for keccak512
+func TestPrecompiledKeccak512(t *testing.T) {
+	for _, test := range keccak512Tests {
+		testPrecompiled("f2", test, t)
+	}
+}
+
+// Tests the sample inputs for blake2s
+func TestPrecompiledBlake2s(t *testing.T) {
+	for _, test := range blake2sTests {
+		testPrecompiled("f1", test, t)
+	}
+}
+
+// Tests the sample inputs for ed25519Verify
+func TestPrecompiledEd25519Verify(t *testing.T) {
+	for _, test := range ed25519VerifyTests {
+		testPrecompiled("f0", test, t)
+	}
+}
+
+// Benchmarks the sample inputs for ed25519Verify
+func BenchmarkPrecompiledEd25519Verify(bench *testing.B) {
+	for _, test := range ed25519VerifyTests {
+		benchmarkPrecompiled("f0", test, bench)
+	}
+}
+
+// Tests the sample inputs for BLS12-377 G1 addition
+func TestPrecompiledBLS12377G1Add(t *testing.T) {
+	for _, test := range bls12377G1AddTests {
+		testPrecompiled("ef", test, t)
+	}
+}
+
+// Tests the sample inputs for the BLS12-377 pairing check
+func TestPrecompiledBLS12377Pairing(t *testing.T) {
+	for _, test := range bls12377PairingTests {
+		testPrecompiled("e9", test, t)
+	}
+}
+
+// Benchmarks the sample inputs for the BLS12-377 pairing check
+func BenchmarkPrecompiledBLS12377Pairing(bench *testing.B) {
+	for _, test := range bls12377PairingTests {
+		benchmarkPrecompiled("e9", test, bench)
+	}
+}
+
+// Tests the sample inputs for BLS12-381 G1 addition
+func TestPrecompiledBLS12381G1Add(t *testing.T) {
+	for _, test := range bls12381G1AddTests {
+		testPrecompiled("e6", test, t)
+	}
+}
+
+// Tests the sample inputs for the BLS12-381 pairing check
+func TestPrecompiledBLS12381Pairing(t *testing.T) {
+	for _, test := range bls12381PairingTests {
+		testPrecompiled("e0", test, t)
+	}
+}
+
+// Benchmarks the sample inputs for the BLS12-381 pairing check
+func BenchmarkPrecompiledBLS12381Pairing(bench *testing.B) {
+	for _, test := range bls12381PairingTests {
+		benchmarkPrecompiled("e0", test, bench)
+	}
+}
+
+// Tests the sample inputs for the BLS12-381 G1 mapping
+func TestPrecompiledBLS12381MapG1(t *testing.T) {
+	for _, test := range bls12381MapG1Tests {
+		testPrecompiled("df", test, t)
+	}
+}
+
+// Tests the sample inputs for the BLS12-381 G2 mapping
+func TestPrecompiledBLS12381MapG2(t *testing.T) {
+	for _, test := range bls12381MapG2Tests {
+		testPrecompiled("de", test, t)
+	}
+}
+
+// Benchmarks the sample inputs for the BLS12-381 G2 mapping
+func BenchmarkPrecompiledBLS12381MapG2(bench *testing.B) {
+	for _, test := range bls12381MapG2Tests {
+		benchmarkPrecompiled("de", test, bench)
+	}
+}
@@ -33,9 +33,14 @@ const (
 
 var getAddressForFuncABI, _ = abi.JSON(strings.NewReader(getAddressForABI))
 
-// TODO(kevjue) - Re-Enable caching of the retrieved registered address
-// See this commit for the removed code for caching:  https://github.com/celo-org/geth/commit/43a275273c480d307a3d2b3c55ca3b3ee31ec7dd.
+// GetRegisteredAddressWithEvm returns the address registered in the Registry
+// for the given identifier, using the Registry lookup cache of the EVM if any.
 func GetRegisteredAddressWithEvm(registryId [32]byte, evm *EVM) (*common.Address, error) {
+	if evm.RegistryCache != nil {
+		if address, ok := evm.RegistryCache.get(registryId); ok {
+			return &address, nil
+		}
+	}
 	evm.DontMeterGas = true
 	defer func() { evm.DontMeterGas = false }()
 
@@ -49,7 +54,7 @@ func GetRegisteredAddressWithEvm(registryId [32]byte, evm *EVM) (*common.Address
 
 	// TODO (mcortesi) Remove ErrEmptyArguments check after we change Proxy to fail on unset impl
 	// TODO(asa): Why was this change necessary?
-	if err == abi.ErrEmptyArguments || err == errExecutionReverted {
+	if err == abi.ErrEmptyArguments || err == ErrExecutionReverted {
 		return nil, errors.ErrRegistryContractNotDeployed
 	} else if err != nil {
 		return nil, err
@@ -58,6 +63,9 @@ func GetRegisteredAddressWithEvm(registryId [32]byte, evm *EVM) (*common.Address
 	if contractAddress == common.ZeroAddress {
 		return nil, errors.ErrSmartContractNotDeployed
 	}
+	if evm.RegistryCache != nil {
+		evm.RegistryCache.set(registryId, contractAddress)
+	}
 
 	return &contractAddress, nil
 }
@@ -17,7 +17,6 @@
 package vm
 
 import (
-	"bytes"
 	"encoding/binary"
 	goerrors "errors"
 	"math/big"
@@ -41,6 +40,16 @@ var emptyCodeHash = crypto.Keccak256Hash(nil)
 // systemCaller is the caller when the EVM is invoked from the within the blockchain system.
 var systemCaller = AccountRef(common.HexToAddress("0x0"))
 
+// Origins of the system calls, made by the protocol rather than by the code of
+// a transaction.
+const (
+	SystemCallFeeDebit      = "fee-debit"      // Debiting of the fees of a transaction paid in a non native currency
+	SystemCallFeeCredit     = "fee-credit"     // Crediting of the fees of a transaction paid in a non native currency
+	SystemCallEpochRewards  = "epoch-rewards"  // Distribution of the epoch rewards
+	SystemCallBlockFinalize = "block-finalize" // Any other call made while finalizing a block
+	SystemCallRandomness    = "randomness"     // Reveal and commit of the block randomness, before any transaction
+)
+
 type (
 	// CanTransferFunc is the signature of a transfer guard function
 	CanTransferFunc func(StateDB, common.Address, *big.Int) bool
@@ -56,17 +65,32 @@ type (
 	VerifySealFunc func(*types.Header) bool
 )
 
+// precompiles returns the precompiled contracts active under the chain rules of
+// the EVM.
+func (evm *EVM) precompiles() map[common.Address]PrecompiledContract {
+	switch {
+	case evm.chainRules.IsDonut:
+		return PrecompiledContractsDonut
+	case evm.chainRules.IsIstanbul:
+		return PrecompiledContractsIstanbul
+	case evm.chainRules.IsByzantium:
+		return PrecompiledContractsByzantium
+	default:
+		return PrecompiledContractsHomestead
+	}
+}
+
+// IsPrecompiled reports whether addr is a precompiled contract under the chain
+// rules of the EVM.
+func (evm *EVM) IsPrecompiled(addr common.Address) bool {
+	_, ok := evm.precompiles()[addr]
+	return ok
+}
+
 // run runs the given contract and takes care of running precompiles with a fallback to the byte code interpreter.
 func run(evm *EVM, contract *Contract, input []byte, readOnly bool) ([]byte, error) {
 	if contract.CodeAddr != nil {
-		precompiles := PrecompiledContractsHomestead
-		if evm.chainRules.IsByzantium {
-			precompiles = PrecompiledContractsByzantium
-		}
-		if evm.chainRules.IsIstanbul {
-			precompiles = PrecompiledContractsIstanbul
-		}
-		if p := precompiles[*contract.CodeAddr]; p != nil {
+		if p := evm.precompiles()[*contract.CodeAddr]; p != nil {
 			return RunPrecompiledContract(p, input, contract, evm)
 		}
 	}
@@ -113,6 +137,9 @@ type Context struct {
 	Header *types.Header
 
 	Engine consensus.Engine
+
+	// RegistryCache caches the Registry lookups, nil if they aren't cached
+	RegistryCache *RegistryCache
 }
 
 // EVM is the Ethereum Virtual Machine base object and provides
@@ -153,6 +180,9 @@ type EVM struct {
 	callGasTemp uint64
 
 	DontMeterGas bool
+
+	// inSystemCall is set while a system call is being made, see StartSystemCall.
+	inSystemCall bool
 }
 
 // NewEVM returns a new EVM. The returned EVM is not thread safe and should
@@ -224,6 +254,32 @@ func (evm *EVM) SetDebug(value bool) {
 	evm.vmConfig.Debug = value
 }
 
+// StartSystemCall hides the calls made until the returned function is invoked
+// from the tracer, as they're made by the protocol on behalf of a transaction or
+// block. If the configuration has a system call tracer, they're traced by a new
+// tracer for the given origin instead, unless it fails to create one. The
+// failure is left to the system call tracer to report. Nested system calls
+// belong to the outermost one.
+func (evm *EVM) StartSystemCall(origin string) func() {
+	if evm.inSystemCall {
+		return func() {}
+	}
+	debug, tracer := evm.vmConfig.Debug, evm.vmConfig.Tracer
+
+	evm.vmConfig.Debug = false
+	if evm.vmConfig.SystemCallTracer != nil {
+		if tracer, err := evm.vmConfig.SystemCallTracer(origin); err == nil {
+			evm.vmConfig.Debug, evm.vmConfig.Tracer = true, tracer
+		}
+	}
+	evm.inSystemCall = true
+
+	return func() {
+		evm.vmConfig.Debug, evm.vmConfig.Tracer = debug, tracer
+		evm.inSystemCall = false
+	}
+}
+
 // Call executes the contract associated with the addr with the given input as
 // parameters. It also handles any necessary value transfer required and takes
 // the necessary steps to create accounts and reverses the state in case of an
@@ -247,14 +303,7 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 		snapshot = evm.StateDB.Snapshot()
 	)
 	if !evm.StateDB.Exist(addr) {
-		precompiles := PrecompiledContractsHomestead
-		if evm.chainRules.IsByzantium {
-			precompiles = PrecompiledContractsByzantium
-		}
-		if evm.chainRules.IsIstanbul {
-			precompiles = PrecompiledContractsIstanbul
-		}
-		if precompiles[addr] == nil && evm.chainRules.IsEIP158 && value.Sign() == 0 {
+		if !evm.IsPrecompiled(addr) && evm.chainRules.IsEIP158 && value.Sign() == 0 {
 			// Calling a non existing account, don't do anything, but ping the tracer
 			if evm.vmConfig.Debug && evm.depth == 0 {
 				evm.vmConfig.Tracer.CaptureStart(caller.Address(), addr, false, input, gas, value)
@@ -292,7 +341,7 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 	// when we're in homestead this also counts for code storage gas errors.
 	if err != nil {
 		evm.StateDB.RevertToSnapshot(snapshot)
-		if err != errExecutionReverted {
+		if err != ErrExecutionReverted {
 			contract.UseGas(contract.Gas)
 		}
 	}
@@ -332,7 +381,7 @@ func (evm *EVM) CallCode(caller ContractRef, addr common.Address, input []byte,
 	ret, err = run(evm, contract, input, false)
 	if err != nil {
 		evm.StateDB.RevertToSnapshot(snapshot)
-		if err != errExecutionReverted {
+		if err != ErrExecutionReverted {
 			contract.UseGas(contract.Gas)
 		}
 	}
@@ -365,7 +414,7 @@ func (evm *EVM) DelegateCall(caller ContractRef, addr common.Address, input []by
 	ret, err = run(evm, contract, input, false)
 	if err != nil {
 		evm.StateDB.RevertToSnapshot(snapshot)
-		if err != errExecutionReverted {
+		if err != ErrExecutionReverted {
 			contract.UseGas(contract.Gas)
 		}
 	}
@@ -406,7 +455,7 @@ func (evm *EVM) StaticCall(caller ContractRef, addr common.Address, input []byte
 	ret, err = run(evm, contract, input, true)
 	if err != nil {
 		evm.StateDB.RevertToSnapshot(snapshot)
-		if err != errExecutionReverted {
+		if err != ErrExecutionReverted {
 			contract.UseGas(contract.Gas)
 		}
 	}
@@ -491,7 +540,7 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 	// when we're in homestead this also counts for code storage gas errors.
 	if maxCodeSizeExceeded || (err != nil && (evm.chainRules.IsHomestead || err != ErrCodeStoreOutOfGas)) {
 		evm.StateDB.RevertToSnapshot(snapshot)
-		if err != errExecutionReverted {
+		if err != ErrExecutionReverted {
 			contract.UseGas(contract.Gas)
 		}
 	}
@@ -595,20 +644,12 @@ func (evm *EVM) CallFromSystem(contractAddress common.Address, abi abipkg.ABI, f
 	return evm.handleABICall(abi, funcName, args, returnObj, call)
 }
 
-var (
-	errorSig     = []byte{0x08, 0xc3, 0x79, 0xa0} // Keccak256("Error(string)")[:4]
-	abiString, _ = abipkg.NewType("string", "", nil)
-)
-
 func unpackError(result []byte) (string, error) {
-	if len(result) < 4 || !bytes.Equal(result[:4], errorSig) {
-		return "<tx result not Error(string)>", goerrors.New("TX result not of type Error(string)")
-	}
-	vs, err := abipkg.Arguments{{Type: abiString}}.UnpackValues(result[4:])
+	reason, err := abipkg.UnpackRevert(result)
 	if err != nil {
-		return "<invalid tx result>", err
+		return "<tx result not Error(string)>", err
 	}
-	return vs[0].(string), nil
+	return reason, nil
 }
 
 func (evm *EVM) handleABICall(abi abipkg.ABI, funcName string, args []interface{}, returnObj interface{}, call func([]byte) ([]byte, uint64, error)) (uint64, error) {
@@ -0,0 +1,125 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that system calls are hidden from the tracer of the EVM, and traced by
+// a tracer of their own if configured.
+func TestStartSystemCall(t *testing.T) {
+	address := common.BytesToAddress([]byte("contract"))
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb.CreateAccount(address)
+	statedb.SetCode(address, hexutil.MustDecode("0x6001600055")) // sstore(0, 1), stop
+
+	vmctx := Context{
+		CanTransfer: func(StateDB, common.Address, *big.Int) bool { return true },
+		Transfer:    func(StateDB, common.Address, common.Address, *big.Int) {},
+	}
+	call := func(evm *EVM) {
+		if _, _, err := evm.Call(AccountRef(common.Address{}), address, nil, 100000, new(big.Int)); err != nil {
+			t.Fatalf("call failed: %v", err)
+		}
+	}
+	// Without a system call tracer, system calls aren't traced at all
+	tracer := NewStructLogger(nil)
+	evm := NewEVM(vmctx, statedb, params.IstanbulTestChainConfig, Config{Debug: true, Tracer: tracer})
+
+	end := evm.StartSystemCall(SystemCallFeeDebit)
+	call(evm)
+	end()
+	if logs := len(tracer.StructLogs()); logs != 0 {
+		t.Errorf("system call traced by the EVM tracer: have %d logs, want 0", logs)
+	}
+	if !evm.GetDebug() {
+		t.Errorf("debugging not restored after the system call")
+	}
+	// With a system call tracer, every outermost system call gets its own
+	tracer = NewStructLogger(nil)
+	var system []string
+	var systemTracers []*StructLogger
+	evm = NewEVM(vmctx, statedb, params.IstanbulTestChainConfig, Config{
+		Debug:  true,
+		Tracer: tracer,
+		SystemCallTracer: func(origin string) (Tracer, error) {
+			system = append(system, origin)
+			systemTracers = append(systemTracers, NewStructLogger(nil))
+			return systemTracers[len(systemTracers)-1], nil
+		},
+	})
+	end = evm.StartSystemCall(SystemCallFeeDebit)
+	evm.StartSystemCall(SystemCallFeeCredit)() // Nested, part of the outer call
+	call(evm)
+	end()
+	call(evm)
+
+	if len(system) != 1 || system[0] != SystemCallFeeDebit {
+		t.Fatalf("system call origins mismatch: have %v, want [%s]", system, SystemCallFeeDebit)
+	}
+	if logs := len(systemTracers[0].StructLogs()); logs != 4 {
+		t.Errorf("system call logs mismatch: have %d, want 4", logs)
+	}
+	if logs := len(tracer.StructLogs()); logs != 4 {
+		t.Errorf("EVM tracer logs mismatch: have %d, want 4", logs)
+	}
+	// If no system call tracer can be created, system calls are hidden
+	tracer = NewStructLogger(nil)
+	evm = NewEVM(vmctx, statedb, params.IstanbulTestChainConfig, Config{
+		Debug:            true,
+		Tracer:           tracer,
+		SystemCallTracer: func(origin string) (Tracer, error) { return nil, errors.New("no tracer") },
+	})
+	end = evm.StartSystemCall(SystemCallFeeDebit)
+	call(evm)
+	end()
+	if logs := len(tracer.StructLogs()); logs != 0 {
+		t.Errorf("system call traced by the EVM tracer: have %d logs, want 0", logs)
+	}
+}
+
+// Tests that the precompiled contracts reported by the EVM follow the chain
+// rules of the executed block.
+func TestIsPrecompiled(t *testing.T) {
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+
+	config := *params.IstanbulTestChainConfig
+	config.DonutBlock = big.NewInt(10)
+
+	for _, tt := range []struct {
+		number uint64
+		want   bool
+	}{{9, false}, {10, true}} {
+		evm := NewEVM(Context{BlockNumber: new(big.Int).SetUint64(tt.number)}, statedb, &config, Config{})
+		if !evm.IsPrecompiled(common.BytesToAddress([]byte{1})) {
+			t.Errorf("block %d: ecrecover not precompiled", tt.number)
+		}
+		if have := evm.IsPrecompiled(ed25519VerifyAddress); have != tt.want {
+			t.Errorf("block %d: Ed25519 verification precompiled mismatch: have %v, want %v", tt.number, have, tt.want)
+		}
+	}
+}
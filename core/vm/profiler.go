@@ -0,0 +1,228 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"encoding/binary"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/pprof/profile"
+)
+
+// GasProfiler is a Tracer aggregating the gas used, the wall time spent and the
+// number of steps executed per opcode, program counter and contract address.
+// The result is a pprof profile, which can be inspected with `go tool pprof`.
+//
+// Every sample of the profile has the executed opcode as its leaf, called by
+// the program counter it was executed at, itself called by the program counters
+// of the contract call frames it was executed in, from callee to caller. The
+// contract of a frame is the one whose code is executed, so the opcodes of
+// a DELEGATECALL are attributed to the library rather than the caller.
+//
+// The values of an opcode are exclusive of the frames it calls: the gas and
+// time spent in the contract called by a CALL are attributed to the opcodes
+// executed there rather than to the CALL itself.
+type GasProfiler struct {
+	frames []*profileFrame // Contract call frames being executed
+
+	functions map[string]*profile.Function
+	locations map[string]*profile.Location
+	samples   map[string]*profile.Sample
+	prof      *profile.Profile
+}
+
+// profileFrame is a contract call frame being profiled, along with the opcode
+// currently executed in it.
+type profileFrame struct {
+	address common.Address
+	pc      uint64
+	op      OpCode
+	gas     uint64    // Gas available before executing the opcode
+	cost    uint64    // Gas cost of the opcode
+	start   time.Time // Time the opcode started executing
+	burnt   bool      // Whether the opcode failed, consuming all the gas of the frame
+
+	childGas  uint64 // Gas recorded in the frames called by the opcode
+	childTime int64  // Time recorded in the frames called by the opcode
+	totalGas  uint64 // Gas recorded in the frame and the frames it called
+	totalTime int64  // Time recorded in the frame and the frames it called
+}
+
+// NewGasProfiler returns a new profiler. It can be used to trace several
+// transactions in a row, aggregating their profiles.
+func NewGasProfiler() *GasProfiler {
+	return &GasProfiler{
+		functions: make(map[string]*profile.Function),
+		locations: make(map[string]*profile.Location),
+		samples:   make(map[string]*profile.Sample),
+		prof: &profile.Profile{
+			SampleType: []*profile.ValueType{
+				{Type: "gas", Unit: "count"},
+				{Type: "time", Unit: "nanoseconds"},
+				{Type: "steps", Unit: "count"},
+			},
+			DefaultSampleType: "gas",
+			PeriodType:        &profile.ValueType{Type: "steps", Unit: "count"},
+			Period:            1,
+		},
+	}
+}
+
+// CaptureStart implements the Tracer interface.
+func (p *GasProfiler) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	return nil
+}
+
+// CaptureState records the previous opcode of the frame the new one is executed
+// in, now that its gas usage is known, and starts measuring the new opcode.
+func (p *GasProfiler) CaptureState(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	now := time.Now()
+	p.unwind(depth, now)
+
+	if len(p.frames) == depth {
+		frame := p.frames[depth-1]
+		var used uint64
+		if frame.gas > gas {
+			used = frame.gas - gas
+		}
+		p.record(used, now)
+	} else {
+		address := contract.Address()
+		if contract.CodeAddr != nil {
+			address = *contract.CodeAddr
+		}
+		p.frames = append(p.frames, &profileFrame{address: address})
+	}
+	frame := p.frames[len(p.frames)-1]
+
+	frame.pc, frame.op, frame.gas, frame.cost, frame.start = pc, op, gas, cost, now
+	frame.burnt = err != nil && err != ErrExecutionReverted
+	frame.childGas, frame.childTime = 0, 0
+	return nil
+}
+
+// CaptureFault marks the current opcode as having consumed all the gas of its
+// frame, unless it merely reverted.
+func (p *GasProfiler) CaptureFault(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	p.unwind(depth, time.Now())
+	if len(p.frames) == depth && err != ErrExecutionReverted {
+		p.frames[depth-1].burnt = true
+	}
+	return nil
+}
+
+// CaptureEnd records the last opcodes of the frames still being executed.
+func (p *GasProfiler) CaptureEnd(output []byte, gasUsed uint64, t time.Duration, err error) error {
+	p.unwind(0, time.Now())
+	return nil
+}
+
+// Profile returns a copy of the profile aggregated so far.
+func (p *GasProfiler) Profile() *profile.Profile {
+	return p.prof.Copy()
+}
+
+// unwind records the last opcodes of the frames deeper than depth, which have
+// returned, and adds their totals to their callers.
+func (p *GasProfiler) unwind(depth int, now time.Time) {
+	for len(p.frames) > depth {
+		frame := p.frames[len(p.frames)-1]
+		if frame.burnt {
+			p.record(frame.gas, now)
+		} else {
+			p.record(frame.cost, now)
+		}
+		p.frames = p.frames[:len(p.frames)-1]
+
+		if len(p.frames) > 0 {
+			caller := p.frames[len(p.frames)-1]
+			caller.childGas += frame.totalGas
+			caller.childTime += frame.totalTime
+		}
+	}
+}
+
+// record adds the current opcode of the innermost frame to the profile, given
+// the gas it used including the frames it called.
+func (p *GasProfiler) record(used uint64, now time.Time) {
+	frame := p.frames[len(p.frames)-1]
+
+	var gas uint64
+	if used > frame.childGas {
+		gas = used - frame.childGas
+	}
+	elapsed := now.Sub(frame.start).Nanoseconds() - frame.childTime
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	frame.totalGas += gas + frame.childGas
+	frame.totalTime += elapsed + frame.childTime
+
+	// Assemble the call stack of the opcode, from the leaf to the root
+	locations := make([]*profile.Location, 0, len(p.frames)+1)
+	locations = append(locations, p.location(frame.op.String(), frame.op.String(), 0))
+	for i := len(p.frames) - 1; i >= 0; i-- {
+		address := p.frames[i].address.Hex()
+		locations = append(locations, p.location(address+":"+strconv.FormatUint(p.frames[i].pc, 10), address, int64(p.frames[i].pc)))
+	}
+	key := make([]byte, 8*len(locations))
+	for i, location := range locations {
+		binary.BigEndian.PutUint64(key[8*i:], location.ID)
+	}
+	sample, ok := p.samples[string(key)]
+	if !ok {
+		sample = &profile.Sample{
+			Location: locations,
+			Value:    make([]int64, len(p.prof.SampleType)),
+			Label:    map[string][]string{"opcode": {frame.op.String()}},
+		}
+		p.samples[string(key)] = sample
+		p.prof.Sample = append(p.prof.Sample, sample)
+	}
+	sample.Value[0] += int64(gas)
+	sample.Value[1] += elapsed
+	sample.Value[2]++
+}
+
+// location returns the profile location with the given key, creating it at the
+// given line of the given function if it doesn't exist yet.
+func (p *GasProfiler) location(key string, function string, line int64) *profile.Location {
+	if location, ok := p.locations[key]; ok {
+		return location
+	}
+	fn, ok := p.functions[function]
+	if !ok {
+		fn = &profile.Function{
+			ID:         uint64(len(p.prof.Function) + 1),
+			Name:       function,
+			SystemName: function,
+			Filename:   function,
+		}
+		p.functions[function] = fn
+		p.prof.Function = append(p.prof.Function, fn)
+	}
+	location := &profile.Location{
+		ID:   uint64(len(p.prof.Location) + 1),
+		Line: []profile.Line{{Function: fn, Line: line}},
+	}
+	p.locations[key] = location
+	p.prof.Location = append(p.prof.Location, location)
+	return location
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	registryCacheHitMeter  = metrics.NewRegisteredMeter("vm/registry/cache/hit", nil)
+	registryCacheMissMeter = metrics.NewRegisteredMeter("vm/registry/cache/miss", nil)
+)
+
+// RegistryCache caches the core contract addresses looked up in the Registry
+// while processing a block, by the EVMs it's set in the Context of. Once the
+// storage of the Registry is written, the cache is emptied and stays disabled
+// for the rest of the block, so lookups made after a write, even a reverted
+// one, always reflect the current state.
+type RegistryCache struct {
+	addresses map[[32]byte]common.Address
+	written   bool // Whether the Registry storage has been written during the block
+	lock      sync.Mutex
+}
+
+// NewRegistryCache creates an empty Registry lookup cache.
+func NewRegistryCache() *RegistryCache {
+	return &RegistryCache{addresses: make(map[[32]byte]common.Address)}
+}
+
+// get returns the cached address registered for the given identifier, if any.
+func (c *RegistryCache) get(registryId [32]byte) (common.Address, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	address, ok := c.addresses[registryId]
+	if ok {
+		registryCacheHitMeter.Mark(1)
+	} else {
+		registryCacheMissMeter.Mark(1)
+	}
+	return address, ok
+}
+
+// set caches the address registered for the given identifier, unless the
+// Registry has been written during the block.
+func (c *RegistryCache) set(registryId [32]byte, address common.Address) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.written {
+		c.addresses[registryId] = address
+	}
+}
+
+// invalidate empties the cache and disables it for the rest of the block.
+func (c *RegistryCache) invalidate() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.written = true
+	c.addresses = make(map[[32]byte]common.Address)
+}
@@ -0,0 +1,86 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that Registry lookups are cached by the EVMs with a cache in their
+// context, and that writing the Registry storage invalidates the cache.
+func TestRegistryCache(t *testing.T) {
+	var (
+		registryId = params.GoldTokenRegistryId
+		first      = common.HexToAddress("0x01")
+		second     = common.HexToAddress("0x02")
+		registry   = params.RegistrySmartContractAddress
+	)
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb.CreateAccount(registry)
+	// Return sload(0) to any call with data, sstore(0, 0x42) otherwise
+	statedb.SetCode(registry, hexutil.MustDecode("0x361560105760005460005260206000f35b604260005500"))
+	statedb.SetState(registry, common.Hash{}, first.Hash())
+
+	vmctx := Context{
+		CanTransfer: func(StateDB, common.Address, *big.Int) bool { return true },
+		Transfer:    func(StateDB, common.Address, common.Address, *big.Int) {},
+		BlockNumber: new(big.Int),
+	}
+	lookup := func(want common.Address) {
+		t.Helper()
+		evm := NewEVM(vmctx, statedb, params.IstanbulTestChainConfig, Config{})
+		address, err := GetRegisteredAddressWithEvm(registryId, evm)
+		if err != nil {
+			t.Fatalf("lookup failed: %v", err)
+		}
+		if *address != want {
+			t.Fatalf("registered address mismatch: have %x, want %x", *address, want)
+		}
+	}
+	// Without caching, lookups always reflect the state
+	lookup(first)
+	statedb.SetState(registry, common.Hash{}, second.Hash())
+	lookup(second)
+
+	// With caching, changes outside the EVM go unnoticed
+	vmctx.RegistryCache = NewRegistryCache()
+	lookup(second)
+	statedb.SetState(registry, common.Hash{}, first.Hash())
+	lookup(second)
+
+	// Writing the Registry storage from the EVM invalidates the cache for good
+	evm := NewEVM(vmctx, statedb, params.IstanbulTestChainConfig, Config{})
+	if _, _, err := evm.Call(AccountRef(common.Address{}), registry, nil, 100000, new(big.Int)); err != nil {
+		t.Fatalf("registry write failed: %v", err)
+	}
+	lookup(common.HexToAddress("0x42"))
+	statedb.SetState(registry, common.Hash{}, first.Hash())
+	lookup(first)
+
+	// A new cache, as for the next block, caches the lookups again
+	vmctx.RegistryCache = NewRegistryCache()
+	lookup(first)
+	statedb.SetState(registry, common.Hash{}, second.Hash())
+	lookup(first)
+}
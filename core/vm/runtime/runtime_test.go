@@ -17,7 +17,9 @@
 package runtime
 
 import (
+	"fmt"
 	"math/big"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -328,3 +330,59 @@ func TestBlockhash(t *testing.T) {
 		t.Errorf("suboptimal; too much chain iteration, expected %d, got %d", exp, got)
 	}
 }
+
+func TestGasProfiler(t *testing.T) {
+	for _, callee := range [][]byte{
+		{byte(vm.PUSH1), 1, byte(vm.PUSH1), 0, byte(vm.SSTORE), byte(vm.STOP)},
+		{byte(vm.PUSH1), 1, byte(vm.PUSH1), 0, byte(vm.SSTORE), 0xfe},
+	} {
+		state, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+		caller, calleeAddr := common.HexToAddress("0xaa"), common.HexToAddress("0xbb")
+		state.SetCode(caller, []byte{
+			byte(vm.PUSH1), 0, // retSize
+			byte(vm.PUSH1), 0, // retOffset
+			byte(vm.PUSH1), 0, // inSize
+			byte(vm.PUSH1), 0, // inOffset
+			byte(vm.PUSH1), 0, // value
+			byte(vm.PUSH1), 0xbb,
+			byte(vm.GAS),
+			byte(vm.CALL),
+			byte(vm.POP),
+			byte(vm.STOP),
+		})
+		state.SetCode(calleeAddr, callee)
+
+		profiler := vm.NewGasProfiler()
+		cfg := &Config{State: state, GasLimit: 100000, EVMConfig: vm.Config{Debug: true, Tracer: profiler}}
+		_, leftOverGas, err := Call(caller, nil, cfg)
+		if err != nil {
+			t.Fatal("didn't expect error", err)
+		}
+		prof := profiler.Profile()
+		if err := prof.CheckValid(); err != nil {
+			t.Fatalf("invalid profile: %v", err)
+		}
+		// The gas of all samples must add up to the gas used by the call
+		var total, sstores int64
+		for _, sample := range prof.Sample {
+			total += sample.Value[0]
+			if sample.Label["opcode"][0] == vm.SSTORE.String() {
+				sstores++
+				var stack []string
+				for _, location := range sample.Location {
+					stack = append(stack, fmt.Sprintf("%s:%d", location.Line[0].Function.Name, location.Line[0].Line))
+				}
+				want := []string{"SSTORE:0", calleeAddr.Hex() + ":4", caller.Hex() + ":13"}
+				if !reflect.DeepEqual(stack, want) {
+					t.Errorf("SSTORE stack mismatch: have %v, want %v", stack, want)
+				}
+			}
+		}
+		if used := int64(cfg.GasLimit - leftOverGas); total != used {
+			t.Errorf("profiled gas mismatch: have %d, want %d", total, used)
+		}
+		if sstores != 1 {
+			t.Errorf("SSTORE sample count mismatch: have %d, want 1", sstores)
+		}
+	}
+}
@@ -552,3 +552,29 @@ func (api *PrivateDebugAPI) getModifiedAccounts(startBlock, endBlock *types.Bloc
 	}
 	return dirty, nil
 }
+
+// GetStateDiff returns the accounts and storage slots changed by a block, along
+// with their values before and after it. The block is re-executed on top of its
+// parent's state, so changes made by the consensus engine when finalizing the
+// block (e.g. epoch rewards and the randomness reveal) are included.
+func (api *PrivateDebugAPI) GetStateDiff(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*core.BlockStateDiff, error) {
+	block, err := api.eth.APIBackend.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, errors.New("block not found")
+	}
+	if block.NumberU64() == 0 {
+		return nil, errors.New("genesis has no state diff")
+	}
+	parent := api.eth.blockchain.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, fmt.Errorf("parent %#x not found", block.ParentHash())
+	}
+	statedb, err := api.computeStateDB(parent, defaultTraceReexec)
+	if err != nil {
+		return nil, err
+	}
+	return core.DiffBlock(api.eth.blockchain.Config(), api.eth.blockchain.Processor(), block, statedb)
+}
@@ -197,6 +197,19 @@ func (b *EthAPIBackend) GetEVM(ctx context.Context, msg vm.Message, header *type
 	return vm.NewEVM(context, state, b.eth.blockchain.Config(), *b.eth.blockchain.GetVMConfig()), vmError, nil
 }
 
+// GetSimulationEVM returns an EVM for simulating messages on the given state.
+// Unlike GetEVM, the sender isn't credited, so it pays for the messages.
+func (b *EthAPIBackend) GetSimulationEVM(ctx context.Context, msg vm.Message, header *types.Header, state *state.StateDB, vmCfg vm.Config) (*vm.EVM, func() error, error) {
+	context := vm.NewEVMContext(msg, header, b.eth.BlockChain(), nil)
+	return vm.NewEVM(context, state, b.eth.blockchain.Config(), vmCfg), func() error { return nil }, nil
+}
+
+// StateAtTransaction returns the message of a transaction together with the
+// context and state it was executed on, regenerating historical state if needed.
+func (b *EthAPIBackend) StateAtTransaction(ctx context.Context, blockHash common.Hash, txIndex int) (vm.Message, vm.Context, *state.StateDB, error) {
+	return NewPrivateDebugAPI(b.eth).computeTxEnv(blockHash, txIndex, defaultTraceReexec)
+}
+
 func (b *EthAPIBackend) SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent) event.Subscription {
 	return b.eth.BlockChain().SubscribeRemovedLogsEvent(ch)
 }
@@ -28,14 +28,19 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/contract_comm/random"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/tracers"
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native" // Register the native tracers
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -57,9 +62,10 @@ const (
 // TraceConfig holds extra parameters to trace functions.
 type TraceConfig struct {
 	*vm.LogConfig
-	Tracer  *string
-	Timeout *string
-	Reexec  *uint64
+	Tracer      *string
+	Timeout     *string
+	Reexec      *uint64
+	SystemCalls bool // Trace the system calls made for transactions and blocks separately
 }
 
 // StdTraceConfig holds extra parameters to standard-json trace functions.
@@ -71,6 +77,17 @@ type StdTraceConfig struct {
 
 // txTraceResult is the result of a single transaction trace.
 type txTraceResult struct {
+	Result      interface{}        `json:"result,omitempty"`            // Trace results produced by the tracer
+	Error       string             `json:"error,omitempty"`             // Trace failure produced by the tracer
+	SystemCalls []*systemCallTrace `json:"systemCalls,omitempty"`       // Traces of the system calls, if requested
+	Finalize    bool               `json:"blockFinalization,omitempty"` // Whether the system calls are the block's own
+}
+
+// systemCallTrace is the trace of a call made by the protocol on behalf of a
+// transaction or block, such as the debit of fees or the distribution of epoch
+// rewards.
+type systemCallTrace struct {
+	Origin string      `json:"origin"`           // Reason of the call, one of the vm.SystemCall* origins
 	Result interface{} `json:"result,omitempty"` // Trace results produced by the tracer
 	Error  string      `json:"error,omitempty"`  // Trace failure produced by the tracer
 }
@@ -136,7 +153,8 @@ func (api *PrivateDebugAPI) TraceChain(ctx context.Context, start, end rpc.Block
 
 // traceChain configures a new tracer according to the provided configuration, and
 // executes all the transactions contained within. The return value will be one item
-// per transaction, dependent on the requested tracer.
+// per transaction, dependent on the requested tracer. If system calls are traced,
+// each block has a trailing block finalization item, as in traceBlock.
 func (api *PrivateDebugAPI) traceChain(ctx context.Context, start, end *types.Block, config *TraceConfig) (*rpc.Subscription, error) {
 	// Tracing a chain is a **long** operation, only do with subscriptions
 	notifier, supported := rpc.NotifierFromContext(ctx)
@@ -203,21 +221,52 @@ func (api *PrivateDebugAPI) traceChain(ctx context.Context, start, end *types.Bl
 			for task := range tasks {
 				signer := types.MakeSigner(api.eth.blockchain.Config(), task.block.Number())
 
+				// Reveal the randomness, tracing it with the block's own system calls
+				var system *systemCallTracer
+				if config != nil && config.SystemCalls {
+					system = newSystemCallTracer(ctx, config)
+				}
+				if err := revealRandomness(task.block, task.statedb, system); err != nil {
+					log.Warn("Tracing failed", "block", task.block.NumberU64(), "err", err)
+					for i := range task.results {
+						task.results[i] = &txTraceResult{Error: err.Error()}
+					}
+					system.cancel()
+					select {
+					case results <- task:
+					case <-notifier.Closed():
+						return
+					}
+					continue
+				}
 				// Trace all the transactions contained within
+				failed := false
 				for i, tx := range task.block.Transactions() {
 					msg, _ := tx.AsMessage(signer)
 					vmctx := vm.NewEVMContext(msg, task.block.Header(), api.eth.blockchain, nil)
 
-					res, err := api.traceTx(ctx, msg, vmctx, task.statedb, config)
+					res, calls, err := api.traceTx(ctx, msg, vmctx, task.statedb, config)
 					if err != nil {
 						task.results[i] = &txTraceResult{Error: err.Error()}
 						log.Warn("Tracing failed", "hash", tx.Hash(), "block", task.block.NumberU64(), "err", err)
+						failed = true
 						break
 					}
 					// Only delete empty objects if EIP158/161 (a.k.a Spurious Dragon) is in effect
 					task.statedb.Finalise(api.eth.blockchain.Config().IsEIP158(task.block.Number()))
-					task.results[i] = &txTraceResult{Result: res}
+					task.results[i] = &txTraceResult{Result: res, SystemCalls: calls}
 				}
+				// Finalize the block to trace its system calls after the transactions
+				if system != nil && !failed {
+					result, err := api.traceFinalize(task.block, task.statedb, system)
+					if err != nil {
+						result = &txTraceResult{Error: err.Error(), Finalize: true}
+						log.Warn("Tracing failed", "block", task.block.NumberU64(), "err", err)
+					}
+					task.results = append(task.results, result)
+				}
+				system.cancel()
+
 				// Stream the result back to the user or abort on teardown
 				select {
 				case results <- task:
@@ -440,7 +489,8 @@ func (api *PrivateDebugAPI) StandardTraceBadBlockToFile(ctx context.Context, has
 
 // traceBlock configures a new tracer according to the provided configuration, and
 // executes all the transactions contained within. The return value will be one item
-// per transaction, dependent on the requestd tracer.
+// per transaction, dependent on the requestd tracer. If system calls are traced, a
+// trailing block finalization item holds the ones made for the block itself.
 func (api *PrivateDebugAPI) traceBlock(ctx context.Context, block *types.Block, config *TraceConfig) ([]*txTraceResult, error) {
 	// Create the parent state database
 	if err := api.eth.engine.VerifyHeader(api.eth.blockchain, block.Header(), true); err != nil {
@@ -458,6 +508,16 @@ func (api *PrivateDebugAPI) traceBlock(ctx context.Context, block *types.Block,
 	if err != nil {
 		return nil, err
 	}
+	// Reveal the randomness before the transactions, tracing it along with the
+	// system calls of the block itself if requested
+	var system *systemCallTracer
+	if config != nil && config.SystemCalls {
+		system = newSystemCallTracer(ctx, config)
+		defer system.cancel()
+	}
+	if err := revealRandomness(block, statedb, system); err != nil {
+		return nil, err
+	}
 	// Execute all the transaction contained within the block concurrently
 	var (
 		signer = types.MakeSigner(api.eth.blockchain.Config(), block.Number())
@@ -482,12 +542,12 @@ func (api *PrivateDebugAPI) traceBlock(ctx context.Context, block *types.Block,
 				msg, _ := txs[task.index].AsMessage(signer)
 				vmctx := vm.NewEVMContext(msg, block.Header(), api.eth.blockchain, nil)
 
-				res, err := api.traceTx(ctx, msg, vmctx, task.statedb, config)
+				res, calls, err := api.traceTx(ctx, msg, vmctx, task.statedb, config)
 				if err != nil {
 					results[task.index] = &txTraceResult{Error: err.Error()}
 					continue
 				}
-				results[task.index] = &txTraceResult{Result: res}
+				results[task.index] = &txTraceResult{Result: res, SystemCalls: calls}
 			}
 		}()
 	}
@@ -517,9 +577,60 @@ func (api *PrivateDebugAPI) traceBlock(ctx context.Context, block *types.Block,
 	if failed != nil {
 		return nil, failed
 	}
+	// Finalize the block to trace its system calls after the transactions
+	if system != nil {
+		result, err := api.traceFinalize(block, statedb, system)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
 	return results, nil
 }
 
+// systemCallFinalizer is implemented by the consensus engines able to trace the
+// system calls made when finalizing a block.
+type systemCallFinalizer interface {
+	FinalizeTraced(chain consensus.ChainReader, header *types.Header, state *state.StateDB, txs []*types.Transaction, tracer func(origin string) (vm.Tracer, error))
+}
+
+// revealRandomness reveals and commits the randomness of the block on the state
+// before its transactions, tracing it if system calls are traced.
+func revealRandomness(block *types.Block, statedb *state.StateDB, system *systemCallTracer) error {
+	if !random.IsRunning() {
+		return nil
+	}
+	var systemState vm.StateDB = statedb
+	if system != nil {
+		systemState = contract_comm.NewTracedState(statedb, system.newTracer)
+		defer contract_comm.StartSystemCall(systemState, vm.SystemCallRandomness)()
+	}
+	header := block.Header()
+	if err := random.RevealAndCommit(block.Randomness().Revealed, block.Randomness().Committed, header.Coinbase, header, systemState); err != nil {
+		return err
+	}
+	// always true (EIP158)
+	statedb.IntermediateRoot(true)
+	return nil
+}
+
+// traceFinalize finalizes the block on the state after its transactions,
+// returning the trailing block finalization item with the traces of the
+// system calls made for the block itself.
+func (api *PrivateDebugAPI) traceFinalize(block *types.Block, statedb *state.StateDB, system *systemCallTracer) (*txTraceResult, error) {
+	statedb.Prepare(common.Hash{}, block.Hash(), len(block.Transactions()))
+	if engine, ok := api.eth.engine.(systemCallFinalizer); ok {
+		engine.FinalizeTraced(api.eth.blockchain, block.Header(), statedb, block.Transactions(), system.newTracer)
+	} else {
+		api.eth.engine.Finalize(api.eth.blockchain, block.Header(), statedb, block.Transactions())
+	}
+	calls, err := system.results()
+	if err != nil {
+		return nil, err
+	}
+	return &txTraceResult{SystemCalls: calls, Finalize: true}, nil
+}
+
 // standardTraceBlockToFile configures a new tracer which uses standard JSON output,
 // and traces either a full block or an individual transaction. The return value will
 // be one filename per transaction traced.
@@ -716,53 +827,162 @@ func (api *PrivateDebugAPI) TraceTransaction(ctx context.Context, hash common.Ha
 		return nil, err
 	}
 	// Trace the transaction and return
-	return api.traceTx(ctx, msg, vmctx, statedb, config)
+	res, calls, err := api.traceTx(ctx, msg, vmctx, statedb, config)
+	if err != nil || calls == nil {
+		return res, err
+	}
+	return &txTraceResult{Result: res, SystemCalls: calls}, nil
+}
+
+// ProfileConfig holds extra parameters to profiling functions.
+type ProfileConfig struct {
+	Reexec *uint64
+}
+
+// ProfileTransaction re-executes the given transaction with the gas profiler,
+// returning the gas used and the time spent per opcode, program counter and
+// contract call frame as a gzipped pprof profile, to be inspected with
+// `go tool pprof`.
+func (api *PrivateDebugAPI) ProfileTransaction(ctx context.Context, hash common.Hash, config *ProfileConfig) (hexutil.Bytes, error) {
+	// Retrieve the transaction and assemble its EVM context
+	tx, blockHash, _, index := rawdb.ReadTransaction(api.eth.ChainDb(), hash)
+	if tx == nil {
+		return nil, fmt.Errorf("transaction %#x not found", hash)
+	}
+	reexec := defaultTraceReexec
+	if config != nil && config.Reexec != nil {
+		reexec = *config.Reexec
+	}
+	msg, vmctx, statedb, err := api.computeTxEnv(blockHash, int(index), reexec)
+	if err != nil {
+		return nil, err
+	}
+	// Profile the transaction and return the encoded profile
+	profiler := vm.NewGasProfiler()
+	vmenv := vm.NewEVM(vmctx, statedb, api.eth.blockchain.Config(), vm.Config{Debug: true, Tracer: profiler})
+
+	if _, _, _, err := core.ApplyMessage(vmenv, msg, new(core.GasPool).AddGas(msg.Gas())); err != nil {
+		return nil, fmt.Errorf("profiling failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := profiler.Profile().Write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RevertReason is the result of re-executing a reverted transaction, holding
+// the data it reverted with and the decoded Error(string) reason, if any.
+type RevertReason struct {
+	Data   hexutil.Bytes `json:"data"`
+	Reason string        `json:"reason,omitempty"`
+}
+
+// GetRevertReason re-executes the given transaction and returns the data it
+// reverted with, so that failed receipts can be inspected after the fact.
+func (api *PrivateDebugAPI) GetRevertReason(ctx context.Context, hash common.Hash) (*RevertReason, error) {
+	// Retrieve the transaction and assemble its EVM context
+	tx, blockHash, _, index := rawdb.ReadTransaction(api.eth.ChainDb(), hash)
+	if tx == nil {
+		return nil, fmt.Errorf("transaction %#x not found", hash)
+	}
+	msg, vmctx, statedb, err := api.computeTxEnv(blockHash, int(index), defaultTraceReexec)
+	if err != nil {
+		return nil, err
+	}
+	// Re-execute the transaction and decode the data it reverted with
+	vmenv := vm.NewEVM(vmctx, statedb, api.eth.blockchain.Config(), vm.Config{})
+	st := core.NewStateTransition(vmenv, msg, new(core.GasPool).AddGas(msg.Gas()))
+
+	data, _, _, err := st.TransitionDb()
+	if err != nil {
+		return nil, fmt.Errorf("execution failed: %v", err)
+	}
+	if st.VMError() != vm.ErrExecutionReverted {
+		return nil, fmt.Errorf("transaction %#x did not revert", hash)
+	}
+	result := &RevertReason{Data: data}
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		result.Reason = reason
+	}
+	return result, nil
 }
 
 // traceTx configures a new tracer according to the provided configuration, and
 // executes the given message in the provided environment. The return value will
-// be tracer dependent.
-func (api *PrivateDebugAPI) traceTx(ctx context.Context, message vm.Message, vmctx vm.Context, statedb *state.StateDB, config *TraceConfig) (interface{}, error) {
-	// Assemble the structured logger or the JavaScript tracer
-	var (
-		tracer vm.Tracer
-		err    error
-	)
+// be tracer dependent, along with the traces of the system calls made for the
+// message if requested.
+func (api *PrivateDebugAPI) traceTx(ctx context.Context, message vm.Message, vmctx vm.Context, statedb *state.StateDB, config *TraceConfig) (interface{}, []*systemCallTrace, error) {
+	tracer, cancel, err := newTracer(ctx, config)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cancel()
+
+	// Run the transaction with tracing enabled.
+	vmconf := vm.Config{Debug: true, Tracer: tracer}
+
+	var system *systemCallTracer
+	if config != nil && config.SystemCalls {
+		system = newSystemCallTracer(ctx, config)
+		defer system.cancel()
+		vmconf.SystemCallTracer = system.newTracer
+	}
+	vmenv := vm.NewEVM(vmctx, statedb, api.eth.blockchain.Config(), vmconf)
+
+	ret, gas, failed, err := core.ApplyMessage(vmenv, message, new(core.GasPool).AddGas(message.Gas()))
+	if err != nil {
+		return nil, nil, fmt.Errorf("tracing failed: %v", err)
+	}
+	result, err := formatTraceResult(tracer, ret, gas, failed)
+	if err != nil {
+		return nil, nil, err
+	}
+	calls, err := system.results()
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, calls, nil
+}
+
+// newTracer assembles the structured logger, or the native or JavaScript tracer
+// requested by the configuration. The returned function releases the resources
+// of the tracer's timeout.
+func newTracer(ctx context.Context, config *TraceConfig) (vm.Tracer, context.CancelFunc, error) {
 	switch {
 	case config != nil && config.Tracer != nil:
 		// Define a meaningful timeout of a single transaction trace
 		timeout := defaultTraceTimeout
 		if config.Timeout != nil {
+			var err error
 			if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 		}
-		// Constuct the JavaScript tracer to execute with
-		if tracer, err = tracers.New(*config.Tracer); err != nil {
-			return nil, err
+		// Constuct the native or JavaScript tracer to execute with
+		tracer, err := tracers.NewTracer(*config.Tracer)
+		if err != nil {
+			return nil, nil, err
 		}
 		// Handle timeouts and RPC cancellations
 		deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
 		go func() {
 			<-deadlineCtx.Done()
-			tracer.(*tracers.Tracer).Stop(errors.New("execution timeout"))
+			tracer.Stop(errors.New("execution timeout"))
 		}()
-		defer cancel()
+		return tracer, cancel, nil
 
 	case config == nil:
-		tracer = vm.NewStructLogger(nil)
+		return vm.NewStructLogger(nil), func() {}, nil
 
 	default:
-		tracer = vm.NewStructLogger(config.LogConfig)
+		return vm.NewStructLogger(config.LogConfig), func() {}, nil
 	}
-	// Run the transaction with tracing enabled.
-	vmenv := vm.NewEVM(vmctx, statedb, api.eth.blockchain.Config(), vm.Config{Debug: true, Tracer: tracer})
+}
 
-	ret, gas, failed, err := core.ApplyMessage(vmenv, message, new(core.GasPool).AddGas(message.Gas()))
-	if err != nil {
-		return nil, fmt.Errorf("tracing failed: %v", err)
-	}
-	// Depending on the tracer type, format and return the output
+// formatTraceResult formats the output of a finished trace, depending on the
+// tracer type.
+func formatTraceResult(tracer vm.Tracer, ret []byte, gas uint64, failed bool) (interface{}, error) {
 	switch tracer := tracer.(type) {
 	case *vm.StructLogger:
 		return &ethapi.ExecutionResult{
@@ -772,7 +992,7 @@ func (api *PrivateDebugAPI) traceTx(ctx context.Context, message vm.Message, vmc
 			StructLogs:  ethapi.FormatLogs(tracer.StructLogs()),
 		}, nil
 
-	case *tracers.Tracer:
+	case tracers.ResultTracer:
 		return tracer.GetResult()
 
 	default:
@@ -780,6 +1000,85 @@ func (api *PrivateDebugAPI) traceTx(ctx context.Context, message vm.Message, vmc
 	}
 }
 
+// systemCall is a system call being traced. It records the outcome of the call
+// reported to the tracer, which the structured logger doesn't keep.
+type systemCall struct {
+	vm.Tracer
+
+	origin  string
+	output  []byte
+	gasUsed uint64
+	err     error
+}
+
+// CaptureEnd implements the vm.Tracer interface to record the outcome of the call.
+func (c *systemCall) CaptureEnd(output []byte, gasUsed uint64, t time.Duration, err error) error {
+	c.output, c.gasUsed, c.err = output, gasUsed, err
+	return c.Tracer.CaptureEnd(output, gasUsed, t, err)
+}
+
+// systemCallTracer creates a tracer for each system call, as configured for the
+// transaction or block they're made for, keeping them in execution order.
+type systemCallTracer struct {
+	ctx     context.Context
+	config  *TraceConfig
+	calls   []*systemCall
+	cancels []context.CancelFunc
+	err     error // First failure to create a tracer
+}
+
+func newSystemCallTracer(ctx context.Context, config *TraceConfig) *systemCallTracer {
+	return &systemCallTracer{ctx: ctx, config: config}
+}
+
+// newTracer creates the tracer of a system call with the given origin. Failures
+// are also reported by results, as the EVM carries on without tracing the call.
+func (t *systemCallTracer) newTracer(origin string) (vm.Tracer, error) {
+	tracer, cancel, err := newTracer(t.ctx, t.config)
+	if err != nil {
+		err = fmt.Errorf("failed to create system call tracer: %v", err)
+		if t.err == nil {
+			t.err = err
+		}
+		return nil, err
+	}
+	call := &systemCall{Tracer: tracer, origin: origin}
+	t.calls = append(t.calls, call)
+	t.cancels = append(t.cancels, cancel)
+	return call, nil
+}
+
+// cancel releases the resources of the tracers' timeouts.
+func (t *systemCallTracer) cancel() {
+	if t == nil {
+		return
+	}
+	for _, cancel := range t.cancels {
+		cancel()
+	}
+}
+
+// results returns the traces of the system calls made so far, nil if system
+// calls aren't traced. It fails if a tracer could not be created.
+func (t *systemCallTracer) results() ([]*systemCallTrace, error) {
+	if t == nil {
+		return nil, nil
+	}
+	if t.err != nil {
+		return nil, t.err
+	}
+	results := make([]*systemCallTrace, len(t.calls))
+	for i, call := range t.calls {
+		results[i] = &systemCallTrace{Origin: call.origin}
+		if res, err := formatTraceResult(call.Tracer, call.output, call.gasUsed, call.err != nil); err != nil {
+			results[i].Error = err.Error()
+		} else {
+			results[i].Result = res
+		}
+	}
+	return results, nil
+}
+
 // computeTxEnv returns the execution environment of a certain transaction.
 func (api *PrivateDebugAPI) computeTxEnv(blockHash common.Hash, txIndex int, reexec uint64) (vm.Message, vm.Context, *state.StateDB, error) {
 	// Create the parent state database
@@ -795,6 +1094,9 @@ func (api *PrivateDebugAPI) computeTxEnv(blockHash common.Hash, txIndex int, ree
 	if err != nil {
 		return nil, vm.Context{}, nil, err
 	}
+	if err := revealRandomness(block, statedb, nil); err != nil {
+		return nil, vm.Context{}, nil, err
+	}
 
 	if txIndex == 0 && len(block.Transactions()) == 0 {
 		return nil, vm.Context{}, statedb, nil
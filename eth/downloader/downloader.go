@@ -94,6 +94,8 @@ var (
 	errCanceled                = errors.New("syncing canceled (requested)")
 	errNoSyncActive            = errors.New("no sync active")
 	errTooOld                  = errors.New("peer doesn't speak recent enough protocol version (need version >= 62)")
+	errInvalidEpochBlock       = errors.New("epoch block header does not match the verified epoch headers")
+	errEpochSyncUnsupported    = errors.New("epoch sync requires istanbul consensus")
 )
 
 // If you adding a new variable add it at the bottom. Otherwise, you can end up making some uint64 unaligned to 8-byte
@@ -161,6 +163,8 @@ type Downloader struct {
 	quitLock      sync.RWMutex  // Lock to prevent double closes
 	epoch         uint64        // Epoch value is useful in IBFT consensus
 	ibftConsensus bool          // True if we are in IBFT consensus mode
+	epochBlock    uint64        // Number of the latest epoch block authenticated by the epoch headers (only used in epoch sync mode)
+	epochHash     common.Hash   // Hash of the latest epoch block authenticated by the epoch headers (only used in epoch sync mode)
 
 	// Testing hooks
 	syncInitHook     func(uint64, uint64)  // Method to call upon initiating a new sync run
@@ -218,6 +222,10 @@ type BlockChain interface {
 
 	// InsertReceiptChain inserts a batch of receipts into the local chain.
 	InsertReceiptChain(types.Blocks, []types.Receipts, uint64) (int, error)
+
+	// VerifyEpochHeaders verifies a chain of consecutive epoch block headers,
+	// following the last epoch block known to the local chain.
+	VerifyEpochHeaders([]*types.Header) error
 }
 
 // TODO(tim) previously passing mode here!
@@ -290,7 +298,7 @@ func (d *Downloader) Progress() ethereum.SyncProgress {
 	switch {
 	case d.blockchain != nil && d.Mode == FullSync:
 		current = d.blockchain.CurrentBlock().NumberU64()
-	case d.blockchain != nil && d.Mode == FastSync:
+	case d.blockchain != nil && d.Mode.SyncPivotState():
 		current = d.blockchain.CurrentFastBlock().NumberU64()
 	case d.lightchain != nil:
 		current = d.lightchain.CurrentHeader().Number.Uint64()
@@ -497,9 +505,12 @@ func (d *Downloader) syncWithPeer(p *peerConnection, hash common.Hash, td *big.I
 	d.syncStatsChainHeight = height
 	d.syncStatsLock.Unlock()
 
+	if d.Mode == EpochSync && d.epoch == 0 {
+		return errEpochSyncUnsupported
+	}
 	// Ensure our origin point is below any fast sync pivot point
 	pivot := uint64(0)
-	if d.Mode == FastSync {
+	if d.Mode.SyncPivotState() {
 		pivot = d.calcPivot(height)
 		rawdb.WriteLastPivotNumber(d.stateDB, pivot)
 		if pivot == 0 {
@@ -508,11 +519,22 @@ func (d *Downloader) syncWithPeer(p *peerConnection, hash common.Hash, td *big.I
 			origin = pivot - 1
 		}
 	}
+	// In epoch sync, the pivot is an epoch block, authenticated through the epoch
+	// headers leading up to it before downloading anything else. The headers after
+	// it are verified as usual on import, so the pivot is free to move with the
+	// chain head.
+	d.epochBlock, d.epochHash = 0, common.Hash{}
+	if d.Mode == EpochSync && pivot > origin {
+		if d.epochHash, err = d.verifyEpochHeaders(p, origin, pivot); err != nil {
+			return err
+		}
+		d.epochBlock = pivot
+	}
 	d.committed = 1
-	if d.Mode == FastSync && pivot != 0 {
+	if d.Mode.SyncPivotState() && pivot != 0 {
 		d.committed = 0
 	}
-	if d.Mode == FastSync {
+	if d.Mode.SyncPivotState() {
 		// Set the ancient data limitation.
 		// If we are running fast sync, all block data older than ancientLimit will be
 		// written to the ancient store. More recent data will be written to the active
@@ -560,7 +582,7 @@ func (d *Downloader) syncWithPeer(p *peerConnection, hash common.Hash, td *big.I
 		func() error { return d.fetchReceipts(origin + 1) },                // Receipts are retrieved during fast sync
 		func() error { return d.processHeaders(origin+1, pivot, td) },
 	}
-	if d.Mode == FastSync {
+	if d.Mode.SyncPivotState() {
 		fetchers = append(fetchers, func() error { return d.processFastSyncContent(latest) })
 	} else if d.Mode == FullSync {
 		fetchers = append(fetchers, d.processFullSyncContent)
@@ -667,7 +689,7 @@ func (d *Downloader) fetchHeight(p *peerConnection) (*types.Header, error) {
 				return nil, errBadPeer
 			}
 			head := headers[0]
-			if (d.Mode == FastSync || d.Mode == LightSync) && head.Number.Uint64() < d.checkpoint {
+			if (d.Mode.SyncPivotState() || d.Mode == LightSync) && head.Number.Uint64() < d.checkpoint {
 				p.log.Warn("Remote head below checkpoint", "number", head.Number, "hash", head.Hash())
 				return nil, errUnsyncedPeer
 			}
@@ -685,6 +707,72 @@ func (d *Downloader) fetchHeight(p *peerConnection) (*types.Header, error) {
 	}
 }
 
+// verifyEpochHeaders retrieves the headers of all epoch blocks after origin up
+// to and including target, which must be an epoch block, from the given peer and
+// verifies the validator set transitions they encode. It returns the hash of the
+// verified target header.
+func (d *Downloader) verifyEpochHeaders(p *peerConnection, origin uint64, target uint64) (common.Hash, error) {
+	var (
+		from    = (origin/d.epoch + 1) * d.epoch
+		headers = make([]*types.Header, 0, (target-from)/d.epoch+1)
+	)
+	p.log.Debug("Retrieving epoch headers", "from", from, "target", target)
+	for from <= target {
+		count := (target-from)/d.epoch + 1
+		if count > uint64(MaxEpochHeaderFetch) {
+			count = uint64(MaxEpochHeaderFetch)
+		}
+		go p.peer.RequestHeadersByNumber(from, int(count), int(d.epoch-1), false)
+
+		ttl := d.requestTTL()
+		timeout := time.After(ttl)
+	wait:
+		for {
+			select {
+			case <-d.cancelCh:
+				return common.Hash{}, errCanceled
+
+			case packet := <-d.headerCh:
+				// Discard anything not from the origin peer
+				if packet.PeerId() != p.id {
+					log.Debug("Received headers from incorrect peer", "peer", packet.PeerId())
+					break
+				}
+				// Make sure the peer gave us the requested epoch blocks in order
+				delivered := packet.(*headerPack).headers
+				if len(delivered) == 0 || uint64(len(delivered)) > count {
+					p.log.Debug("Invalid epoch header count", "requested", count, "delivered", len(delivered))
+					return common.Hash{}, errBadPeer
+				}
+				for i, header := range delivered {
+					if header.Number.Uint64() != from+uint64(i)*d.epoch {
+						p.log.Debug("Unrequested epoch header", "number", header.Number, "want", from+uint64(i)*d.epoch)
+						return common.Hash{}, errBadPeer
+					}
+				}
+				headers = append(headers, delivered...)
+				from += uint64(len(delivered)) * d.epoch
+				break wait
+
+			case <-timeout:
+				p.log.Debug("Waiting for epoch headers timed out", "elapsed", ttl)
+				return common.Hash{}, errTimeout
+
+			case <-d.bodyCh:
+			case <-d.receiptCh:
+				// Out of bounds delivery, ignore
+			}
+		}
+	}
+	if err := d.blockchain.VerifyEpochHeaders(headers); err != nil {
+		p.log.Warn("Invalid epoch headers", "target", target, "err", err)
+		return common.Hash{}, fmt.Errorf("%w: %v", errInvalidChain, err)
+	}
+	targetHeader := headers[len(headers)-1]
+	p.log.Info("Verified epoch headers", "count", len(headers), "target", target, "hash", targetHeader.Hash())
+	return targetHeader.Hash(), nil
+}
+
 // calculateRequestSpan calculates what headers to request from a peer when trying to determine the
 // common ancestor.
 // It returns parameters to be used for peer.RequestHeadersByNumber:
@@ -752,7 +840,7 @@ func (d *Downloader) findAncestor(p *peerConnection, remoteHeader *types.Header)
 	switch d.Mode {
 	case FullSync:
 		localHeight = d.blockchain.CurrentBlock().NumberU64()
-	case FastSync:
+	case FastSync, EpochSync:
 		localHeight = d.blockchain.CurrentFastBlock().NumberU64()
 	default:
 		localHeight = d.lightchain.CurrentHeader().Number.Uint64()
@@ -838,7 +926,7 @@ func (d *Downloader) findAncestor(p *peerConnection, remoteHeader *types.Header)
 				switch d.Mode {
 				case FullSync:
 					known = d.blockchain.HasBlock(h, n)
-				case FastSync:
+				case FastSync, EpochSync:
 					known = d.blockchain.HasFastBlock(h, n)
 				default:
 					known = d.lightchain.HasHeader(h, n)
@@ -911,7 +999,7 @@ func (d *Downloader) findAncestor(p *peerConnection, remoteHeader *types.Header)
 				switch d.Mode {
 				case FullSync:
 					known = d.blockchain.HasBlock(h, n)
-				case FastSync:
+				case FastSync, EpochSync:
 					known = d.blockchain.HasFastBlock(h, n)
 				default:
 					known = d.lightchain.HasHeader(h, n)
@@ -1568,7 +1656,7 @@ func (d *Downloader) processHeaders(origin uint64, pivot uint64, td *big.Int) er
 				// queued for processing when the header download completes. However, as long as the
 				// peer gave us something useful, we're already happy/progressed (above check).
 
-				if d.Mode == FastSync || d.Mode == LightSync {
+				if d.Mode.SyncPivotState() || d.Mode == LightSync {
 					head := d.lightchain.CurrentHeader()
 					if td.Cmp(d.lightchain.GetTd(head.Hash(), head.Number.Uint64())) > 0 {
 						rollbackErr = errStallingPeer
@@ -1597,7 +1685,7 @@ func (d *Downloader) processHeaders(origin uint64, pivot uint64, td *big.Int) er
 				chunk := headers[:limit]
 
 				// In case of header only syncing, validate the chunk immediately
-				if mode == FastSync || !mode.SyncFullBlockChain() {
+				if mode.SyncPivotState() || !mode.SyncFullBlockChain() {
 					// If we're importing pure headers, verify based on their recentness
 					frequency := fsHeaderCheckFrequency
 					if chunk[len(chunk)-1].Number.Uint64()+uint64(fsHeaderForceVerify) > pivot {
@@ -1613,6 +1701,20 @@ func (d *Downloader) processHeaders(origin uint64, pivot uint64, td *big.Int) er
 						log.Debug("Invalid header encountered", "number", chunk[n].Number, "hash", chunk[n].Hash(), "err", err)
 						return fmt.Errorf("%w: %v", errInvalidChain, err)
 					}
+					// In epoch sync the latest epoch block was authenticated upfront,
+					// which in turn authenticates all headers it links back to
+					if d.epochHash != (common.Hash{}) {
+						if first, last := chunk[0].Number.Uint64(), chunk[len(chunk)-1].Number.Uint64(); first <= d.epochBlock && d.epochBlock <= last {
+							if hash := chunk[d.epochBlock-first].Hash(); hash != d.epochHash {
+								rollbackErr = errInvalidEpochBlock
+								if rollback == 0 {
+									rollback = first
+								}
+								log.Debug("Epoch block header mismatch", "number", d.epochBlock, "hash", hash, "want", d.epochHash)
+								return fmt.Errorf("%w: %v", errInvalidChain, errInvalidEpochBlock)
+							}
+						}
+					}
 					// All verifications passed, track all headers within the alloted limits
 					head := chunk[len(chunk)-1].Number.Uint64()
 					if head-rollback > uint64(fsHeaderSafetyNet) {
@@ -1737,6 +1839,16 @@ func computePivot(height uint64, epochSize uint64) uint64 {
 
 }
 
+// computeEpochBlock returns the most recent epoch block at least fsMinFullBlocks
+// below height, or zero (the genesis) if there is none. It is the block epoch
+// sync authenticates through the epoch headers before syncing the pivot state.
+func computeEpochBlock(height uint64, epochSize uint64) uint64 {
+	if height <= fsMinFullBlocks {
+		return 0
+	}
+	return (height - fsMinFullBlocks) / epochSize * epochSize
+}
+
 func (d *Downloader) calcPivot(height uint64) uint64 {
 	// If epoch is not set (not IBFT) use old logic
 	if d.epoch == 0 {
@@ -1745,6 +1857,12 @@ func (d *Downloader) calcPivot(height uint64) uint64 {
 		}
 		return height - fsMinFullBlocks
 	}
+	// Epoch sync pivots on the epoch block it authenticates. Like the first block
+	// of an epoch the fast sync pivot is on, its state holds no uptime of the
+	// following epoch, which is then accumulated in full by importing its blocks.
+	if d.Mode == EpochSync {
+		return computeEpochBlock(height, d.epoch)
+	}
 	return computePivot(height, d.epoch)
 }
 
@@ -28,6 +28,7 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -51,6 +52,7 @@ func init() {
 // downloadTester is a test simulator for mocking out local block chain.
 type downloadTester struct {
 	downloader *Downloader
+	config     *params.ChainConfig // Chain configuration, nil for a non-istanbul chain
 
 	genesis *types.Block   // Genesis blocks used by the tester and peers
 	stateDb ethdb.Database // Database used by the tester for syncing from peers
@@ -68,16 +70,31 @@ type downloadTester struct {
 	ancientReceipts map[common.Hash]types.Receipts // Ancient receipts belonging to the tester
 	ancientChainTd  map[common.Hash]*big.Int       // Ancient total difficulties of the blocks in the local chain
 
+	uptime map[uint64]uint64 // Number of fully imported blocks tallied into the uptime of each epoch
+
 	lock sync.RWMutex
 }
 
 func (dl *downloadTester) Config() *params.ChainConfig {
-	return nil
+	return dl.config
 }
 
 // newTester creates a new downloader test mocker.
 func newTester() *downloadTester {
+	return newTesterWithConfig(nil)
+}
+
+// newEpochTester creates a new downloader test mocker for an istanbul chain with
+// epochs of testEpoch blocks.
+func newEpochTester() *downloadTester {
+	return newTesterWithConfig(&params.ChainConfig{Istanbul: &params.IstanbulConfig{Epoch: testEpoch}})
+}
+
+// newTesterWithConfig creates a new downloader test mocker for a chain with the
+// given configuration.
+func newTesterWithConfig(config *params.ChainConfig) *downloadTester {
 	tester := &downloadTester{
+		config:      config,
 		genesis:     testGenesis,
 		peerDb:      testDB,
 		peers:       make(map[string]*downloadTesterPeer),
@@ -92,6 +109,8 @@ func newTester() *downloadTester {
 		ancientBlocks:   map[common.Hash]*types.Block{testGenesis.Hash(): testGenesis},
 		ancientReceipts: map[common.Hash]types.Receipts{testGenesis.Hash(): nil},
 		ancientChainTd:  map[common.Hash]*big.Int{testGenesis.Hash(): testGenesis.TotalDifficulty()},
+
+		uptime: make(map[uint64]uint64),
 	}
 	tester.stateDb = rawdb.NewMemoryDatabase()
 	tester.stateDb.Put(testGenesis.Root().Bytes(), []byte{0x00})
@@ -242,6 +261,29 @@ func (dl *downloadTester) FastSyncCommitHead(hash common.Hash) error {
 	return fmt.Errorf("non existent block: %x", hash[:4])
 }
 
+// VerifyEpochHeaders checks that the headers are the consecutive epoch blocks
+// following the last epoch block of the local chain, and that each of them hands
+// over to the validator of the canonical epoch test chain (generated with seed
+// zero). This stands in for the aggregated seal checks done by istanbul.
+func (dl *downloadTester) VerifyEpochHeaders(headers []*types.Header) error {
+	if dl.config == nil || dl.config.Istanbul == nil {
+		return errors.New("epoch headers verified without istanbul config")
+	}
+	epoch := dl.config.Istanbul.Epoch
+	next := dl.CurrentHeader().Number.Uint64()/epoch*epoch + epoch
+	for _, header := range headers {
+		number := header.Number.Uint64()
+		if number != next {
+			return fmt.Errorf("epoch block %d out of sequence, want %d", number, next)
+		}
+		if want := epochValidator(number, 0); common.BytesToAddress(header.Extra) != want || len(header.Extra) != common.AddressLength {
+			return fmt.Errorf("epoch block %d validator mismatch: have %x, want %x", number, header.Extra, want)
+		}
+		next += epoch
+	}
+	return nil
+}
+
 // GetTd retrieves the block's total difficulty from the canonical chain.
 func (dl *downloadTester) GetTd(hash common.Hash, number uint64) *big.Int {
 	dl.lock.RLock()
@@ -309,6 +351,13 @@ func (dl *downloadTester) InsertChain(blocks types.Blocks) (i int, err error) {
 			dl.ownHashes = append(dl.ownHashes, block.Hash())
 			dl.ownHeaders[block.Hash()] = block.Header()
 		}
+		// Tally the uptime like the blockchain does when writing executed blocks,
+		// skipping the first block of each epoch
+		if _, ok := dl.ownBlocks[block.Hash()]; !ok && dl.config != nil && dl.config.Istanbul != nil {
+			if epoch := dl.config.Istanbul.Epoch; !istanbul.IsFirstBlockOfEpoch(block.NumberU64(), epoch) {
+				dl.uptime[istanbul.GetEpochNumber(block.NumberU64(), epoch)]++
+			}
+		}
 		dl.ownBlocks[block.Hash()] = block
 		dl.ownReceipts[block.Hash()] = make(types.Receipts, 0)
 		dl.stateDb.Put(block.Root().Bytes(), []byte{0x00})
@@ -1642,3 +1691,82 @@ func TestPivot(t *testing.T) {
 		}
 	}
 }
+
+func TestEpochBlock(t *testing.T) {
+	testCases := []struct {
+		height   uint64
+		epoch    uint64
+		expected uint64
+	}{
+		{0, 17280, 0},
+		{172, 17280, 0},
+		{17280, 17280, 0},
+		{17280 + fsMinFullBlocks, 17280, 17280},
+		{17280*10 + 1000, 17280, 17280 * 10},
+		{17280*10 + 10, 17280, 17280 * 9},
+		{17280 * 10, 17280, 17280 * 9},
+	}
+	for _, tt := range testCases {
+		if res := computeEpochBlock(tt.height, tt.epoch); res != tt.expected {
+			t.Errorf("Got %v expected %v for value %v", res, tt.expected, tt.height)
+		}
+	}
+}
+
+// Tests that epoch sync completes against a chain announcing the expected
+// validators at its epoch blocks, and that an otherwise valid chain handing over
+// to different validators is rejected before anything is imported.
+func TestEpochSync64(t *testing.T) { testEpochSync(t, 64) }
+func TestEpochSync65(t *testing.T) { testEpochSync(t, 65) }
+
+func testEpochSync(t *testing.T, protocol int) {
+	t.Parallel()
+
+	tester := newEpochTester()
+	defer tester.terminate()
+
+	// End the chain late in an epoch, with the blocks fsMinFullBlocks below the
+	// head past the first block of the epoch
+	chain := newEpochTestChain(11*testEpoch-testEpoch/5+1, tester.genesis, 0)
+	height := uint64(chain.len() - 1)
+	epochBlock := computeEpochBlock(height, testEpoch)
+	if epochBlock < 2*testEpoch {
+		t.Fatalf("test chain too short: epoch block %d", epochBlock)
+	}
+	// Fork off a chain announcing other validators from the epoch block before
+	// the authenticated one on and check that it is rejected
+	fork := int(epochBlock - testEpoch)
+	tampered := chain.shorten(fork).makeFork(chain.len()-fork, false, 1)
+	tester.newPeer("tampered", protocol, tampered)
+	if err := tester.sync("tampered", nil, EpochSync); !errors.Is(err, errInvalidChain) {
+		t.Fatalf("tampered chain sync error mismatch: have %v, want %v", err, errInvalidChain)
+	}
+	if head := tester.CurrentHeader().Number.Uint64(); head != 0 {
+		t.Fatalf("tampered chain partially imported: head %d", head)
+	}
+	// Synchronise with the canonical chain and check that everything, including
+	// the epoch block, is imported
+	tester.newPeer("peer", protocol, chain)
+	if err := tester.sync("peer", nil, EpochSync); err != nil {
+		t.Fatalf("failed to synchronise blocks: %v", err)
+	}
+	assertOwnChain(t, tester, chain.len())
+
+	epochHeader := chain.headerm[chain.chain[epochBlock]]
+	if block := tester.GetBlockByHash(epochHeader.Hash()); block == nil {
+		t.Fatalf("epoch block %d missing", epochBlock)
+	}
+	if head := tester.CurrentBlock().NumberU64(); head != height {
+		t.Fatalf("head block mismatch: have %d, want %d", head, height)
+	}
+	// The state pivot must be the authenticated epoch block, so that the uptime
+	// of the head epoch is accumulated over all its blocks
+	if pivot := rawdb.ReadLastPivotNumber(tester.stateDb); pivot == nil || *pivot != epochBlock {
+		t.Fatalf("pivot mismatch: have %v, want %d", pivot, epochBlock)
+	}
+	headEpoch := istanbul.GetEpochNumber(height, testEpoch)
+	first, _ := istanbul.GetEpochFirstBlockNumber(headEpoch, testEpoch)
+	if uptime := tester.uptime[headEpoch]; uptime != height-first {
+		t.Fatalf("uptime tally mismatch: have %d blocks, want %d", uptime, height-first)
+	}
+}
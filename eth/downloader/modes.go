@@ -26,10 +26,11 @@ const (
 	FastSync                     // Quickly download the headers, full sync only at the chain head
 	LightSync                    // Download only the headers and terminate afterwards
 	LightestSync                 // Synchronise one block per Epoch (Celo-specific mode)
+	EpochSync                    // Download the state of the latest epoch block, full sync from there on (Celo-specific mode)
 )
 
 func (mode SyncMode) IsValid() bool {
-	return mode >= FullSync && mode <= LightestSync
+	return mode >= FullSync && mode <= EpochSync
 }
 
 // String implements the stringer interface.
@@ -43,6 +44,8 @@ func (mode SyncMode) String() string {
 		return "light"
 	case LightestSync:
 		return "lightest"
+	case EpochSync:
+		return "epoch"
 	default:
 		return "unknown"
 	}
@@ -58,6 +61,8 @@ func (mode SyncMode) MarshalText() ([]byte, error) {
 		return []byte("light"), nil
 	case LightestSync:
 		return []byte("lightest"), nil
+	case EpochSync:
+		return []byte("epoch"), nil
 	default:
 		return nil, fmt.Errorf("unknown sync mode %d", mode)
 	}
@@ -73,8 +78,10 @@ func (mode *SyncMode) UnmarshalText(text []byte) error {
 		*mode = LightSync
 	case "lightest":
 		*mode = LightestSync
+	case "epoch":
+		*mode = EpochSync
 	default:
-		return fmt.Errorf(`unknown sync mode %q, want "full", "fast", "light", or "lightest"`, text)
+		return fmt.Errorf(`unknown sync mode %q, want "full", "fast", "light", "lightest" or "epoch"`, text)
 	}
 	return nil
 }
@@ -90,6 +97,8 @@ func (mode SyncMode) SyncFullHeaderChain() bool {
 		return true
 	case LightestSync:
 		return false
+	case EpochSync:
+		return true
 	default:
 		panic(fmt.Errorf("unknown sync mode %d", mode))
 	}
@@ -107,6 +116,28 @@ func (mode SyncMode) SyncFullBlockChain() bool {
 		return false
 	case LightestSync:
 		return false
+	case EpochSync:
+		return true
+	default:
+		panic(fmt.Errorf("unknown sync mode %d", mode))
+	}
+}
+
+// Returns true if the state is downloaded at a pivot block, instead of being
+// generated by executing all blocks from genesis.
+// If a mode returns true here then it will return true for `SyncFullBlockChain` as well.
+func (mode SyncMode) SyncPivotState() bool {
+	switch mode {
+	case FullSync:
+		return false
+	case FastSync:
+		return true
+	case LightSync:
+		return false
+	case LightestSync:
+		return false
+	case EpochSync:
+		return true
 	default:
 		panic(fmt.Errorf("unknown sync mode %d", mode))
 	}
@@ -38,6 +38,9 @@ var (
 	testGenesis = core.GenesisBlockForTesting(testDB, testAddress, big.NewInt(1000000000))
 )
 
+// Epoch size of the epoch test chains.
+const testEpoch = 100
+
 // The common prefix of all test chains:
 var testChainBase = newTestChain(blockCacheItems+200, testGenesis)
 
@@ -56,6 +59,7 @@ func init() {
 
 type testChain struct {
 	genesis  *types.Block
+	epoch    uint64 // Epoch size if epoch blocks announce validators, zero otherwise
 	chain    []common.Hash
 	headerm  map[common.Hash]*types.Header
 	blockm   map[common.Hash]*types.Block
@@ -75,6 +79,27 @@ func newTestChain(length int, genesis *types.Block) *testChain {
 	return tc
 }
 
+// newEpochTestChain creates a blockchain of the given length whose epoch blocks
+// announce the validator taking over at the next epoch. Chains generated with
+// different seeds hand over to different validators.
+func newEpochTestChain(length int, genesis *types.Block, seed byte) *testChain {
+	tc := new(testChain).copy(length)
+	tc.genesis = genesis
+	tc.epoch = testEpoch
+	tc.chain = append(tc.chain, genesis.Hash())
+	tc.headerm[tc.genesis.Hash()] = tc.genesis.Header()
+	tc.tdm[tc.genesis.Hash()] = tc.genesis.TotalDifficulty()
+	tc.blockm[tc.genesis.Hash()] = tc.genesis
+	tc.generate(length-1, seed, genesis, false)
+	return tc
+}
+
+// epochValidator returns the validator announced by the epoch block with the
+// given number in an epoch test chain generated with the given seed.
+func epochValidator(number uint64, seed byte) common.Address {
+	return common.BytesToAddress(crypto.Keccak256([]byte{seed}, new(big.Int).SetUint64(number).Bytes()))
+}
+
 // makeFork creates a fork on top of the test chain.
 func (tc *testChain) makeFork(length int, heavy bool, seed byte) *testChain {
 	fork := tc.copy(tc.len() + length)
@@ -94,6 +119,7 @@ func (tc *testChain) shorten(length int) *testChain {
 func (tc *testChain) copy(newlen int) *testChain {
 	cpy := &testChain{
 		genesis:  tc.genesis,
+		epoch:    tc.epoch,
 		headerm:  make(map[common.Hash]*types.Header, newlen),
 		blockm:   make(map[common.Hash]*types.Block, newlen),
 		receiptm: make(map[common.Hash][]*types.Receipt, newlen),
@@ -119,6 +145,10 @@ func (tc *testChain) generate(n int, seed byte, parent *types.Block, heavy bool)
 
 	blocks, receipts := core.GenerateChain(params.IstanbulTestChainConfig, parent, mockEngine.NewFaker(), testDB, n, func(i int, block *core.BlockGen) {
 		block.SetCoinbase(common.Address{seed})
+		// Announce the next validator in epoch blocks
+		if number := block.Number().Uint64(); tc.epoch != 0 && number%tc.epoch == 0 {
+			block.SetExtra(epochValidator(number, seed).Bytes())
+		}
 		// If a heavy chain is requested, delay blocks to raise difficulty
 		if heavy {
 			block.OffsetTime(-1)
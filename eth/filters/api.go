@@ -30,6 +30,8 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -164,6 +166,73 @@ func (api *PublicFilterAPI) NewPendingTransactions(ctx context.Context) (*rpc.Su
 	return rpcSub, nil
 }
 
+// PendingTransactionsCriteria restricts the transactions streamed by a
+// newFullPendingTransactions subscription. Empty fields match everything.
+type PendingTransactionsCriteria struct {
+	From                []common.Address `json:"from"`
+	To                  []common.Address `json:"to"`
+	FeeCurrency         []common.Address `json:"feeCurrency"` // the zero address matches transactions paying fees in the native token
+	GatewayFeeRecipient []common.Address `json:"gatewayFeeRecipient"`
+	MethodSelector      []hexutil.Bytes  `json:"methodSelector"`
+	MinGasPrice         *hexutil.Big     `json:"minGasPrice"` // in the native token, transactions paying fees in another currency don't match
+}
+
+// validate checks that the criteria are well formed.
+func (crit *PendingTransactionsCriteria) validate() error {
+	for _, selector := range crit.MethodSelector {
+		if len(selector) != 4 {
+			return fmt.Errorf("invalid method selector %x, want 4 bytes", []byte(selector))
+		}
+	}
+	if crit.MinGasPrice != nil && crit.MinGasPrice.ToInt().Sign() < 0 {
+		return errors.New("negative minimum gas price")
+	}
+	return nil
+}
+
+// NewFullPendingTransactions creates a subscription that is triggered each time
+// a transaction matching the given criteria enters the transaction pool. Unlike
+// NewPendingTransactions the full transaction is sent. Transactions are dropped
+// if the subscriber can't keep up.
+func (api *PublicFilterAPI) NewFullPendingTransactions(ctx context.Context, crit *PendingTransactionsCriteria) (*rpc.Subscription, error) {
+	if crit == nil {
+		crit = new(PendingTransactionsCriteria)
+	}
+	if err := crit.validate(); err != nil {
+		return nil, err
+	}
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		txs := make(chan *types.Transaction, pendingTxsBufferSize)
+		pendingTxSub := api.events.SubscribeFullPendingTxs(*crit, txs)
+		defer func() {
+			pendingTxSub.Unsubscribe()
+			if dropped := pendingTxSub.Dropped(); dropped > 0 {
+				log.Debug("Dropped pending transactions for slow subscriber", "id", rpcSub.ID, "dropped", dropped)
+			}
+		}()
+
+		for {
+			select {
+			case tx := <-txs:
+				notifier.Notify(rpcSub.ID, ethapi.NewRPCPendingTransaction(tx))
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
 // NewBlockFilter creates a filter that fetches blocks that are imported into the chain.
 // It is part of the filter package since polling goes with eth_getFilterChanges.
 //
@@ -17,6 +17,7 @@
 package filters
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"math/big"
@@ -318,6 +319,62 @@ Logs:
 	return ret
 }
 
+// filterTransaction reports whether the transaction matches the given criteria.
+// The minimum gas price is denominated in the native token, so transactions
+// paying fees in another currency never match it.
+func filterTransaction(tx *types.Transaction, crit *PendingTransactionsCriteria) bool {
+	if crit.MinGasPrice != nil {
+		if tx.FeeCurrency() != nil || tx.GasPrice().Cmp(crit.MinGasPrice.ToInt()) < 0 {
+			return false
+		}
+	}
+	if len(crit.To) > 0 && (tx.To() == nil || !includes(crit.To, *tx.To())) {
+		return false
+	}
+	if len(crit.FeeCurrency) > 0 {
+		var feeCurrency common.Address
+		if tx.FeeCurrency() != nil {
+			feeCurrency = *tx.FeeCurrency()
+		}
+		if !includes(crit.FeeCurrency, feeCurrency) {
+			return false
+		}
+	}
+	if len(crit.GatewayFeeRecipient) > 0 {
+		if tx.GatewayFeeRecipient() == nil || !includes(crit.GatewayFeeRecipient, *tx.GatewayFeeRecipient()) {
+			return false
+		}
+	}
+	if len(crit.MethodSelector) > 0 {
+		data := tx.Data()
+		if len(data) < 4 {
+			return false
+		}
+		var match bool
+		for _, selector := range crit.MethodSelector {
+			if bytes.Equal(selector, data[:4]) {
+				match = true
+				break
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+	// Recovering the sender is the most expensive check, so do it last
+	if len(crit.From) > 0 {
+		var signer types.Signer = types.FrontierSigner{}
+		if tx.Protected() {
+			signer = types.NewEIP155Signer(tx.ChainId())
+		}
+		from, err := types.Sender(signer, tx)
+		if err != nil || !includes(crit.From, from) {
+			return false
+		}
+	}
+	return true
+}
+
 func bloomFilter(bloom types.Bloom, addresses []common.Address, topics [][]common.Hash) bool {
 	if len(addresses) > 0 {
 		var included bool
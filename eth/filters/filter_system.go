@@ -22,6 +22,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	ethereum "github.com/ethereum/go-ethereum"
@@ -31,6 +32,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -52,6 +54,9 @@ const (
 	PendingTransactionsSubscription
 	// BlocksSubscription queries hashes for blocks that are imported
 	BlocksSubscription
+	// PendingFullTransactionsSubscription queries full transactions entering the
+	// pending state that match the subscription criteria
+	PendingFullTransactionsSubscription
 	// LastSubscription keeps track of the last index
 	LastIndexSubscription
 )
@@ -66,16 +71,26 @@ const (
 	logsChanSize = 10
 	// chainEvChanSize is the size of channel listening to ChainEvent.
 	chainEvChanSize = 10
+	// pendingTxsBufferSize is the number of full pending transactions buffered
+	// per subscriber before further transactions are dropped.
+	pendingTxsBufferSize = 1024
 )
 
+// droppedPendingTxsCounter counts the full pending transactions dropped because
+// a subscriber was not keeping up.
+var droppedPendingTxsCounter = metrics.NewRegisteredCounter("eth/filters/pendingtxs/dropped", nil)
+
 type subscription struct {
 	id        rpc.ID
 	typ       Type
 	created   time.Time
 	logsCrit  ethereum.FilterQuery
+	txsCrit   PendingTransactionsCriteria
 	logs      chan []*types.Log
 	hashes    chan []common.Hash
 	headers   chan *types.Header
+	txs       chan *types.Transaction
+	dropped   uint64        // number of transactions dropped because txs was full, accessed atomically
 	installed chan struct{} // closed when the filter is installed
 	err       chan error    // closed when the filter is uninstalled
 }
@@ -152,6 +167,12 @@ func (sub *Subscription) Err() <-chan error {
 	return sub.f.err
 }
 
+// Dropped returns the number of events dropped because the subscriber was not
+// keeping up. Only full pending transaction subscriptions drop events.
+func (sub *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&sub.f.dropped)
+}
+
 // Unsubscribe uninstalls the subscription from the event broadcast loop.
 func (sub *Subscription) Unsubscribe() {
 	sub.unsubOnce.Do(func() {
@@ -306,6 +327,25 @@ func (es *EventSystem) SubscribePendingTxs(hashes chan []common.Hash) *Subscript
 	return es.subscribe(sub)
 }
 
+// SubscribeFullPendingTxs creates a subscription that writes the transactions
+// entering the transaction pool that match the given criteria. Transactions are
+// dropped instead of blocking the event loop if the txs channel is full.
+func (es *EventSystem) SubscribeFullPendingTxs(crit PendingTransactionsCriteria, txs chan *types.Transaction) *Subscription {
+	sub := &subscription{
+		id:        rpc.NewID(),
+		typ:       PendingFullTransactionsSubscription,
+		txsCrit:   crit,
+		created:   time.Now(),
+		logs:      make(chan []*types.Log),
+		hashes:    make(chan []common.Hash),
+		headers:   make(chan *types.Header),
+		txs:       txs,
+		installed: make(chan struct{}),
+		err:       make(chan error),
+	}
+	return es.subscribe(sub)
+}
+
 type filterIndex map[Type]map[rpc.ID]*subscription
 
 func (es *EventSystem) handleLogs(filters filterIndex, ev []*types.Log) {
@@ -349,6 +389,19 @@ func (es *EventSystem) handleTxsEvent(filters filterIndex, ev core.NewTxsEvent)
 	for _, f := range filters[PendingTransactionsSubscription] {
 		f.hashes <- hashes
 	}
+	for _, f := range filters[PendingFullTransactionsSubscription] {
+		for _, tx := range ev.Txs {
+			if !filterTransaction(tx, &f.txsCrit) {
+				continue
+			}
+			select {
+			case f.txs <- tx:
+			default:
+				atomic.AddUint64(&f.dropped, 1)
+				droppedPendingTxsCounter.Inc(1)
+			}
+		}
+	}
 }
 
 func (es *EventSystem) handleChainEvent(filters filterIndex, ev core.ChainEvent) {
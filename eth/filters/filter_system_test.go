@@ -27,11 +27,13 @@ import (
 
 	ethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	mockEngine "github.com/ethereum/go-ethereum/consensus/consensustest"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/bloombits"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/params"
@@ -263,6 +265,144 @@ func TestPendingTxFilter(t *testing.T) {
 	}
 }
 
+// TestFullPendingTxSubscription tests whether full pending transaction
+// subscriptions only receive the transactions matching their criteria.
+func TestFullPendingTxSubscription(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		backend = &testBackend{db: db}
+		api     = NewPublicFilterAPI(backend, false)
+
+		key, _      = crypto.GenerateKey()
+		signer      = types.NewEIP155Signer(big.NewInt(1))
+		sender      = crypto.PubkeyToAddress(key.PublicKey)
+		recipient   = common.HexToAddress("0xb794f5ea0ba39494ce83a213fffba74279579268")
+		feeCurrency = common.HexToAddress("0x765de816845861e75a25fca122bb6898b8b1282a")
+		selector    = hexutil.Bytes{0xa9, 0x05, 0x9c, 0xbb}
+	)
+	sign := func(tx *types.Transaction) *types.Transaction {
+		signed, err := types.SignTx(tx, signer, key)
+		if err != nil {
+			t.Fatalf("failed to sign transaction: %v", err)
+		}
+		return signed
+	}
+	transactions := []*types.Transaction{
+		sign(types.NewTransaction(0, recipient, new(big.Int), 0, big.NewInt(1), nil, nil, nil, nil)),
+		sign(types.NewTransaction(1, recipient, new(big.Int), 0, big.NewInt(10), &feeCurrency, nil, nil, append(selector, 0x01))),
+		sign(types.NewTransaction(2, common.Address{0x01}, new(big.Int), 0, big.NewInt(10), nil, nil, nil, selector)),
+		types.NewTransaction(3, recipient, new(big.Int), 0, big.NewInt(10), nil, nil, nil, selector),
+	}
+
+	testCases := []struct {
+		crit     PendingTransactionsCriteria
+		expected []*types.Transaction
+	}{
+		{PendingTransactionsCriteria{}, transactions},
+		{PendingTransactionsCriteria{From: []common.Address{sender}}, transactions[:3]},
+		{PendingTransactionsCriteria{To: []common.Address{recipient}}, []*types.Transaction{transactions[0], transactions[1], transactions[3]}},
+		{PendingTransactionsCriteria{FeeCurrency: []common.Address{feeCurrency}}, transactions[1:2]},
+		{PendingTransactionsCriteria{FeeCurrency: []common.Address{{}}}, []*types.Transaction{transactions[0], transactions[2], transactions[3]}},
+		{PendingTransactionsCriteria{GatewayFeeRecipient: []common.Address{recipient}}, nil},
+		{PendingTransactionsCriteria{MethodSelector: []hexutil.Bytes{selector}}, transactions[1:]},
+		{PendingTransactionsCriteria{MinGasPrice: (*hexutil.Big)(big.NewInt(5)), From: []common.Address{sender}}, transactions[2:3]},
+		{PendingTransactionsCriteria{MinGasPrice: (*hexutil.Big)(big.NewInt(5)), FeeCurrency: []common.Address{feeCurrency}}, nil},
+	}
+
+	txChans := make([]chan *types.Transaction, len(testCases))
+	for i, tc := range testCases {
+		txChans[i] = make(chan *types.Transaction, len(transactions))
+		sub := api.events.SubscribeFullPendingTxs(tc.crit, txChans[i])
+		defer sub.Unsubscribe()
+	}
+
+	time.Sleep(1 * time.Second)
+	backend.txFeed.Send(core.NewTxsEvent{Txs: transactions})
+
+	for i, tc := range testCases {
+		var received []*types.Transaction
+	fetch:
+		for len(received) < len(tc.expected) {
+			select {
+			case tx := <-txChans[i]:
+				received = append(received, tx)
+			case <-time.After(1 * time.Second):
+				break fetch
+			}
+		}
+		// Make sure no unexpected transactions trail behind
+		select {
+		case tx := <-txChans[i]:
+			received = append(received, tx)
+		case <-time.After(100 * time.Millisecond):
+		}
+		if len(received) != len(tc.expected) {
+			t.Errorf("test %d: invalid number of transactions, want %d, got %d", i, len(tc.expected), len(received))
+			continue
+		}
+		for j := range received {
+			if received[j].Hash() != tc.expected[j].Hash() {
+				t.Errorf("test %d: transaction %d invalid, want %x, got %x", i, j, tc.expected[j].Hash(), received[j].Hash())
+			}
+		}
+	}
+}
+
+// TestFullPendingTxSubscriptionDrops tests that a slow full pending transaction
+// subscriber doesn't block the event loop and has its missed transactions counted.
+func TestFullPendingTxSubscriptionDrops(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		backend = &testBackend{db: db}
+		api     = NewPublicFilterAPI(backend, false)
+
+		transactions []*types.Transaction
+	)
+	for i := 0; i < 5; i++ {
+		transactions = append(transactions, types.NewTransaction(uint64(i), common.HexToAddress("0xb794f5ea0ba39494ce83a213fffba74279579268"), new(big.Int), 0, new(big.Int), nil, nil, nil, nil))
+	}
+
+	txs := make(chan *types.Transaction, 2)
+	sub := api.events.SubscribeFullPendingTxs(PendingTransactionsCriteria{}, txs)
+	defer sub.Unsubscribe()
+
+	hashes := make(chan []common.Hash)
+	hashSub := api.events.SubscribePendingTxs(hashes)
+	defer hashSub.Unsubscribe()
+
+	time.Sleep(1 * time.Second)
+	backend.txFeed.Send(core.NewTxsEvent{Txs: transactions})
+
+	// The hash subscription is served after the full one in the same event, so
+	// once it fires the drops have been accounted for.
+	select {
+	case <-hashes:
+	case <-time.After(1 * time.Second):
+		t.Fatal("event loop blocked by slow subscriber")
+	}
+	if len(txs) != 2 {
+		t.Errorf("invalid number of buffered transactions, want 2, got %d", len(txs))
+	}
+	if dropped := sub.Dropped(); dropped != 3 {
+		t.Errorf("invalid number of dropped transactions, want 3, got %d", dropped)
+	}
+}
+
+func TestInvalidFullPendingTxCriteria(t *testing.T) {
+	crit := &PendingTransactionsCriteria{MethodSelector: []hexutil.Bytes{{0x01, 0x02}}}
+	if err := crit.validate(); err == nil {
+		t.Error("expected error for short method selector")
+	}
+	crit = &PendingTransactionsCriteria{MinGasPrice: (*hexutil.Big)(big.NewInt(-1))}
+	if err := crit.validate(); err == nil {
+		t.Error("expected error for negative minimum gas price")
+	}
+}
+
 // TestLogFilterCreation test whether a given filter criteria makes sense.
 // If not it must return an error.
 func TestLogFilterCreation(t *testing.T) {
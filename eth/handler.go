@@ -69,6 +69,8 @@ type ProtocolManager struct {
 	fastSync  uint32 // Flag whether fast sync is enabled (gets disabled if we already have blocks)
 	acceptTxs uint32 // Flag whether we're considered synchronised (enables transaction processing)
 
+	pivotSyncMode downloader.SyncMode // Mode used while fast sync is enabled (fast or epoch sync)
+
 	checkpointNumber uint64      // Block number for the sync progress validator to cross reference
 	checkpointHash   common.Hash // Block hash for the sync progress validator to cross reference
 
@@ -136,6 +138,11 @@ func NewProtocolManager(config *params.ChainConfig, checkpoint *params.TrustedCh
 		handler.SetBroadcaster(manager)
 		handler.SetP2PServer(server)
 	}
+	// Epoch sync is fast sync with the pivot at an epoch block
+	manager.pivotSyncMode = downloader.FastSync
+	if mode == downloader.EpochSync {
+		manager.pivotSyncMode = downloader.EpochSync
+	}
 	if mode == downloader.FullSync {
 		// The database seems empty as the current block is the genesis. Yet the fast
 		// block is ahead, so fast sync was enabled for this node at a certain point.
@@ -200,12 +200,12 @@ func (pm *ProtocolManager) synchronise(peer *peer) {
 	mode := downloader.FullSync
 	if atomic.LoadUint32(&pm.fastSync) == 1 {
 		// Fast sync was explicitly requested, and explicitly granted
-		mode = downloader.FastSync
+		mode = pm.pivotSyncMode
 	} else if pivot := rawdb.ReadLastPivotNumber(pm.chaindb); pivot != nil {
 		if currentBlock.NumberU64() < *pivot {
 			block := pm.blockchain.CurrentFastBlock()
 			td = pm.blockchain.GetTdByHash(block.Hash())
-			mode = downloader.FastSync
+			mode = pm.pivotSyncMode
 		}
 	}
 
@@ -214,7 +214,7 @@ func (pm *ProtocolManager) synchronise(peer *peer) {
 		return
 	}
 
-	if mode == downloader.FastSync {
+	if mode.SyncPivotState() {
 		// Make sure the peer's total difficulty we are synchronizing is higher.
 		currentBlock := pm.blockchain.CurrentFastBlock().Hash()
 		td := pm.blockchain.GetTdByHash(currentBlock)
@@ -0,0 +1,112 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+)
+
+// fourByteTracer is a native implementation of the JavaScript 4byteTracer,
+// which collects the 4byte method identifiers of all calls along with the size
+// of the supplied data, so a reversed signature can be matched against it.
+// The result maps identifiers with data sizes, like "0x27dc297e-128", to the
+// number of calls made with them.
+type fourByteTracer struct {
+	interrupter
+
+	ids   map[string]int // Number of calls by identifier and data size
+	input []byte         // Input of the outer call
+}
+
+func newFourByteTracer() tracers.ResultTracer {
+	return &fourByteTracer{ids: make(map[string]int)}
+}
+
+// store counts the given identifier and data size.
+func (t *fourByteTracer) store(id []byte, size uint64) {
+	t.ids[hexutil.Encode(id)+"-"+strconv.FormatUint(size, 10)]++
+}
+
+// CaptureStart implements the vm.Tracer interface to initialize the tracing operation.
+func (t *fourByteTracer) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	t.input = input
+	return nil
+}
+
+// CaptureState implements the vm.Tracer interface to trace a single step of VM execution.
+func (t *fourByteTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	if t.stopped() {
+		return nil
+	}
+	// Skip any opcodes that are not internal calls, the stack index of the
+	// input offset depends on whether the call carries a value
+	var in int
+	switch op {
+	case vm.CALL, vm.CALLCODE:
+		in = 3
+	case vm.DELEGATECALL, vm.STATICCALL:
+		in = 2
+	default:
+		return nil
+	}
+	// Skip any pre-compile invocations, those are just fancy opcodes
+	if env.IsPrecompiled(stackAddress(stack, 1)) {
+		return nil
+	}
+	// Gather internal call details
+	if size := stack.Back(in + 1); size.Cmp(big.NewInt(4)) >= 0 && size.IsUint64() {
+		t.store(memorySlice(memory, stack.Back(in), big.NewInt(4)), size.Uint64()-4)
+	}
+	return nil
+}
+
+// CaptureFault implements the vm.Tracer interface to trace an execution fault
+// while running an opcode.
+func (t *fourByteTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *fourByteTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	return nil
+}
+
+// GetResult returns the collected identifiers, including the one of the outer
+// call, or the error that interrupted the trace.
+func (t *fourByteTracer) GetResult() (json.RawMessage, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	ids := t.ids
+	if len(t.input) >= 4 {
+		// Count the outer call without altering the collected identifiers
+		ids = make(map[string]int, len(t.ids)+1)
+		for id, count := range t.ids {
+			ids[id] = count
+		}
+		ids[hexutil.Encode(t.input[:4])+"-"+strconv.FormatUint(uint64(len(t.input)-4), 10)]++
+	}
+	return json.Marshal(ids)
+}
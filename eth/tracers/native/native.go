@@ -0,0 +1,84 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package native contains Go implementations of the built in JavaScript
+// tracers, producing the same results at a fraction of the cost. Importing
+// the package registers them with the tracers package under the names of the
+// JavaScript tracers they replace.
+package native
+
+import (
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+func init() {
+	tracers.RegisterNative("callTracer", newCallTracer)
+	tracers.RegisterNative("prestateTracer", newPrestateTracer)
+	tracers.RegisterNative("4byteTracer", newFourByteTracer)
+}
+
+// interrupter implements the interruption of a tracer, mirroring the way the
+// JavaScript tracers are stopped.
+type interrupter struct {
+	interrupt uint32 // Atomic flag to signal execution interruption
+	reason    error  // Textual reason for the interruption
+	err       error  // Error recorded once the interruption was noticed
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (i *interrupter) Stop(err error) {
+	i.reason = err
+	atomic.StoreUint32(&i.interrupt, 1)
+}
+
+// stopped reports whether tracing was interrupted, recording the reason as the
+// error of the trace.
+func (i *interrupter) stopped() bool {
+	if i.err != nil {
+		return true
+	}
+	if atomic.LoadUint32(&i.interrupt) > 0 {
+		i.err = i.reason
+		return true
+	}
+	return false
+}
+
+// stackAddress interprets the n-th stack item from the top as an address.
+func stackAddress(stack *vm.Stack, n int) common.Address {
+	return common.BigToAddress(stack.Back(n))
+}
+
+// memorySlice returns a copy of the requested memory range, or nil if it's out
+// of bounds, in which case the JavaScript tracers log a warning and use an
+// empty slice too.
+func memorySlice(memory *vm.Memory, offset, size *big.Int) []byte {
+	if size.Sign() == 0 {
+		return []byte{}
+	}
+	end := new(big.Int).Add(offset, size)
+	if !end.IsUint64() || uint64(memory.Len()) < end.Uint64() {
+		log.Warn("Tracer accessed out of bound memory", "available", memory.Len(), "offset", offset, "size", size)
+		return nil
+	}
+	return memory.GetCopy(offset.Int64(), size.Int64())
+}
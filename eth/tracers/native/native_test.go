@@ -0,0 +1,157 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/tests"
+)
+
+// callTracerTest defines a single test to check the call tracer against.
+type callTracerTest struct {
+	Genesis *core.Genesis `json:"genesis"`
+	Context *struct {
+		Number   math.HexOrDecimal64 `json:"number"`
+		Time     math.HexOrDecimal64 `json:"timestamp"`
+		GasLimit math.HexOrDecimal64 `json:"gasLimit"`
+		Miner    common.Address      `json:"miner"`
+	} `json:"context"`
+	Input  string                 `json:"input"`
+	Result map[string]interface{} `json:"result"`
+}
+
+// runTracer executes the transaction of a test against its prestate with the
+// given tracer, returning the decoded result.
+func runTracer(t *testing.T, test *callTracerTest, tracer tracers.ResultTracer) map[string]interface{} {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(common.FromHex(test.Input), tx); err != nil {
+		t.Fatalf("failed to parse testcase input: %v", err)
+	}
+	signer := types.MakeSigner(test.Genesis.Config, new(big.Int).SetUint64(uint64(test.Context.Number)))
+	msg, err := tx.AsMessage(signer)
+	if err != nil {
+		t.Fatalf("failed to prepare transaction for tracing: %v", err)
+	}
+	context := vm.Context{
+		CanTransfer: vm.CanTransfer,
+		Transfer:    vm.Transfer,
+		Origin:      msg.From(),
+		Coinbase:    test.Context.Miner,
+		BlockNumber: new(big.Int).SetUint64(uint64(test.Context.Number)),
+		Time:        new(big.Int).SetUint64(uint64(test.Context.Time)),
+		GasPrice:    tx.GasPrice(),
+	}
+	statedb := tests.MakePreState(rawdb.NewMemoryDatabase(), test.Genesis.Alloc)
+	evm := vm.NewEVM(context, statedb, test.Genesis.Config, vm.Config{Debug: true, Tracer: tracer})
+
+	if _, _, _, err := core.NewStateTransition(evm, msg, new(core.GasPool).AddGas(tx.Gas())).TransitionDb(); err != nil {
+		t.Fatalf("failed to execute transaction: %v", err)
+	}
+	res, err := tracer.GetResult()
+	if err != nil {
+		t.Fatalf("failed to retrieve trace result: %v", err)
+	}
+	ret := make(map[string]interface{})
+	if err := json.Unmarshal(res, &ret); err != nil {
+		t.Fatalf("failed to unmarshal trace result: %v", err)
+	}
+	return ret
+}
+
+// Iterates over all the input-output datasets in the tracer test harness and
+// checks that the native tracers produce the same result as the JavaScript ones.
+func TestNativeTracers(t *testing.T) {
+	files, err := ioutil.ReadDir(filepath.Join("..", "testdata"))
+	if err != nil {
+		t.Fatalf("failed to retrieve tracer test suite: %v", err)
+	}
+	for _, file := range files {
+		if !strings.HasPrefix(file.Name(), "call_tracer_") {
+			continue
+		}
+		file := file // capture range variable
+		name := strings.TrimSuffix(strings.TrimPrefix(file.Name(), "call_tracer_"), ".json")
+		t.Run(name, func(t *testing.T) {
+			blob, err := ioutil.ReadFile(filepath.Join("..", "testdata", file.Name()))
+			if err != nil {
+				t.Fatalf("failed to read testcase: %v", err)
+			}
+			test := new(callTracerTest)
+			if err := json.Unmarshal(blob, test); err != nil {
+				t.Fatalf("failed to parse testcase: %v", err)
+			}
+			for _, tracer := range []string{"callTracer", "prestateTracer", "4byteTracer"} {
+				native, err := tracers.NewTracer(tracer)
+				if err != nil {
+					t.Fatalf("failed to create native %s: %v", tracer, err)
+				}
+				if _, ok := native.(*tracers.Tracer); ok {
+					t.Fatalf("%s is not a native tracer", tracer)
+				}
+				js, err := tracers.New(tracer)
+				if err != nil {
+					t.Fatalf("failed to create JavaScript %s: %v", tracer, err)
+				}
+				have, want := runTracer(t, test, native), runTracer(t, test, js)
+
+				// The execution time differs between runs
+				delete(have, "time")
+				delete(want, "time")
+				if !reflect.DeepEqual(have, want) {
+					t.Errorf("%s result mismatch:\nhave %+v\nwant %+v", tracer, have, want)
+				}
+				if tracer == "callTracer" {
+					// Some fixture inputs contain checksummed addresses
+					have, _ := json.Marshal(have)
+					want, _ := json.Marshal(test.Result)
+					if !bytes.Equal(bytes.ToLower(have), bytes.ToLower(want)) {
+						t.Errorf("call trace mismatch:\nhave %s\nwant %s", have, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+// Tests that an interrupted native tracer reports the interruption.
+func TestNativeTracerStop(t *testing.T) {
+	tracer, err := tracers.NewTracer("callTracer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracer.Stop(vm.ErrOutOfGas)
+	tracer.CaptureState(&vm.EVM{}, 0, vm.STOP, 0, 0, nil, nil, nil, 1, nil)
+	if _, err := tracer.GetResult(); err != vm.ErrOutOfGas {
+		t.Errorf("interruption error mismatch: have %v, want %v", err, vm.ErrOutOfGas)
+	}
+}
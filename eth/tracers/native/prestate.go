@@ -0,0 +1,169 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+)
+
+// errNoPrestate is returned if the traced transaction executed no code, so no
+// state was ever accessed.
+var errNoPrestate = errors.New("no code executed, prestate unavailable")
+
+// prestateAccount is the state of an account before a transaction, in the
+// format of a genesis allocation.
+type prestateAccount struct {
+	Balance *hexutil.Big                `json:"balance"`
+	Nonce   uint64                      `json:"nonce"`
+	Code    hexutil.Bytes               `json:"code"`
+	Storage map[common.Hash]common.Hash `json:"storage"`
+}
+
+// prestateTracer is a native implementation of the JavaScript prestateTracer,
+// which outputs sufficient information to create a local execution of the
+// transaction from a custom assembled genesis block.
+type prestateTracer struct {
+	interrupter
+
+	prestate map[common.Address]*prestateAccount // Genesis that is being built
+	db       vm.StateDB                          // State the accounts are looked up in
+
+	create bool // Transaction context gathered throughout execution
+	from   common.Address
+	to     common.Address
+	value  *big.Int
+}
+
+func newPrestateTracer() tracers.ResultTracer {
+	return new(prestateTracer)
+}
+
+// lookupAccount injects the specified account into the prestate.
+func (t *prestateTracer) lookupAccount(addr common.Address) {
+	if _, ok := t.prestate[addr]; ok {
+		return
+	}
+	t.prestate[addr] = &prestateAccount{
+		Balance: (*hexutil.Big)(new(big.Int).Set(t.db.GetBalance(addr))),
+		Nonce:   t.db.GetNonce(addr),
+		Code:    common.CopyBytes(t.db.GetCode(addr)),
+		Storage: make(map[common.Hash]common.Hash),
+	}
+}
+
+// lookupStorage injects the specified storage entry of the given account into
+// the prestate.
+func (t *prestateTracer) lookupStorage(addr common.Address, key common.Hash) {
+	if _, ok := t.prestate[addr].Storage[key]; !ok {
+		t.prestate[addr].Storage[key] = t.db.GetState(addr, key)
+	}
+}
+
+// CaptureStart implements the vm.Tracer interface to initialize the tracing operation.
+func (t *prestateTracer) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	t.create, t.from, t.to, t.value = create, from, to, value
+	return nil
+}
+
+// CaptureState implements the vm.Tracer interface to trace a single step of VM execution.
+func (t *prestateTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	if t.stopped() {
+		return nil
+	}
+	t.db = env.StateDB
+
+	// Add the current account if we just started tracing. Its balance includes
+	// the value sent along with the message, which is fixed in GetResult.
+	if t.prestate == nil {
+		t.prestate = make(map[common.Address]*prestateAccount)
+		t.lookupAccount(contract.Address())
+	}
+	// Whenever new state is accessed, add it to the prestate
+	switch op {
+	case vm.EXTCODECOPY, vm.EXTCODESIZE, vm.BALANCE:
+		t.lookupAccount(stackAddress(stack, 0))
+
+	case vm.CREATE:
+		from := contract.Address()
+		t.lookupAccount(crypto.CreateAddress(from, t.db.GetNonce(from)))
+
+	case vm.CREATE2:
+		// stack: endowment, offset, size, salt
+		code := memorySlice(memory, stack.Back(1), stack.Back(2))
+		salt := common.BigToHash(stack.Back(3))
+		t.lookupAccount(crypto.CreateAddress2(contract.Address(), salt, crypto.Keccak256(code)))
+
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		t.lookupAccount(stackAddress(stack, 1))
+
+	case vm.SSTORE, vm.SLOAD:
+		t.lookupStorage(contract.Address(), common.BigToHash(stack.Back(0)))
+	}
+	return nil
+}
+
+// CaptureFault implements the vm.Tracer interface to trace an execution fault
+// while running an opcode.
+func (t *prestateTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *prestateTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	return nil
+}
+
+// GetResult returns the assembled prestate, or the error that interrupted the
+// trace.
+func (t *prestateTracer) GetResult() (json.RawMessage, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	if t.prestate == nil {
+		return nil, errNoPrestate
+	}
+	// At this point, we need to deduct the 'value' from the outer transaction,
+	// and move it back to the origin
+	t.lookupAccount(t.from)
+
+	value := t.value
+	if value == nil {
+		value = new(big.Int)
+	}
+	if to := t.prestate[t.to]; to != nil {
+		to.Balance = (*hexutil.Big)(new(big.Int).Sub(to.Balance.ToInt(), value))
+	}
+	from := t.prestate[t.from]
+	from.Balance = (*hexutil.Big)(new(big.Int).Add(from.Balance.ToInt(), value))
+
+	// Decrement the caller's nonce, and remove empty create targets. Any existing
+	// state of the contract would have caused the transaction to be rejected.
+	from.Nonce--
+	if t.create {
+		delete(t.prestate, t.to)
+	}
+	return json.Marshal(t.prestate)
+}
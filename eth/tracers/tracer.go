@@ -299,6 +299,8 @@ type Tracer struct {
 	contractWrapper *contractWrapper // Wrapper around the contract object
 	dbWrapper       *dbWrapper       // Wrapper around the VM environment
 
+	evm *vm.EVM // EVM being traced, resolving the precompiles active at the traced block
+
 	pcValue     *uint   // Swappable pc value wrapped by a log accessor
 	gasValue    *uint   // Swappable gas value wrapped by a log accessor
 	costValue   *uint   // Swappable cost value wrapped by a log accessor
@@ -399,8 +401,8 @@ func New(code string) (*Tracer, error) {
 		return 1
 	})
 	tracer.vm.PushGlobalGoFunction("isPrecompiled", func(ctx *duktape.Context) int {
-		_, ok := vm.PrecompiledContractsIstanbul[common.BytesToAddress(popSlice(ctx))]
-		ctx.PushBoolean(ok)
+		addr := common.BytesToAddress(popSlice(ctx))
+		ctx.PushBoolean(tracer.evm != nil && tracer.evm.IsPrecompiled(addr))
 		return 1
 	})
 	tracer.vm.PushGlobalGoFunction("slice", func(ctx *duktape.Context) int {
@@ -558,6 +560,7 @@ func (jst *Tracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost
 		jst.memoryWrapper.memory = memory
 		jst.contractWrapper.contract = contract
 		jst.dbWrapper.db = env.StateDB
+		jst.evm = env
 
 		*jst.pcValue = uint(pc)
 		*jst.gasValue = uint(gas)
@@ -14,18 +14,58 @@
 // You should have received a copy of the GNU Lesser General Public License
 // along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
 
-// Package tracers is a collection of JavaScript transaction tracers.
+// Package tracers is a collection of JavaScript and native transaction tracers.
 package tracers
 
 import (
+	"encoding/json"
 	"strings"
 	"unicode"
 
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/tracers/internal/tracers"
 )
 
-// all contains all the built in JavaScript tracers by name.
-var all = make(map[string]string)
+// ResultTracer is a transaction tracer assembling a JSON result, which can be
+// interrupted while tracing. It is implemented by both the JavaScript and the
+// native tracers.
+type ResultTracer interface {
+	vm.Tracer
+
+	// GetResult returns the result of the trace, or any error that occurred.
+	GetResult() (json.RawMessage, error)
+
+	// Stop terminates the trace at the first opportune moment.
+	Stop(err error)
+}
+
+var (
+	// all contains all the built in JavaScript tracers by name.
+	all = make(map[string]string)
+
+	// natives contains the constructors of all the native tracers by name.
+	natives = make(map[string]func() ResultTracer)
+)
+
+// RegisterNative makes a native tracer available under the given name, taking
+// precedence over a JavaScript tracer of the same name. It is meant to be called
+// from the init function of the package implementing the tracer, and panics if
+// the name is registered twice.
+func RegisterNative(name string, ctor func() ResultTracer) {
+	if _, ok := natives[name]; ok {
+		panic("tracers: native tracer " + name + " registered twice")
+	}
+	natives[name] = ctor
+}
+
+// NewTracer creates the native tracer registered under the given name, or else
+// a JavaScript tracer evaluating the given code, see New.
+func NewTracer(code string) (ResultTracer, error) {
+	if ctor, ok := natives[code]; ok {
+		return ctor(), nil
+	}
+	return New(code)
+}
 
 // camel converts a snake cased input string into a camel cased output.
 func camel(str string) string {
@@ -25,6 +25,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -493,7 +494,7 @@ func (ec *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockN
 	var hex hexutil.Bytes
 	err := ec.c.CallContext(ctx, &hex, "eth_call", toCallArg(msg), toBlockNumArg(blockNumber))
 	if err != nil {
-		return nil, err
+		return nil, toRevertError(err)
 	}
 	return hex, nil
 }
@@ -504,11 +505,34 @@ func (ec *Client) PendingCallContract(ctx context.Context, msg ethereum.CallMsg)
 	var hex hexutil.Bytes
 	err := ec.c.CallContext(ctx, &hex, "eth_call", toCallArg(msg), "pending")
 	if err != nil {
-		return nil, err
+		return nil, toRevertError(err)
 	}
 	return hex, nil
 }
 
+// toRevertError converts the error of a call or gas estimation into an
+// abi.RevertError if the node reports that the execution reverted, passing
+// other errors through.
+func toRevertError(err error) error {
+	rpcErr, ok := err.(rpc.Error)
+	if !ok || rpcErr.ErrorCode() != 3 {
+		return err
+	}
+	dataErr, ok := err.(rpc.DataError)
+	if !ok {
+		return err
+	}
+	hex, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return err
+	}
+	data, decodeErr := hexutil.Decode(hex)
+	if decodeErr != nil {
+		return err
+	}
+	return abi.NewRevertError(data)
+}
+
 // SuggestGasPrice retrieves the currently suggested gas price to allow a timely
 // execution of a transaction.
 func (ec *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
@@ -537,7 +561,7 @@ func (ec *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64
 	var hex hexutil.Uint64
 	err := ec.c.CallContext(ctx, &hex, "eth_estimateGas", toCallArg(msg))
 	if err != nil {
-		return 0, err
+		return 0, toRevertError(err)
 	}
 	return uint64(hex), nil
 }
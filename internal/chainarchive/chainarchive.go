@@ -0,0 +1,361 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package chainarchive implements a segmented, checksummed file format for
+// exported chain data.
+//
+// An archive is a directory of segment files. Every segment holds the headers,
+// bodies, receipts and total difficulties of a contiguous range of blocks whose
+// boundaries are aligned to the end of an epoch. A segment file is an RLP
+// stream made of a Header, Header.Count Entry items and a fixed size Trailer
+// carrying the accumulator root over the stored block hashes and a SHA-256
+// checksum of everything preceding it.
+package chainarchive
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Version is the segment format version written by this package.
+const Version = 1
+
+// fileSuffix is the extension of segment files inside an archive directory.
+const fileSuffix = ".seg"
+
+// trailerSize is the length of an RLP encoded Trailer: a list header followed
+// by two 32 byte strings.
+const trailerSize = 2 + 2*(1+common.HashLength)
+
+var (
+	errBadChecksum   = errors.New("segment checksum mismatch")
+	errBadRoot       = errors.New("segment accumulator root mismatch")
+	errShortSegment  = errors.New("segment ends before declared block count")
+	errLongSegment   = errors.New("segment contains more blocks than declared")
+	errTruncatedFile = errors.New("segment file too short")
+)
+
+// Header is the first item of every segment file.
+type Header struct {
+	Version   uint64
+	Index     uint64 // Position of the segment within the archive
+	First     uint64 // Number of the first block stored in the segment
+	Count     uint64 // Number of blocks stored in the segment
+	EpochSize uint64 // Epoch length the segment boundaries are aligned to
+}
+
+// Entry is a single block stored in a segment.
+type Entry struct {
+	Header   *types.Header
+	Body     *types.Body
+	Receipts []*types.ReceiptForStorage
+	TD       *big.Int
+}
+
+// Block assembles the block stored in the entry.
+func (e *Entry) Block() *types.Block {
+	return types.NewBlockWithHeader(e.Header).WithBody(e.Body.Transactions, e.Body.Randomness, e.Body.EpochSnarkData)
+}
+
+// Trailer is the last item of every segment file.
+type Trailer struct {
+	Root     common.Hash // Accumulator root over the hashes of all stored blocks
+	Checksum common.Hash // SHA-256 of the segment contents preceding the trailer
+}
+
+// FileName returns the name of the segment file with the given index.
+func FileName(index uint64) string {
+	return fmt.Sprintf("celo-%06d%s", index, fileSuffix)
+}
+
+// SegmentIndex returns the index of the segment holding the given block. The
+// genesis block is never archived, block 1 is the first block of segment 0.
+func SegmentIndex(number uint64, size uint64) uint64 {
+	if number == 0 {
+		return 0
+	}
+	return (number - 1) / size
+}
+
+// SegmentRange returns the first and last block numbers of a segment.
+func SegmentRange(index uint64, size uint64) (uint64, uint64) {
+	return index*size + 1, (index + 1) * size
+}
+
+// Files returns the paths of all segment files in an archive directory, sorted
+// by segment index.
+func Files(dir string) ([]string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), fileSuffix) {
+			continue
+		}
+		files = append(files, filepath.Join(dir, entry.Name()))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Accumulate computes the binary merkle root over a list of block hashes. The
+// leaves are padded with empty hashes up to the next power of two.
+func Accumulate(hashes []common.Hash) common.Hash {
+	if len(hashes) == 0 {
+		return common.Hash{}
+	}
+	width := 1
+	for width < len(hashes) {
+		width <<= 1
+	}
+	level := make([]common.Hash, width)
+	copy(level, hashes)
+	for len(level) > 1 {
+		for i := 0; i < len(level)/2; i++ {
+			level[i] = crypto.Keccak256Hash(level[2*i][:], level[2*i+1][:])
+		}
+		level = level[:len(level)/2]
+	}
+	return level[0]
+}
+
+// Writer produces a single segment file.
+type Writer struct {
+	file   io.Writer
+	out    io.Writer
+	hasher hash.Hash
+	header Header
+	hashes []common.Hash
+}
+
+// NewWriter writes the segment header to w and returns a writer expecting
+// exactly header.Count blocks to be appended.
+func NewWriter(w io.Writer, header Header) (*Writer, error) {
+	hasher := sha256.New()
+	writer := &Writer{
+		file:   w,
+		out:    io.MultiWriter(w, hasher),
+		hasher: hasher,
+		header: header,
+		hashes: make([]common.Hash, 0, header.Count),
+	}
+	if err := rlp.Encode(writer.out, &header); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+// Append adds the next block of the segment together with its receipts and
+// total difficulty.
+func (w *Writer) Append(block *types.Block, receipts types.Receipts, td *big.Int) error {
+	if uint64(len(w.hashes)) >= w.header.Count {
+		return errLongSegment
+	}
+	if want := w.header.First + uint64(len(w.hashes)); block.NumberU64() != want {
+		return fmt.Errorf("non contiguous block in segment: have %d, want %d", block.NumberU64(), want)
+	}
+	entry := &Entry{
+		Header:   block.Header(),
+		Body:     block.Body(),
+		Receipts: make([]*types.ReceiptForStorage, len(receipts)),
+		TD:       td,
+	}
+	for i, receipt := range receipts {
+		entry.Receipts[i] = (*types.ReceiptForStorage)(receipt)
+	}
+	if err := rlp.Encode(w.out, entry); err != nil {
+		return err
+	}
+	w.hashes = append(w.hashes, block.Hash())
+	return nil
+}
+
+// Finish seals the segment by writing its trailer.
+func (w *Writer) Finish() (Trailer, error) {
+	if uint64(len(w.hashes)) != w.header.Count {
+		return Trailer{}, errShortSegment
+	}
+	var trailer Trailer
+	trailer.Root = Accumulate(w.hashes)
+	copy(trailer.Checksum[:], w.hasher.Sum(nil))
+
+	// The trailer is not covered by the checksum, write it to the file only
+	if err := rlp.Encode(w.file, &trailer); err != nil {
+		return Trailer{}, err
+	}
+	return trailer, nil
+}
+
+// Reader iterates over the entries of a single segment file.
+type Reader struct {
+	file    *os.File
+	stream  *rlp.Stream
+	size    int64 // Length of the checksummed part of the file
+	header  Header
+	trailer Trailer
+	read    uint64
+}
+
+// Open opens a segment file and decodes its header and trailer.
+func Open(path string) (*Reader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if info.Size() < trailerSize {
+		file.Close()
+		return nil, errTruncatedFile
+	}
+	body := info.Size() - trailerSize
+
+	reader := &Reader{file: file, size: body}
+	if err := rlp.NewStream(io.NewSectionReader(file, body, trailerSize), trailerSize).Decode(&reader.trailer); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("invalid segment trailer: %v", err)
+	}
+	reader.stream = rlp.NewStream(io.NewSectionReader(file, 0, body), uint64(body))
+	if err := reader.stream.Decode(&reader.header); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("invalid segment header: %v", err)
+	}
+	if reader.header.Version != Version {
+		file.Close()
+		return nil, fmt.Errorf("unsupported segment version %d", reader.header.Version)
+	}
+	return reader, nil
+}
+
+// Header returns the segment header.
+func (r *Reader) Header() Header { return r.header }
+
+// Trailer returns the segment trailer.
+func (r *Reader) Trailer() Trailer { return r.trailer }
+
+// Next decodes the next entry of the segment, returning io.EOF once all of the
+// declared blocks have been read.
+func (r *Reader) Next() (*Entry, error) {
+	if r.read == r.header.Count {
+		if _, err := r.stream.Raw(); err != io.EOF {
+			return nil, errLongSegment
+		}
+		return nil, io.EOF
+	}
+	entry := new(Entry)
+	if err := r.stream.Decode(entry); err == io.EOF {
+		return nil, errShortSegment
+	} else if err != nil {
+		return nil, fmt.Errorf("at block %d: %v", r.header.First+r.read, err)
+	}
+	r.read++
+	return entry, nil
+}
+
+// Close releases the underlying file.
+func (r *Reader) Close() error {
+	return r.file.Close()
+}
+
+// Summary describes a verified segment.
+type Summary struct {
+	Path       string
+	Header     Header
+	Trailer    Trailer
+	ParentHash common.Hash // Parent hash of the first block in the segment
+	LastHash   common.Hash // Hash of the last block in the segment
+}
+
+// Verify checks the checksum of a segment file, that its blocks form a
+// contiguous chain, that the transaction and receipt roots of every block match
+// its header and that the accumulator root matches the stored blocks. Seals are
+// not checked, as that requires the validator set of the preceding epoch.
+func Verify(path string) (*Summary, error) {
+	reader, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	// Verify the checksum over the raw bytes before trusting any content
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, io.NewSectionReader(reader.file, 0, reader.size)); err != nil {
+		return nil, err
+	}
+	if common.BytesToHash(hasher.Sum(nil)) != reader.trailer.Checksum {
+		return nil, errBadChecksum
+	}
+	summary := &Summary{
+		Path:    path,
+		Header:  reader.header,
+		Trailer: reader.trailer,
+	}
+	hashes := make([]common.Hash, 0, reader.header.Count)
+	for {
+		entry, err := reader.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		number := reader.header.First + uint64(len(hashes))
+		if entry.Header.Number == nil || entry.Header.Number.Uint64() != number {
+			return nil, fmt.Errorf("non contiguous block in segment: have %v, want %d", entry.Header.Number, number)
+		}
+		if len(hashes) == 0 {
+			summary.ParentHash = entry.Header.ParentHash
+		} else if entry.Header.ParentHash != hashes[len(hashes)-1] {
+			return nil, fmt.Errorf("block %d: parent hash mismatch", number)
+		}
+		if root := types.DeriveSha(types.Transactions(entry.Body.Transactions)); root != entry.Header.TxHash {
+			return nil, fmt.Errorf("block %d: transaction root mismatch: have %x, want %x", number, root, entry.Header.TxHash)
+		}
+		receipts := make(types.Receipts, len(entry.Receipts))
+		for i, receipt := range entry.Receipts {
+			receipts[i] = (*types.Receipt)(receipt)
+		}
+		if root := types.DeriveSha(receipts); root != entry.Header.ReceiptHash {
+			return nil, fmt.Errorf("block %d: receipt root mismatch: have %x, want %x", number, root, entry.Header.ReceiptHash)
+		}
+		hashes = append(hashes, entry.Header.Hash())
+	}
+	if root := Accumulate(hashes); root != reader.trailer.Root {
+		return nil, errBadRoot
+	}
+	if len(hashes) > 0 {
+		summary.LastHash = hashes[len(hashes)-1]
+	}
+	return summary, nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package chainarchive
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// makeBlocks creates a chain of n blocks starting at number first, each with a
+// single transaction and receipt.
+func makeBlocks(first uint64, n int) ([]*types.Block, []types.Receipts) {
+	var (
+		blocks   []*types.Block
+		receipts []types.Receipts
+		parent   = common.HexToHash("0x01")
+	)
+	for i := 0; i < n; i++ {
+		tx := types.NewTransaction(uint64(i), common.Address{0x02}, big.NewInt(1), 21000, big.NewInt(1), nil, nil, nil, nil)
+		receipt := types.NewReceipt(nil, false, 21000)
+		receipt.Logs = []*types.Log{{Address: common.Address{0x03}, Data: []byte{byte(i)}}}
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+
+		header := &types.Header{ParentHash: parent, Number: new(big.Int).SetUint64(first + uint64(i))}
+		block := types.NewBlock(header, []*types.Transaction{tx}, []*types.Receipt{receipt}, nil)
+		blocks = append(blocks, block)
+		receipts = append(receipts, types.Receipts{receipt})
+		parent = block.Hash()
+	}
+	return blocks, receipts
+}
+
+func writeSegment(t *testing.T, path string, first uint64, n int) Trailer {
+	blocks, receipts := makeBlocks(first, n)
+
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf, Header{Version: Version, First: first, Count: uint64(n), EpochSize: uint64(n)})
+	if err != nil {
+		t.Fatalf("failed to create writer: %v", err)
+	}
+	for i, block := range blocks {
+		if err := writer.Append(block, receipts[i], big.NewInt(int64(i))); err != nil {
+			t.Fatalf("failed to append block %d: %v", i, err)
+		}
+	}
+	trailer, err := writer.Finish()
+	if err != nil {
+		t.Fatalf("failed to finish segment: %v", err)
+	}
+	if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("failed to write segment: %v", err)
+	}
+	return trailer
+}
+
+func TestTrailerSize(t *testing.T) {
+	blob, err := rlp.EncodeToBytes(&Trailer{Root: common.HexToHash("0x01"), Checksum: common.HexToHash("0x02")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blob) != trailerSize {
+		t.Fatalf("trailer size mismatch: have %d, want %d", len(blob), trailerSize)
+	}
+}
+
+func TestSegmentRoundtrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chainarchive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, FileName(0))
+	trailer := writeSegment(t, path, 1, 5)
+
+	summary, err := Verify(path)
+	if err != nil {
+		t.Fatalf("failed to verify segment: %v", err)
+	}
+	if summary.Trailer != trailer {
+		t.Errorf("trailer mismatch: have %v, want %v", summary.Trailer, trailer)
+	}
+	if summary.ParentHash != common.HexToHash("0x01") {
+		t.Errorf("parent hash mismatch: have %x", summary.ParentHash)
+	}
+	reader, err := Open(path)
+	if err != nil {
+		t.Fatalf("failed to open segment: %v", err)
+	}
+	defer reader.Close()
+
+	blocks, _ := makeBlocks(1, 5)
+	for i := 0; ; i++ {
+		entry, err := reader.Next()
+		if err == io.EOF {
+			if i != len(blocks) {
+				t.Fatalf("segment ended early: have %d blocks, want %d", i, len(blocks))
+			}
+			break
+		} else if err != nil {
+			t.Fatalf("failed to read entry %d: %v", i, err)
+		}
+		if entry.Block().Hash() != blocks[i].Hash() {
+			t.Errorf("block %d: hash mismatch", i)
+		}
+		if entry.TD.Int64() != int64(i) {
+			t.Errorf("block %d: td mismatch: have %v", i, entry.TD)
+		}
+	}
+	if summary.LastHash != blocks[len(blocks)-1].Hash() {
+		t.Errorf("last hash mismatch: have %x", summary.LastHash)
+	}
+}
+
+func TestSegmentCorruption(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chainarchive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, FileName(0))
+	writeSegment(t, path, 1, 3)
+
+	blob, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob[len(blob)/2] ^= 0xff
+	if err := ioutil.WriteFile(path, blob, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Verify(path); err != errBadChecksum {
+		t.Fatalf("corruption not detected: have %v, want %v", err, errBadChecksum)
+	}
+}
+
+func TestAccumulate(t *testing.T) {
+	a, b, c := common.HexToHash("0x0a"), common.HexToHash("0x0b"), common.HexToHash("0x0c")
+	if Accumulate([]common.Hash{a}) != a {
+		t.Errorf("single leaf root should equal the leaf")
+	}
+	if Accumulate([]common.Hash{a, b, c}) != Accumulate([]common.Hash{a, b, c, {}}) {
+		t.Errorf("odd leaf count should be padded with empty hashes")
+	}
+	if Accumulate([]common.Hash{a, b}) == Accumulate([]common.Hash{b, a}) {
+		t.Errorf("root should depend on leaf order")
+	}
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/accounts/scwallet"
 	"github.com/ethereum/go-ethereum/common"
@@ -34,6 +35,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/contract_comm/blockchain_parameters"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -122,7 +124,7 @@ func (s *PublicTxPoolAPI) Content() map[string]map[string]map[string]*RPCTransac
 	for account, txs := range pending {
 		dump := make(map[string]*RPCTransaction)
 		for _, tx := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce())] = newRPCPendingTransaction(tx)
+			dump[fmt.Sprintf("%d", tx.Nonce())] = NewRPCPendingTransaction(tx)
 		}
 		content["pending"][account.Hex()] = dump
 	}
@@ -130,7 +132,7 @@ func (s *PublicTxPoolAPI) Content() map[string]map[string]map[string]*RPCTransac
 	for account, txs := range queue {
 		dump := make(map[string]*RPCTransaction)
 		for _, tx := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce())] = newRPCPendingTransaction(tx)
+			dump[fmt.Sprintf("%d", tx.Nonce())] = NewRPCPendingTransaction(tx)
 		}
 		content["queued"][account.Hex()] = dump
 	}
@@ -309,6 +311,14 @@ func (s *PrivateAccountAPI) ImportRawKey(privkey string, password string) (commo
 	return acc.Address, err
 }
 
+// ImportMnemonic creates an HD wallet in the key directory from the given BIP-39
+// mnemonic and mnemonic passphrase, encrypting it with the password. It returns
+// the address of the first account on the Celo derivation path.
+func (s *PrivateAccountAPI) ImportMnemonic(mnemonic string, mnemonicPassphrase string, password string) (common.Address, error) {
+	acc, err := fetchKeystore(s.am).ImportMnemonic(mnemonic, mnemonicPassphrase, password)
+	return acc.Address, err
+}
+
 // UnlockAccount will unlock the account associated with the given address with
 // the given password for duration seconds. If duration is nil it will use a
 // default of 300 seconds. It returns an indication if the account was unlocked.
@@ -753,25 +763,9 @@ type account struct {
 	StateDiff *map[common.Hash]common.Hash `json:"stateDiff"`
 }
 
-func DoCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides map[common.Address]account, vmCfg vm.Config, timeout time.Duration, globalGasCap *big.Int, estimate bool) (res []byte, gas uint64, failed bool, err error) {
-	defer func(start time.Time) { log.Debug("Executing EVM call finished", "runtime", time.Since(start)) }(time.Now())
-
-	state, header, err := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
-	if state == nil || err != nil {
-		return nil, 0, false, err
-	}
-	// Set sender address or use a default if none specified
-	var addr common.Address
-	if args.From == nil {
-		if wallets := b.AccountManager().Wallets(); len(wallets) > 0 {
-			if accounts := wallets[0].Accounts(); len(accounts) > 0 {
-				addr = accounts[0].Address
-			}
-		}
-	} else {
-		addr = *args.From
-	}
-	// Override the fields of specified contracts before execution.
+// applyStateOverrides overrides the fields of the specified accounts in the
+// given state.
+func applyStateOverrides(state *state.StateDB, overrides map[common.Address]account) error {
 	for addr, account := range overrides {
 		// Override account nonce.
 		if account.Nonce != nil {
@@ -786,7 +780,7 @@ func DoCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.Blo
 			state.SetBalance(addr, (*big.Int)(*account.Balance))
 		}
 		if account.State != nil && account.StateDiff != nil {
-			return nil, 0, false, fmt.Errorf("account %s has both 'state' and 'stateDiff'", addr.Hex())
+			return fmt.Errorf("account %s has both 'state' and 'stateDiff'", addr.Hex())
 		}
 		// Replace entire state if caller requires.
 		if account.State != nil {
@@ -799,8 +793,26 @@ func DoCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.Blo
 			}
 		}
 	}
+	return nil
+}
+
+// toMessage converts the call arguments into a message to execute on the given
+// state, filling in defaults for the missing fields. The gas defaults to
+// defaultGas, both capped by globalGasCap.
+func (args *CallArgs) toMessage(ctx context.Context, b Backend, header *types.Header, state *state.StateDB, defaultGas uint64, globalGasCap *big.Int) (types.Message, error) {
+	// Set sender address or use a default if none specified
+	var addr common.Address
+	if args.From == nil {
+		if wallets := b.AccountManager().Wallets(); len(wallets) > 0 {
+			if accounts := wallets[0].Accounts(); len(accounts) > 0 {
+				addr = accounts[0].Address
+			}
+		}
+	} else {
+		addr = *args.From
+	}
 	// Set default gas & gas price if none were set
-	gas = uint64(math.MaxUint64 / 2)
+	gas := defaultGas
 	if args.Gas != nil {
 		gas = uint64(*args.Gas)
 	}
@@ -820,10 +832,11 @@ func DoCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.Blo
 	// TODO(asa): Remove this once this is handled in the Provider.
 	if gasPrice.Sign() == 0 || gasPrice.Cmp(big.NewInt(0)) == 0 {
 		// TODO(mcortesi): change SuggestGastPriceInCurrent so it doesn't return an error
+		var err error
 		gasPrice, err = b.SuggestPriceInCurrency(ctx, args.FeeCurrency, header, state)
 		if err != nil {
-			log.Error("Error suggesting gas price", "block", blockNrOrHash, "err", err)
-			return nil, 0, false, err
+			log.Error("Error suggesting gas price", "block", header.Number, "err", err)
+			return types.Message{}, err
 		}
 	}
 
@@ -838,7 +851,31 @@ func DoCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.Blo
 	}
 
 	// Create new call message
-	msg := types.NewMessage(addr, args.To, 0, value, gas, gasPrice, args.FeeCurrency, args.GatewayFeeRecipient, args.GatewayFee.ToInt(), data, false)
+	return types.NewMessage(addr, args.To, 0, value, gas, gasPrice, args.FeeCurrency, args.GatewayFeeRecipient, args.GatewayFee.ToInt(), data, false), nil
+}
+
+func DoCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides map[common.Address]account, vmCfg vm.Config, timeout time.Duration, globalGasCap *big.Int, estimate bool) ([]byte, uint64, bool, error) {
+	res, gas, vmerr, err := doCall(ctx, b, args, blockNrOrHash, overrides, vmCfg, timeout, globalGasCap, estimate)
+	return res, gas, vmerr != nil, err
+}
+
+// doCall executes the call like DoCall, but returns the error the EVM execution
+// failed with instead of whether it failed.
+func doCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides map[common.Address]account, vmCfg vm.Config, timeout time.Duration, globalGasCap *big.Int, estimate bool) (res []byte, gas uint64, vmerr error, err error) {
+	defer func(start time.Time) { log.Debug("Executing EVM call finished", "runtime", time.Since(start)) }(time.Now())
+
+	state, header, err := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, 0, nil, err
+	}
+	// Override the fields of specified contracts before execution.
+	if err := applyStateOverrides(state, overrides); err != nil {
+		return nil, 0, nil, err
+	}
+	msg, err := args.toMessage(ctx, b, header, state, uint64(math.MaxUint64/2), globalGasCap)
+	if err != nil {
+		return nil, 0, nil, err
+	}
 
 	// Setup context so it may be cancelled the call has completed
 	// or, in case of unmetered gas, setup a context with a timeout.
@@ -855,7 +892,7 @@ func DoCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.Blo
 	// Get a new instance of the EVM.
 	evm, vmError, err := b.GetEVM(ctx, msg, header, state)
 	if err != nil {
-		return nil, 0, false, err
+		return nil, 0, nil, err
 	}
 	// Wait for the context to be done and cancel the evm. Even if the
 	// EVM has finished, cancelling may be done (repeatedly)
@@ -866,20 +903,47 @@ func DoCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.Blo
 
 	// Setup the gas pool (also for unmetered requests) and apply the message.
 	gp := new(core.GasPool).AddGas(math.MaxUint64)
+	var st *core.StateTransition
 	if estimate {
-		res, gas, failed, err = core.ApplyEstimatorMessage(evm, msg, gp)
+		st = core.NewStateTransitionGasEstimator(evm, msg, gp)
 	} else {
-		res, gas, failed, err = core.ApplyMessage(evm, msg, gp)
+		st = core.NewStateTransition(evm, msg, gp)
 	}
+	res, gas, _, err = st.TransitionDb()
 
 	if err := vmError(); err != nil {
-		return nil, 0, false, err
+		return nil, 0, nil, err
 	}
 	// If the timer caused an abort, return an appropriate error message
 	if evm.Cancelled() {
-		return nil, 0, false, fmt.Errorf("execution aborted (timeout = %v)", timeout)
+		return nil, 0, nil, fmt.Errorf("execution aborted (timeout = %v)", timeout)
+	}
+	return res, gas, st.VMError(), err
+}
+
+// revertError is an API error carrying the data of a reverted execution, with
+// its Error(string) reason decoded into the message.
+type revertError struct {
+	error
+	data string // hex encoded revert data
+}
+
+// ErrorCode returns the JSON-RPC error code of a revert.
+// See: https://github.com/ethereum/wiki/wiki/JSON-RPC-Error-Codes-Improvement-Proposal
+func (e *revertError) ErrorCode() int {
+	return 3
+}
+
+// ErrorData returns the hex encoded revert data.
+func (e *revertError) ErrorData() interface{} {
+	return e.data
+}
+
+func newRevertError(data []byte) *revertError {
+	return &revertError{
+		error: abi.NewRevertError(data),
+		data:  hexutil.Encode(data),
 	}
-	return res, gas, failed, err
 }
 
 // Call executes the given transaction on the state for the given block number.
@@ -893,7 +957,10 @@ func (s *PublicBlockChainAPI) Call(ctx context.Context, args CallArgs, blockNrOr
 	if overrides != nil {
 		accounts = *overrides
 	}
-	result, _, _, err := DoCall(ctx, s.b, args, blockNrOrHash, accounts, vm.Config{}, 50*time.Second, s.b.RPCGasCap(), false)
+	result, _, vmerr, err := doCall(ctx, s.b, args, blockNrOrHash, accounts, vm.Config{}, 50*time.Second, s.b.RPCGasCap(), false)
+	if err == nil && vmerr == vm.ErrExecutionReverted {
+		return nil, newRevertError(result)
+	}
 	return (hexutil.Bytes)(result), err
 }
 
@@ -938,20 +1005,24 @@ func DoEstimateGas(ctx context.Context, b Backend, args CallArgs, blockNrOrHash
 	if args.From == nil {
 		args.From = &common.Address{}
 	}
-	// Create a helper to check if a gas allowance results in an executable transaction
-	executable := func(gas uint64) bool {
+	// Create a helper to check if a gas allowance results in an executable transaction,
+	// also returning the revert error if the transaction reverted
+	executable := func(gas uint64) (bool, error) {
 		args.Gas = (*hexutil.Uint64)(&gas)
 
-		_, _, failed, err := DoCall(ctx, b, args, blockNrOrHash, nil, vm.Config{}, 0, gasCap, true)
-		if err != nil || failed {
-			return false
+		res, _, vmerr, err := doCall(ctx, b, args, blockNrOrHash, nil, vm.Config{}, 0, gasCap, true)
+		if err != nil {
+			return false, nil
+		}
+		if vmerr == vm.ErrExecutionReverted {
+			return false, newRevertError(res)
 		}
-		return true
+		return vmerr == nil, nil
 	}
 	// Execute the binary search and hone in on an executable gas limit
 	for lo+1 < hi {
 		mid := (hi + lo) / 2
-		if !executable(mid) {
+		if ok, _ := executable(mid); !ok {
 			lo = mid
 		} else {
 			hi = mid
@@ -959,7 +1030,10 @@ func DoEstimateGas(ctx context.Context, b Backend, args CallArgs, blockNrOrHash
 	}
 	// Reject the transaction as invalid if it still fails at the highest allowance
 	if hi == cap {
-		if !executable(hi) {
+		if ok, revert := executable(hi); !ok {
+			if revert != nil {
+				return 0, revert
+			}
 			return 0, fmt.Errorf("gas required exceeds allowance (%d) or always failing transaction", cap)
 		}
 	}
@@ -1173,8 +1247,8 @@ func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber
 	return result
 }
 
-// newRPCPendingTransaction returns a pending transaction that will serialize to the RPC representation
-func newRPCPendingTransaction(tx *types.Transaction) *RPCTransaction {
+// NewRPCPendingTransaction returns a pending transaction that will serialize to the RPC representation
+func NewRPCPendingTransaction(tx *types.Transaction) *RPCTransaction {
 	return newRPCTransaction(tx, common.Hash{}, 0, 0)
 }
 
@@ -1299,7 +1373,7 @@ func (s *PublicTransactionPoolAPI) GetTransactionByHash(ctx context.Context, has
 	}
 	// No finalized transaction, try to retrieve it from the pool
 	if tx := s.b.GetPoolTransaction(hash); tx != nil {
-		return newRPCPendingTransaction(tx), nil
+		return NewRPCPendingTransaction(tx), nil
 	}
 
 	// Transaction unknown, return as such
@@ -1668,7 +1742,7 @@ func (s *PublicTransactionPoolAPI) PendingTransactions() ([]*RPCTransaction, err
 		}
 		from, _ := types.Sender(signer, tx)
 		if _, exists := accounts[from]; exists {
-			transactions = append(transactions, newRPCPendingTransaction(tx))
+			transactions = append(transactions, NewRPCPendingTransaction(tx))
 		}
 	}
 	return transactions, nil
@@ -0,0 +1,263 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// simulationTimeout is the time a whole bundle simulation may take before
+// being aborted.
+const simulationTimeout = 50 * time.Second
+
+// PublicCeloAPI provides an API to access Celo specific functionality.
+type PublicCeloAPI struct {
+	b Backend
+}
+
+// NewPublicCeloAPI creates a new Celo API.
+func NewPublicCeloAPI(b Backend) *PublicCeloAPI {
+	return &PublicCeloAPI{b}
+}
+
+// SimulateTx is a message of a simulated bundle, either a call or a signed
+// transaction. Calls are given as call objects, transactions as the hex string
+// of their RLP encoding.
+type SimulateTx struct {
+	Call *CallArgs
+	Tx   *types.Transaction
+}
+
+// UnmarshalJSON decodes a call object or a signed transaction.
+func (t *SimulateTx) UnmarshalJSON(input []byte) error {
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(input, &raw); err == nil {
+		t.Tx = new(types.Transaction)
+		return rlp.DecodeBytes(raw, t.Tx)
+	}
+	t.Call = new(CallArgs)
+	return json.Unmarshal(input, t.Call)
+}
+
+// BlockOverrides are the fields of the block context a bundle is simulated in
+// that replace the ones of the block it's simulated on.
+type BlockOverrides struct {
+	Number   *hexutil.Big    `json:"number"`
+	Time     *hexutil.Uint64 `json:"timestamp"`
+	Coinbase *common.Address `json:"coinbase"`
+	GasLimit *hexutil.Uint64 `json:"gasLimit"`
+}
+
+// SimulateOptions are the optional settings of a bundle simulation.
+type SimulateOptions struct {
+	Tracer  *string `json:"tracer"`  // Name or code of the tracer to trace the messages with, if any
+	Timeout *string `json:"timeout"` // Timeout of the whole simulation
+}
+
+// FeeDebit is the fee charged to the sender of a message in the fee currency,
+// net of the refund.
+type FeeDebit struct {
+	Currency *common.Address `json:"currency"` // Fee currency, nil for CELO
+	Amount   *hexutil.Big    `json:"amount"`
+}
+
+// SimulateResult is the outcome of a single message of a simulated bundle.
+type SimulateResult struct {
+	TxHash      *common.Hash    `json:"txHash,omitempty"` // Hash of the signed transaction, nil for calls
+	ReturnValue hexutil.Bytes   `json:"returnValue"`
+	Logs        []*types.Log    `json:"logs"`
+	GasUsed     hexutil.Uint64  `json:"gasUsed"`
+	Failed      bool            `json:"failed"`          // Whether the execution reverted or ran out of gas
+	Error       string          `json:"error,omitempty"` // Reason the message couldn't be applied at all
+	FeeDebit    *FeeDebit       `json:"feeDebit,omitempty"`
+	Trace       json.RawMessage `json:"trace,omitempty"`
+}
+
+// SimulateBundle applies the given calls and signed transactions in sequence on
+// top of the state of the given block, after applying the state overrides. The
+// messages are executed in the context of a block following it, whose fields
+// may be overridden, going through the whole state transition including the
+// fee currency handling. The block follows it by a second at least, as when
+// mined. Messages that can't be applied are reported and leave
+// the state untouched.
+//
+// Note, this function doesn't make any changes in the state/blockchain.
+func (s *PublicCeloAPI) SimulateBundle(ctx context.Context, txs []SimulateTx, blockNrOrHash rpc.BlockNumberOrHash, stateOverrides *map[common.Address]account, blockOverrides *BlockOverrides, options *SimulateOptions) ([]*SimulateResult, error) {
+	defer func(start time.Time) {
+		log.Debug("Simulating bundle finished", "txs", len(txs), "runtime", time.Since(start))
+	}(time.Now())
+
+	state, parent, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	if stateOverrides != nil {
+		if err := applyStateOverrides(state, *stateOverrides); err != nil {
+			return nil, err
+		}
+	}
+	// Assemble the context of the block the bundle is simulated in
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Coinbase:   parent.Coinbase,
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		Time:       uint64(time.Now().Unix()),
+	}
+	if header.Time <= parent.Time {
+		header.Time = parent.Time + 1
+	}
+	block, err := s.b.BlockByHash(ctx, parent.Hash())
+	if err != nil {
+		return nil, err
+	}
+	gasLimit := core.CalcGasLimit(block, state)
+	if blockOverrides != nil {
+		if blockOverrides.Number != nil {
+			header.Number = blockOverrides.Number.ToInt()
+		}
+		if blockOverrides.Time != nil {
+			header.Time = uint64(*blockOverrides.Time)
+		}
+		if blockOverrides.Coinbase != nil {
+			header.Coinbase = *blockOverrides.Coinbase
+		}
+		if blockOverrides.GasLimit != nil {
+			gasLimit = uint64(*blockOverrides.GasLimit)
+		}
+	}
+	timeout := simulationTimeout
+	if options != nil && options.Timeout != nil {
+		if timeout, err = time.ParseDuration(*options.Timeout); err != nil {
+			return nil, err
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var (
+		signer  = types.MakeSigner(s.b.ChainConfig(), header.Number)
+		gp      = new(core.GasPool).AddGas(gasLimit)
+		results = make([]*SimulateResult, len(txs))
+	)
+	for i, tx := range txs {
+		// Assemble the message, defaulting calls to the remaining gas of the block
+		var (
+			msg    types.Message
+			result = new(SimulateResult)
+			txHash common.Hash
+		)
+		if tx.Tx != nil {
+			txHash = tx.Tx.Hash()
+			result.TxHash = &txHash
+			msg, err = tx.Tx.AsMessage(signer)
+		} else if tx.Call != nil {
+			msg, err = tx.Call.toMessage(ctx, s.b, header, state, gp.Gas(), s.b.RPCGasCap())
+		} else {
+			err = errors.New("empty message")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %v", i, err)
+		}
+		// Set up the tracer if requested and apply the message
+		var (
+			tracer tracers.ResultTracer
+			vmCfg  vm.Config
+		)
+		if options != nil && options.Tracer != nil {
+			if tracer, err = tracers.NewTracer(*options.Tracer); err != nil {
+				return nil, err
+			}
+			vmCfg = vm.Config{Debug: true, Tracer: tracer}
+		}
+		evm, vmError, err := s.b.GetSimulationEVM(ctx, msg, header, state, vmCfg)
+		if err != nil {
+			return nil, err
+		}
+		evm.Coinbase = header.Coinbase
+
+		// Abort the execution and tracing once the simulation times out
+		done := make(chan struct{})
+		go func() {
+			select {
+			case <-ctx.Done():
+				evm.Cancel()
+				if tracer != nil {
+					tracer.Stop(errors.New("execution timeout"))
+				}
+			case <-done:
+			}
+		}()
+		// Calls have no hash, so their logs are collected under their position
+		logKey := txHash
+		if tx.Tx == nil {
+			logKey = common.BigToHash(big.NewInt(int64(i + 1)))
+		}
+		state.Prepare(logKey, common.Hash{}, i)
+		snapshot := state.Snapshot()
+
+		ret, gas, failed, fee, err := core.ApplyMessageWithFee(evm, msg, gp)
+		close(done)
+
+		if err := vmError(); err != nil {
+			return nil, err
+		}
+		if evm.Cancelled() {
+			return nil, fmt.Errorf("execution aborted (timeout = %v)", timeout)
+		}
+		if err != nil {
+			// The message is invalid, it wouldn't be included in a block
+			state.RevertToSnapshot(snapshot)
+			result.Error = err.Error()
+			results[i] = result
+			continue
+		}
+		state.Finalise(s.b.ChainConfig().IsEIP158(header.Number))
+
+		result.ReturnValue = ret
+		result.Logs = state.GetLogs(logKey)
+		for _, l := range result.Logs {
+			l.TxHash = txHash
+		}
+		result.GasUsed = hexutil.Uint64(gas)
+		result.Failed = failed
+
+		result.FeeDebit = &FeeDebit{Currency: msg.FeeCurrency(), Amount: (*hexutil.Big)(fee)}
+
+		if tracer != nil {
+			if result.Trace, err = tracer.GetResult(); err != nil {
+				return nil, err
+			}
+		}
+		results[i] = result
+	}
+	return results, nil
+}
@@ -0,0 +1,254 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// simulateBackend implements the parts of the API backend used by bundle
+// simulations on top of a blockchain.
+type simulateBackend struct {
+	ethapi.Backend
+	chain *core.BlockChain
+}
+
+func (b *simulateBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error) {
+	header := b.chain.CurrentHeader()
+	statedb, err := b.chain.StateAt(header.Root)
+	return statedb, header, err
+}
+
+func (b *simulateBackend) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	return b.chain.GetBlockByHash(hash), nil
+}
+
+func (b *simulateBackend) ChainConfig() *params.ChainConfig {
+	return b.chain.Config()
+}
+
+func (b *simulateBackend) RPCGasCap() *big.Int {
+	return nil
+}
+
+func (b *simulateBackend) GetSimulationEVM(ctx context.Context, msg vm.Message, header *types.Header, state *state.StateDB, vmCfg vm.Config) (*vm.EVM, func() error, error) {
+	context := vm.NewEVMContext(msg, header, b.chain, nil)
+	return vm.NewEVM(context, state, b.chain.Config(), vmCfg), func() error { return nil }, nil
+}
+
+var (
+	simulateKey, _  = crypto.GenerateKey()
+	simulateSender  = crypto.PubkeyToAddress(simulateKey.PublicKey)
+	simulateGasCost = big.NewInt(10)
+
+	// clockAddress holds a contract returning the timestamp and number of the block.
+	clockAddress = common.HexToAddress("0xc10c")
+	clockCode    = common.FromHex("0x426000524360205260406000f3")
+)
+
+// newSimulateAPI creates a Celo API on a chain whose sender holds CELO and
+// StableToken.
+func newSimulateAPI(t *testing.T) (*ethapi.PublicCeloAPI, *backends.SimulatedBackend) {
+	sim := backends.NewCeloSimulatedBackend(core.GenesisAlloc{
+		simulateSender: {Balance: big.NewInt(params.Ether)},
+		clockAddress:   {Code: clockCode, Balance: new(big.Int)},
+	}, nil)
+	if err := sim.MintStableToken(simulateSender, big.NewInt(params.Ether)); err != nil {
+		t.Fatalf("could not mint: %v", err)
+	}
+	sim.Commit()
+	return ethapi.NewPublicCeloAPI(&simulateBackend{chain: sim.Blockchain()}), sim
+}
+
+func signSimulateTx(t *testing.T, nonce uint64, to common.Address, value *big.Int, feeCurrency, gatewayFeeRecipient *common.Address, gatewayFee *big.Int, data []byte) ethapi.SimulateTx {
+	tx := types.NewTransaction(nonce, to, value, 100000, simulateGasCost, feeCurrency, gatewayFeeRecipient, gatewayFee, data)
+	tx, err := types.SignTx(tx, types.NewEIP155Signer(params.IstanbulTestChainConfig.ChainID), simulateKey)
+	if err != nil {
+		t.Fatalf("could not sign: %v", err)
+	}
+	return ethapi.SimulateTx{Tx: tx}
+}
+
+// transferData encodes an ERC20 transfer.
+func transferData(to common.Address, value *big.Int) []byte {
+	return append(append(common.FromHex("0xa9059cbb"), to.Hash().Bytes()...), common.BigToHash(value).Bytes()...)
+}
+
+// wantFee returns the fee net of the refund of a message using the gas, when
+// the base fee is refunded for lack of a Governance contract.
+func wantFee(gasUsed hexutil.Uint64, gatewayFee *big.Int) *big.Int {
+	tip := new(big.Int).Sub(simulateGasCost, common.Big1) // The gas price minimum is 1
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(uint64(gasUsed)), tip)
+	return fee.Add(fee, gatewayFee)
+}
+
+func checkFeeDebit(t *testing.T, index int, result *ethapi.SimulateResult, currency *common.Address, want *big.Int) {
+	t.Helper()
+	if result.FeeDebit == nil {
+		t.Fatalf("message %d: no fee debit", index)
+	}
+	if (result.FeeDebit.Currency == nil) != (currency == nil) || (currency != nil && *result.FeeDebit.Currency != *currency) {
+		t.Errorf("message %d: fee currency mismatch: have %v, want %v", index, result.FeeDebit.Currency, currency)
+	}
+	if have := result.FeeDebit.Amount.ToInt(); have.Cmp(want) != 0 {
+		t.Errorf("message %d: fee debit mismatch: have %v, want %v", index, have, want)
+	}
+}
+
+func TestSimulateBundle(t *testing.T) {
+	api, sim := newSimulateAPI(t)
+	defer sim.Close()
+
+	var (
+		recipient = common.HexToAddress("0x1")
+		gateway   = common.HexToAddress("0x2")
+		stable    = backends.StableTokenAddress
+		value     = big.NewInt(1000)
+		latest    = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	)
+	txs := []ethapi.SimulateTx{
+		// Transfers of CELO, the later ones depending on the earlier ones
+		signSimulateTx(t, 0, recipient, value, nil, nil, nil, nil),
+		signSimulateTx(t, 1, recipient, value, nil, &gateway, big.NewInt(7), nil),
+		// Transfer of StableToken paid in StableToken
+		signSimulateTx(t, 2, stable, new(big.Int), &stable, &gateway, big.NewInt(7), transferData(recipient, value)),
+		// Transfer of more StableToken than held, reverting
+		signSimulateTx(t, 3, stable, new(big.Int), nil, nil, nil, transferData(recipient, big.NewInt(2*params.Ether))),
+		// Transaction that can't be applied
+		signSimulateTx(t, 5, recipient, value, nil, nil, nil, nil),
+	}
+	results, err := api.SimulateBundle(context.Background(), txs, latest, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("simulation failed: %v", err)
+	}
+	if len(results) != len(txs) {
+		t.Fatalf("result count mismatch: have %d, want %d", len(results), len(txs))
+	}
+	for i, result := range results[:4] {
+		if result.Error != "" {
+			t.Fatalf("message %d: not applied: %v", i, result.Error)
+		}
+		if hash := txs[i].Tx.Hash(); result.TxHash == nil || *result.TxHash != hash {
+			t.Errorf("message %d: hash mismatch: have %v, want %x", i, result.TxHash, hash)
+		}
+		if wantFailed := i == 3; result.Failed != wantFailed {
+			t.Errorf("message %d: failure mismatch: have %v, want %v", i, result.Failed, wantFailed)
+		}
+	}
+	checkFeeDebit(t, 0, results[0], nil, wantFee(results[0].GasUsed, new(big.Int)))
+	checkFeeDebit(t, 1, results[1], nil, wantFee(results[1].GasUsed, big.NewInt(7)))
+	// The transferred StableToken isn't part of the fee
+	checkFeeDebit(t, 2, results[2], &stable, wantFee(results[2].GasUsed, big.NewInt(7)))
+	if len(results[2].Logs) == 0 || results[2].Logs[0].Address != stable || results[2].Logs[0].TxHash != *results[2].TxHash {
+		t.Errorf("message 2: transfer log missing: %v", results[2].Logs)
+	}
+	// Reverted messages still pay for their gas
+	checkFeeDebit(t, 3, results[3], nil, wantFee(results[3].GasUsed, new(big.Int)))
+	if len(results[3].Logs) != 0 {
+		t.Errorf("message 3: reverted message has logs: %v", results[3].Logs)
+	}
+	if results[4].Error == "" || results[4].FeeDebit != nil {
+		t.Errorf("message 4: invalid message applied: %+v", results[4])
+	}
+
+	// The chain is left untouched
+	if balance, _ := sim.BalanceAt(context.Background(), recipient, nil); balance.Sign() != 0 {
+		t.Errorf("simulation changed the chain: recipient balance %v", balance)
+	}
+}
+
+// Tests that the fee of a sender mining the block and collecting the gateway
+// fee isn't offset by the fees credited back to it.
+func TestSimulateBundleSenderCoinbase(t *testing.T) {
+	api, sim := newSimulateAPI(t)
+	defer sim.Close()
+
+	var (
+		recipient = common.HexToAddress("0x1")
+		stable    = backends.StableTokenAddress
+		latest    = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	)
+	txs := []ethapi.SimulateTx{
+		signSimulateTx(t, 0, recipient, big.NewInt(1000), nil, &simulateSender, big.NewInt(7), nil),
+		signSimulateTx(t, 1, stable, new(big.Int), &stable, &simulateSender, big.NewInt(7), transferData(recipient, big.NewInt(1000))),
+	}
+	results, err := api.SimulateBundle(context.Background(), txs, latest, nil, &ethapi.BlockOverrides{Coinbase: &simulateSender}, nil)
+	if err != nil {
+		t.Fatalf("simulation failed: %v", err)
+	}
+	for i, result := range results {
+		if result.Error != "" || result.Failed {
+			t.Fatalf("message %d: not applied: %+v", i, result)
+		}
+	}
+	checkFeeDebit(t, 0, results[0], nil, wantFee(results[0].GasUsed, big.NewInt(7)))
+	checkFeeDebit(t, 1, results[1], &stable, wantFee(results[1].GasUsed, big.NewInt(7)))
+}
+
+func TestSimulateBundleBlockContext(t *testing.T) {
+	api, sim := newSimulateAPI(t)
+	defer sim.Close()
+
+	var (
+		gasPrice = hexutil.Big(*simulateGasCost)
+		latest   = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		parent   = sim.Blockchain().CurrentHeader()
+		call     = ethapi.SimulateTx{Call: &ethapi.CallArgs{From: &simulateSender, To: &clockAddress, GasPrice: &gasPrice}}
+	)
+	results, err := api.SimulateBundle(context.Background(), []ethapi.SimulateTx{call}, latest, nil, nil, nil)
+	if err != nil || results[0].Error != "" {
+		t.Fatalf("simulation failed: %v %+v", err, results)
+	}
+	ret := results[0].ReturnValue
+	if time := new(big.Int).SetBytes(ret[:32]).Uint64(); time <= parent.Time {
+		t.Errorf("block time not advanced: have %d, parent %d", time, parent.Time)
+	}
+	if number := new(big.Int).SetBytes(ret[32:]); number.Uint64() != parent.Number.Uint64()+1 {
+		t.Errorf("block number mismatch: have %v, want %d", number, parent.Number.Uint64()+1)
+	}
+
+	// Overridden fields replace the ones of the following block
+	var (
+		time   = hexutil.Uint64(parent.Time + 100)
+		number = hexutil.Big(*big.NewInt(1000))
+	)
+	results, err = api.SimulateBundle(context.Background(), []ethapi.SimulateTx{call}, latest, nil, &ethapi.BlockOverrides{Time: &time, Number: &number}, nil)
+	if err != nil || results[0].Error != "" {
+		t.Fatalf("simulation failed: %v %+v", err, results)
+	}
+	ret = results[0].ReturnValue
+	if have := new(big.Int).SetBytes(ret[:32]).Uint64(); have != uint64(time) {
+		t.Errorf("overridden block time mismatch: have %d, want %d", have, time)
+	}
+	if have := new(big.Int).SetBytes(ret[32:]); have.Cmp(number.ToInt()) != 0 {
+		t.Errorf("overridden block number mismatch: have %v, want %v", have, number.ToInt())
+	}
+}
@@ -20,6 +20,7 @@ package web3ext
 var Modules = map[string]string{
 	"accounting": AccountingJs,
 	"admin":      AdminJs,
+	"celo":       CeloJs,
 	"chequebook": ChequebookJs,
 	"debug":      DebugJs,
 	"eth":        EthJs,
@@ -27,6 +28,7 @@ var Modules = map[string]string{
 	"miner":      MinerJs,
 	"net":        NetJs,
 	"personal":   PersonalJs,
+	"relay":      RelayJs,
 	"rpc":        RpcJs,
 	"shh":        ShhJs,
 	"swarmfs":    SwarmfsJs,
@@ -345,6 +347,17 @@ web3._extend({
 			params: 2,
 			inputFormatter: [null, null]
 		}),
+		new web3._extend.Method({
+			name: 'profileTransaction',
+			call: 'debug_profileTransaction',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
+		new web3._extend.Method({
+			name: 'getRevertReason',
+			call: 'debug_getRevertReason',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'preimage',
 			call: 'debug_preimage',
@@ -373,6 +386,12 @@ web3._extend({
 			params: 2,
 			inputFormatter:[null, null],
 		}),
+		new web3._extend.Method({
+			name: 'getStateDiff',
+			call: 'debug_getStateDiff',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter],
+		}),
 		new web3._extend.Method({
 			name: 'freezeClient',
 			call: 'debug_freezeClient',
@@ -552,6 +571,11 @@ web3._extend({
 			call: 'personal_importRawKey',
 			params: 2
 		}),
+		new web3._extend.Method({
+			name: 'importMnemonic',
+			call: 'personal_importMnemonic',
+			params: 3
+		}),
 		new web3._extend.Method({
 			name: 'sign',
 			call: 'personal_sign',
@@ -656,6 +680,20 @@ web3._extend({
 });
 `
 
+const CeloJs = `
+web3._extend({
+	property: 'celo',
+	methods: [
+		new web3._extend.Method({
+			name: 'simulateBundle',
+			call: 'celo_simulateBundle',
+			params: 5,
+			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter, null, null, null]
+		}),
+	]
+});
+`
+
 const TxpoolJs = `
 web3._extend({
 	property: 'txpool',
@@ -910,3 +948,34 @@ web3._extend({
 	]
 });
 `
+
+const RelayJs = `
+web3._extend({
+	property: 'relay',
+	methods: [
+		new web3._extend.Method({
+			name: 'sendMetaTransaction',
+			call: 'relay_sendMetaTransaction',
+			params: 2
+		}),
+		new web3._extend.Method({
+			name: 'getStatus',
+			call: 'relay_getStatus',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getNonce',
+			call: 'relay_getNonce',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter],
+			outputFormatter: web3._extend.utils.toBigNumber
+		}),
+	],
+	properties: [
+		new web3._extend.Property({
+			name: 'info',
+			getter: 'relay_info'
+		}),
+	]
+});
+`
@@ -0,0 +1,221 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package istanbulclient provides a client for the Istanbul consensus RPC API.
+package istanbulclient
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	"github.com/ethereum/go-ethereum/consensus/istanbul/backend"
+	"github.com/ethereum/go-ethereum/consensus/istanbul/core"
+	"github.com/ethereum/go-ethereum/consensus/istanbul/proxy"
+	blscrypto "github.com/ethereum/go-ethereum/crypto/bls"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Client defines typed wrappers for the Istanbul RPC API.
+type Client struct {
+	c *rpc.Client
+}
+
+// Dial connects a client to the given URL.
+func Dial(rawurl string) (*Client, error) {
+	return DialContext(context.Background(), rawurl)
+}
+
+// DialContext connects a client to the given URL with the given context.
+func DialContext(ctx context.Context, rawurl string) (*Client, error) {
+	c, err := rpc.DialContext(ctx, rawurl)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(c), nil
+}
+
+// NewClient creates a client that uses the given RPC client.
+func NewClient(c *rpc.Client) *Client {
+	return &Client{c}
+}
+
+// Close closes the underlying RPC connection.
+func (ic *Client) Close() {
+	ic.c.Close()
+}
+
+// Snapshot returns the validator set snapshot at the given block. If number is
+// nil, the snapshot at the latest known block is returned.
+func (ic *Client) Snapshot(ctx context.Context, number *big.Int) (*backend.Snapshot, error) {
+	var snap *backend.Snapshot
+	if err := ic.c.CallContext(ctx, &snap, "istanbul_getSnapshot", toBlockNumArg(number)); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// Validators returns the addresses of the validators that must sign the given
+// block. If number is nil, the validators of the latest known block are returned.
+func (ic *Client) Validators(ctx context.Context, number *big.Int) ([]common.Address, error) {
+	var validators []common.Address
+	err := ic.c.CallContext(ctx, &validators, "istanbul_getValidators", toBlockNumArg(number))
+	return validators, err
+}
+
+// ValidatorsBLSPublicKeys returns the BLS public keys of the validators that
+// must sign the given block. If number is nil, the keys of the validators of
+// the latest known block are returned.
+func (ic *Client) ValidatorsBLSPublicKeys(ctx context.Context, number *big.Int) ([]blscrypto.SerializedPublicKey, error) {
+	var keys []blscrypto.SerializedPublicKey
+	err := ic.c.CallContext(ctx, &keys, "istanbul_getValidatorsBLSPublicKeys", toBlockNumArg(number))
+	return keys, err
+}
+
+// Proposer returns the proposer of the given block (i.e. sequence) in the
+// given round. If number is nil, the latest known block is used.
+func (ic *Client) Proposer(ctx context.Context, number *big.Int, round uint64) (common.Address, error) {
+	var proposer common.Address
+	err := ic.c.CallContext(ctx, &proposer, "istanbul_getProposer", toBlockNumArg(number), round)
+	return proposer, err
+}
+
+// ValEnodeTable returns the validator enode table of the node, keyed by the
+// hex encoded validator address.
+func (ic *Client) ValEnodeTable(ctx context.Context) (map[string]*istanbul.ValEnodeEntryInfo, error) {
+	var table map[string]*istanbul.ValEnodeEntryInfo
+	err := ic.c.CallContext(ctx, &table, "istanbul_getValEnodeTable")
+	return table, err
+}
+
+// VersionCertificateTable returns the version certificate table of the node,
+// keyed by the hex encoded validator address.
+func (ic *Client) VersionCertificateTable(ctx context.Context) (map[string]*istanbul.VersionCertificateEntryInfo, error) {
+	var table map[string]*istanbul.VersionCertificateEntryInfo
+	err := ic.c.CallContext(ctx, &table, "istanbul_getVersionCertificateTableInfo")
+	return table, err
+}
+
+// CurrentRoundState returns a summary of the current IBFT round state.
+func (ic *Client) CurrentRoundState(ctx context.Context) (*core.RoundStateSummary, error) {
+	var state *core.RoundStateSummary
+	if err := ic.c.CallContext(ctx, &state, "istanbul_getCurrentRoundState"); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// CurrentReplicaState returns a summary of the current replica state.
+func (ic *Client) CurrentReplicaState(ctx context.Context) (*istanbul.ReplicaStateSummary, error) {
+	var state *istanbul.ReplicaStateSummary
+	if err := ic.c.CallContext(ctx, &state, "istanbul_getCurrentReplicaState"); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// ProxiesInfo returns information on the proxies of a proxied validator.
+func (ic *Client) ProxiesInfo(ctx context.Context) ([]*proxy.ProxyInfo, error) {
+	var proxies []*proxy.ProxyInfo
+	err := ic.c.CallContext(ctx, &proxies, "istanbul_getProxiesInfo")
+	return proxies, err
+}
+
+// ProxiedValidators returns information on the validators proxied by a proxy.
+func (ic *Client) ProxiedValidators(ctx context.Context) ([]*proxy.ProxiedValidatorInfo, error) {
+	var validators []*proxy.ProxiedValidatorInfo
+	err := ic.c.CallContext(ctx, &validators, "istanbul_getProxiedValidators")
+	return validators, err
+}
+
+// IsValidating reports whether the node is participating in consensus.
+func (ic *Client) IsValidating(ctx context.Context) (bool, error) {
+	var validating bool
+	err := ic.c.CallContext(ctx, &validating, "istanbul_isValidating")
+	return validating, err
+}
+
+// StartValidating makes the node participate in consensus.
+func (ic *Client) StartValidating(ctx context.Context) error {
+	return ic.c.CallContext(ctx, nil, "istanbul_startValidating")
+}
+
+// StopValidating makes the node stop participating in consensus.
+func (ic *Client) StopValidating(ctx context.Context) error {
+	return ic.c.CallContext(ctx, nil, "istanbul_stopValidating")
+}
+
+// StartValidatingAtBlock makes the node start participating in consensus at
+// the given block.
+func (ic *Client) StartValidatingAtBlock(ctx context.Context, number int64) error {
+	return ic.c.CallContext(ctx, nil, "istanbul_startValidatingAtBlock", number)
+}
+
+// StopValidatingAtBlock makes the node stop participating in consensus at the
+// given block.
+func (ic *Client) StopValidatingAtBlock(ctx context.Context, number int64) error {
+	return ic.c.CallContext(ctx, nil, "istanbul_stopValidatingAtBlock", number)
+}
+
+// AddProxy connects the proxied validator to a proxy, given by its internal
+// and external enode URLs.
+func (ic *Client) AddProxy(ctx context.Context, url, externalURL string) error {
+	return ic.c.CallContext(ctx, nil, "istanbul_addProxy", url, externalURL)
+}
+
+// RemoveProxy disconnects the proxied validator from a proxy.
+func (ic *Client) RemoveProxy(ctx context.Context, url string) error {
+	return ic.c.CallContext(ctx, nil, "istanbul_removeProxy", url)
+}
+
+// ForceRoundChange forces the node to move to the next consensus round.
+func (ic *Client) ForceRoundChange(ctx context.Context) error {
+	return ic.c.CallContext(ctx, nil, "istanbul_forceRoundChange")
+}
+
+// SubscribeRoundChange subscribes to notifications about the node moving to a
+// higher consensus round.
+func (ic *Client) SubscribeRoundChange(ctx context.Context, ch chan<- istanbul.RoundChangeEvent) (ethereum.Subscription, error) {
+	return ic.c.Subscribe(ctx, "istanbul", ch, "roundChange")
+}
+
+// SubscribeFinalCommitted subscribes to notifications about blocks being
+// inserted into the chain.
+func (ic *Client) SubscribeFinalCommitted(ctx context.Context, ch chan<- istanbul.FinalCommittedBlockEvent) (ethereum.Subscription, error) {
+	return ic.c.Subscribe(ctx, "istanbul", ch, "finalCommitted")
+}
+
+// SubscribeValidatorSetChange subscribes to notifications about the validators
+// added and removed at the end of each epoch.
+func (ic *Client) SubscribeValidatorSetChange(ctx context.Context, ch chan<- istanbul.ValidatorSetChangeEvent) (ethereum.Subscription, error) {
+	return ic.c.Subscribe(ctx, "istanbul", ch, "validatorSetChange")
+}
+
+// SubscribeEpochRewards subscribes to notifications about the epoch rewards
+// paid out at the end of each epoch.
+func (ic *Client) SubscribeEpochRewards(ctx context.Context, ch chan<- istanbul.EpochRewardsEvent) (ethereum.Subscription, error) {
+	return ic.c.Subscribe(ctx, "istanbul", ch, "epochRewards")
+}
+
+func toBlockNumArg(number *big.Int) string {
+	if number == nil {
+		return "latest"
+	}
+	return hexutil.EncodeBig(number)
+}
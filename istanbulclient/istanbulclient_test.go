@@ -0,0 +1,259 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package istanbulclient
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	"github.com/ethereum/go-ethereum/consensus/istanbul/backend"
+	"github.com/ethereum/go-ethereum/consensus/istanbul/core"
+	"github.com/ethereum/go-ethereum/consensus/istanbul/proxy"
+	"github.com/ethereum/go-ethereum/consensus/istanbul/validator"
+	blscrypto "github.com/ethereum/go-ethereum/crypto/bls"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	testValidators = []istanbul.ValidatorData{
+		{Address: common.HexToAddress("0x01"), BLSPublicKey: blscrypto.SerializedPublicKey{1}},
+		{Address: common.HexToAddress("0x02"), BLSPublicKey: blscrypto.SerializedPublicKey{2}},
+	}
+	testNode = enode.MustParse("enode://ba85011c70bcc5c04d8607d3a0ed29aa6179c092cbdda10d5d32684fb33ed01bd94f588ca8f91ac48318087dcb02eaf36773a7a453f0eedd6742af668097b29c@10.0.1.16:30303")
+)
+
+// testService mimics the istanbul RPC API, serving fixed results and recording
+// the arguments it was called with.
+type testService struct {
+	number   *rpc.BlockNumber
+	startsAt int64
+}
+
+func (s *testService) GetSnapshot(number *rpc.BlockNumber) (*backend.Snapshot, error) {
+	s.number = number
+	return &backend.Snapshot{Epoch: 17280, Number: 5, Hash: common.HexToHash("0x05"), ValSet: validator.NewSet(testValidators)}, nil
+}
+
+func (s *testService) GetValidators(number *rpc.BlockNumber) ([]common.Address, error) {
+	s.number = number
+	return istanbul.MapValidatorsToAddresses(validator.NewSet(testValidators).List()), nil
+}
+
+func (s *testService) GetValidatorsBLSPublicKeys(number *rpc.BlockNumber) ([]blscrypto.SerializedPublicKey, error) {
+	s.number = number
+	return istanbul.MapValidatorsToPublicKeys(validator.NewSet(testValidators).List()), nil
+}
+
+func (s *testService) GetProposer(number *rpc.BlockNumber, round *uint64) (common.Address, error) {
+	s.number = number
+	return testValidators[*round%uint64(len(testValidators))].Address, nil
+}
+
+func (s *testService) GetValEnodeTable() (map[string]*istanbul.ValEnodeEntryInfo, error) {
+	return map[string]*istanbul.ValEnodeEntryInfo{
+		testValidators[0].Address.Hex(): {Enode: testNode.String(), Version: 3, HighestKnownVersion: 4},
+	}, nil
+}
+
+func (s *testService) GetCurrentRoundState() (*core.RoundStateSummary, error) {
+	return &core.RoundStateSummary{
+		State:         "Accept request",
+		Sequence:      big.NewInt(6),
+		Round:         big.NewInt(1),
+		DesiredRound:  big.NewInt(1),
+		ValidatorSet:  []common.Address{testValidators[0].Address, testValidators[1].Address},
+		Proposer:      testValidators[1].Address,
+		Prepares:      []common.Address{},
+		Commits:       []common.Address{},
+		ParentCommits: []common.Address{testValidators[0].Address},
+	}, nil
+}
+
+func (s *testService) GetCurrentReplicaState() (*istanbul.ReplicaStateSummary, error) {
+	return &istanbul.ReplicaStateSummary{State: "Primary in given range", IsPrimary: true, StartValidatingBlock: big.NewInt(10), StopValidatingBlock: big.NewInt(20)}, nil
+}
+
+func (s *testService) GetProxiesInfo() ([]*proxy.ProxyInfo, error) {
+	return []*proxy.ProxyInfo{{InternalNode: testNode, ExternalNode: testNode, IsPeered: true, AssignedRemoteValidators: []common.Address{testValidators[0].Address}}}, nil
+}
+
+func (s *testService) IsValidating() bool {
+	return true
+}
+
+func (s *testService) StartValidatingAtBlock(number int64) error {
+	s.startsAt = number
+	return nil
+}
+
+func (s *testService) RoundChange(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	notifier.Notify(sub.ID, istanbul.RoundChangeEvent{Sequence: big.NewInt(6), Round: big.NewInt(2), Reason: istanbul.RoundChangeReasonTimeout})
+	return sub, nil
+}
+
+func newTestClient(t *testing.T) (*Client, *testService) {
+	service := new(testService)
+	server := rpc.NewServer()
+	if err := server.RegisterName("istanbul", service); err != nil {
+		t.Fatalf("failed to register service: %v", err)
+	}
+	return NewClient(rpc.DialInProc(server)), service
+}
+
+func TestValidators(t *testing.T) {
+	client, service := newTestClient(t)
+	defer client.Close()
+	ctx := context.Background()
+
+	validators, err := client.Validators(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to retrieve validators: %v", err)
+	}
+	if want := []common.Address{testValidators[0].Address, testValidators[1].Address}; !reflect.DeepEqual(validators, want) {
+		t.Errorf("validators mismatch: have %v, want %v", validators, want)
+	}
+	if service.number == nil || *service.number != rpc.LatestBlockNumber {
+		t.Errorf("nil block number not sent as latest: %v", service.number)
+	}
+	keys, err := client.ValidatorsBLSPublicKeys(ctx, big.NewInt(3))
+	if err != nil {
+		t.Fatalf("failed to retrieve validator BLS keys: %v", err)
+	}
+	if want := []blscrypto.SerializedPublicKey{testValidators[0].BLSPublicKey, testValidators[1].BLSPublicKey}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("validator BLS keys mismatch: have %v, want %v", keys, want)
+	}
+	if service.number == nil || *service.number != 3 {
+		t.Errorf("block number mismatch: have %v, want 3", service.number)
+	}
+	proposer, err := client.Proposer(ctx, nil, 1)
+	if err != nil {
+		t.Fatalf("failed to retrieve proposer: %v", err)
+	}
+	if proposer != testValidators[1].Address {
+		t.Errorf("proposer mismatch: have %x, want %x", proposer, testValidators[1].Address)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	client, _ := newTestClient(t)
+	defer client.Close()
+
+	snap, err := client.Snapshot(context.Background(), big.NewInt(5))
+	if err != nil {
+		t.Fatalf("failed to retrieve snapshot: %v", err)
+	}
+	if snap.Epoch != 17280 || snap.Number != 5 || snap.Hash != common.HexToHash("0x05") {
+		t.Errorf("snapshot mismatch: have epoch %d number %d hash %x", snap.Epoch, snap.Number, snap.Hash)
+	}
+	if data := validator.MapValidatorsToData(snap.ValSet.List()); !reflect.DeepEqual(data, testValidators) {
+		t.Errorf("snapshot validators mismatch: have %v, want %v", data, testValidators)
+	}
+}
+
+func TestNodeState(t *testing.T) {
+	client, service := newTestClient(t)
+	defer client.Close()
+	ctx := context.Background()
+
+	table, err := client.ValEnodeTable(ctx)
+	if err != nil {
+		t.Fatalf("failed to retrieve val enode table: %v", err)
+	}
+	want, _ := service.GetValEnodeTable()
+	if !reflect.DeepEqual(table, want) {
+		t.Errorf("val enode table mismatch: have %v, want %v", table, want)
+	}
+	roundState, err := client.CurrentRoundState(ctx)
+	if err != nil {
+		t.Fatalf("failed to retrieve round state: %v", err)
+	}
+	if wantRoundState, _ := service.GetCurrentRoundState(); !reflect.DeepEqual(roundState, wantRoundState) {
+		t.Errorf("round state mismatch: have %+v, want %+v", roundState, wantRoundState)
+	}
+	replicaState, err := client.CurrentReplicaState(ctx)
+	if err != nil {
+		t.Fatalf("failed to retrieve replica state: %v", err)
+	}
+	if wantReplicaState, _ := service.GetCurrentReplicaState(); !reflect.DeepEqual(replicaState, wantReplicaState) {
+		t.Errorf("replica state mismatch: have %+v, want %+v", replicaState, wantReplicaState)
+	}
+	proxies, err := client.ProxiesInfo(ctx)
+	if err != nil {
+		t.Fatalf("failed to retrieve proxies: %v", err)
+	}
+	if len(proxies) != 1 || proxies[0].InternalNode.ID() != testNode.ID() || !proxies[0].IsPeered {
+		t.Errorf("proxies mismatch: have %v", proxies)
+	}
+}
+
+func TestValidating(t *testing.T) {
+	client, service := newTestClient(t)
+	defer client.Close()
+	ctx := context.Background()
+
+	validating, err := client.IsValidating(ctx)
+	if err != nil {
+		t.Fatalf("failed to retrieve validating status: %v", err)
+	}
+	if !validating {
+		t.Error("node reported as not validating")
+	}
+	if err := client.StartValidatingAtBlock(ctx, 100); err != nil {
+		t.Fatalf("failed to schedule validating: %v", err)
+	}
+	if service.startsAt != 100 {
+		t.Errorf("start block mismatch: have %d, want 100", service.startsAt)
+	}
+	if err := client.StopValidating(ctx); err == nil {
+		t.Error("expected error calling a method the service does not serve")
+	}
+}
+
+func TestSubscribeRoundChange(t *testing.T) {
+	client, _ := newTestClient(t)
+	defer client.Close()
+
+	roundChanges := make(chan istanbul.RoundChangeEvent)
+	sub, err := client.SubscribeRoundChange(context.Background(), roundChanges)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	select {
+	case ev := <-roundChanges:
+		want := istanbul.RoundChangeEvent{Sequence: big.NewInt(6), Round: big.NewInt(2), Reason: istanbul.RoundChangeReasonTimeout}
+		if !reflect.DeepEqual(ev, want) {
+			t.Errorf("round change mismatch: have %+v, want %+v", ev, want)
+		}
+	case err := <-sub.Err():
+		t.Fatalf("subscription failed: %v", err)
+	case <-time.After(time.Second):
+		t.Fatal("no round change notification")
+	}
+}
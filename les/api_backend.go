@@ -174,6 +174,13 @@ func (b *LesApiBackend) GetEVM(ctx context.Context, msg vm.Message, header *type
 	return vm.NewEVM(context, state, b.eth.chainConfig, vm.Config{}), state.Error, nil
 }
 
+// GetSimulationEVM returns an EVM for simulating messages on the given state.
+// Unlike GetEVM, the sender isn't credited, so it pays for the messages.
+func (b *LesApiBackend) GetSimulationEVM(ctx context.Context, msg vm.Message, header *types.Header, state *state.StateDB, vmCfg vm.Config) (*vm.EVM, func() error, error) {
+	context := vm.NewEVMContext(msg, header, b.eth.blockchain, nil)
+	return vm.NewEVM(context, state, b.eth.chainConfig, vmCfg), state.Error, nil
+}
+
 func (b *LesApiBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
 	return b.eth.txPool.Add(ctx, signedTx)
 }
@@ -34,6 +34,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
@@ -99,6 +100,8 @@ type environment struct {
 	txs        []*types.Transaction
 	receipts   []*types.Receipt
 	randomness *types.Randomness // The types.Randomness of the last block by mined by this worker.
+
+	registryCache *vm.RegistryCache // Registry lookups cached for the block
 }
 
 // task contains all information for consensus engine sealing and result submitting.
@@ -619,11 +622,12 @@ func (w *worker) makeCurrent(parent *types.Block, header *types.Header) error {
 	}
 
 	env := &environment{
-		signer:    types.NewEIP155Signer(w.chainConfig.ChainID),
-		state:     state,
-		ancestors: mapset.NewSet(),
-		header:    header,
-		gasLimit:  core.CalcGasLimit(parent, state),
+		signer:        types.NewEIP155Signer(w.chainConfig.ChainID),
+		state:         state,
+		ancestors:     mapset.NewSet(),
+		header:        header,
+		gasLimit:      core.CalcGasLimit(parent, state),
+		registryCache: vm.NewRegistryCache(),
 	}
 
 	// when 08 is processed ancestors contain 07 (quick block)
@@ -656,7 +660,7 @@ func (w *worker) updateSnapshot() {
 func (w *worker) commitTransaction(tx *types.Transaction, coinbase common.Address) ([]*types.Log, error) {
 	snap := w.current.state.Snapshot()
 
-	receipt, err := core.ApplyTransaction(w.chainConfig, w.chain, &coinbase, w.current.gasPool, w.current.state, w.current.header, tx, &w.current.header.GasUsed, *w.chain.GetVMConfig())
+	receipt, err := core.ApplyTransaction(w.chainConfig, w.chain, &coinbase, w.current.gasPool, w.current.state, w.current.header, tx, &w.current.header.GasUsed, *w.chain.GetVMConfig(), w.current.registryCache)
 	if err != nil {
 		w.current.state.RevertToSnapshot(snap)
 		return nil, err
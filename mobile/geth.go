@@ -31,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/ethstats"
 	"github.com/ethereum/go-ethereum/internal/debug"
+	"github.com/ethereum/go-ethereum/istanbulclient"
 	"github.com/ethereum/go-ethereum/les"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/p2p"
@@ -319,6 +320,15 @@ func (n *Node) GetEthereumClient() (client *EthereumClient, _ error) {
 	return &EthereumClient{ethclient.NewClient(rpc)}, nil
 }
 
+// GetIstanbulClient retrieves a client to access the Istanbul consensus subsystem.
+func (n *Node) GetIstanbulClient() (client *IstanbulClient, _ error) {
+	rpc, err := n.node.Attach()
+	if err != nil {
+		return nil, err
+	}
+	return &IstanbulClient{istanbulclient.NewClient(rpc)}, nil
+}
+
 // GetNodeInfo gathers and returns a collection of metadata known about the host.
 func (n *Node) GetNodeInfo() *NodeInfo {
 	return &NodeInfo{n.node.Server().NodeInfo()}
@@ -0,0 +1,318 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+// Contains a wrapper for the read-only calls of the Istanbul client.
+
+package geth
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	"github.com/ethereum/go-ethereum/consensus/istanbul/backend"
+	"github.com/ethereum/go-ethereum/consensus/istanbul/core"
+	"github.com/ethereum/go-ethereum/consensus/istanbul/proxy"
+	blscrypto "github.com/ethereum/go-ethereum/crypto/bls"
+	"github.com/ethereum/go-ethereum/istanbulclient"
+)
+
+// IstanbulClient provides access to the Istanbul consensus APIs.
+type IstanbulClient struct {
+	client *istanbulclient.Client
+}
+
+// NewIstanbulClient connects a client to the given URL.
+func NewIstanbulClient(rawurl string) (client *IstanbulClient, _ error) {
+	rawClient, err := istanbulclient.Dial(rawurl)
+	return &IstanbulClient{rawClient}, err
+}
+
+// GetSnapshot returns the validator set snapshot at the given block. If number
+// is <0, the snapshot at the latest known block is returned.
+func (ic *IstanbulClient) GetSnapshot(ctx *Context, number int64) (snapshot *IstanbulSnapshot, _ error) {
+	rawSnapshot, err := ic.client.Snapshot(ctx.context, toBlockNumber(number))
+	if err != nil {
+		return nil, err
+	}
+	return &IstanbulSnapshot{rawSnapshot}, nil
+}
+
+// GetValidators returns the addresses of the validators that must sign the
+// given block. If number is <0, the validators of the latest known block are
+// returned.
+func (ic *IstanbulClient) GetValidators(ctx *Context, number int64) (validators *Addresses, _ error) {
+	rawValidators, err := ic.client.Validators(ctx.context, toBlockNumber(number))
+	return &Addresses{rawValidators}, err
+}
+
+// GetValidatorsBLSPublicKeys returns the BLS public keys of the validators that
+// must sign the given block. If number is <0, the keys of the validators of the
+// latest known block are returned.
+func (ic *IstanbulClient) GetValidatorsBLSPublicKeys(ctx *Context, number int64) (keys *BLSPublicKeys, _ error) {
+	rawKeys, err := ic.client.ValidatorsBLSPublicKeys(ctx.context, toBlockNumber(number))
+	return &BLSPublicKeys{rawKeys}, err
+}
+
+// GetProposer returns the proposer of the given block in the given round. If
+// number is <0, the latest known block is used.
+func (ic *IstanbulClient) GetProposer(ctx *Context, number int64, round int64) (proposer *Address, _ error) {
+	if round < 0 {
+		return nil, errors.New("negative round")
+	}
+	rawProposer, err := ic.client.Proposer(ctx.context, toBlockNumber(number), uint64(round))
+	return &Address{rawProposer}, err
+}
+
+// GetValEnodeTable returns the validator enode table of the node.
+func (ic *IstanbulClient) GetValEnodeTable(ctx *Context) (table *ValEnodeTable, _ error) {
+	rawTable, err := ic.client.ValEnodeTable(ctx.context)
+	if err != nil {
+		return nil, err
+	}
+	return &ValEnodeTable{rawTable}, nil
+}
+
+// GetCurrentRoundState returns a summary of the current IBFT round state.
+func (ic *IstanbulClient) GetCurrentRoundState(ctx *Context) (state *IstanbulRoundState, _ error) {
+	rawState, err := ic.client.CurrentRoundState(ctx.context)
+	if err != nil {
+		return nil, err
+	}
+	return &IstanbulRoundState{rawState}, nil
+}
+
+// GetCurrentReplicaState returns a summary of the current replica state.
+func (ic *IstanbulClient) GetCurrentReplicaState(ctx *Context) (state *IstanbulReplicaState, _ error) {
+	rawState, err := ic.client.CurrentReplicaState(ctx.context)
+	if err != nil {
+		return nil, err
+	}
+	return &IstanbulReplicaState{rawState}, nil
+}
+
+// GetProxiesInfo returns information on the proxies of a proxied validator.
+func (ic *IstanbulClient) GetProxiesInfo(ctx *Context) (proxies *ProxyInfos, _ error) {
+	rawProxies, err := ic.client.ProxiesInfo(ctx.context)
+	return &ProxyInfos{rawProxies}, err
+}
+
+// IsValidating reports whether the node is participating in consensus.
+func (ic *IstanbulClient) IsValidating(ctx *Context) (bool, error) {
+	return ic.client.IsValidating(ctx.context)
+}
+
+// toBlockNumber converts a mobile block number to a client one, mapping
+// negative numbers to the latest known block.
+func toBlockNumber(number int64) *big.Int {
+	if number < 0 {
+		return nil
+	}
+	return big.NewInt(number)
+}
+
+// IstanbulSnapshot represents the validator set at a given block.
+type IstanbulSnapshot struct {
+	snapshot *backend.Snapshot
+}
+
+// GetEpoch returns the epoch size of the chain, in blocks.
+func (s *IstanbulSnapshot) GetEpoch() int64 { return int64(s.snapshot.Epoch) }
+
+// GetNumber returns the number of the block the snapshot was created at.
+func (s *IstanbulSnapshot) GetNumber() int64 { return int64(s.snapshot.Number) }
+
+// GetHash returns the hash of the block the snapshot was created at.
+func (s *IstanbulSnapshot) GetHash() *Hash { return &Hash{s.snapshot.Hash} }
+
+// GetValidators returns the addresses of the validators in the snapshot.
+func (s *IstanbulSnapshot) GetValidators() *Addresses {
+	return &Addresses{istanbul.MapValidatorsToAddresses(s.snapshot.ValSet.List())}
+}
+
+// GetValidatorsBLSPublicKeys returns the BLS public keys of the validators in
+// the snapshot.
+func (s *IstanbulSnapshot) GetValidatorsBLSPublicKeys() *BLSPublicKeys {
+	return &BLSPublicKeys{istanbul.MapValidatorsToPublicKeys(s.snapshot.ValSet.List())}
+}
+
+// BLSPublicKeys represents a slice of serialized BLS public keys.
+type BLSPublicKeys struct {
+	keys []blscrypto.SerializedPublicKey
+}
+
+// Size returns the number of keys in the slice.
+func (k *BLSPublicKeys) Size() int {
+	return len(k.keys)
+}
+
+// Get returns the serialized key at the given index from the slice.
+func (k *BLSPublicKeys) Get(index int) (key []byte, _ error) {
+	if index < 0 || index >= len(k.keys) {
+		return nil, errors.New("index out of bounds")
+	}
+	return common.CopyBytes(k.keys[index][:]), nil
+}
+
+// ValEnodeTable represents the validator enode table of a node.
+type ValEnodeTable struct {
+	entries map[string]*istanbul.ValEnodeEntryInfo
+}
+
+// Size returns the number of entries in the table.
+func (t *ValEnodeTable) Size() int {
+	return len(t.entries)
+}
+
+// GetAddresses returns the validator addresses in the table, in ascending order.
+func (t *ValEnodeTable) GetAddresses() *Addresses {
+	addresses := make([]common.Address, 0, len(t.entries))
+	for address := range t.entries {
+		addresses = append(addresses, common.HexToAddress(address))
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].Hex() < addresses[j].Hex()
+	})
+	return &Addresses{addresses}
+}
+
+// Get returns the entry of the given validator from the table.
+func (t *ValEnodeTable) Get(address *Address) (entry *ValEnodeEntry, _ error) {
+	rawEntry, ok := t.entries[address.address.Hex()]
+	if !ok {
+		return nil, errors.New("validator not found")
+	}
+	return &ValEnodeEntry{rawEntry}, nil
+}
+
+// ValEnodeEntry represents the entry of a validator in the validator enode table.
+type ValEnodeEntry struct {
+	entry *istanbul.ValEnodeEntryInfo
+}
+
+// GetPublicKey returns the hex encoded compressed public key of the validator.
+func (e *ValEnodeEntry) GetPublicKey() string { return e.entry.PublicKey }
+
+// GetEnode returns the enode URL of the validator.
+func (e *ValEnodeEntry) GetEnode() string { return e.entry.Enode }
+
+// GetVersion returns the version of the validator's enode URL.
+func (e *ValEnodeEntry) GetVersion() int64 { return int64(e.entry.Version) }
+
+// GetHighestKnownVersion returns the highest version of the validator's enode
+// URL the node has heard of.
+func (e *ValEnodeEntry) GetHighestKnownVersion() int64 { return int64(e.entry.HighestKnownVersion) }
+
+// IstanbulRoundState represents a summary of the IBFT round state of a node.
+type IstanbulRoundState struct {
+	state *core.RoundStateSummary
+}
+
+// GetState returns the name of the current consensus state.
+func (s *IstanbulRoundState) GetState() string { return s.state.State }
+
+// GetSequence returns the block number being agreed upon.
+func (s *IstanbulRoundState) GetSequence() *BigInt { return &BigInt{s.state.Sequence} }
+
+// GetRound returns the current round.
+func (s *IstanbulRoundState) GetRound() *BigInt { return &BigInt{s.state.Round} }
+
+// GetDesiredRound returns the round the node wants to move to.
+func (s *IstanbulRoundState) GetDesiredRound() *BigInt { return &BigInt{s.state.DesiredRound} }
+
+// GetProposer returns the proposer of the current round.
+func (s *IstanbulRoundState) GetProposer() *Address { return &Address{s.state.Proposer} }
+
+// GetValidatorSet returns the validators of the current sequence.
+func (s *IstanbulRoundState) GetValidatorSet() *Addresses { return &Addresses{s.state.ValidatorSet} }
+
+// GetPrepares returns the validators whose prepare messages have been received.
+func (s *IstanbulRoundState) GetPrepares() *Addresses { return &Addresses{s.state.Prepares} }
+
+// GetCommits returns the validators whose commit messages have been received.
+func (s *IstanbulRoundState) GetCommits() *Addresses { return &Addresses{s.state.Commits} }
+
+// GetParentCommits returns the validators whose commit messages for the parent
+// block have been received.
+func (s *IstanbulRoundState) GetParentCommits() *Addresses { return &Addresses{s.state.ParentCommits} }
+
+// IstanbulReplicaState represents a summary of the replica state of a node.
+type IstanbulReplicaState struct {
+	state *istanbul.ReplicaStateSummary
+}
+
+// GetState returns the name of the current replica state.
+func (s *IstanbulReplicaState) GetState() string { return s.state.State }
+
+// IsPrimary reports whether the node is currently the primary validator.
+func (s *IstanbulReplicaState) IsPrimary() bool { return s.state.IsPrimary }
+
+// GetStartValidatingBlock returns the block the node starts validating at, or
+// nil if none is scheduled.
+func (s *IstanbulReplicaState) GetStartValidatingBlock() *BigInt {
+	if s.state.StartValidatingBlock == nil {
+		return nil
+	}
+	return &BigInt{s.state.StartValidatingBlock}
+}
+
+// GetStopValidatingBlock returns the block the node stops validating at, or nil
+// if none is scheduled.
+func (s *IstanbulReplicaState) GetStopValidatingBlock() *BigInt {
+	if s.state.StopValidatingBlock == nil {
+		return nil
+	}
+	return &BigInt{s.state.StopValidatingBlock}
+}
+
+// ProxyInfo represents the state of a proxy of a proxied validator.
+type ProxyInfo struct {
+	info *proxy.ProxyInfo
+}
+
+// GetInternalEnode returns the enode URL the validator connects to the proxy on.
+func (pi *ProxyInfo) GetInternalEnode() string { return pi.info.InternalNode.String() }
+
+// GetExternalEnode returns the enode URL the proxy is reachable on by the network.
+func (pi *ProxyInfo) GetExternalEnode() string { return pi.info.ExternalNode.String() }
+
+// IsPeered reports whether the validator is connected to the proxy.
+func (pi *ProxyInfo) IsPeered() bool { return pi.info.IsPeered }
+
+// GetValidators returns the remote validators assigned to the proxy.
+func (pi *ProxyInfo) GetValidators() *Addresses {
+	return &Addresses{pi.info.AssignedRemoteValidators}
+}
+
+// ProxyInfos represents a slice of proxy infos.
+type ProxyInfos struct {
+	infos []*proxy.ProxyInfo
+}
+
+// Size returns the number of proxy infos in the slice.
+func (pi *ProxyInfos) Size() int {
+	return len(pi.infos)
+}
+
+// Get returns the proxy info at the given index from the slice.
+func (pi *ProxyInfos) Get(index int) (info *ProxyInfo, _ error) {
+	if index < 0 || index >= len(pi.infos) {
+		return nil, errors.New("index out of bounds")
+	}
+	return &ProxyInfo{pi.infos[index]}, nil
+}
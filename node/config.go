@@ -167,6 +167,12 @@ type Config struct {
 	// private APIs to untrusted users is a major security risk.
 	WSExposeAll bool `toml:",omitempty"`
 
+	// RPCPolicy restricts the methods served over the HTTP, websocket and IPC
+	// interfaces, and limits the rate, batch size, response size and duration of
+	// their requests. If nil, the requests aren't restricted beyond the modules
+	// exposed.
+	RPCPolicy *rpc.PolicyConfig `toml:",omitempty"`
+
 	// GraphQLHost is the host interface on which to start the GraphQL server. If this
 	// field is empty, no GraphQL API endpoint will be started.
 	GraphQLHost string `toml:",omitempty"`
@@ -143,8 +143,33 @@ const (
 	GetParentSealBitmapGas      uint64 = 100    // Cost of reading the parent seal bitmap from the chain.
 	// May take a bit more time with 100 validators, need to bench that
 	GetVerifiedSealBitmapGas uint64 = 350000 // Cost of verifying the seal on a given RLP encoded header.
+
+	// Celo precompiled contracts introduced in the Donut fork
+	Sha3_512BaseGas         uint64 = 60   // Base price for a SHA3-512 operation
+	Sha3_512PerWordGas      uint64 = 12   // Per-word price for a SHA3-512 operation
+	Keccak512BaseGas        uint64 = 60   // Base price for a Keccak-512 operation
+	Keccak512PerWordGas     uint64 = 12   // Per-word price for a Keccak-512 operation
+	Blake2sBaseGas          uint64 = 60   // Base price for a BLAKE2s operation
+	Blake2sPerWordGas       uint64 = 12   // Per-word price for a BLAKE2s operation
+	Ed25519VerifyGas        uint64 = 2000 // Base price for verifying an Ed25519 signature
+	Ed25519VerifyPerWordGas uint64 = 12   // Per-word price for hashing the input of an Ed25519 verification
+
+	// BLS12-377 and BLS12-381 curve operations introduced in the Donut fork
+	Bls12G1AddGas          uint64 = 600    // Price for a G1 point addition
+	Bls12G1MulGas          uint64 = 12000  // Price for a G1 point scalar multiplication
+	Bls12G2AddGas          uint64 = 4500   // Price for a G2 point addition
+	Bls12G2MulGas          uint64 = 55000  // Price for a G2 point scalar multiplication
+	Bls12PairingBaseGas    uint64 = 115000 // Base price for a pairing check
+	Bls12PairingPerPairGas uint64 = 23000  // Per-pair price for a pairing check
+	Bls12MapG1Gas          uint64 = 5500   // Price for mapping a field element to a G1 point
+	Bls12MapG2Gas          uint64 = 110000 // Price for mapping a field element to a G2 point
 )
 
+// Bls12MultiExpDiscountTable is the gas discount table for BLS12-377 and
+// BLS12-381 G1 and G2 multi exponentiation operations, in thousandths, indexed
+// by the number of pairs minus one.
+var Bls12MultiExpDiscountTable = [128]uint64{1200, 888, 764, 641, 594, 547, 500, 453, 438, 423, 408, 394, 379, 364, 349, 334, 330, 326, 322, 318, 314, 310, 306, 302, 298, 294, 289, 285, 281, 277, 273, 269, 268, 266, 265, 263, 262, 260, 259, 257, 256, 254, 253, 251, 250, 248, 247, 245, 244, 242, 241, 239, 238, 236, 235, 233, 232, 231, 229, 228, 226, 225, 223, 222, 221, 220, 219, 219, 218, 217, 216, 216, 215, 214, 213, 213, 212, 211, 211, 210, 209, 208, 208, 207, 206, 205, 205, 204, 203, 202, 202, 201, 200, 199, 199, 198, 197, 196, 196, 195, 194, 193, 193, 192, 191, 191, 190, 189, 188, 188, 187, 186, 185, 185, 184, 183, 182, 182, 181, 180, 179, 179, 178, 177, 176, 176, 175, 174}
+
 var (
 	RegistrySmartContractAddress = common.HexToAddress("0x000000000000000000000000000000000000ce10")
 
@@ -0,0 +1,75 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core"
+)
+
+// PublicRelayAPI provides an API to relay meta-transactions.
+type PublicRelayAPI struct {
+	r *Relayer
+}
+
+// NewPublicRelayAPI creates a new meta-transaction relay API.
+func NewPublicRelayAPI(r *Relayer) *PublicRelayAPI {
+	return &PublicRelayAPI{r}
+}
+
+// RelayInfo describes how the relay executes meta-transactions.
+type RelayInfo struct {
+	Forwarder   common.Address  `json:"forwarder"`
+	Account     common.Address  `json:"account"`
+	FeeCurrency *common.Address `json:"feeCurrency"`
+	ChainID     *hexutil.Big    `json:"chainId"`
+	MaxGas      hexutil.Uint64  `json:"maxGas"`
+}
+
+// Info returns the forwarder the meta-transactions are executed through and
+// the account relaying them.
+func (api *PublicRelayAPI) Info() *RelayInfo {
+	return &RelayInfo{
+		Forwarder:   api.r.config.Forwarder,
+		Account:     api.r.config.Account,
+		FeeCurrency: api.r.config.feeCurrency(),
+		ChainID:     (*hexutil.Big)(api.r.chainID),
+		MaxGas:      hexutil.Uint64(api.r.config.MaxGas),
+	}
+}
+
+// GetNonce returns the forwarder nonce to sign the next meta-transaction of
+// the given account with.
+func (api *PublicRelayAPI) GetNonce(ctx context.Context, from common.Address) (*hexutil.Big, error) {
+	nonce, err := api.r.Nonce(ctx, from)
+	return (*hexutil.Big)(nonce), err
+}
+
+// SendMetaTransaction queues a ForwardRequest, signed according to EIP-712 by
+// its sender, for relaying and returns its id.
+func (api *PublicRelayAPI) SendMetaTransaction(ctx context.Context, typedData core.TypedData, signature hexutil.Bytes) (common.Hash, error) {
+	return api.r.Submit(ctx, typedData, signature)
+}
+
+// GetStatus returns the status of the meta-transaction with the given id, or
+// nil if it is unknown.
+func (api *PublicRelayAPI) GetStatus(id common.Hash) (*Entry, error) {
+	return api.r.Status(id)
+}
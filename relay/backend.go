@@ -0,0 +1,173 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Backend is the chain access the relay needs.
+type Backend interface {
+	// ChainID returns the id of the chain transactions are signed for.
+	ChainID() *big.Int
+
+	// Call executes a message call on the latest state, returning an error
+	// carrying the revert reason if the execution reverts.
+	Call(ctx context.Context, args ethapi.CallArgs) ([]byte, error)
+
+	// EstimateGas estimates the gas needed to execute a message call on the
+	// pending state.
+	EstimateGas(ctx context.Context, args ethapi.CallArgs) (uint64, error)
+
+	// SuggestGasPrice returns the gas price to pay, denominated in the given
+	// fee currency or the native token if nil.
+	SuggestGasPrice(ctx context.Context, feeCurrency *common.Address) (*big.Int, error)
+
+	// PoolNonce returns the next nonce of the account, taking the pending
+	// transactions into account.
+	PoolNonce(ctx context.Context, account common.Address) (uint64, error)
+
+	// SendTx submits a signed transaction to the transaction pool.
+	SendTx(ctx context.Context, tx *types.Transaction) error
+
+	// Receipt returns the receipt of a mined transaction, or nil if it has not
+	// been mined.
+	Receipt(ctx context.Context, hash common.Hash) (*types.Receipt, error)
+
+	// TransactionResult returns the return data of a mined transaction by
+	// executing it again on the state it was mined on.
+	TransactionResult(ctx context.Context, hash common.Hash) ([]byte, error)
+
+	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+}
+
+// StateBackend is the backend of the RPC APIs of a node able to regenerate the
+// state its transactions were executed on.
+type StateBackend interface {
+	ethapi.Backend
+
+	// StateAtTransaction returns the message of a mined transaction together
+	// with the context and state it was executed on, including the block level
+	// changes preceding the transactions such as the randomness commitment.
+	StateAtTransaction(ctx context.Context, blockHash common.Hash, txIndex int) (vm.Message, vm.Context, *state.StateDB, error)
+}
+
+// ethBackend implements Backend on top of the backend of the RPC APIs.
+type ethBackend struct {
+	b     StateBackend
+	eth   *ethapi.PublicEthereumAPI
+	chain *ethapi.PublicBlockChainAPI
+}
+
+// NewEthBackend creates a relay backend accessing the chain through the given
+// RPC API backend.
+func NewEthBackend(b StateBackend) Backend {
+	return &ethBackend{
+		b:     b,
+		eth:   ethapi.NewPublicEthereumAPI(b),
+		chain: ethapi.NewPublicBlockChainAPI(b),
+	}
+}
+
+func (b *ethBackend) ChainID() *big.Int {
+	return b.b.ChainConfig().ChainID
+}
+
+func (b *ethBackend) Call(ctx context.Context, args ethapi.CallArgs) ([]byte, error) {
+	return b.chain.Call(ctx, args, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), nil)
+}
+
+func (b *ethBackend) EstimateGas(ctx context.Context, args ethapi.CallArgs) (uint64, error) {
+	gas, err := ethapi.DoEstimateGas(ctx, b.b, args, rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber), b.b.RPCGasCap())
+	return uint64(gas), err
+}
+
+func (b *ethBackend) SuggestGasPrice(ctx context.Context, feeCurrency *common.Address) (*big.Int, error) {
+	price, err := b.eth.GasPrice(ctx, feeCurrency)
+	return (*big.Int)(price), err
+}
+
+func (b *ethBackend) PoolNonce(ctx context.Context, account common.Address) (uint64, error) {
+	return b.b.GetPoolNonce(ctx, account)
+}
+
+func (b *ethBackend) SendTx(ctx context.Context, tx *types.Transaction) error {
+	_, err := ethapi.SubmitTransaction(ctx, b.b, tx)
+	return err
+}
+
+func (b *ethBackend) Receipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	tx, blockHash, _, index, err := b.b.GetTransaction(ctx, hash)
+	if tx == nil || err != nil {
+		return nil, err
+	}
+	receipts, err := b.b.GetReceipts(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(receipts) <= int(index) {
+		return nil, nil
+	}
+	return receipts[index], nil
+}
+
+func (b *ethBackend) TransactionResult(ctx context.Context, hash common.Hash) ([]byte, error) {
+	tx, blockHash, _, index, err := b.b.GetTransaction(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("transaction %#x not found", hash)
+	}
+	msg, vmctx, statedb, err := b.b.StateAtTransaction(ctx, blockHash, int(index))
+	if err != nil {
+		return nil, err
+	}
+	evm := vm.NewEVM(vmctx, statedb, b.b.ChainConfig(), vm.Config{})
+	res, _, _, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(msg.Gas()))
+	if err != nil {
+		return nil, fmt.Errorf("transaction %#x failed: %v", hash, err)
+	}
+	return res, nil
+}
+
+func (b *ethBackend) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
+	return b.b.SubscribeChainHeadEvent(ch)
+}
+
+// callArgs assembles the arguments of a message call.
+func callArgs(from *common.Address, to common.Address, feeCurrency *common.Address, data []byte) ethapi.CallArgs {
+	input := hexutil.Bytes(data)
+	return ethapi.CallArgs{
+		From:        from,
+		To:          &to,
+		FeeCurrency: feeCurrency,
+		Data:        &input,
+	}
+}
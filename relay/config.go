@@ -0,0 +1,79 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultConfig contains the default settings of the relay.
+var DefaultConfig = Config{
+	MaxGas:    1000000,
+	MaxQueued: 1024,
+}
+
+// Config are the configuration parameters of the meta-transaction relay.
+type Config struct {
+	// Forwarder is the address of the trusted forwarder contract the signed
+	// requests are executed through. The relay is disabled if it is unset.
+	Forwarder common.Address `toml:",omitempty"`
+
+	// Account signs the relayed transactions and pays for their fees. It has
+	// to be unlocked in the node's account manager.
+	Account common.Address `toml:",omitempty"`
+
+	// FeeCurrency is the token the relay fees are paid in, the native token
+	// if unset.
+	FeeCurrency common.Address `toml:",omitempty"`
+
+	// MaxGas is the maximum gas limit a meta-transaction may request.
+	MaxGas uint64
+
+	// MaxQueued is the maximum number of meta-transactions waiting to be
+	// relayed or mined.
+	MaxQueued int
+}
+
+// Enabled reports whether a forwarder contract is configured.
+func (c *Config) Enabled() bool {
+	return c.Forwarder != (common.Address{})
+}
+
+// feeCurrency returns the fee currency of the relayed transactions, nil for
+// the native token.
+func (c *Config) feeCurrency() *common.Address {
+	if c.FeeCurrency == (common.Address{}) {
+		return nil
+	}
+	feeCurrency := c.FeeCurrency
+	return &feeCurrency
+}
+
+func (c *Config) validate() error {
+	if !c.Enabled() {
+		return errors.New("no forwarder contract configured")
+	}
+	if c.Account == (common.Address{}) {
+		return errors.New("no relayer account configured")
+	}
+	if c.MaxQueued <= 0 {
+		return errors.New("queue size must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core"
+)
+
+// forwarderABI is the ABI of the subset of the trusted forwarder (EIP-2771)
+// methods used by the relay, following OpenZeppelin's MinimalForwarder.
+const forwarderABI = `[
+	{"type":"function","name":"getNonce","stateMutability":"view","inputs":[{"name":"from","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"verify","stateMutability":"view","inputs":[{"name":"req","type":"tuple","components":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"gas","type":"uint256"},{"name":"nonce","type":"uint256"},{"name":"data","type":"bytes"}]},{"name":"signature","type":"bytes"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"execute","stateMutability":"payable","inputs":[{"name":"req","type":"tuple","components":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"gas","type":"uint256"},{"name":"nonce","type":"uint256"},{"name":"data","type":"bytes"}]},{"name":"signature","type":"bytes"}],"outputs":[{"name":"","type":"bool"},{"name":"","type":"bytes"}]}
+]`
+
+// forwardRequestType is the EIP-712 encoding of the request type the forwarder
+// verifies signatures against.
+const forwardRequestType = "ForwardRequest(address from,address to,uint256 value,uint256 gas,uint256 nonce,bytes data)"
+
+var forwarder abi.ABI
+
+func init() {
+	var err error
+	if forwarder, err = abi.JSON(strings.NewReader(forwarderABI)); err != nil {
+		panic(err)
+	}
+}
+
+// ForwardRequest is a call signed by a user, to be executed through the
+// forwarder contract on their behalf.
+type ForwardRequest struct {
+	From  common.Address `json:"from"`
+	To    common.Address `json:"to"`
+	Value *hexutil.Big   `json:"value"`
+	Gas   hexutil.Uint64 `json:"gas"`
+	Nonce *hexutil.Big   `json:"nonce"`
+	Data  hexutil.Bytes  `json:"data"`
+}
+
+// forwardRequest is the ABI representation of a ForwardRequest.
+type forwardRequest struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	Gas   *big.Int
+	Nonce *big.Int
+	Data  []byte
+}
+
+func (req *ForwardRequest) abiValue() forwardRequest {
+	return forwardRequest{
+		From:  req.From,
+		To:    req.To,
+		Value: req.Value.ToInt(),
+		Gas:   new(big.Int).SetUint64(uint64(req.Gas)),
+		Nonce: req.Nonce.ToInt(),
+		Data:  req.Data,
+	}
+}
+
+// parseForwardRequest extracts the forward request from EIP-712 typed data,
+// checking that it is of the type the forwarder expects.
+func parseForwardRequest(typedData *core.TypedData) (*ForwardRequest, error) {
+	if typedData.PrimaryType != "ForwardRequest" {
+		return nil, fmt.Errorf("primary type %q is not ForwardRequest", typedData.PrimaryType)
+	}
+	if _, ok := typedData.Types["ForwardRequest"]; !ok {
+		return nil, errors.New("missing ForwardRequest type")
+	}
+	if encType := string(typedData.EncodeType("ForwardRequest")); encType != forwardRequestType {
+		return nil, fmt.Errorf("invalid ForwardRequest type %q", encType)
+	}
+	var (
+		msg = typedData.Message
+		req = new(ForwardRequest)
+		err error
+	)
+	if req.From, err = messageAddress(msg, "from"); err != nil {
+		return nil, err
+	}
+	if req.To, err = messageAddress(msg, "to"); err != nil {
+		return nil, err
+	}
+	value, err := messageInt(msg, "value")
+	if err != nil {
+		return nil, err
+	}
+	gas, err := messageInt(msg, "gas")
+	if err != nil {
+		return nil, err
+	}
+	if !gas.IsUint64() {
+		return nil, errors.New("gas limit too high")
+	}
+	nonce, err := messageInt(msg, "nonce")
+	if err != nil {
+		return nil, err
+	}
+	data, ok := msg["data"].(string)
+	if !ok {
+		return nil, errors.New("invalid data")
+	}
+	if req.Data, err = hexutil.Decode(data); err != nil {
+		return nil, fmt.Errorf("invalid data: %v", err)
+	}
+	req.Value, req.Gas, req.Nonce = (*hexutil.Big)(value), hexutil.Uint64(gas.Uint64()), (*hexutil.Big)(nonce)
+	return req, nil
+}
+
+func messageAddress(msg core.TypedDataMessage, name string) (common.Address, error) {
+	s, ok := msg[name].(string)
+	if !ok || !common.IsHexAddress(s) {
+		return common.Address{}, fmt.Errorf("invalid %s address", name)
+	}
+	return common.HexToAddress(s), nil
+}
+
+func messageInt(msg core.TypedDataMessage, name string) (*big.Int, error) {
+	var n *big.Int
+	switch v := msg[name].(type) {
+	case string:
+		n, _ = math.ParseBig256(v)
+	case float64:
+		// Plain JSON numbers are only exact up to 2^53
+		if v >= 0 && v <= 1<<53 && v == float64(uint64(v)) {
+			n = new(big.Int).SetUint64(uint64(v))
+		}
+	}
+	if n == nil || n.Sign() < 0 {
+		return nil, fmt.Errorf("invalid %s", name)
+	}
+	return n, nil
+}
@@ -0,0 +1,208 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Status is the processing status of a meta-transaction.
+type Status string
+
+const (
+	StatusQueued   Status = "queued"   // Accepted, waiting to be relayed
+	StatusPending  Status = "pending"  // Relayed, waiting to be mined
+	StatusExecuted Status = "executed" // Mined successfully
+	StatusFailed   Status = "failed"   // Could not be relayed, or the relayed transaction failed
+)
+
+// entryRetention is how long entries of processed meta-transactions are kept.
+const entryRetention = 7 * 24 * time.Hour
+
+var (
+	entryPrefix = []byte("e") // entryPrefix + seq (uint64 big endian) -> entry
+	indexPrefix = []byte("i") // indexPrefix + id -> seq (uint64 big endian)
+)
+
+// Entry tracks a meta-transaction through the relay.
+type Entry struct {
+	ID          common.Hash     `json:"id"`
+	Request     *ForwardRequest `json:"request"`
+	Signature   hexutil.Bytes   `json:"signature"`
+	Status      Status          `json:"status"`
+	TxHash      *common.Hash    `json:"txHash,omitempty"`
+	BlockNumber *hexutil.Uint64 `json:"blockNumber,omitempty"`
+	Error       string          `json:"error,omitempty"`
+	Created     hexutil.Uint64  `json:"created"`
+	Updated     hexutil.Uint64  `json:"updated"`
+
+	seq uint64 // Position in the queue
+}
+
+func (e *Entry) done() bool {
+	return e.Status == StatusExecuted || e.Status == StatusFailed
+}
+
+func entryKey(seq uint64) []byte {
+	key := make([]byte, len(entryPrefix)+8)
+	copy(key, entryPrefix)
+	binary.BigEndian.PutUint64(key[len(entryPrefix):], seq)
+	return key
+}
+
+func indexKey(id common.Hash) []byte {
+	return append(append([]byte{}, indexPrefix...), id.Bytes()...)
+}
+
+// queue is the persistent queue of meta-transactions. The entries still being
+// processed are kept in memory, processed ones are only read from disk.
+type queue struct {
+	db      ethdb.KeyValueStore
+	active  map[common.Hash]*Entry
+	nextSeq uint64
+	now     func() time.Time
+}
+
+// newQueue loads the queue from the database, dropping the processed entries
+// past their retention period.
+func newQueue(db ethdb.KeyValueStore, now func() time.Time) (*queue, error) {
+	q := &queue{
+		db:     db,
+		active: make(map[common.Hash]*Entry),
+		now:    now,
+	}
+	var (
+		it      = db.NewIteratorWithPrefix(entryPrefix)
+		batch   = db.NewBatch()
+		expired = uint64(now().Add(-entryRetention).Unix())
+	)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(entryPrefix)+8 {
+			continue
+		}
+		entry := new(Entry)
+		if err := json.Unmarshal(it.Value(), entry); err != nil {
+			log.Warn("Dropping corrupted relay entry", "key", hexutil.Encode(key), "err", err)
+			batch.Delete(key)
+			continue
+		}
+		entry.seq = binary.BigEndian.Uint64(key[len(entryPrefix):])
+		q.nextSeq = entry.seq + 1
+
+		switch {
+		case !entry.done():
+			q.active[entry.ID] = entry
+		case uint64(entry.Updated) < expired:
+			batch.Delete(key)
+			if seq, ok := q.lookup(entry.ID); ok && seq == entry.seq {
+				batch.Delete(indexKey(entry.ID))
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	return q, batch.Write()
+}
+
+// lookup returns the queue position of the latest entry with the given id.
+func (q *queue) lookup(id common.Hash) (uint64, bool) {
+	blob, err := q.db.Get(indexKey(id))
+	if err != nil || len(blob) != 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(blob), true
+}
+
+// get returns the latest entry with the given id, or nil if there is none.
+func (q *queue) get(id common.Hash) (*Entry, error) {
+	if entry, ok := q.active[id]; ok {
+		return entry, nil
+	}
+	seq, ok := q.lookup(id)
+	if !ok {
+		return nil, nil
+	}
+	blob, err := q.db.Get(entryKey(seq))
+	if err != nil {
+		return nil, nil
+	}
+	entry := new(Entry)
+	if err := json.Unmarshal(blob, entry); err != nil {
+		return nil, err
+	}
+	entry.seq = seq
+	return entry, nil
+}
+
+// add appends a new entry to the queue.
+func (q *queue) add(entry *Entry) error {
+	entry.seq = q.nextSeq
+	entry.Created = hexutil.Uint64(q.now().Unix())
+
+	var seq [8]byte
+	binary.BigEndian.PutUint64(seq[:], entry.seq)
+	if err := q.db.Put(indexKey(entry.ID), seq[:]); err != nil {
+		return err
+	}
+	if err := q.update(entry); err != nil {
+		return err
+	}
+	q.nextSeq++
+	return nil
+}
+
+// update persists the entry, removing it from the active set once processed.
+func (q *queue) update(entry *Entry) error {
+	entry.Updated = hexutil.Uint64(q.now().Unix())
+	blob, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := q.db.Put(entryKey(entry.seq), blob); err != nil {
+		return err
+	}
+	if entry.done() {
+		delete(q.active, entry.ID)
+	} else {
+		q.active[entry.ID] = entry
+	}
+	return nil
+}
+
+// list returns the active entries with the given status in queue order.
+func (q *queue) list(status Status) []*Entry {
+	var entries []*Entry
+	for _, entry := range q.active {
+		if entry.Status == status {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].seq < entries[j].seq })
+	return entries
+}
@@ -0,0 +1,488 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package relay implements a relay for meta-transactions, calls signed by users
+// that are executed through a trusted forwarder contract by a relayer account
+// paying for the fees.
+package relay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/rpc"
+	signercore "github.com/ethereum/go-ethereum/signer/core"
+)
+
+const (
+	// relayTimeout is the time allowed for the chain accesses needed to relay
+	// a meta-transaction or to check on a relayed one.
+	relayTimeout = 10 * time.Second
+
+	// pendingTimeout is how long a relayed transaction may stay unmined
+	// before the meta-transaction is considered failed.
+	pendingTimeout = 30 * time.Minute
+)
+
+var (
+	// ErrQueueFull is returned if too many meta-transactions are waiting to be
+	// relayed or mined.
+	ErrQueueFull = errors.New("relay queue is full")
+
+	// ErrInvalidSignature is returned if the meta-transaction is not signed by
+	// its sender.
+	ErrInvalidSignature = errors.New("invalid meta-transaction signature")
+
+	errValueTransfer = errors.New("meta-transactions transferring value are not relayed")
+)
+
+// Relayer is the meta-transaction relay service.
+type Relayer struct {
+	config  Config
+	backend Backend
+	wallet  accounts.Wallet
+	account accounts.Account
+	chainID *big.Int
+	db      ethdb.KeyValueStore
+
+	queue *queue
+	lock  sync.Mutex // Protects the queue, not held during chain accesses
+
+	notify chan struct{}
+	quit   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// New creates a relay executing the meta-transactions through the configured
+// forwarder, persisting its queue in db.
+func New(config Config, backend Backend, am *accounts.Manager, db ethdb.KeyValueStore) (*Relayer, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	account := accounts.Account{Address: config.Account}
+	wallet, err := am.Find(account)
+	if err != nil {
+		return nil, fmt.Errorf("relayer account %s: %v", config.Account.Hex(), err)
+	}
+	queue, err := newQueue(db, time.Now)
+	if err != nil {
+		return nil, err
+	}
+	return &Relayer{
+		config:  config,
+		backend: backend,
+		wallet:  wallet,
+		account: account,
+		chainID: backend.ChainID(),
+		db:      db,
+		queue:   queue,
+		notify:  make(chan struct{}, 1),
+		quit:    make(chan struct{}),
+	}, nil
+}
+
+// Protocols implements node.Service, returning no p2p protocols.
+func (r *Relayer) Protocols() []p2p.Protocol { return nil }
+
+// APIs implements node.Service, returning the relay namespace.
+func (r *Relayer) APIs() []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "relay",
+			Version:   "1.0",
+			Service:   NewPublicRelayAPI(r),
+			Public:    true,
+		},
+	}
+}
+
+// Start implements node.Service, starting the relay loop.
+func (r *Relayer) Start(server *p2p.Server) error {
+	heads := make(chan core.ChainHeadEvent, 16)
+	sub := r.backend.SubscribeChainHeadEvent(heads)
+
+	r.wg.Add(1)
+	go r.loop(heads, sub)
+	r.trigger()
+
+	log.Info("Started meta-transaction relay", "forwarder", r.config.Forwarder, "account", r.config.Account)
+	return nil
+}
+
+// Stop implements node.Service, terminating the relay loop.
+func (r *Relayer) Stop() error {
+	close(r.quit)
+	r.wg.Wait()
+
+	log.Info("Meta-transaction relay stopped")
+	return r.db.Close()
+}
+
+// trigger wakes up the relay loop to process the queued meta-transactions.
+func (r *Relayer) trigger() {
+	select {
+	case r.notify <- struct{}{}:
+	default:
+	}
+}
+
+// loop relays the queued meta-transactions and tracks the relayed ones.
+func (r *Relayer) loop(heads chan core.ChainHeadEvent, sub event.Subscription) {
+	defer r.wg.Done()
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case <-r.notify:
+			r.relayQueued()
+		case <-heads:
+			r.checkPending()
+		case <-sub.Err():
+			return
+		case <-r.quit:
+			return
+		}
+	}
+}
+
+// Submit validates a meta-transaction given as EIP-712 typed data and queues it
+// for relaying, returning its id. Resubmitting a meta-transaction that has not
+// failed returns the id of the existing entry.
+func (r *Relayer) Submit(ctx context.Context, typedData signercore.TypedData, signature []byte) (common.Hash, error) {
+	req, err := parseForwardRequest(&typedData)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	domain := typedData.Domain
+	if !common.IsHexAddress(domain.VerifyingContract) || common.HexToAddress(domain.VerifyingContract) != r.config.Forwarder {
+		return common.Hash{}, fmt.Errorf("verifying contract is not the forwarder %s", r.config.Forwarder.Hex())
+	}
+	if domain.ChainId == nil || (*big.Int)(domain.ChainId).Cmp(r.chainID) != 0 {
+		return common.Hash{}, fmt.Errorf("chain id is not %v", r.chainID)
+	}
+	if req.Value.ToInt().Sign() != 0 {
+		return common.Hash{}, errValueTransfer
+	}
+	if uint64(req.Gas) > r.config.MaxGas {
+		return common.Hash{}, fmt.Errorf("gas limit %d above the maximum %d", req.Gas, r.config.MaxGas)
+	}
+	// Make sure the request is signed by its sender
+	sighash, _, err := signercore.TypedDataAndHash(typedData)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if len(signature) != crypto.SignatureLength {
+		return common.Hash{}, ErrInvalidSignature
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pubkey, err := crypto.SigToPub(sighash, sig)
+	if err != nil || crypto.PubkeyToAddress(*pubkey) != req.From {
+		return common.Hash{}, ErrInvalidSignature
+	}
+	// The forwarder expects the legacy recovery id
+	sig[crypto.RecoveryIDOffset] += 27
+
+	id := common.BytesToHash(sighash)
+	if r.known(id) {
+		return id, nil
+	}
+	// Check that the forwarder accepts the request and that the call succeeds
+	if err := r.verify(ctx, req, sig); err != nil {
+		return common.Hash{}, err
+	}
+	if _, err := r.simulate(ctx, req, sig); err != nil {
+		return common.Hash{}, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.knownLocked(id) {
+		return id, nil
+	}
+	if len(r.queue.active) >= r.config.MaxQueued {
+		return common.Hash{}, ErrQueueFull
+	}
+	entry := &Entry{
+		ID:        id,
+		Request:   req,
+		Signature: sig,
+		Status:    StatusQueued,
+	}
+	if err := r.queue.add(entry); err != nil {
+		return common.Hash{}, err
+	}
+	log.Debug("Queued meta-transaction", "id", id, "from", req.From, "to", req.To)
+	r.trigger()
+	return id, nil
+}
+
+// known reports whether the meta-transaction has been queued without failing.
+func (r *Relayer) known(id common.Hash) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.knownLocked(id)
+}
+
+func (r *Relayer) knownLocked(id common.Hash) bool {
+	entry, err := r.queue.get(id)
+	return err == nil && entry != nil && entry.Status != StatusFailed
+}
+
+// Status returns the entry of the meta-transaction with the given id, or nil if
+// it is unknown.
+func (r *Relayer) Status(id common.Hash) (*Entry, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	entry, err := r.queue.get(id)
+	if entry == nil || err != nil {
+		return nil, err
+	}
+	cpy := *entry
+	return &cpy, nil
+}
+
+// Nonce returns the next forwarder nonce of the given account.
+func (r *Relayer) Nonce(ctx context.Context, from common.Address) (*big.Int, error) {
+	data, err := forwarder.Pack("getNonce", from)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.callForwarder(ctx, nil, data)
+	if err != nil {
+		return nil, err
+	}
+	nonce := new(big.Int)
+	if err := forwarder.Unpack(&nonce, "getNonce", res); err != nil {
+		return nil, err
+	}
+	return nonce, nil
+}
+
+// callForwarder executes a call to the forwarder on the latest state, paying
+// the fees in the relay fee currency if made from the relayer account.
+func (r *Relayer) callForwarder(ctx context.Context, from *common.Address, data []byte) ([]byte, error) {
+	var feeCurrency *common.Address
+	if from != nil {
+		feeCurrency = r.config.feeCurrency()
+	}
+	res, err := r.backend.Call(ctx, callArgs(from, r.config.Forwarder, feeCurrency, data))
+	if err != nil {
+		return nil, err
+	}
+	// Calls to accounts without code succeed without returning anything
+	if len(res) == 0 {
+		return nil, fmt.Errorf("no forwarder contract at %s", r.config.Forwarder.Hex())
+	}
+	return res, nil
+}
+
+// verify checks that the forwarder accepts the signed request.
+func (r *Relayer) verify(ctx context.Context, req *ForwardRequest, sig []byte) error {
+	data, err := forwarder.Pack("verify", req.abiValue(), sig)
+	if err != nil {
+		return err
+	}
+	res, err := r.callForwarder(ctx, nil, data)
+	if err != nil {
+		return err
+	}
+	var ok bool
+	if err := forwarder.Unpack(&ok, "verify", res); err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("request rejected by the forwarder, the nonce may be outdated")
+	}
+	return nil
+}
+
+// simulate executes the request through the forwarder from the relayer account,
+// returning the execute call data if the forwarded call succeeds.
+func (r *Relayer) simulate(ctx context.Context, req *ForwardRequest, sig []byte) ([]byte, error) {
+	data, err := forwarder.Pack("execute", req.abiValue(), sig)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.callForwarder(ctx, &r.config.Account, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := executeResult(res); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// executeResult decodes the return data of the forwarder's execute method. The
+// forwarder does not revert if the forwarded call fails, but reports it in the
+// returned success flag.
+func executeResult(res []byte) error {
+	out, err := forwarder.Methods["execute"].Outputs.UnpackValues(res)
+	if err != nil {
+		return err
+	}
+	if success, _ := out[0].(bool); !success {
+		ret, _ := out[1].([]byte)
+		if reason, err := abi.UnpackRevert(ret); err == nil {
+			return fmt.Errorf("forwarded call reverted: %s", reason)
+		}
+		return errors.New("forwarded call reverted")
+	}
+	return nil
+}
+
+// relayQueued relays the queued meta-transactions in order.
+func (r *Relayer) relayQueued() {
+	for _, entry := range r.entries(StatusQueued) {
+		select {
+		case <-r.quit:
+			return
+		default:
+		}
+		hash, err := r.relay(entry)
+		if err != nil {
+			log.Warn("Failed to relay meta-transaction", "id", entry.ID, "err", err)
+			entry.Status, entry.Error = StatusFailed, err.Error()
+		} else {
+			log.Info("Relayed meta-transaction", "id", entry.ID, "tx", hash)
+			entry.Status, entry.TxHash = StatusPending, &hash
+		}
+		r.update(entry)
+	}
+}
+
+// entries returns copies of the active entries with the given status in queue
+// order, so that they can be processed without holding the lock.
+func (r *Relayer) entries(status Status) []*Entry {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	entries := r.queue.list(status)
+	for i, entry := range entries {
+		cpy := *entry
+		entries[i] = &cpy
+	}
+	return entries
+}
+
+// update stores a processed copy of an entry.
+func (r *Relayer) update(entry *Entry) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if err := r.queue.update(entry); err != nil {
+		log.Error("Failed to store relay entry", "id", entry.ID, "err", err)
+	}
+}
+
+// relay wraps the meta-transaction in a transaction to the forwarder, signed
+// by the relayer account, and submits it.
+func (r *Relayer) relay(entry *Entry) (common.Hash, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), relayTimeout)
+	defer cancel()
+
+	// The state may have changed since the request was queued, check again
+	data, err := r.simulate(ctx, entry.Request, entry.Signature)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	feeCurrency := r.config.feeCurrency()
+	gas, err := r.backend.EstimateGas(ctx, callArgs(&r.config.Account, r.config.Forwarder, feeCurrency, data))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to estimate gas: %v", err)
+	}
+	gasPrice, err := r.backend.SuggestGasPrice(ctx, feeCurrency)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to suggest gas price: %v", err)
+	}
+	nonce, err := r.backend.PoolNonce(ctx, r.config.Account)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx := types.NewTransaction(nonce, r.config.Forwarder, new(big.Int), gas, gasPrice, feeCurrency, nil, nil, data)
+	signed, err := r.wallet.SignTx(r.account, tx, r.chainID)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to sign transaction: %v", err)
+	}
+	if err := r.backend.SendTx(ctx, signed); err != nil {
+		return common.Hash{}, err
+	}
+	return signed.Hash(), nil
+}
+
+// checkPending updates the relayed meta-transactions whose transaction got
+// mined, or that have been pending for too long.
+func (r *Relayer) checkPending() {
+	ctx, cancel := context.WithTimeout(context.Background(), relayTimeout)
+	defer cancel()
+
+	for _, entry := range r.entries(StatusPending) {
+		receipt, err := r.backend.Receipt(ctx, *entry.TxHash)
+		if err != nil {
+			log.Warn("Failed to retrieve relay receipt", "id", entry.ID, "tx", entry.TxHash, "err", err)
+			continue
+		}
+		switch {
+		case receipt == nil:
+			if r.queue.now().Sub(time.Unix(int64(entry.Updated), 0)) < pendingTimeout {
+				continue
+			}
+			entry.Status, entry.Error = StatusFailed, "relayed transaction not mined"
+		case receipt.Status == types.ReceiptStatusSuccessful:
+			// The forwarder succeeds even if the forwarded call fails, check
+			// the outcome it returned
+			res, err := r.backend.TransactionResult(ctx, *entry.TxHash)
+			if err != nil {
+				log.Warn("Failed to retrieve relay result", "id", entry.ID, "tx", entry.TxHash, "err", err)
+				continue
+			}
+			if err := executeResult(res); err != nil {
+				entry.Status, entry.Error = StatusFailed, err.Error()
+			} else {
+				entry.Status = StatusExecuted
+			}
+		default:
+			entry.Status, entry.Error = StatusFailed, "relayed transaction failed"
+		}
+		if receipt != nil && receipt.BlockNumber != nil {
+			number := hexutil.Uint64(receipt.BlockNumber.Uint64())
+			entry.BlockNumber = &number
+		}
+		log.Debug("Meta-transaction processed", "id", entry.ID, "status", entry.Status)
+		r.update(entry)
+	}
+}
@@ -0,0 +1,514 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	signercore "github.com/ethereum/go-ethereum/signer/core"
+)
+
+var (
+	testChainID     = big.NewInt(44787)
+	testForwarder   = common.HexToAddress("0x00000000000000000000000000000000000f0f0f")
+	testFeeCurrency = common.HexToAddress("0x874069Fa1Eb16D44d622F2e0Ca25eeA172369bC1")
+	testTarget      = common.HexToAddress("0x000000000000000000000000000000000000abcd")
+)
+
+// testBackend emulates a forwarder contract and the transaction pool.
+type testBackend struct {
+	mu       sync.Mutex
+	nonces   map[common.Address]int64 // Forwarder nonces
+	reject   bool                     // Whether verify rejects requests
+	revert   string                   // Revert reason of the forwarded calls, if any
+	sent     []*types.Transaction
+	receipts map[common.Hash]*types.Receipt
+	reverted map[common.Hash]string // Revert reason of the calls forwarded by mined transactions
+	stall    chan struct{}          // Blocks receipt retrievals until closed, if set
+	heads    event.Feed
+}
+
+func newTestBackend() *testBackend {
+	return &testBackend{
+		nonces:   make(map[common.Address]int64),
+		receipts: make(map[common.Hash]*types.Receipt),
+		reverted: make(map[common.Hash]string),
+	}
+}
+
+func (b *testBackend) ChainID() *big.Int { return testChainID }
+
+func (b *testBackend) Call(ctx context.Context, args ethapi.CallArgs) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if *args.To != testForwarder {
+		return nil, errors.New("unexpected call target")
+	}
+	method, err := forwarder.MethodById(*args.Data)
+	if err != nil {
+		return nil, err
+	}
+	in, err := method.Inputs.UnpackValues((*args.Data)[4:])
+	if err != nil {
+		return nil, err
+	}
+	switch method.Name {
+	case "getNonce":
+		return method.Outputs.Pack(big.NewInt(b.nonces[in[0].(common.Address)]))
+	case "verify":
+		return method.Outputs.Pack(!b.reject)
+	case "execute":
+		return executeOutput(b.revert)
+	}
+	return nil, fmt.Errorf("unexpected method %s", method.Name)
+}
+
+// executeOutput packs the return data of the forwarder's execute method.
+func executeOutput(revert string) ([]byte, error) {
+	method := forwarder.Methods["execute"]
+	if revert != "" {
+		reason, _ := (abiString{revert}).pack()
+		return method.Outputs.Pack(false, reason)
+	}
+	return method.Outputs.Pack(true, []byte{})
+}
+
+func (b *testBackend) EstimateGas(ctx context.Context, args ethapi.CallArgs) (uint64, error) {
+	return 100000, nil
+}
+
+func (b *testBackend) SuggestGasPrice(ctx context.Context, feeCurrency *common.Address) (*big.Int, error) {
+	if feeCurrency == nil || *feeCurrency != testFeeCurrency {
+		return nil, errors.New("unexpected fee currency")
+	}
+	return big.NewInt(1000), nil
+}
+
+func (b *testBackend) PoolNonce(ctx context.Context, account common.Address) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return uint64(len(b.sent)), nil
+}
+
+func (b *testBackend) SendTx(ctx context.Context, tx *types.Transaction) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sent = append(b.sent, tx)
+	return nil
+}
+
+func (b *testBackend) Receipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	if b.stall != nil {
+		<-b.stall
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.receipts[hash], nil
+}
+
+func (b *testBackend) TransactionResult(ctx context.Context, hash common.Hash) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.receipts[hash] == nil {
+		return nil, errors.New("transaction not mined")
+	}
+	return executeOutput(b.reverted[hash])
+}
+
+func (b *testBackend) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
+	return b.heads.Subscribe(ch)
+}
+
+// abiString packs an Error(string) revert reason.
+type abiString struct{ reason string }
+
+func (s abiString) pack() ([]byte, error) {
+	data := crypto.Keccak256([]byte("Error(string)"))[:4]
+	length := common.LeftPadBytes(big.NewInt(int64(len(s.reason))).Bytes(), 32)
+	padded := common.RightPadBytes([]byte(s.reason), (len(s.reason)+31)/32*32)
+	data = append(data, common.LeftPadBytes([]byte{0x20}, 32)...)
+	return append(append(data, length...), padded...), nil
+}
+
+// newTestRelayer creates a relay with an unlocked relayer account.
+func newTestRelayer(t *testing.T, backend Backend, db ethdb.KeyValueStore) (*Relayer, func()) {
+	dir, err := ioutil.TempDir("", "relay-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.NewAccount("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Unlock(account, ""); err != nil {
+		t.Fatal(err)
+	}
+	config := DefaultConfig
+	config.Forwarder = testForwarder
+	config.Account = account.Address
+	config.FeeCurrency = testFeeCurrency
+	config.MaxQueued = 2
+
+	r, err := New(config, backend, accounts.NewManager(&accounts.Config{}, ks), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r, func() { os.RemoveAll(dir) }
+}
+
+// signRequest creates the typed data of a forward request and signs it.
+func signRequest(t *testing.T, key *ecdsa.PrivateKey, message string) (signercore.TypedData, []byte) {
+	var typedData signercore.TypedData
+	blob := `{
+		"types": {
+			"EIP712Domain": [
+				{"name": "name", "type": "string"},
+				{"name": "version", "type": "string"},
+				{"name": "chainId", "type": "uint256"},
+				{"name": "verifyingContract", "type": "address"}
+			],
+			"ForwardRequest": [
+				{"name": "from", "type": "address"},
+				{"name": "to", "type": "address"},
+				{"name": "value", "type": "uint256"},
+				{"name": "gas", "type": "uint256"},
+				{"name": "nonce", "type": "uint256"},
+				{"name": "data", "type": "bytes"}
+			]
+		},
+		"primaryType": "ForwardRequest",
+		"domain": {"name": "MinimalForwarder", "version": "0.0.1", "chainId": "44787", "verifyingContract": "` + testForwarder.Hex() + `"},
+		"message": ` + strings.Replace(message, "$from", crypto.PubkeyToAddress(key.PublicKey).Hex(), 1) + `
+	}`
+	if err := json.Unmarshal([]byte(blob), &typedData); err != nil {
+		t.Fatal(err)
+	}
+	sighash, _, err := signercore.TypedDataAndHash(typedData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := crypto.Sign(sighash, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return typedData, sig
+}
+
+const testMessage = `{"from": "$from", "to": "0x000000000000000000000000000000000000abcd", "value": "0", "gas": "50000", "nonce": "0", "data": "0x12345678"}`
+
+func TestRelay(t *testing.T) {
+	backend := newTestBackend()
+	db := rawdb.NewMemoryDatabase()
+	r, cleanup := newTestRelayer(t, backend, db)
+	defer cleanup()
+	api := NewPublicRelayAPI(r)
+
+	key, _ := crypto.GenerateKey()
+	typedData, sig := signRequest(t, key, testMessage)
+	id, err := api.SendMetaTransaction(context.Background(), typedData, sig)
+	if err != nil {
+		t.Fatalf("failed to submit meta-transaction: %v", err)
+	}
+	if entry, _ := api.GetStatus(id); entry == nil || entry.Status != StatusQueued {
+		t.Fatalf("meta-transaction not queued: %+v", entry)
+	}
+	// Resubmitting returns the queued entry
+	if dup, err := api.SendMetaTransaction(context.Background(), typedData, sig); err != nil || dup != id {
+		t.Fatalf("resubmission mismatch: have %x (%v), want %x", dup, err, id)
+	}
+
+	// Relay it and check the wrapping transaction
+	r.relayQueued()
+	entry, _ := api.GetStatus(id)
+	if entry.Status != StatusPending || len(backend.sent) != 1 || *entry.TxHash != backend.sent[0].Hash() {
+		t.Fatalf("meta-transaction not relayed: %+v", entry)
+	}
+	tx := backend.sent[0]
+	if *tx.To() != testForwarder || tx.FeeCurrency() == nil || *tx.FeeCurrency() != testFeeCurrency || tx.GasPrice().Int64() != 1000 {
+		t.Errorf("unexpected relay transaction: to %x, fee currency %v, gas price %v", tx.To(), tx.FeeCurrency(), tx.GasPrice())
+	}
+	if from, err := types.Sender(types.NewEIP155Signer(testChainID), tx); err != nil || from != r.config.Account {
+		t.Errorf("relay transaction signed by %x (%v), want %x", from, err, r.config.Account)
+	}
+	in, err := forwarder.Methods["execute"].Inputs.UnpackValues(tx.Data()[4:])
+	if err != nil {
+		t.Fatalf("failed to decode execute call: %v", err)
+	}
+	if !bytes.Equal(in[1].([]byte), sig) {
+		t.Errorf("forwarded signature mismatch: have %x, want %x", in[1], sig)
+	}
+
+	// Not mined yet, nothing changes
+	r.checkPending()
+	if entry, _ := api.GetStatus(id); entry.Status != StatusPending {
+		t.Fatalf("unmined meta-transaction status %s", entry.Status)
+	}
+	// Mine it, and let the relay loop pick it up
+	backend.receipts[tx.Hash()] = &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(7)}
+	if err := r.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	backend.heads.Send(core.ChainHeadEvent{})
+	for i := 0; ; i++ {
+		entry, _ = api.GetStatus(id)
+		if entry.Status == StatusExecuted {
+			break
+		}
+		if i == 100 {
+			t.Fatalf("meta-transaction not executed: %+v", entry)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if entry.BlockNumber == nil || *entry.BlockNumber != 7 {
+		t.Errorf("block number mismatch: have %v, want 7", entry.BlockNumber)
+	}
+	r.Stop()
+}
+
+func TestRelayRejects(t *testing.T) {
+	backend := newTestBackend()
+	r, cleanup := newTestRelayer(t, backend, rawdb.NewMemoryDatabase())
+	defer cleanup()
+
+	key, _ := crypto.GenerateKey()
+	other, _ := crypto.GenerateKey()
+
+	tests := []struct {
+		name    string
+		prepare func(*signercore.TypedData, []byte) []byte
+		message string
+		reject  bool
+		revert  string
+	}{
+		{
+			name:    "wrong forwarder",
+			message: testMessage,
+			prepare: func(td *signercore.TypedData, sig []byte) []byte {
+				td.Domain.VerifyingContract = testTarget.Hex()
+				return sig
+			},
+		},
+		{
+			name:    "wrong chain",
+			message: testMessage,
+			prepare: func(td *signercore.TypedData, sig []byte) []byte {
+				td.Domain.ChainId.UnmarshalText([]byte("42220"))
+				return sig
+			},
+		},
+		{
+			name:    "wrong signer",
+			message: strings.Replace(testMessage, "$from", crypto.PubkeyToAddress(other.PublicKey).Hex(), 1),
+		},
+		{
+			name:    "bad signature",
+			message: testMessage,
+			prepare: func(td *signercore.TypedData, sig []byte) []byte { return sig[:64] },
+		},
+		{
+			name:    "value transfer",
+			message: strings.Replace(testMessage, `"value": "0"`, `"value": "1"`, 1),
+		},
+		{
+			name:    "gas above maximum",
+			message: strings.Replace(testMessage, `"gas": "50000"`, `"gas": "1000001"`, 1),
+		},
+		{
+			name:    "rejected by forwarder",
+			message: testMessage,
+			reject:  true,
+		},
+		{
+			name:    "reverting call",
+			message: testMessage,
+			revert:  "not allowed",
+		},
+	}
+	for _, tt := range tests {
+		backend.reject, backend.revert = tt.reject, tt.revert
+
+		typedData, sig := signRequest(t, key, tt.message)
+		if tt.prepare != nil {
+			sig = tt.prepare(&typedData, sig)
+		}
+		if _, err := r.Submit(context.Background(), typedData, sig); err == nil {
+			t.Errorf("%s: meta-transaction accepted", tt.name)
+		}
+	}
+	if len(r.queue.active) != 0 {
+		t.Errorf("rejected meta-transactions queued: %d", len(r.queue.active))
+	}
+}
+
+func TestRelayQueue(t *testing.T) {
+	var (
+		backend = newTestBackend()
+		db      = rawdb.NewMemoryDatabase()
+		keys    = make([]*ecdsa.PrivateKey, 3)
+		ids     = make([]common.Hash, 3)
+	)
+	r, cleanup := newTestRelayer(t, backend, db)
+	defer cleanup()
+
+	// Fill the queue
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		typedData, sig := signRequest(t, keys[i], testMessage)
+
+		id, err := r.Submit(context.Background(), typedData, sig)
+		if i < 2 && err != nil {
+			t.Fatalf("failed to submit meta-transaction %d: %v", i, err)
+		}
+		if i == 2 && err != ErrQueueFull {
+			t.Fatalf("error mismatch: have %v, want %v", err, ErrQueueFull)
+		}
+		ids[i] = id
+	}
+	// Relay them in order
+	r.relayQueued()
+	for i, id := range ids[:2] {
+		entry, _ := r.Status(id)
+		if entry.Status != StatusPending || *entry.TxHash != backend.sent[i].Hash() {
+			t.Fatalf("meta-transaction %d not relayed in order: %+v", i, entry)
+		}
+	}
+
+	// Restart the relay on the same database, the entries must survive
+	reloaded, err := newQueue(db, time.Now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reloaded.active) != 2 || reloaded.nextSeq != 2 {
+		t.Fatalf("queue not restored: %d active, next %d", len(reloaded.active), reloaded.nextSeq)
+	}
+	for _, id := range ids[:2] {
+		have, _ := reloaded.get(id)
+		want, _ := r.Status(id)
+		if have == nil || have.Status != want.Status || have.seq != want.seq {
+			t.Errorf("entry %x mismatch: have %+v, want %+v", id, have, want)
+		}
+	}
+	// Processed entries are dropped after the retention period
+	failed, _ := reloaded.get(ids[1])
+	failed.Status = StatusFailed
+	if err := reloaded.update(failed); err != nil {
+		t.Fatal(err)
+	}
+	later := func() time.Time { return time.Now().Add(entryRetention + time.Hour) }
+	if reloaded, err = newQueue(db, later); err != nil {
+		t.Fatal(err)
+	}
+	if entry, _ := reloaded.get(ids[1]); entry != nil {
+		t.Errorf("expired entry not dropped: %+v", entry)
+	}
+	if entry, _ := reloaded.get(ids[0]); entry == nil {
+		t.Errorf("active entry dropped")
+	}
+}
+
+func TestRelayForwardedCallFails(t *testing.T) {
+	backend := newTestBackend()
+	r, cleanup := newTestRelayer(t, backend, rawdb.NewMemoryDatabase())
+	defer cleanup()
+
+	key, _ := crypto.GenerateKey()
+	typedData, sig := signRequest(t, key, testMessage)
+	id, err := r.Submit(context.Background(), typedData, sig)
+	if err != nil {
+		t.Fatalf("failed to submit meta-transaction: %v", err)
+	}
+	r.relayQueued()
+
+	// The relay transaction succeeds, but the forwarded call reverts
+	tx := backend.sent[0]
+	backend.receipts[tx.Hash()] = &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(7)}
+	backend.reverted[tx.Hash()] = "insufficient balance"
+
+	r.checkPending()
+	entry, _ := r.Status(id)
+	if entry.Status != StatusFailed {
+		t.Fatalf("status mismatch: have %s, want %s", entry.Status, StatusFailed)
+	}
+	if !strings.Contains(entry.Error, "insufficient balance") {
+		t.Errorf("error does not carry the revert reason: %q", entry.Error)
+	}
+	if entry.BlockNumber == nil || *entry.BlockNumber != 7 {
+		t.Errorf("block number mismatch: have %v, want 7", entry.BlockNumber)
+	}
+}
+
+func TestRelayUnlockedChainAccess(t *testing.T) {
+	backend := newTestBackend()
+	r, cleanup := newTestRelayer(t, backend, rawdb.NewMemoryDatabase())
+	defer cleanup()
+
+	key, _ := crypto.GenerateKey()
+	typedData, sig := signRequest(t, key, testMessage)
+	id, err := r.Submit(context.Background(), typedData, sig)
+	if err != nil {
+		t.Fatalf("failed to submit meta-transaction: %v", err)
+	}
+	r.relayQueued()
+
+	// Stall the receipt retrieval, the queue must stay accessible meanwhile
+	backend.stall = make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		r.checkPending()
+		close(done)
+	}()
+	status := make(chan *Entry)
+	go func() {
+		entry, _ := r.Status(id)
+		status <- entry
+	}()
+	select {
+	case entry := <-status:
+		if entry == nil || entry.Status != StatusPending {
+			t.Errorf("unexpected entry: %+v", entry)
+		}
+	case <-time.After(time.Second):
+		t.Error("status blocked by a chain access")
+	}
+	close(backend.stall)
+	<-done
+}
@@ -42,6 +42,12 @@ func checkModuleAvailability(modules []string, apis []API) (bad, available []str
 
 // StartHTTPEndpoint starts the HTTP RPC endpoint, configured with cors/vhosts/modules.
 func StartHTTPEndpoint(endpoint string, apis []API, modules []string, cors []string, vhosts []string, timeouts HTTPTimeouts) (net.Listener, *Server, error) {
+	return StartHTTPEndpointWithPolicy(endpoint, apis, modules, cors, vhosts, timeouts, nil)
+}
+
+// StartHTTPEndpointWithPolicy starts the HTTP RPC endpoint, configured with
+// cors/vhosts/modules and the optional request policy.
+func StartHTTPEndpointWithPolicy(endpoint string, apis []API, modules []string, cors []string, vhosts []string, timeouts HTTPTimeouts, policy *Policy) (net.Listener, *Server, error) {
 	if bad, available := checkModuleAvailability(modules, apis); len(bad) > 0 {
 		log.Error("Unavailable modules in HTTP API list", "unavailable", bad, "available", available)
 	}
@@ -60,6 +66,9 @@ func StartHTTPEndpoint(endpoint string, apis []API, modules []string, cors []str
 			log.Debug("HTTP registered", "namespace", api.Namespace)
 		}
 	}
+	if policy != nil {
+		handler.SetPolicy(policy, TransportHTTP)
+	}
 	// All APIs registered, start the HTTP listener
 	var (
 		listener net.Listener
@@ -74,6 +83,12 @@ func StartHTTPEndpoint(endpoint string, apis []API, modules []string, cors []str
 
 // StartWSEndpoint starts a websocket endpoint.
 func StartWSEndpoint(endpoint string, apis []API, modules []string, wsOrigins []string, exposeAll bool) (net.Listener, *Server, error) {
+	return StartWSEndpointWithPolicy(endpoint, apis, modules, wsOrigins, exposeAll, nil)
+}
+
+// StartWSEndpointWithPolicy starts a websocket endpoint, with the optional request
+// policy.
+func StartWSEndpointWithPolicy(endpoint string, apis []API, modules []string, wsOrigins []string, exposeAll bool, policy *Policy) (net.Listener, *Server, error) {
 	if bad, available := checkModuleAvailability(modules, apis); len(bad) > 0 {
 		log.Error("Unavailable modules in WS API list", "unavailable", bad, "available", available)
 	}
@@ -92,6 +107,9 @@ func StartWSEndpoint(endpoint string, apis []API, modules []string, wsOrigins []
 			log.Debug("WebSocket registered", "service", api.Service, "namespace", api.Namespace)
 		}
 	}
+	if policy != nil {
+		handler.SetPolicy(policy, TransportWS)
+	}
 	// All APIs registered, start the HTTP listener
 	var (
 		listener net.Listener
@@ -107,6 +125,12 @@ func StartWSEndpoint(endpoint string, apis []API, modules []string, wsOrigins []
 
 // StartIPCEndpoint starts an IPC endpoint.
 func StartIPCEndpoint(ipcEndpoint string, apis []API) (net.Listener, *Server, error) {
+	return StartIPCEndpointWithPolicy(ipcEndpoint, apis, nil)
+}
+
+// StartIPCEndpointWithPolicy starts an IPC endpoint, with the optional request
+// policy.
+func StartIPCEndpointWithPolicy(ipcEndpoint string, apis []API, policy *Policy) (net.Listener, *Server, error) {
 	// Register all the APIs exposed by the services.
 	handler := NewServer()
 	for _, api := range apis {
@@ -115,6 +139,9 @@ func StartIPCEndpoint(ipcEndpoint string, apis []API) (net.Listener, *Server, er
 		}
 		log.Debug("IPC registered", "namespace", api.Namespace)
 	}
+	if policy != nil {
+		handler.SetPolicy(policy, TransportIPC)
+	}
 	// All APIs registered, start the IPC listener.
 	listener, err := ipcListen(ipcEndpoint)
 	if err != nil {
@@ -63,3 +63,28 @@ type invalidParamsError struct{ message string }
 func (e *invalidParamsError) ErrorCode() int { return -32602 }
 
 func (e *invalidParamsError) Error() string { return e.message }
+
+// method not allowed by the policy of the server
+type methodDeniedError struct{ method string }
+
+func (e *methodDeniedError) ErrorCode() int { return -32004 }
+
+func (e *methodDeniedError) Error() string {
+	return fmt.Sprintf("the method %s is not allowed", e.method)
+}
+
+// a limit set by the policy of the server was exceeded
+type limitExceededError struct{ message string }
+
+func (e *limitExceededError) ErrorCode() int { return -32005 }
+
+func (e *limitExceededError) Error() string { return e.message }
+
+// the method didn't answer within the time allowed by the policy of the server
+type requestTimeoutError struct{ method string }
+
+func (e *requestTimeoutError) ErrorCode() int { return -32002 }
+
+func (e *requestTimeoutError) Error() string {
+	return fmt.Sprintf("the method %s timed out", e.method)
+}
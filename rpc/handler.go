@@ -61,6 +61,7 @@ type handler struct {
 	conn           jsonWriter                     // where responses will be sent
 	log            log.Logger
 	allowSubscribe bool
+	policy         *connPolicy // restrictions of the requests served, nil if unrestricted
 
 	subLock    sync.Mutex
 	serverSubs map[ID]*Subscription
@@ -101,6 +102,12 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 		})
 		return
 	}
+	if err := h.policy.checkBatch(len(msgs)); err != nil {
+		h.startCallProc(func(cp *callProc) {
+			h.conn.writeJSON(cp.ctx, errorMessage(err))
+		})
+		return
+	}
 
 	// Handle non-call messages first:
 	calls := make([]*jsonrpcMessage, 0, len(msgs))
@@ -311,6 +318,9 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 
 // handleCall processes method calls.
 func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+	if err := h.policy.checkCall(msg.Method); err != nil {
+		return msg.errorResponse(err)
+	}
 	if msg.isSubscribe() {
 		return h.handleSubscribe(cp, msg)
 	}
@@ -327,7 +337,9 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 	if err != nil {
 		return msg.errorResponse(&invalidParamsError{err.Error()})
 	}
-
+	if timeout := h.policy.timeout(msg.Method); timeout > 0 {
+		return h.runMethodWithTimeout(cp.ctx, msg, callb, args, timeout)
+	}
 	return h.runMethod(cp.ctx, msg, callb, args)
 }
 
@@ -370,7 +382,33 @@ func (h *handler) runMethod(ctx context.Context, msg *jsonrpcMessage, callb *cal
 	if err != nil {
 		return msg.errorResponse(err)
 	}
-	return msg.response(result)
+	resp := msg.response(result)
+	if err := h.policy.checkResponse(resp); err != nil {
+		return msg.errorResponse(err)
+	}
+	return resp
+}
+
+// runMethodWithTimeout runs the Go callback for an RPC method, answering with an
+// error if it doesn't return in time. The callback's context is cancelled then,
+// but the callback keeps running in the background if it doesn't honor it.
+func (h *handler) runMethodWithTimeout(ctx context.Context, msg *jsonrpcMessage, callb *callback, args []reflect.Value, timeout time.Duration) *jsonrpcMessage {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	answer := make(chan *jsonrpcMessage, 1)
+	go func() {
+		answer <- h.runMethod(ctx, msg, callb, args)
+	}()
+	select {
+	case resp := <-answer:
+		return resp
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			policyTimeoutMeter.Mark(1)
+		}
+		return msg.errorResponse(&requestTimeoutError{method: msg.Method})
+	}
 }
 
 // unsubscribe is the callback function for all *_unsubscribe calls.
@@ -274,7 +274,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("content-type", contentType)
 	codec := newHTTPServerConn(r, w)
 	defer codec.close()
-	s.serveSingleRequest(ctx, codec)
+	s.serveSingleRequest(ctx, codec, s.connPolicy(r))
 }
 
 // validateRequest returns a non-zero response code and error message if the
@@ -0,0 +1,471 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"encoding"
+	"encoding/json"
+	"math/big"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// openRPCVersion is the version of the OpenRPC specification the discovery
+// documents follow.
+const openRPCVersion = "1.2.6"
+
+// OpenRPCDocument describes the methods served by a server, following the
+// OpenRPC specification (https://spec.open-rpc.org).
+type OpenRPCDocument struct {
+	OpenRPC    string            `json:"openrpc"`
+	Info       OpenRPCInfo       `json:"info"`
+	Methods    []*OpenRPCMethod  `json:"methods"`
+	Components OpenRPCComponents `json:"components"`
+}
+
+// OpenRPCInfo is the metadata of an OpenRPC document.
+type OpenRPCInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// OpenRPCMethod describes a method, its parameters and its result. The
+// subscriptions of a namespace are described by its subscribe method.
+type OpenRPCMethod struct {
+	Name          string                      `json:"name"`
+	Params        []*OpenRPCContentDescriptor `json:"params"`
+	Result        *OpenRPCContentDescriptor   `json:"result"`
+	Subscriptions []*OpenRPCSubscription      `json:"x-subscriptions,omitempty"`
+}
+
+// OpenRPCSubscription describes a subscription and the parameters following its
+// name in the subscribe method.
+type OpenRPCSubscription struct {
+	Name   string                      `json:"name"`
+	Params []*OpenRPCContentDescriptor `json:"params"`
+}
+
+// OpenRPCContentDescriptor describes a parameter or a result.
+type OpenRPCContentDescriptor struct {
+	Name     string      `json:"name"`
+	Required bool        `json:"required,omitempty"`
+	Schema   *JSONSchema `json:"schema"`
+}
+
+// OpenRPCComponents holds the schemas of the named types referenced by the
+// methods of an OpenRPC document.
+type OpenRPCComponents struct {
+	Schemas map[string]*JSONSchema `json:"schemas"`
+}
+
+// JSONSchema is the subset of JSON Schema used to describe the JSON encoding of
+// the parameters and results of the methods.
+type JSONSchema struct {
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+	OneOf                []*JSONSchema          `json:"oneOf,omitempty"`
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// gencodecOverrides are the types the field overrides of gencodec give to the
+// fields of basic types, so they are encoded as hex strings.
+var gencodecOverrides = map[reflect.Type]reflect.Type{
+	reflect.TypeOf(uint64(0)):       reflect.TypeOf(hexutil.Uint64(0)),
+	reflect.TypeOf(uint(0)):         reflect.TypeOf(hexutil.Uint(0)),
+	reflect.TypeOf([]byte(nil)):     reflect.TypeOf(hexutil.Bytes(nil)),
+	reflect.TypeOf((*big.Int)(nil)): reflect.TypeOf((*hexutil.Big)(nil)),
+}
+
+var (
+	hexQuantitySchema = &JSONSchema{Title: "quantity", Type: "string", Pattern: "^0x(0|[1-9a-fA-F][0-9a-fA-F]*)$"}
+	hashSchema        = &JSONSchema{Title: "hash", Type: "string", Pattern: "^0x[0-9a-fA-F]{64}$"}
+	blockNumberSchema = &JSONSchema{Title: "blockNumber", OneOf: []*JSONSchema{
+		{Type: "string", Enum: []string{"earliest", "latest", "pending"}},
+		hexQuantitySchema,
+	}}
+)
+
+// knownSchemas are the schemas of the types whose JSON encoding isn't derived
+// from their Go type.
+var knownSchemas = map[reflect.Type]*JSONSchema{
+	reflect.TypeOf(common.Address{}):  {Title: "address", Type: "string", Pattern: "^0x[0-9a-fA-F]{40}$"},
+	reflect.TypeOf(common.Hash{}):     hashSchema,
+	reflect.TypeOf(hexutil.Big{}):     hexQuantitySchema,
+	reflect.TypeOf(hexutil.Uint64(0)): hexQuantitySchema,
+	reflect.TypeOf(hexutil.Uint(0)):   hexQuantitySchema,
+	reflect.TypeOf(hexutil.Bytes{}):   {Title: "bytes", Type: "string", Pattern: "^0x([0-9a-fA-F]{2})*$"},
+	reflect.TypeOf(big.Int{}):         {Type: "integer"},
+	reflect.TypeOf(json.RawMessage{}): {},
+	reflect.TypeOf(BlockNumber(0)):    blockNumberSchema,
+	reflect.TypeOf(BlockNumberOrHash{}): {Title: "blockNumberOrHash", OneOf: []*JSONSchema{
+		blockNumberSchema,
+		hashSchema,
+		{Type: "object", Properties: map[string]*JSONSchema{
+			"blockNumber":      blockNumberSchema,
+			"blockHash":        hashSchema,
+			"requireCanonical": {Type: "boolean"},
+		}},
+	}},
+}
+
+// discover generates the OpenRPC document of the methods registered in the
+// server, leaving out the ones its policy doesn't allow.
+func (s *Server) discover() *OpenRPCDocument {
+	var rules MethodRules
+	if s.policy != nil {
+		rules = s.policy.rules(s.transport)
+	}
+	gen := &schemaGenerator{
+		schemas: make(map[string]*JSONSchema),
+		names:   make(map[reflect.Type]string),
+	}
+	doc := &OpenRPCDocument{
+		OpenRPC: openRPCVersion,
+		Info:    OpenRPCInfo{Title: "Celo JSON-RPC API", Version: "1.0"},
+		Methods: []*OpenRPCMethod{},
+	}
+	s.services.mu.Lock()
+	defer s.services.mu.Unlock()
+
+	// Walk the services and methods in order, so the names given to the
+	// schemas of types sharing a name are the same on every call
+	for _, namespace := range sortedKeys(s.services.services) {
+		svc := s.services.services[namespace]
+		for _, name := range sortedKeys(svc.callbacks) {
+			callb := svc.callbacks[name]
+			method := namespace + serviceMethodSeparator + name
+			if strings.HasSuffix(method, subscribeMethodSuffix) || strings.HasSuffix(method, unsubscribeMethodSuffix) {
+				continue // shadowed by the subscription handling
+			}
+			if !rules.allows(method) {
+				continue
+			}
+			doc.Methods = append(doc.Methods, &OpenRPCMethod{
+				Name:   method,
+				Params: gen.params(callb.argTypes),
+				Result: gen.result(callb),
+			})
+		}
+		if len(svc.subscriptions) > 0 {
+			if method := namespace + subscribeMethodSuffix; rules.allows(method) {
+				doc.Methods = append(doc.Methods, gen.subscribe(method, svc.subscriptions))
+			}
+			if method := namespace + unsubscribeMethodSuffix; rules.allows(method) {
+				doc.Methods = append(doc.Methods, &OpenRPCMethod{
+					Name:   method,
+					Params: []*OpenRPCContentDescriptor{{Name: "subscriptionId", Required: true, Schema: &JSONSchema{Type: "string"}}},
+					Result: &OpenRPCContentDescriptor{Name: "result", Schema: &JSONSchema{Type: "boolean"}},
+				})
+			}
+		}
+	}
+	sort.Slice(doc.Methods, func(i, j int) bool {
+		return doc.Methods[i].Name < doc.Methods[j].Name
+	})
+	doc.Components.Schemas = gen.schemas
+	return doc
+}
+
+// schemaGenerator derives the JSON schemas of Go types, collecting the schemas
+// of named struct types so they can be referenced, even recursively.
+type schemaGenerator struct {
+	schemas map[string]*JSONSchema  // Schemas of the named struct types by name
+	names   map[reflect.Type]string // Names of the named struct types' schemas
+}
+
+// params describes the parameters of the given types. Trailing pointer
+// parameters may be omitted, so they are the only optional ones.
+func (g *schemaGenerator) params(types []reflect.Type) []*OpenRPCContentDescriptor {
+	params := make([]*OpenRPCContentDescriptor, len(types))
+	seen := make(map[string]bool)
+	optional := true
+	for i := len(types) - 1; i >= 0; i-- {
+		optional = optional && types[i].Kind() == reflect.Ptr
+		params[i] = &OpenRPCContentDescriptor{Required: !optional, Schema: g.schema(types[i])}
+	}
+	for i, typ := range types {
+		name := paramName(typ)
+		if seen[name] {
+			name += strconv.Itoa(i + 1)
+		}
+		seen[name] = true
+		params[i].Name = name
+	}
+	return params
+}
+
+// result describes the result of the given callback, null if it only returns
+// an error or nothing.
+func (g *schemaGenerator) result(callb *callback) *OpenRPCContentDescriptor {
+	fntype := callb.fn.Type()
+	if fntype.NumOut() == 0 || callb.errPos == 0 {
+		return &OpenRPCContentDescriptor{Name: "result", Schema: &JSONSchema{Type: "null"}}
+	}
+	return &OpenRPCContentDescriptor{Name: "result", Schema: g.schema(fntype.Out(0))}
+}
+
+// subscribe describes the subscribe method of a namespace, taking the name of
+// one of the given subscriptions followed by its parameters.
+func (g *schemaGenerator) subscribe(method string, subscriptions map[string]*callback) *OpenRPCMethod {
+	names := make([]string, 0, len(subscriptions))
+	for name := range subscriptions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	m := &OpenRPCMethod{
+		Name:   method,
+		Params: []*OpenRPCContentDescriptor{{Name: "subscription", Required: true, Schema: &JSONSchema{Type: "string", Enum: names}}},
+		Result: &OpenRPCContentDescriptor{Name: "subscriptionId", Schema: &JSONSchema{Type: "string"}},
+	}
+	for _, name := range names {
+		m.Subscriptions = append(m.Subscriptions, &OpenRPCSubscription{
+			Name:   name,
+			Params: g.params(subscriptions[name].argTypes),
+		})
+	}
+	return m
+}
+
+// schema returns the JSON schema of the encoding of the given type.
+func (g *schemaGenerator) schema(typ reflect.Type) *JSONSchema {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if schema, ok := knownSchemas[typ]; ok {
+		return schema
+	}
+	// Types encoding themselves can't be described any further than their
+	// encoding as strings, if so, unless it's generated by gencodec.
+	ptr := reflect.PtrTo(typ)
+	switch {
+	case gencodecFields(typ) != nil:
+		return g.structSchema(typ)
+	case typ.Implements(jsonMarshalerType) || ptr.Implements(jsonMarshalerType):
+		return &JSONSchema{Title: typ.Name()}
+	case typ.Implements(textMarshalerType) || ptr.Implements(textMarshalerType):
+		return &JSONSchema{Title: typ.Name(), Type: "string"}
+	}
+	switch typ.Kind() {
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return &JSONSchema{Title: "base64", Type: "string"} // encoding/json encodes byte slices in base64
+		}
+		return &JSONSchema{Type: "array", Items: g.schema(typ.Elem())}
+	case reflect.Array:
+		return &JSONSchema{Type: "array", Items: g.schema(typ.Elem())}
+	case reflect.Map:
+		return &JSONSchema{Type: "object", AdditionalProperties: g.schema(typ.Elem())}
+	case reflect.Struct:
+		return g.structSchema(typ)
+	default:
+		return &JSONSchema{}
+	}
+}
+
+// structSchema returns the JSON schema of a struct type, or of a type encoded
+// by gencodec, a reference to the collected schema if the type is named.
+func (g *schemaGenerator) structSchema(typ reflect.Type) *JSONSchema {
+	if typ.Name() == "" {
+		schema := &JSONSchema{Type: "object", Properties: make(map[string]*JSONSchema)}
+		g.addFields(schema, typ, false)
+		return schema
+	}
+	name, ok := g.names[typ]
+	if !ok {
+		// Qualify the name by package if it's taken by another type
+		name = typ.Name()
+		if _, taken := g.schemas[name]; taken {
+			name = path.Base(typ.PkgPath()) + "." + name
+		}
+		g.names[typ] = name
+
+		// Register the schema before filling it, for recursive types
+		schema := &JSONSchema{Title: typ.Name(), Type: "object", Properties: make(map[string]*JSONSchema)}
+		g.schemas[name] = schema
+		if fields := gencodecFields(typ); fields != nil {
+			g.addFields(schema, fields, true)
+			g.addComputedFields(schema, typ)
+		} else {
+			g.addFields(schema, typ, false)
+		}
+	}
+	return &JSONSchema{Ref: "#/components/schemas/" + name}
+}
+
+// addFields adds the properties encoding the fields of a struct type to the
+// schema, following the rules of encoding/json. The types of the fields of a
+// type encoded by gencodec are replaced by the ones of its overrides, and its
+// fields tagged as required are always required.
+func (g *schemaGenerator) addFields(schema *JSONSchema, typ reflect.Type, gencodec bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if override, ok := gencodecOverrides[field.Type]; ok && gencodec {
+			field.Type = override
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts := tag, ""
+		if idx := strings.Index(tag, ","); idx >= 0 {
+			name, opts = tag[:idx], tag[idx+1:]
+		}
+		// Untagged embedded structs have their fields promoted
+		ftype := field.Type
+		if ftype.Kind() == reflect.Ptr {
+			ftype = ftype.Elem()
+		}
+		if field.Anonymous && name == "" && ftype.Kind() == reflect.Struct {
+			g.addFields(schema, ftype, false)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = g.schema(field.Type)
+		if field.Tag.Get("gencodec") == "required" || (!strings.Contains(opts, "omitempty") && field.Type.Kind() != reflect.Ptr) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// addComputedFields adds the properties of the encoding of a type encoded by
+// gencodec that aren't fields of the struct, like the hashes computed by
+// MarshalJSON, as found in the encoding of its zero value. Their types can't be
+// told any further than by their values.
+func (g *schemaGenerator) addComputedFields(schema *JSONSchema, typ reflect.Type) {
+	enc, err := json.Marshal(reflect.New(typ).Interface())
+	if err != nil {
+		return
+	}
+	var props map[string]interface{}
+	if err := json.Unmarshal(enc, &props); err != nil {
+		return
+	}
+	for _, name := range sortedKeys(props) {
+		if _, ok := schema.Properties[name]; ok {
+			continue
+		}
+		switch value := props[name].(type) {
+		case string:
+			if len(value) == 2+2*common.HashLength {
+				schema.Properties[name] = hashSchema
+			} else {
+				schema.Properties[name] = &JSONSchema{Type: "string"}
+			}
+		case bool:
+			schema.Properties[name] = &JSONSchema{Type: "boolean"}
+		default:
+			schema.Properties[name] = &JSONSchema{}
+		}
+		schema.Required = append(schema.Required, name)
+	}
+}
+
+// gencodecFields returns the struct type whose fields are encoded by the
+// MarshalJSON method generated by gencodec of the given type, if any: the type
+// itself or the struct it wraps, like transactions do. Such structs are told by
+// the gencodec tags of their fields.
+func gencodecFields(typ reflect.Type) reflect.Type {
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+	if !typ.Implements(jsonMarshalerType) && !reflect.PtrTo(typ).Implements(jsonMarshalerType) {
+		return nil
+	}
+	hasTags := func(typ reflect.Type) bool {
+		for i := 0; i < typ.NumField(); i++ {
+			if _, ok := typ.Field(i).Tag.Lookup("gencodec"); ok {
+				return true
+			}
+		}
+		return false
+	}
+	if hasTags(typ) {
+		return typ
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		if field := typ.Field(i); field.Type.Kind() == reflect.Struct && hasTags(field.Type) {
+			return field.Type
+		}
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of a map with string keys in order.
+func sortedKeys(m interface{}) []string {
+	keys := make([]string, 0)
+	for _, key := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// paramName derives the name of a parameter from its type.
+func paramName(typ reflect.Type) string {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	switch {
+	case typ.Name() != "":
+		name := typ.Name()
+		if strings.ToUpper(name) == name {
+			return strings.ToLower(name)
+		}
+		runes := []rune(name)
+		runes[0] = unicode.ToLower(runes[0])
+		return string(runes)
+	case typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array:
+		return paramName(typ.Elem()) + "List"
+	case typ.Kind() == reflect.Map:
+		return "object"
+	default:
+		return "value"
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestDiscover(t *testing.T) {
+	server := newTestServer()
+	policy, _ := NewPolicy(PolicyConfig{IPC: MethodRules{Deny: []string{"test_sleep"}}})
+	server.SetPolicy(policy, TransportIPC)
+
+	client := DialInProc(server)
+	defer client.Close()
+
+	var doc OpenRPCDocument
+	if err := client.Call(&doc, "rpc_discover"); err != nil {
+		t.Fatalf("discovery failed: %v", err)
+	}
+	methods := make(map[string]*OpenRPCMethod)
+	for _, method := range doc.Methods {
+		methods[method.Name] = method
+	}
+	if _, ok := methods["test_sleep"]; ok {
+		t.Error("denied method test_sleep discovered")
+	}
+	for _, name := range []string{"rpc_discover", "rpc_modules", "test_echo", "nftest_subscribe", "nftest_unsubscribe"} {
+		if methods[name] == nil {
+			t.Errorf("method %s not discovered", name)
+		}
+	}
+	// Check the parameters and result of a method
+	echo := methods["test_echo"]
+	var (
+		names    []string
+		required []bool
+	)
+	for _, param := range echo.Params {
+		names, required = append(names, param.Name), append(required, param.Required)
+	}
+	if want := []string{"string", "int", "echoArgs"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("param names mismatch: have %v, want %v", names, want)
+	}
+	if want := []bool{true, true, false}; !reflect.DeepEqual(required, want) {
+		t.Errorf("param requirements mismatch: have %v, want %v", required, want)
+	}
+	if ref := echo.Result.Schema.Ref; ref != "#/components/schemas/echoResult" {
+		t.Errorf("result schema mismatch: have %q", ref)
+	}
+	result := doc.Components.Schemas["echoResult"]
+	if result == nil || len(result.Properties) != 3 || result.Properties["Args"].Ref != "#/components/schemas/echoArgs" {
+		t.Errorf("invalid result schema: %+v", result)
+	}
+	// Check the subscriptions of a namespace
+	var subs []string
+	for _, sub := range methods["nftest_subscribe"].Subscriptions {
+		subs = append(subs, sub.Name)
+	}
+	if want := []string{"hangSubscription", "someSubscription"}; !reflect.DeepEqual(subs, want) {
+		t.Errorf("subscriptions mismatch: have %v, want %v", subs, want)
+	}
+}
+
+// Header collides with the name of types.Header.
+type Header struct {
+	Number hexutil.Uint64 `json:"number"`
+}
+
+type collidingAService struct{}
+
+func (collidingAService) Header() *types.Header { return nil }
+
+type collidingBService struct{}
+
+func (collidingBService) Header() *Header { return nil }
+
+func TestDiscoverCollidingNames(t *testing.T) {
+	server := NewServer()
+	server.RegisterName("b", new(collidingBService))
+	server.RegisterName("a", new(collidingAService))
+
+	// The type reached first in the order of the methods keeps its name
+	for i := 0; i < 10; i++ {
+		doc := server.discover()
+		methods := make(map[string]*OpenRPCMethod)
+		for _, method := range doc.Methods {
+			methods[method.Name] = method
+		}
+		if ref := methods["a_header"].Result.Schema.Ref; ref != "#/components/schemas/Header" {
+			t.Fatalf("types.Header schema mismatch: have %q", ref)
+		}
+		if ref := methods["b_header"].Result.Schema.Ref; ref != "#/components/schemas/rpc.Header" {
+			t.Fatalf("rpc.Header schema mismatch: have %q", ref)
+		}
+	}
+}
+
+type schemaTestEmbedded struct {
+	Embedded string `json:"embedded"`
+}
+
+type schemaTestStruct struct {
+	schemaTestEmbedded
+	Address  common.Address      `json:"address"`
+	Balance  *hexutil.Big        `json:"balance"`
+	Data     []hexutil.Bytes     `json:"data,omitempty"`
+	Block    BlockNumberOrHash   `json:"block"`
+	Children []*schemaTestStruct `json:"children"`
+	Extra    map[string]uint64   `json:"extra"`
+	Skipped  bool                `json:"-"`
+	Raw      json.RawMessage     `json:"raw"`
+	Nested   struct{ Flag bool } `json:"nested"`
+	internal int
+}
+
+func TestSchemaGenerator(t *testing.T) {
+	gen := &schemaGenerator{
+		schemas: make(map[string]*JSONSchema),
+		names:   make(map[reflect.Type]string),
+	}
+	if ref := gen.schema(reflect.TypeOf(&schemaTestStruct{})).Ref; ref != "#/components/schemas/schemaTestStruct" {
+		t.Fatalf("schema reference mismatch: have %q", ref)
+	}
+	schema := gen.schemas["schemaTestStruct"]
+
+	var props []string
+	for name := range schema.Properties {
+		props = append(props, name)
+	}
+	want := []string{"address", "balance", "block", "children", "data", "embedded", "extra", "nested", "raw"}
+	if len(props) != len(want) {
+		t.Fatalf("properties mismatch: have %v, want %v", props, want)
+	}
+	for _, name := range want {
+		if schema.Properties[name] == nil {
+			t.Errorf("property %s missing", name)
+		}
+	}
+	if want := []string{"embedded", "address", "block", "children", "extra", "raw", "nested"}; !reflect.DeepEqual(schema.Required, want) {
+		t.Errorf("required properties mismatch: have %v, want %v", schema.Required, want)
+	}
+	if p := schema.Properties["address"]; p.Type != "string" || p.Pattern == "" {
+		t.Errorf("invalid address schema: %+v", p)
+	}
+	if p := schema.Properties["data"]; p.Type != "array" || p.Items.Title != "bytes" {
+		t.Errorf("invalid data schema: %+v", p)
+	}
+	if p := schema.Properties["children"]; p.Items.Ref != "#/components/schemas/schemaTestStruct" {
+		t.Errorf("invalid recursive schema: %+v", p)
+	}
+	if p := schema.Properties["extra"]; p.Type != "object" || p.AdditionalProperties.Type != "integer" {
+		t.Errorf("invalid map schema: %+v", p)
+	}
+	if p := schema.Properties["block"]; len(p.OneOf) != 3 {
+		t.Errorf("invalid block schema: %+v", p)
+	}
+	if p := schema.Properties["nested"]; p.Type != "object" || p.Properties["Flag"].Type != "boolean" {
+		t.Errorf("invalid nested schema: %+v", p)
+	}
+}
+
+// validateSchema checks that the decoded JSON value follows the schema.
+func validateSchema(gen *schemaGenerator, schema *JSONSchema, value interface{}) error {
+	if schema.Ref != "" {
+		return validateSchema(gen, gen.schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")], value)
+	}
+	if len(schema.OneOf) > 0 {
+		for _, option := range schema.OneOf {
+			if validateSchema(gen, option, value) == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("%v matches none of the options", value)
+	}
+	switch schema.Type {
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%v is not a string", value)
+		}
+		if schema.Pattern != "" && !regexp.MustCompile(schema.Pattern).MatchString(str) {
+			return fmt.Errorf("%q doesn't match %s", str, schema.Pattern)
+		}
+	case "integer", "number":
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("%v is not a number", value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%v is not a boolean", value)
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%v is not an array", value)
+		}
+		for _, item := range items {
+			if err := validateSchema(gen, schema.Items, item); err != nil {
+				return err
+			}
+		}
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%v is not an object", value)
+		}
+		for _, name := range schema.Required {
+			if _, ok := obj[name]; !ok {
+				return fmt.Errorf("required property %s missing", name)
+			}
+		}
+		for name, prop := range obj {
+			propSchema := schema.Properties[name]
+			if propSchema == nil {
+				return fmt.Errorf("unknown property %s", name)
+			}
+			if err := validateSchema(gen, propSchema, prop); err != nil {
+				return fmt.Errorf("%s: %v", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func TestSchemaGeneratorGencodec(t *testing.T) {
+	var (
+		address = common.HexToAddress("0x1")
+		hash    = common.HexToHash("0x2")
+		log     = &types.Log{Address: address, Topics: []common.Hash{hash}, Data: []byte{1}, BlockNumber: 3, TxHash: hash, TxIndex: 4, BlockHash: hash, Index: 5}
+	)
+	values := []interface{}{
+		&types.Header{ParentHash: hash, Coinbase: address, Number: big.NewInt(1), GasUsed: 2, Time: 3, Extra: []byte{4}},
+		log,
+		&types.Receipt{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 1, Logs: []*types.Log{log}, TxHash: hash, ContractAddress: address, GasUsed: 1, BlockHash: hash, BlockNumber: big.NewInt(2), TransactionIndex: 3},
+		types.NewTransaction(1, address, big.NewInt(2), 3, big.NewInt(4), &address, &address, big.NewInt(5), []byte{6}),
+	}
+	gen := &schemaGenerator{
+		schemas: make(map[string]*JSONSchema),
+		names:   make(map[reflect.Type]string),
+	}
+	for _, value := range values {
+		typ := reflect.TypeOf(value).Elem()
+		schema := gen.schema(reflect.TypeOf(value))
+		if schema.Ref == "" || gen.schemas[typ.Name()] == nil {
+			t.Errorf("%s: schema not collected: %+v", typ.Name(), schema)
+			continue
+		}
+		enc, err := json.Marshal(value)
+		if err != nil {
+			t.Fatalf("%s: encoding failed: %v", typ.Name(), err)
+		}
+		var dec map[string]interface{}
+		if err := json.Unmarshal(enc, &dec); err != nil {
+			t.Fatalf("%s: decoding failed: %v", typ.Name(), err)
+		}
+		// The schema describes every property of the encoding and nothing else
+		var encoded, described []string
+		for name := range dec {
+			encoded = append(encoded, name)
+		}
+		for name := range gen.schemas[typ.Name()].Properties {
+			described = append(described, name)
+		}
+		sort.Strings(encoded)
+		sort.Strings(described)
+		if !reflect.DeepEqual(encoded, described) {
+			t.Errorf("%s: properties mismatch: have %v, want %v", typ.Name(), described, encoded)
+		}
+		if err := validateSchema(gen, schema, dec); err != nil {
+			t.Errorf("%s: encoding doesn't follow the schema: %v", typ.Name(), err)
+		}
+	}
+	// The gencodec overrides of the field types are applied
+	if p := gen.schemas["Header"].Properties["number"]; p.Title != "quantity" {
+		t.Errorf("invalid header number schema: %+v", p)
+	}
+	if p := gen.schemas["Transaction"].Properties["input"]; p.Title != "bytes" {
+		t.Errorf("invalid transaction input schema: %+v", p)
+	}
+}
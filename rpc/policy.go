@@ -0,0 +1,291 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	lru "github.com/hashicorp/golang-lru"
+	"golang.org/x/time/rate"
+)
+
+// Transports whose method calls can be restricted by a policy.
+const (
+	TransportHTTP = "http"
+	TransportWS   = "ws"
+	TransportIPC  = "ipc"
+)
+
+// policyClientCacheSize is the maximum number of clients whose rate limits are
+// tracked at once, the least recently active ones being forgotten first.
+const policyClientCacheSize = 16384
+
+var (
+	policyDeniedMeter        = metrics.NewRegisteredMeter("rpc/policy/denied", nil)
+	policyRateLimitMeter     = metrics.NewRegisteredMeter("rpc/policy/ratelimited", nil)
+	policyBatchLimitMeter    = metrics.NewRegisteredMeter("rpc/policy/batchlimit", nil)
+	policyResponseLimitMeter = metrics.NewRegisteredMeter("rpc/policy/responselimit", nil)
+	policyTimeoutMeter       = metrics.NewRegisteredMeter("rpc/policy/timeout", nil)
+)
+
+// MethodRules are the methods allowed and denied over a transport. Methods are
+// given by name, or by prefix when ending with a '*', such as "debug_*". A method
+// is allowed if it matches none of the denied ones and, if any are allowed
+// explicitly, one of the allowed ones.
+type MethodRules struct {
+	Allow []string `toml:",omitempty"`
+	Deny  []string `toml:",omitempty"`
+}
+
+// PolicyConfig holds the restrictions and limits of the requests served.
+type PolicyConfig struct {
+	HTTP MethodRules `toml:",omitempty"`
+	WS   MethodRules `toml:",omitempty"`
+	IPC  MethodRules `toml:",omitempty"`
+
+	// RateLimit is the number of requests per second each HTTP and WebSocket
+	// client may make, identified by IP address. Zero means no limit.
+	RateLimit float64 `toml:",omitempty"`
+
+	// RateBurst is the number of requests a client may make at once, defaulting
+	// to its rate limit.
+	RateBurst int `toml:",omitempty"`
+
+	// APIKeyHeader is the HTTP header identifying the clients by API key rather
+	// than by IP address. Only the keys listed in APIKeys are honored.
+	APIKeyHeader string `toml:",omitempty"`
+
+	// APIKeys are the number of requests per second allowed for each API key,
+	// overriding RateLimit. Zero means no limit.
+	APIKeys map[string]float64 `toml:",omitempty"`
+
+	// MaxBatchSize is the maximum number of requests in a batch. Zero means no
+	// limit.
+	MaxBatchSize int `toml:",omitempty"`
+
+	// MaxResponseSize is the maximum size in bytes of the result of a request.
+	// Zero means no limit.
+	MaxResponseSize int `toml:",omitempty"`
+
+	// MethodTimeouts are the time the methods may take to answer, given by name
+	// or prefix as in MethodRules. The most specific match applies.
+	MethodTimeouts map[string]time.Duration `toml:",omitempty"`
+}
+
+// Policy restricts the methods served over each transport and limits the rate,
+// batch size, response size and duration of the requests. A policy can be shared
+// by several servers, in which case their clients share their rate limits.
+type Policy struct {
+	config PolicyConfig
+
+	limiters *lru.Cache // Token buckets of the rate limited clients
+	lock     sync.Mutex // Lock protecting the creation of token buckets
+}
+
+// NewPolicy creates a policy enforcing the given configuration.
+func NewPolicy(config PolicyConfig) (*Policy, error) {
+	for transport, rules := range map[string]MethodRules{TransportHTTP: config.HTTP, TransportWS: config.WS, TransportIPC: config.IPC} {
+		for _, pattern := range append(append([]string{}, rules.Allow...), rules.Deny...) {
+			if err := validateMethodPattern(pattern); err != nil {
+				return nil, fmt.Errorf("invalid %s method rule: %v", transport, err)
+			}
+		}
+	}
+	for pattern, timeout := range config.MethodTimeouts {
+		if err := validateMethodPattern(pattern); err != nil {
+			return nil, fmt.Errorf("invalid method timeout: %v", err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("invalid timeout %v for %s", timeout, pattern)
+		}
+	}
+	if config.RateLimit < 0 || config.RateBurst < 0 || config.MaxBatchSize < 0 || config.MaxResponseSize < 0 {
+		return nil, fmt.Errorf("negative limit")
+	}
+	for _, limit := range config.APIKeys {
+		if limit < 0 {
+			return nil, fmt.Errorf("negative API key rate limit")
+		}
+	}
+	limiters, _ := lru.New(policyClientCacheSize)
+	return &Policy{config: config, limiters: limiters}, nil
+}
+
+// validateMethodPattern checks that a method pattern is a name, or a prefix
+// followed by a '*'.
+func validateMethodPattern(pattern string) error {
+	if pattern == "" || strings.Contains(strings.TrimSuffix(pattern, "*"), "*") {
+		return fmt.Errorf("invalid method pattern %q", pattern)
+	}
+	return nil
+}
+
+// matchMethod reports whether the method matches the given pattern.
+func matchMethod(pattern, method string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(method, strings.TrimSuffix(pattern, "*"))
+	}
+	return pattern == method
+}
+
+// allows reports whether the method is allowed by the rules.
+func (r MethodRules) allows(method string) bool {
+	for _, pattern := range r.Deny {
+		if matchMethod(pattern, method) {
+			return false
+		}
+	}
+	if len(r.Allow) == 0 {
+		return true
+	}
+	for _, pattern := range r.Allow {
+		if matchMethod(pattern, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// rules returns the method rules of the given transport.
+func (p *Policy) rules(transport string) MethodRules {
+	switch transport {
+	case TransportHTTP:
+		return p.config.HTTP
+	case TransportWS:
+		return p.config.WS
+	case TransportIPC:
+		return p.config.IPC
+	default:
+		return MethodRules{}
+	}
+}
+
+// limiter returns the token bucket of the client making the given request, or
+// nil if it isn't rate limited.
+func (p *Policy) limiter(r *http.Request) *rate.Limiter {
+	key, limit := "", p.config.RateLimit
+	if p.config.APIKeyHeader != "" {
+		if apiKey := r.Header.Get(p.config.APIKeyHeader); apiKey != "" {
+			if keyLimit, ok := p.config.APIKeys[apiKey]; ok {
+				key, limit = "key:"+apiKey, keyLimit
+			}
+		}
+	}
+	if key == "" {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		key = "ip:" + host
+	}
+	if limit <= 0 {
+		return nil
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if limiter, ok := p.limiters.Get(key); ok {
+		return limiter.(*rate.Limiter)
+	}
+	burst := p.config.RateBurst
+	if burst == 0 {
+		burst = int(math.Ceil(limit))
+	}
+	limiter := rate.NewLimiter(rate.Limit(limit), burst)
+	p.limiters.Add(key, limiter)
+	return limiter
+}
+
+// timeout returns the time the given method may take to answer, zero if it
+// isn't limited.
+func (p *Policy) timeout(method string) time.Duration {
+	var (
+		timeout time.Duration
+		longest = -1
+	)
+	for pattern, t := range p.config.MethodTimeouts {
+		if !matchMethod(pattern, method) {
+			continue
+		}
+		if pattern == method {
+			return t
+		}
+		if len(pattern) > longest {
+			timeout, longest = t, len(pattern)
+		}
+	}
+	return timeout
+}
+
+// connPolicy is the policy applied to the requests of a single connection. All
+// its methods can be called on a nil connPolicy, which doesn't restrict anything.
+type connPolicy struct {
+	policy  *Policy
+	rules   MethodRules
+	limiter *rate.Limiter // Token bucket of the client, nil if not rate limited
+}
+
+// checkCall returns an error if the method can't be called over the connection,
+// consuming a token of the client's rate limit otherwise.
+func (c *connPolicy) checkCall(method string) error {
+	if c == nil {
+		return nil
+	}
+	if !c.rules.allows(method) {
+		policyDeniedMeter.Mark(1)
+		return &methodDeniedError{method: method}
+	}
+	if c.limiter != nil && !c.limiter.Allow() {
+		policyRateLimitMeter.Mark(1)
+		return &limitExceededError{"rate limit exceeded"}
+	}
+	return nil
+}
+
+// checkBatch returns an error if the batch of the given size is too large.
+func (c *connPolicy) checkBatch(size int) error {
+	if c == nil || c.policy.config.MaxBatchSize == 0 || size <= c.policy.config.MaxBatchSize {
+		return nil
+	}
+	policyBatchLimitMeter.Mark(1)
+	return &limitExceededError{fmt.Sprintf("batch too large (%d > %d)", size, c.policy.config.MaxBatchSize)}
+}
+
+// checkResponse returns an error if the result of the response is too large.
+func (c *connPolicy) checkResponse(resp *jsonrpcMessage) error {
+	if c == nil || c.policy.config.MaxResponseSize == 0 || len(resp.Result) <= c.policy.config.MaxResponseSize {
+		return nil
+	}
+	policyResponseLimitMeter.Mark(1)
+	return &limitExceededError{fmt.Sprintf("response too large (%d > %d)", len(resp.Result), c.policy.config.MaxResponseSize)}
+}
+
+// timeout returns the time the given method may take to answer, zero if it
+// isn't limited.
+func (c *connPolicy) timeout(method string) time.Duration {
+	if c == nil {
+		return 0
+	}
+	return c.policy.timeout(method)
+}
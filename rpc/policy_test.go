@@ -0,0 +1,150 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// policyTestCall posts a request to the policy restricted HTTP server, returning
+// the error code of the response, zero if it succeeded.
+func policyTestCall(t *testing.T, url string, header http.Header, body string) int {
+	req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	req.Header.Set("content-type", contentType)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var msg jsonrpcMessage
+	if err := json.NewDecoder(resp.Body).Decode(&msg); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if msg.Error != nil {
+		return msg.Error.Code
+	}
+	return 0
+}
+
+func newPolicyTestServer(t *testing.T, config PolicyConfig) *httptest.Server {
+	policy, err := NewPolicy(config)
+	if err != nil {
+		t.Fatalf("failed to create policy: %v", err)
+	}
+	server := newTestServer()
+	server.SetPolicy(policy, TransportHTTP)
+	return httptest.NewServer(server)
+}
+
+const (
+	policyTestEcho  = `{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["x",1]}`
+	policyTestSleep = `{"jsonrpc":"2.0","id":1,"method":"test_sleep","params":[1000000000]}`
+)
+
+func TestPolicyMethodRules(t *testing.T) {
+	ts := newPolicyTestServer(t, PolicyConfig{
+		HTTP: MethodRules{Allow: []string{"test_*"}, Deny: []string{"test_sleep"}},
+		WS:   MethodRules{Deny: []string{"test_*"}},
+	})
+	defer ts.Close()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{policyTestEcho, 0},
+		{policyTestSleep, -32004},
+		{`{"jsonrpc":"2.0","id":1,"method":"rpc_modules"}`, -32004},
+	}
+	for i, tt := range tests {
+		if code := policyTestCall(t, ts.URL, nil, tt.body); code != tt.code {
+			t.Errorf("test %d: error code mismatch: have %d, want %d", i, code, tt.code)
+		}
+	}
+}
+
+func TestPolicyRateLimit(t *testing.T) {
+	ts := newPolicyTestServer(t, PolicyConfig{
+		RateLimit:    0.001,
+		RateBurst:    2,
+		APIKeyHeader: "X-Api-Key",
+		APIKeys:      map[string]float64{"partner": 0},
+	})
+	defer ts.Close()
+
+	for i, want := range []int{0, 0, -32005} {
+		if code := policyTestCall(t, ts.URL, nil, policyTestEcho); code != want {
+			t.Errorf("call %d: error code mismatch: have %d, want %d", i, code, want)
+		}
+	}
+	// Known API keys get their own limit, unknown ones fall back to the IP's
+	if code := policyTestCall(t, ts.URL, http.Header{"X-Api-Key": {"partner"}}, policyTestEcho); code != 0 {
+		t.Errorf("API key call: error code mismatch: have %d, want 0", code)
+	}
+	if code := policyTestCall(t, ts.URL, http.Header{"X-Api-Key": {"unknown"}}, policyTestEcho); code != -32005 {
+		t.Errorf("unknown API key call: error code mismatch: have %d, want -32005", code)
+	}
+}
+
+func TestPolicyLimits(t *testing.T) {
+	ts := newPolicyTestServer(t, PolicyConfig{
+		MaxBatchSize:    2,
+		MaxResponseSize: 32,
+		MethodTimeouts:  map[string]time.Duration{"test_*": time.Minute, "test_sleep": 50 * time.Millisecond},
+	})
+	defer ts.Close()
+
+	if code := policyTestCall(t, ts.URL, nil, "["+strings.Repeat(policyTestEcho+",", 2)+policyTestEcho+"]"); code != -32005 {
+		t.Errorf("batch: error code mismatch: have %d, want -32005", code)
+	}
+	if code := policyTestCall(t, ts.URL, nil, policyTestEcho); code != -32005 {
+		t.Errorf("large response: error code mismatch: have %d, want -32005", code)
+	}
+	if code := policyTestCall(t, ts.URL, nil, `{"jsonrpc":"2.0","id":1,"method":"test_noArgsRets"}`); code != 0 {
+		t.Errorf("small response: error code mismatch: have %d, want 0", code)
+	}
+	start := time.Now()
+	if code := policyTestCall(t, ts.URL, nil, policyTestSleep); code != -32002 {
+		t.Errorf("timeout: error code mismatch: have %d, want -32002", code)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("timeout not enforced: took %v", elapsed)
+	}
+}
+
+func TestPolicyValidation(t *testing.T) {
+	invalid := []PolicyConfig{
+		{HTTP: MethodRules{Deny: []string{"eth_*call"}}},
+		{IPC: MethodRules{Allow: []string{""}}},
+		{MethodTimeouts: map[string]time.Duration{"eth_call": 0}},
+		{RateLimit: -1},
+	}
+	for i, config := range invalid {
+		if _, err := NewPolicy(config); err == nil {
+			t.Errorf("config %d: expected error", i)
+		}
+	}
+}
@@ -19,6 +19,7 @@ package rpc
 import (
 	"context"
 	"io"
+	"net/http"
 	"sync/atomic"
 
 	mapset "github.com/deckarep/golang-set"
@@ -46,6 +47,9 @@ type Server struct {
 	idgen    func() ID
 	run      int32
 	codecs   mapset.Set
+
+	policy    *Policy // Restrictions of the requests served, nil if unrestricted
+	transport string  // Transport whose method rules apply
 }
 
 // NewServer creates a new server instance with no registered handlers.
@@ -66,12 +70,37 @@ func (s *Server) RegisterName(name string, receiver interface{}) error {
 	return s.services.registerName(name, receiver)
 }
 
+// SetPolicy restricts and limits the requests served according to the policy,
+// applying the method rules of the given transport. It must be called before
+// any request is served.
+func (s *Server) SetPolicy(policy *Policy, transport string) {
+	s.policy, s.transport = policy, transport
+}
+
+// connPolicy returns the policy applied to a connection, rate limiting the client
+// making the given request if it's not nil.
+func (s *Server) connPolicy(r *http.Request) *connPolicy {
+	if s.policy == nil {
+		return nil
+	}
+	c := &connPolicy{policy: s.policy, rules: s.policy.rules(s.transport)}
+	if r != nil {
+		c.limiter = s.policy.limiter(r)
+	}
+	return c
+}
+
 // ServeCodec reads incoming requests from codec, calls the appropriate callback and writes
 // the response back using the given codec. It will block until the codec is closed or the
 // server is stopped. In either case the codec is closed.
 //
 // Note that codec options are no longer supported.
 func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
+	s.serveCodec(codec, s.connPolicy(nil))
+}
+
+// serveCodec serves the requests read from codec, applying the given policy.
+func (s *Server) serveCodec(codec ServerCodec, policy *connPolicy) {
 	defer codec.close()
 
 	// Don't serve if server is stopped.
@@ -83,7 +112,7 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 	s.codecs.Add(codec)
 	defer s.codecs.Remove(codec)
 
-	c := initClient(codec, s.idgen, &s.services)
+	c := initClient(codec, s.idgen, &s.services, policy)
 	<-codec.closed()
 	c.Close()
 }
@@ -91,7 +120,7 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 // serveSingleRequest reads and processes a single RPC request from the given codec. This
 // is used to serve HTTP connections. Subscriptions and reverse calls are not allowed in
 // this mode.
-func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
+func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec, policy *connPolicy) {
 	// Don't serve if server is stopped.
 	if atomic.LoadInt32(&s.run) == 0 {
 		return
@@ -99,6 +128,7 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 
 	h := newHandler(ctx, codec, s.idgen, &s.services)
 	h.allowSubscribe = false
+	h.policy = policy
 	defer h.close(io.EOF, nil)
 
 	reqs, batch, err := codec.readBatch()
@@ -134,6 +164,12 @@ type RPCService struct {
 	server *Server
 }
 
+// Discover returns an OpenRPC document describing the methods offered, with the
+// JSON schemas of their parameters and results.
+func (s *RPCService) Discover() *OpenRPCDocument {
+	return s.server.discover()
+}
+
 // Modules returns the list of RPC services with their version number
 func (s *RPCService) Modules() map[string]string {
 	s.server.services.mu.Lock()
@@ -93,6 +93,15 @@ type UIClientAPI interface {
 	RegisterUIServer(api *UIServerAPI)
 }
 
+// SignTxObserver is implemented by UIs keeping state for the transactions they
+// approve, like amounts reserved by the rules, that must be given back if the
+// transaction ends up not being signed.
+type SignTxObserver interface {
+	// OnSignTxDone is invoked once signing an approved request is over, with the
+	// error that prevented it, if any.
+	OnSignTxDone(request *SignTxRequest, err error)
+}
+
 // Validator defines the methods required to validate a transaction against some
 // sanity defaults as well as any underlying 4byte method database.
 //
@@ -525,6 +534,10 @@ func (api *SignerAPI) SignTransaction(ctx context.Context, args SendTxArgs, meth
 	if !result.Approved {
 		return nil, ErrRequestDenied
 	}
+	// All the failures below are reported through err
+	if observer, ok := api.UI.(SignTxObserver); ok {
+		defer func() { observer.OnSignTxDone(&req, err) }()
+	}
 	// Log changes made by the UI to the signing-request
 	logDiff(&req, &result)
 	var (
@@ -0,0 +1,149 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// stableTokenABIJSON is the subset of the ERC-20 interface of the stable tokens
+// that moves funds on behalf of the signer.
+const stableTokenABIJSON = `[
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"transferFrom","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"approve","inputs":[{"name":"spender","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}
+]`
+
+var stableTokenABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(stableTokenABIJSON))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// StableTokens maps the addresses of the whitelisted stable token contracts to
+// their symbols.
+type StableTokens map[common.Address]string
+
+// TokenCall is a decoded transfer, transferFrom or approve call on one of the
+// whitelisted stable tokens.
+type TokenCall struct {
+	Token  common.Address  `json:"token"`
+	Symbol string          `json:"symbol"`
+	Method string          `json:"method"`
+	From   *common.Address `json:"from,omitempty"` // Only set for transferFrom
+	To     common.Address  `json:"to"`             // Recipient, or spender for approve
+	Value  *hexutil.Big    `json:"value"`
+}
+
+// CeloTxInfo contains the decoded Celo specific fields of a transaction.
+type CeloTxInfo struct {
+	FeeCurrency         *common.Address `json:"feeCurrency"`       // Nil if fees are paid in the native token
+	FeeCurrencySymbol   string          `json:"feeCurrencySymbol"` // Empty if the fee currency is not whitelisted
+	GatewayFeeRecipient *common.Address `json:"gatewayFeeRecipient"`
+	GatewayFee          *hexutil.Big    `json:"gatewayFee"`
+	TokenCall           *TokenCall      `json:"tokenCall"` // Nil unless calling a whitelisted stable token
+}
+
+// DecodeTokenCall decodes a call to one of the whitelisted stable tokens that
+// moves funds, returning nil for any other call.
+func (tokens StableTokens) DecodeTokenCall(to *common.Address, data []byte) *TokenCall {
+	if to == nil || len(data) < 4 {
+		return nil
+	}
+	symbol, ok := tokens[*to]
+	if !ok {
+		return nil
+	}
+	method, err := stableTokenABI.MethodById(data[:4])
+	if err != nil {
+		return nil
+	}
+	args, err := method.Inputs.UnpackValues(data[4:])
+	if err != nil {
+		return nil
+	}
+	call := &TokenCall{Token: *to, Symbol: symbol, Method: method.Name}
+	if method.Name == "transferFrom" {
+		from := args[0].(common.Address)
+		call.From, args = &from, args[1:]
+	}
+	call.To = args[0].(common.Address)
+	call.Value = (*hexutil.Big)(args[1].(*big.Int))
+	return call
+}
+
+// DecodeTx decodes the Celo specific fields of the given transaction.
+func (tokens StableTokens) DecodeTx(tx *types.Transaction) *CeloTxInfo {
+	if tx == nil {
+		return nil
+	}
+	info := &CeloTxInfo{
+		FeeCurrency:         tx.FeeCurrency(),
+		GatewayFeeRecipient: tx.GatewayFeeRecipient(),
+		GatewayFee:          (*hexutil.Big)(tx.GatewayFee()),
+		TokenCall:           tokens.DecodeTokenCall(tx.To(), tx.Data()),
+	}
+	if info.FeeCurrency != nil {
+		info.FeeCurrencySymbol = tokens[*info.FeeCurrency]
+	}
+	return info
+}
+
+// DecodeTxArgs decodes the Celo specific fields of the transaction described by
+// the given arguments.
+func (tokens StableTokens) DecodeTxArgs(args *SendTxArgs) *CeloTxInfo {
+	return tokens.DecodeTx(args.toTransaction())
+}
+
+// CeloValidator wraps a Validator, additionally checking the Celo specific
+// fields of transactions.
+type CeloValidator struct {
+	Validator
+	tokens        StableTokens
+	maxGatewayFee *big.Int
+}
+
+// NewCeloValidator creates a validator that warns about fees paid in currencies
+// other than the whitelisted stable tokens, and about gateway fees exceeding
+// maxGatewayFee. If maxGatewayFee is nil, gateway fees exceeding the maximum gas
+// fee of the transaction are warned about.
+func NewCeloValidator(validator Validator, tokens StableTokens, maxGatewayFee *big.Int) *CeloValidator {
+	return &CeloValidator{
+		Validator:     validator,
+		tokens:        tokens,
+		maxGatewayFee: maxGatewayFee,
+	}
+}
+
+// ValidateTransaction implements Validator, running the wrapped validator before
+// checking the Celo specific fields.
+func (v *CeloValidator) ValidateTransaction(selector *string, tx *SendTxArgs) (*ValidationMessages, error) {
+	messages, err := v.Validator.ValidateTransaction(selector, tx)
+	if err != nil {
+		return nil, err
+	}
+	ValidateCeloFields(tx, v.tokens, v.maxGatewayFee, messages)
+	return messages, nil
+}
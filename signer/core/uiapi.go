@@ -130,6 +130,19 @@ func (s *UIServerAPI) ImportRawKey(privkey string, password string) (accounts.Ac
 	return fetchKeystore(s.am).ImportECDSA(key, password)
 }
 
+// ImportMnemonic creates an HD wallet in the key directory from the given BIP-39
+// mnemonic and mnemonic passphrase, encrypting it with the password. The first
+// account on the Celo derivation path is returned, more can be derived with
+// clef_openWallet and clef_deriveAccount.
+// Example call
+// {"jsonrpc":"2.0","method":"clef_importMnemonic","params":["abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about","","test1234"], "id":6}
+func (s *UIServerAPI) ImportMnemonic(mnemonic string, mnemonicPassphrase string, password string) (accounts.Account, error) {
+	if err := ValidatePasswordFormat(password); err != nil {
+		return accounts.Account{}, fmt.Errorf("password requirements not met: %v", err)
+	}
+	return fetchKeystore(s.am).ImportMnemonic(mnemonic, mnemonicPassphrase, password)
+}
+
 // OpenWallet initiates a hardware wallet opening procedure, establishing a USB
 // connection and attempting to authenticate via the provided passphrase. Note,
 // the method may return an extra challenge requiring a second open (e.g. the
@@ -175,6 +188,9 @@ func (s *UIServerAPI) Export(ctx context.Context, addr common.Address) (json.Raw
 	if wallet.URL().Scheme != keystore.KeyStoreScheme {
 		return nil, fmt.Errorf("account is not a keystore-account")
 	}
+	if accs := wallet.Accounts(); len(accs) != 1 || accs[0].URL != wallet.URL() {
+		return nil, fmt.Errorf("account is derived from an hd wallet")
+	}
 	return ioutil.ReadFile(wallet.URL().Path)
 }
 
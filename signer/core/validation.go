@@ -18,6 +18,8 @@ package core
 
 import (
 	"errors"
+	"fmt"
+	"math/big"
 	"regexp"
 )
 
@@ -34,3 +36,35 @@ func ValidatePasswordFormat(password string) error {
 	}
 	return nil
 }
+
+// ValidateCeloFields checks the Celo specific fields of the transaction, warning
+// about fees paid in currencies other than the whitelisted stable tokens and
+// about gateway fees exceeding maxGatewayFee. If maxGatewayFee is nil, gateway
+// fees exceeding the maximum gas fee of the transaction are warned about.
+func ValidateCeloFields(tx *SendTxArgs, tokens StableTokens, maxGatewayFee *big.Int, messages *ValidationMessages) {
+	if tx.FeeCurrency != nil {
+		if !tx.FeeCurrency.ValidChecksum() {
+			messages.Warn("Invalid checksum on fee currency address")
+		}
+		if _, ok := tokens[tx.FeeCurrency.Address()]; !ok {
+			messages.Warn(fmt.Sprintf("Transaction pays fees in unknown currency %s", tx.FeeCurrency.Address().Hex()))
+		}
+	}
+	gatewayFee := tx.GatewayFee.ToInt()
+	if tx.GatewayFeeRecipient == nil {
+		if gatewayFee.Sign() > 0 {
+			messages.Warn("Transaction sets a gateway fee without a gateway fee recipient, it will not be charged")
+		}
+		return
+	}
+	if !tx.GatewayFeeRecipient.ValidChecksum() {
+		messages.Warn("Invalid checksum on gateway fee recipient address")
+	}
+	limit := maxGatewayFee
+	if limit == nil {
+		limit = new(big.Int).Mul(tx.GasPrice.ToInt(), new(big.Int).SetUint64(uint64(tx.Gas)))
+	}
+	if gatewayFee.Cmp(limit) > 0 {
+		messages.Warn(fmt.Sprintf("Gateway fee %v exceeds the limit of %v", gatewayFee, limit))
+	}
+}
@@ -16,7 +16,13 @@
 
 package core
 
-import "testing"
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
 
 func TestPasswordValidation(t *testing.T) {
 	testcases := []struct {
@@ -43,3 +49,83 @@ func TestPasswordValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateCeloFields(t *testing.T) {
+	var (
+		cusd    = common.NewMixedcaseAddress(common.HexToAddress("0x765DE816845861e75A25fCA122bb6898B8B1282a"))
+		unknown = common.NewMixedcaseAddress(common.HexToAddress("0x000000000000000000000000000000000000dEaD"))
+		tokens  = StableTokens{cusd.Address(): "cUSD"}
+	)
+	testcases := []struct {
+		feeCurrency   *common.MixedcaseAddress
+		recipient     *common.MixedcaseAddress
+		gatewayFee    int64
+		maxGatewayFee *big.Int
+		warnings      int
+	}{
+		{nil, nil, 0, nil, 0},
+		{&cusd, nil, 0, nil, 0},
+		{&unknown, nil, 0, nil, 1},
+		{nil, nil, 1, nil, 1},
+		{nil, &unknown, 21000, nil, 0}, // Within the maximum gas fee
+		{nil, &unknown, 21001, nil, 1}, // Above the maximum gas fee
+		{&cusd, &unknown, 100, big.NewInt(100), 0},
+		{&unknown, &unknown, 101, big.NewInt(100), 2},
+	}
+	for i, tc := range testcases {
+		tx := &SendTxArgs{
+			Gas:                 21000,
+			GasPrice:            hexutil.Big(*big.NewInt(1)),
+			FeeCurrency:         tc.feeCurrency,
+			GatewayFeeRecipient: tc.recipient,
+			GatewayFee:          hexutil.Big(*big.NewInt(tc.gatewayFee)),
+		}
+		messages := new(ValidationMessages)
+		ValidateCeloFields(tx, tokens, tc.maxGatewayFee, messages)
+		if len(messages.Messages) != tc.warnings {
+			t.Errorf("test %d: warning count mismatch: have %d, want %d: %v", i, len(messages.Messages), tc.warnings, messages.Messages)
+		}
+	}
+}
+
+func TestDecodeTokenCall(t *testing.T) {
+	var (
+		cusd   = common.HexToAddress("0x765DE816845861e75A25fCA122bb6898B8B1282a")
+		tokens = StableTokens{cusd: "cUSD"}
+		from   = common.LeftPadBytes(common.FromHex("beef"), 32)
+		to     = common.LeftPadBytes(common.FromHex("dead"), 32)
+		value  = common.LeftPadBytes([]byte{0x01, 0x00}, 32)
+	)
+	join := func(parts ...[]byte) []byte {
+		var data []byte
+		for _, part := range parts {
+			data = append(data, part...)
+		}
+		return data
+	}
+	transfer := join(common.FromHex("a9059cbb"), to, value)
+	transferFrom := join(common.FromHex("23b872dd"), from, to, value)
+	approve := join(common.FromHex("095ea7b3"), to, value)
+
+	if call := tokens.DecodeTokenCall(&cusd, transfer); call == nil || call.Method != "transfer" || call.Symbol != "cUSD" ||
+		call.To != common.BytesToAddress(to) || call.From != nil || call.Value.ToInt().Int64() != 256 {
+		t.Errorf("transfer decoded incorrectly: %+v", call)
+	}
+	if call := tokens.DecodeTokenCall(&cusd, transferFrom); call == nil || call.Method != "transferFrom" ||
+		call.From == nil || *call.From != common.BytesToAddress(from) || call.To != common.BytesToAddress(to) {
+		t.Errorf("transferFrom decoded incorrectly: %+v", call)
+	}
+	if call := tokens.DecodeTokenCall(&cusd, approve); call == nil || call.Method != "approve" || call.To != common.BytesToAddress(to) {
+		t.Errorf("approve decoded incorrectly: %+v", call)
+	}
+	other := common.HexToAddress("0x1")
+	if call := tokens.DecodeTokenCall(&other, transfer); call != nil {
+		t.Errorf("expected call on unknown token not to be decoded: %+v", call)
+	}
+	if call := tokens.DecodeTokenCall(&cusd, transfer[:20]); call != nil {
+		t.Errorf("expected truncated call not to be decoded: %+v", call)
+	}
+	if call := tokens.DecodeTokenCall(&cusd, common.FromHex("70a08231")); call != nil {
+		t.Errorf("expected unknown method not to be decoded: %+v", call)
+	}
+}
@@ -19,10 +19,14 @@ package rules
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/dop251/goja"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/signer/core"
@@ -48,20 +52,53 @@ func consoleOutput(call goja.FunctionCall) goja.Value {
 // rulesetUI provides an implementation of UIClientAPI that evaluates a javascript
 // file for each defined UI-method
 type rulesetUI struct {
-	next    core.UIClientAPI // The next handler, for manual processing
-	storage storage.Storage
-	jsRules string // The rules to use
+	next     core.UIClientAPI // The next handler, for manual processing
+	storage  storage.Storage
+	spending *storage.SpendingCounter // Spending counters kept by the rules, persisted in storage
+	tokens   core.StableTokens        // Whitelisted stable tokens whose calls are decoded for the rules
+	jsRules  string                   // The rules to use
+
+	reserved     map[*core.SignTxRequest][]reservation // Amounts reserved for approved transactions being signed
+	reservedLock sync.Mutex
+}
+
+// reservation is an amount reserved by the rules under a spending key.
+type reservation struct {
+	key    string
+	amount *big.Int
+}
+
+// celoSignTxRequest extends a transaction signing request with its decoded Celo
+// specific fields.
+type celoSignTxRequest struct {
+	*core.SignTxRequest
+	Celo *core.CeloTxInfo `json:"celo"`
+}
+
+// celoSignTxResult extends a signed transaction with its decoded Celo specific
+// fields.
+type celoSignTxResult struct {
+	ethapi.SignTransactionResult
+	Celo *core.CeloTxInfo `json:"celo"`
 }
 
 func NewRuleEvaluator(next core.UIClientAPI, jsbackend storage.Storage) (*rulesetUI, error) {
 	c := &rulesetUI{
-		next:    next,
-		storage: jsbackend,
-		jsRules: "",
+		next:     next,
+		storage:  jsbackend,
+		spending: storage.NewSpendingCounter(jsbackend),
+		jsRules:  "",
+		reserved: make(map[*core.SignTxRequest][]reservation),
 	}
 
 	return c, nil
 }
+
+// SetStableTokens sets the stable tokens whose transfer and approve calls are
+// decoded into the "celo" field of transactions passed to the rules.
+func (r *rulesetUI) SetStableTokens(tokens core.StableTokens) {
+	r.tokens = tokens
+}
 func (r *rulesetUI) RegisterUIServer(api *core.UIServerAPI) {
 	// TODO, make it possible to query from js
 }
@@ -71,6 +108,12 @@ func (r *rulesetUI) Init(javascriptRules string) error {
 	return nil
 }
 func (r *rulesetUI) execute(jsfunc string, jsarg interface{}) (goja.Value, error) {
+	return r.executeReserving(jsfunc, jsarg, nil)
+}
+
+// executeReserving runs the given rule function like execute, appending the
+// amounts reserved by the rule to reserved, if set.
+func (r *rulesetUI) executeReserving(jsfunc string, jsarg interface{}, reserved *[]reservation) (goja.Value, error) {
 
 	// Instantiate a fresh vm engine every time
 	vm := goja.New()
@@ -98,6 +141,53 @@ func (r *rulesetUI) execute(jsfunc string, jsarg interface{}) (goja.Value, error
 	})
 	vm.Set("storage", storageObj)
 
+	// Amounts are passed as decimal or hex strings, totals are returned as
+	// decimal strings and windows are given in seconds.
+	spendingObj := vm.NewObject()
+	spendingObj.Set("add", func(call goja.FunctionCall) goja.Value {
+		amount, ok := math.ParseBig256(call.Argument(1).String())
+		if !ok {
+			panic(vm.NewTypeError("invalid amount: %v", call.Argument(1)))
+		}
+		if err := r.spending.Add(call.Argument(0).String(), amount); err != nil {
+			panic(vm.NewGoError(err))
+		}
+		return goja.Null()
+	})
+	spendingObj.Set("reserve", func(call goja.FunctionCall) goja.Value {
+		key := call.Argument(0).String()
+		amount, ok := math.ParseBig256(call.Argument(1).String())
+		if !ok {
+			panic(vm.NewTypeError("invalid amount: %v", call.Argument(1)))
+		}
+		limit, ok := math.ParseBig256(call.Argument(2).String())
+		if !ok {
+			panic(vm.NewTypeError("invalid limit: %v", call.Argument(2)))
+		}
+		window := time.Duration(call.Argument(3).ToInteger()) * time.Second
+		ok, err := r.spending.Reserve(key, amount, limit, window)
+		if err != nil {
+			panic(vm.NewGoError(err))
+		}
+		if ok && reserved != nil {
+			*reserved = append(*reserved, reservation{key, amount})
+		}
+		return vm.ToValue(ok)
+	})
+	spendingObj.Set("total", func(call goja.FunctionCall) goja.Value {
+		window := time.Duration(call.Argument(1).ToInteger()) * time.Second
+		total, err := r.spending.Total(call.Argument(0).String(), window)
+		if err != nil {
+			panic(vm.NewGoError(err))
+		}
+		return vm.ToValue(total.String())
+	})
+	spendingObj.Set("reset", func(call goja.FunctionCall) goja.Value {
+		r.spending.Reset(call.Argument(0).String())
+		return goja.Null()
+	})
+	vm.Set("spending", spendingObj)
+
 	// Load bootstrap libraries
 	script, err := goja.Compile("bignumber.js", string(BigNumber_JS), true)
 	if err != nil {
@@ -134,10 +224,16 @@ func (r *rulesetUI) execute(jsfunc string, jsarg interface{}) (goja.Value, error
 }
 
 func (r *rulesetUI) checkApproval(jsfunc string, jsarg []byte, err error) (bool, error) {
+	return r.checkApprovalReserving(jsfunc, jsarg, err, nil)
+}
+
+// checkApprovalReserving checks the approval like checkApproval, appending the
+// amounts reserved by the rule to reserved, if set.
+func (r *rulesetUI) checkApprovalReserving(jsfunc string, jsarg []byte, err error, reserved *[]reservation) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	v, err := r.execute(jsfunc, string(jsarg))
+	v, err := r.executeReserving(jsfunc, string(jsarg), reserved)
 	if err != nil {
 		log.Info("error occurred during execution", "error", err)
 		return false, err
@@ -154,14 +250,29 @@ func (r *rulesetUI) checkApproval(jsfunc string, jsarg []byte, err error) (bool,
 }
 
 func (r *rulesetUI) ApproveTx(request *core.SignTxRequest) (core.SignTxResponse, error) {
-	jsonreq, err := json.Marshal(request)
-	approved, err := r.checkApproval("ApproveTx", jsonreq, err)
+	var celo *core.CeloTxInfo
+	if request != nil {
+		celo = r.tokens.DecodeTxArgs(&request.Transaction)
+	}
+	jsonreq, err := json.Marshal(&celoSignTxRequest{request, celo})
+
+	var reserved []reservation
+	approved, err := r.checkApprovalReserving("ApproveTx", jsonreq, err, &reserved)
+	if !approved {
+		// Amounts are only kept reserved for the transactions the rules approve
+		r.release(reserved)
+	}
 	if err != nil {
 		log.Info("Rule-based approval error, going to manual", "error", err)
 		return r.next.ApproveTx(request)
 	}
 
 	if approved {
+		if len(reserved) > 0 {
+			r.reservedLock.Lock()
+			r.reserved[request] = reserved
+			r.reservedLock.Unlock()
+		}
 		return core.SignTxResponse{
 				Transaction: request.Transaction,
 				Approved:    true},
@@ -170,6 +281,28 @@ func (r *rulesetUI) ApproveTx(request *core.SignTxRequest) (core.SignTxResponse,
 	return core.SignTxResponse{Approved: false}, err
 }
 
+// OnSignTxDone gives back the amounts reserved by the rules when approving the
+// request, if signing it failed.
+func (r *rulesetUI) OnSignTxDone(request *core.SignTxRequest, err error) {
+	r.reservedLock.Lock()
+	reserved := r.reserved[request]
+	delete(r.reserved, request)
+	r.reservedLock.Unlock()
+
+	if err != nil {
+		r.release(reserved)
+	}
+}
+
+// release gives back reserved amounts.
+func (r *rulesetUI) release(reserved []reservation) {
+	for _, res := range reserved {
+		if err := r.spending.Release(res.key, res.amount); err != nil {
+			log.Warn("Failed to release reserved amount", "key", res.key, "amount", res.amount, "err", err)
+		}
+	}
+}
+
 func (r *rulesetUI) ApproveSignData(request *core.SignDataRequest) (core.SignDataResponse, error) {
 	jsonreq, err := json.Marshal(request)
 	approved, err := r.checkApproval("ApproveSignData", jsonreq, err)
@@ -231,7 +364,7 @@ func (r *rulesetUI) OnSignerStartup(info core.StartupInfo) {
 }
 
 func (r *rulesetUI) OnApprovedTx(tx ethapi.SignTransactionResult) {
-	jsonTx, err := json.Marshal(tx)
+	jsonTx, err := json.Marshal(&celoSignTxResult{tx, r.tokens.DecodeTx(tx.Tx)})
 	if err != nil {
 		log.Warn("failed marshalling transaction", "tx", tx)
 		return
@@ -17,10 +17,13 @@
 package rules
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
@@ -496,6 +499,138 @@ func TestLimitWindow(t *testing.T) {
 	}
 }
 
+const ExampleStableTokenLimit = `
+// Limit cUSD transfers to 1000 cUSD per day, paying fees in cUSD
+var limit = new BigNumber("1000e18");
+var day = 24 * 60 * 60;
+
+function ApproveTx(r){
+	var call = r.celo.tokenCall
+	if (call == null || call.symbol != "cUSD" || call.method != "transfer"){
+		return "Reject"
+	}
+	if (r.celo.feeCurrencySymbol != "cUSD"){
+		return "Reject"
+	}
+	// The amount is given back if the transaction isn't signed
+	if (!spending.reserve("cusd-daily", call.value, limit.toFixed(), day)){
+		return "Reject"
+	}
+	return "Approve"
+}
+`
+
+func TestStableTokenLimit(t *testing.T) {
+	r, err := initRuleEngine(ExampleStableTokenLimit)
+	if err != nil {
+		t.Fatalf("Couldn't create evaluator %v", err)
+	}
+	cusd := common.HexToAddress("0x765DE816845861e75A25fCA122bb6898B8B1282a")
+	r.SetStableTokens(core.StableTokens{cusd: "cUSD"})
+
+	// transfer(0xdead, 400 cUSD)
+	amount := new(big.Int).Mul(big.NewInt(400), big.NewInt(1e18))
+	data := append(common.FromHex("a9059cbb"), common.LeftPadBytes(common.FromHex("dead"), 32)...)
+	data = append(data, common.LeftPadBytes(amount.Bytes(), 32)...)
+
+	transfer := func(token common.Address, feeCurrency *common.Address) *core.SignTxRequest {
+		req := dummyTxWithV(0)
+		to := common.NewMixedcaseAddress(token)
+		req.Transaction.To = &to
+		req.Transaction.Data = (*hexutil.Bytes)(&data)
+		if feeCurrency != nil {
+			fc := common.NewMixedcaseAddress(*feeCurrency)
+			req.Transaction.FeeCurrency = &fc
+		}
+		return req
+	}
+	// Transfers of other tokens and fees in other currencies are rejected
+	if resp, _ := r.ApproveTx(transfer(common.HexToAddress("0x1"), &cusd)); resp.Approved {
+		t.Errorf("Expected transfer of unknown token to be rejected")
+	}
+	if resp, _ := r.ApproveTx(transfer(cusd, nil)); resp.Approved {
+		t.Errorf("Expected transfer paying fees in CELO to be rejected")
+	}
+	// The first two transfers fit in the daily limit
+	for i := 0; i < 2; i++ {
+		req := transfer(cusd, &cusd)
+		resp, err := r.ApproveTx(req)
+		if err != nil {
+			t.Errorf("Unexpected error %v", err)
+		}
+		if !resp.Approved {
+			t.Fatalf("Expected transfer %d to be approved", i)
+		}
+		r.OnSignTxDone(req, nil)
+	}
+	// The third one would exceed it
+	if resp, _ := r.ApproveTx(transfer(cusd, &cusd)); resp.Approved {
+		t.Errorf("Expected transfer exceeding the limit to be rejected")
+	}
+	if spent, _ := r.spending.Total("cusd-daily", time.Hour); spent.Cmp(new(big.Int).Mul(amount, big.NewInt(2))) != 0 {
+		t.Errorf("Spent amount mismatch: have %v, want %v", spent, new(big.Int).Mul(amount, big.NewInt(2)))
+	}
+}
+
+func TestStableTokenLimitConcurrent(t *testing.T) {
+	r, err := initRuleEngine(ExampleStableTokenLimit)
+	if err != nil {
+		t.Fatalf("Couldn't create evaluator %v", err)
+	}
+	cusd := common.HexToAddress("0x765DE816845861e75A25fCA122bb6898B8B1282a")
+	r.SetStableTokens(core.StableTokens{cusd: "cUSD"})
+
+	// transfer(0xdead, 600 cUSD), two of them exceed the daily limit together
+	amount := new(big.Int).Mul(big.NewInt(600), big.NewInt(1e18))
+	data := append(common.FromHex("a9059cbb"), common.LeftPadBytes(common.FromHex("dead"), 32)...)
+	data = append(data, common.LeftPadBytes(amount.Bytes(), 32)...)
+
+	transfer := func() *core.SignTxRequest {
+		req := dummyTxWithV(0)
+		to, fc := common.NewMixedcaseAddress(cusd), common.NewMixedcaseAddress(cusd)
+		req.Transaction.To, req.Transaction.FeeCurrency = &to, &fc
+		req.Transaction.Data = (*hexutil.Bytes)(&data)
+		return req
+	}
+	var (
+		reqs     = []*core.SignTxRequest{transfer(), transfer()}
+		approved = make([]bool, len(reqs))
+		pend     sync.WaitGroup
+	)
+	for i := range reqs {
+		pend.Add(1)
+		go func(i int) {
+			defer pend.Done()
+			resp, err := r.ApproveTx(reqs[i])
+			if err != nil {
+				t.Errorf("Unexpected error %v", err)
+			}
+			approved[i] = resp.Approved
+		}(i)
+	}
+	pend.Wait()
+	if approved[0] == approved[1] {
+		t.Fatalf("Expected exactly one transfer to be approved, have %v", approved)
+	}
+	signed := reqs[0]
+	if approved[1] {
+		signed = reqs[1]
+	}
+	// Signing the approved transfer fails, giving its amount back
+	r.OnSignTxDone(signed, errors.New("could not decrypt key with given password"))
+	if spent, _ := r.spending.Total("cusd-daily", time.Hour); spent.Sign() != 0 {
+		t.Errorf("Spent amount mismatch: have %v, want 0", spent)
+	}
+	req := transfer()
+	if resp, _ := r.ApproveTx(req); !resp.Approved {
+		t.Fatalf("Expected transfer to be approved once the failed one is released")
+	}
+	r.OnSignTxDone(req, nil)
+	if spent, _ := r.spending.Total("cusd-daily", time.Hour); spent.Cmp(amount) != 0 {
+		t.Errorf("Spent amount mismatch: have %v, want %v", spent, amount)
+	}
+}
+
 // dontCallMe is used as a next-handler that does not want to be called - it invokes test failure
 type dontCallMe struct {
 	t *testing.T
@@ -0,0 +1,181 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+)
+
+const (
+	// spendingKeyPrefix namespaces the spending records in the backing storage.
+	spendingKeyPrefix = "spending:"
+
+	// spendingRetention is how long spending records are kept around, bounding
+	// the longest window a limit can be expressed over.
+	spendingRetention = 31 * 24 * time.Hour
+)
+
+// spendingRecord is a single amount spent at a given time.
+type spendingRecord struct {
+	Time   int64    `json:"time"`
+	Amount *big.Int `json:"amount"`
+}
+
+// SpendingCounter keeps track of the amounts spent under a key, e.g. the name
+// of a rule, persisting them into a Storage so that limits like "at most 1000
+// cUSD per day" survive restarts.
+type SpendingCounter struct {
+	storage Storage
+	now     func() time.Time // Clock, overridable in tests
+
+	mu sync.Mutex
+}
+
+// NewSpendingCounter creates a spending counter backed by the given storage.
+func NewSpendingCounter(storage Storage) *SpendingCounter {
+	return &SpendingCounter{storage: storage, now: time.Now}
+}
+
+// Add records the given amount as spent under key at the current time.
+func (c *SpendingCounter) Add(key string, amount *big.Int) error {
+	if amount.Sign() < 0 {
+		return errors.New("negative amount")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	records, err := c.load(key, now.Add(-spendingRetention))
+	if err != nil {
+		return err
+	}
+	return c.store(key, append(records, spendingRecord{Time: now.Unix(), Amount: amount}))
+}
+
+// Reserve records the given amount as spent under key at the current time, if
+// the total spent within the last window stays within the limit. The check and
+// the record are atomic, so concurrent reservations can't exceed the limit
+// together. Reservations that end up unused are given back with Release.
+func (c *SpendingCounter) Reserve(key string, amount *big.Int, limit *big.Int, window time.Duration) (bool, error) {
+	if amount.Sign() < 0 {
+		return false, errors.New("negative amount")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	records, err := c.load(key, now.Add(-spendingRetention))
+	if err != nil {
+		return false, err
+	}
+	total, cutoff := new(big.Int).Set(amount), now.Add(-window).Unix()
+	for _, record := range records {
+		if record.Time > cutoff {
+			total.Add(total, record.Amount)
+		}
+	}
+	if total.Cmp(limit) > 0 {
+		return false, nil
+	}
+	return true, c.store(key, append(records, spendingRecord{Time: now.Unix(), Amount: amount}))
+}
+
+// Release drops the latest record of the given amount spent under key, giving
+// back an amount reserved for a spending that didn't happen.
+func (c *SpendingCounter) Release(key string, amount *big.Int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	records, err := c.load(key, c.now().Add(-spendingRetention))
+	if err != nil {
+		return err
+	}
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].Amount.Cmp(amount) == 0 {
+			return c.store(key, append(records[:i], records[i+1:]...))
+		}
+	}
+	return errors.New("no such spending record")
+}
+
+// Total returns the sum of the amounts spent under key within the last window.
+func (c *SpendingCounter) Total(key string, window time.Duration) (*big.Int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	records, err := c.load(key, c.now().Add(-window))
+	if err != nil {
+		return nil, err
+	}
+	total := new(big.Int)
+	for _, record := range records {
+		total.Add(total, record.Amount)
+	}
+	return total, nil
+}
+
+// Reset drops all the amounts spent under key.
+func (c *SpendingCounter) Reset(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.storage.Del(spendingKeyPrefix + key)
+}
+
+// store persists the records under key. The caller must hold the lock.
+func (c *SpendingCounter) store(key string, records []spendingRecord) error {
+	blob, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	c.storage.Put(spendingKeyPrefix+key, string(blob))
+	return nil
+}
+
+// load retrieves the records stored under key that were spent after the given
+// time. Corrupted records are reported instead of being ignored, so that limits
+// can't be bypassed by them. The caller must hold the lock.
+func (c *SpendingCounter) load(key string, after time.Time) ([]spendingRecord, error) {
+	blob, err := c.storage.Get(spendingKeyPrefix + key)
+	if err == ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var records []spendingRecord
+	if err := json.Unmarshal([]byte(blob), &records); err != nil {
+		return nil, err
+	}
+	var (
+		cutoff = after.Unix()
+		recent []spendingRecord
+	)
+	for _, record := range records {
+		if record.Amount == nil {
+			return nil, errors.New("corrupted spending record")
+		}
+		if record.Time > cutoff {
+			recent = append(recent, record)
+		}
+	}
+	return recent, nil
+}
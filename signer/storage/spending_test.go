@@ -0,0 +1,137 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package storage
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSpendingCounter(t *testing.T) {
+	var (
+		storage = NewEphemeralStorage()
+		counter = NewSpendingCounter(storage)
+		now     = time.Unix(1600000000, 0)
+	)
+	counter.now = func() time.Time { return now }
+
+	check := func(key string, window time.Duration, want int64) {
+		t.Helper()
+		total, err := counter.Total(key, window)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total.Cmp(big.NewInt(want)) != 0 {
+			t.Errorf("%s: total within %v mismatch: have %v, want %d", key, window, total, want)
+		}
+	}
+	check("cusd", 24*time.Hour, 0)
+
+	counter.Add("cusd", big.NewInt(100))
+	now = now.Add(12 * time.Hour)
+	counter.Add("cusd", big.NewInt(200))
+	counter.Add("ceur", big.NewInt(5))
+
+	check("cusd", 24*time.Hour, 300)
+	check("cusd", time.Hour, 200)
+	check("ceur", 24*time.Hour, 5)
+
+	// Older amounts fall out of the window, but are kept within the retention
+	now = now.Add(13 * time.Hour)
+	check("cusd", 24*time.Hour, 200)
+	check("cusd", 7*24*time.Hour, 300)
+
+	// Amounts survive a restart
+	counter = NewSpendingCounter(storage)
+	counter.now = func() time.Time { return now }
+	check("cusd", 7*24*time.Hour, 300)
+
+	// Amounts beyond the retention are dropped on the next addition
+	now = now.Add(spendingRetention)
+	counter.Add("cusd", big.NewInt(1))
+	check("cusd", 2*spendingRetention, 1)
+
+	counter.Reset("cusd")
+	check("cusd", 2*spendingRetention, 0)
+
+	if err := counter.Add("cusd", big.NewInt(-1)); err == nil {
+		t.Error("expected negative amount to be rejected")
+	}
+}
+
+func TestSpendingCounterReserve(t *testing.T) {
+	var (
+		counter = NewSpendingCounter(NewEphemeralStorage())
+		limit   = big.NewInt(1000)
+	)
+	// Concurrent reservations can't exceed the limit together
+	var (
+		reserved int
+		lock     sync.Mutex
+		pend     sync.WaitGroup
+	)
+	for i := 0; i < 25; i++ {
+		pend.Add(1)
+		go func() {
+			defer pend.Done()
+			ok, err := counter.Reserve("cusd", big.NewInt(100), limit, 24*time.Hour)
+			if err != nil {
+				t.Error(err)
+			}
+			if ok {
+				lock.Lock()
+				reserved++
+				lock.Unlock()
+			}
+		}()
+	}
+	pend.Wait()
+	if reserved != 10 {
+		t.Fatalf("reservation count mismatch: have %d, want 10", reserved)
+	}
+	// Released amounts can be reserved again
+	if err := counter.Release("cusd", big.NewInt(100)); err != nil {
+		t.Fatalf("failed to release reservation: %v", err)
+	}
+	if ok, _ := counter.Reserve("cusd", big.NewInt(200), limit, 24*time.Hour); ok {
+		t.Error("expected reservation beyond the limit to fail")
+	}
+	if ok, _ := counter.Reserve("cusd", big.NewInt(100), limit, 24*time.Hour); !ok {
+		t.Error("expected released amount to be reserved again")
+	}
+	if total, _ := counter.Total("cusd", time.Hour); total.Cmp(limit) != 0 {
+		t.Errorf("total mismatch: have %v, want %v", total, limit)
+	}
+	if err := counter.Release("cusd", big.NewInt(7)); err == nil {
+		t.Error("expected release of an unknown amount to fail")
+	}
+}
+
+func TestSpendingCounterCorrupted(t *testing.T) {
+	storage := NewEphemeralStorage()
+	storage.Put(spendingKeyPrefix+"cusd", "not json")
+
+	counter := NewSpendingCounter(storage)
+	if _, err := counter.Total("cusd", time.Hour); err == nil {
+		t.Error("expected corrupted records to be reported")
+	}
+	if err := counter.Add("cusd", big.NewInt(1)); err == nil {
+		t.Error("expected corrupted records not to be overwritten")
+	}
+}